@@ -1,4 +1,6 @@
 #!/usr/bin/env bash
 
 PORT=8083 \
+ENV=dev \
+SMS_PROVIDER=fake \
 neuron-debug.sh
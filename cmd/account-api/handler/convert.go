@@ -3,6 +3,7 @@ package handler
 import (
 	api "github.com/NeuronAccount/account/api/gen/models"
 	"github.com/NeuronAccount/account/models"
+	"github.com/NeuronAccount/account/services"
 	"github.com/go-openapi/strfmt"
 )
 
@@ -88,6 +89,8 @@ func fromOperation(p *models.AccountOperation) (r *api.Operation) {
 	r.SmsScene = p.SmsScene
 	r.UserID = &p.UserId
 	r.UserAgent = p.UserAgent
+	r.ActorID = p.ActorId
+	r.Detail = p.Detail
 
 	return r
 }
@@ -104,3 +107,224 @@ func fromOperationList(p []*models.AccountOperation) (r []*api.Operation) {
 
 	return r
 }
+
+func fromJsonWebKey(p *models.JsonWebKey) (r *api.JSONWebKey) {
+	if p == nil {
+		return nil
+	}
+
+	r = &api.JSONWebKey{}
+	r.Kty = &p.Kty
+	r.Use = &p.Use
+	r.Alg = &p.Alg
+	r.Kid = &p.Kid
+	r.N = p.N
+	r.E = p.E
+	r.Crv = p.Crv
+	r.X = p.X
+	r.Y = p.Y
+
+	return r
+}
+
+func fromSmsRateLimitError(p *services.SmsRateLimitError) (r *api.RateLimitError) {
+	if p == nil {
+		return nil
+	}
+
+	code := services.SmsRateLimitedErrorCode
+	message := p.Message()
+	r = &api.RateLimitError{}
+	r.Code = &code
+	r.Message = &message
+	r.RetryAfter = &p.RetryAfter
+
+	return r
+}
+
+func fromPasswordChallenge(p *models.PasswordChallenge) (r *api.PasswordChallenge) {
+	if p == nil {
+		return nil
+	}
+
+	r = &api.PasswordChallenge{}
+	r.Nonce = &p.Nonce
+	r.ExpiresIn = &p.ExpiresIn
+	r.Key = fromJsonWebKey(p.Key)
+
+	return r
+}
+
+func fromJsonWebKeyList(p []*models.JsonWebKey) (r []*api.JSONWebKey) {
+	if p == nil {
+		return nil
+	}
+
+	r = make([]*api.JSONWebKey, len(p))
+	for i, v := range p {
+		r[i] = fromJsonWebKey(v)
+	}
+
+	return r
+}
+
+func fromTokenIntrospection(p *models.TokenIntrospection) (r *api.IntrospectionResponse) {
+	if p == nil {
+		return nil
+	}
+
+	r = &api.IntrospectionResponse{}
+	r.Active = &p.Active
+	r.Sub = p.Subject
+	r.Exp = p.ExpiresAt
+	r.Iat = p.IssuedAt
+	r.Scope = p.Scope
+	r.ClientID = p.ClientId
+	r.Aud = p.Audience
+	r.TokenType = p.TokenType
+	r.Jti = p.TokenId
+
+	return r
+}
+
+func fromUserSession(p *models.UserSession) (r *api.Session) {
+	if p == nil {
+		return nil
+	}
+
+	r = &api.Session{}
+	r.SessionID = &p.SessionId
+	r.DeviceID = p.DeviceId
+	r.UserAgent = p.UserAgent
+	r.ClientIP = p.ClientIp
+	createTime := strfmt.DateTime(p.CreateTime)
+	r.CreateTime = &createTime
+	lastSeenTime := strfmt.DateTime(p.LastSeenTime)
+	r.LastSeenTime = &lastSeenTime
+	r.IsCurrent = &p.IsCurrent
+
+	return r
+}
+
+func fromUserSessionList(p []*models.UserSession) (r []*api.Session) {
+	if p == nil {
+		return nil
+	}
+
+	r = make([]*api.Session, len(p))
+	for i, v := range p {
+		r[i] = fromUserSession(v)
+	}
+
+	return r
+}
+
+func fromOauthClient(p *models.OauthClient) (r *api.OauthClient) {
+	if p == nil {
+		return nil
+	}
+
+	r = &api.OauthClient{}
+	r.ClientID = &p.ClientId
+	r.ClientSecret = p.ClientSecret
+	r.ClientName = &p.ClientName
+	r.RedirectUris = p.RedirectUris
+	r.IsPublic = &p.IsPublic
+	r.Audience = p.Audience
+	r.GrantTypes = p.GrantTypes
+	r.Scope = p.Scope
+
+	return r
+}
+
+func fromAuthorizeResult(p *models.AuthorizeResult) (r *api.AuthorizeResponse) {
+	if p == nil {
+		return nil
+	}
+
+	r = &api.AuthorizeResponse{}
+	r.ConsentRequired = &p.ConsentRequired
+	r.ClientName = p.ClientName
+	r.Scope = p.Scope
+	r.RedirectURI = p.RedirectUri
+
+	return r
+}
+
+func fromOauthToken(p *models.OauthToken) (r *api.OauthTokenResponse) {
+	if p == nil {
+		return nil
+	}
+
+	r = &api.OauthTokenResponse{}
+	r.AccessToken = &p.AccessToken
+	r.TokenType = &p.TokenType
+	r.ExpiresIn = &p.ExpiresIn
+	r.RefreshToken = p.RefreshToken
+	r.Scope = p.Scope
+	r.IDToken = p.IdToken
+	r.IssuedTokenType = p.IssuedTokenType
+
+	return r
+}
+
+func fromOpenidConfiguration(p *models.OpenidConfiguration) (r *api.OpenidConfiguration) {
+	if p == nil {
+		return nil
+	}
+
+	r = &api.OpenidConfiguration{}
+	r.Issuer = &p.Issuer
+	r.AuthorizationEndpoint = &p.AuthorizationEndpoint
+	r.TokenEndpoint = &p.TokenEndpoint
+	r.UserinfoEndpoint = p.UserinfoEndpoint
+	r.JwksURI = &p.JwksUri
+	r.IntrospectionEndpoint = p.IntrospectionEndpoint
+	r.ScopesSupported = p.ScopesSupported
+	r.ResponseTypesSupported = p.ResponseTypesSupported
+	r.GrantTypesSupported = p.GrantTypesSupported
+	r.SubjectTypesSupported = p.SubjectTypesSupported
+	r.IDTokenSigningAlgValuesSupported = p.IdTokenSigningAlgValuesSupported
+	r.TokenEndpointAuthMethodsSupported = p.TokenEndpointAuthMethodsSupported
+	r.CodeChallengeMethodsSupported = p.CodeChallengeMethodsSupported
+	r.ClaimsSupported = p.ClaimsSupported
+
+	return r
+}
+
+func fromOidcUserInfo(p *models.OidcUserInfo) (r *api.OidcUserInfo) {
+	if p == nil {
+		return nil
+	}
+
+	r = &api.OidcUserInfo{}
+	r.Sub = &p.Subject
+	r.Name = p.Name
+	r.PreferredUsername = p.PreferredUsername
+	r.Picture = p.Picture
+	r.PhoneNumber = p.PhoneNumber
+	r.PhoneNumberVerified = p.PhoneNumberVerified
+
+	return r
+}
+
+func fromPasswordViolation(p *models.PasswordViolation) (r *api.PasswordViolation) {
+	if p == nil {
+		return nil
+	}
+
+	r = &api.PasswordViolation{}
+	r.Code = &p.Code
+	r.Message = &p.Message
+
+	return r
+}
+
+func fromPasswordViolationList(p []*models.PasswordViolation) (r []*api.PasswordViolation) {
+	r = make([]*api.PasswordViolation, len(p))
+	for i, v := range p {
+		r[i] = fromPasswordViolation(v)
+	}
+
+	return r
+}
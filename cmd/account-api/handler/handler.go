@@ -1,29 +1,133 @@
 package handler
 
 import (
+	"context"
 	"fmt"
 	api "github.com/NeuronAccount/account/api/gen/models"
 	"github.com/NeuronAccount/account/api/gen/restapi/operations"
 	"github.com/NeuronAccount/account/models"
+	"github.com/NeuronAccount/account/remotes/captcha"
+	"github.com/NeuronAccount/account/remotes/sms"
 	"github.com/NeuronAccount/account/services"
 	"github.com/NeuronFramework/log"
 	"github.com/NeuronFramework/rest"
-	"github.com/dgrijalva/jwt-go"
-	"github.com/go-openapi/runtime"
+	"github.com/go-openapi/errors"
+	"github.com/go-openapi/loads"
 	"github.com/go-openapi/runtime/middleware"
+	"github.com/go-openapi/swag"
 	"go.uber.org/zap"
+	"net"
 	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
 )
 
 type AccountHandler struct {
-	logger  *zap.Logger
-	service *services.AccountService
+	logger         *zap.Logger
+	service        *services.AccountService
+	trustedProxies []*net.IPNet //可信的反向代理，只有来自这些地址的转发头才使用
 }
 
-func NewAccountHandler() (h *AccountHandler, err error) {
+func NewAccountHandler(swaggerSpec *loads.Document) (h *AccountHandler, err error) {
 	h = &AccountHandler{}
 	h.logger = log.TypedLogger(h)
-	h.service, err = services.NewAccountService(&services.AccountServiceOptions{})
+	h.trustedProxies, err = parseTrustedProxies(os.Getenv("TRUSTED_PROXIES"))
+	if err != nil {
+		return nil, err
+	}
+	jwtClockSkew := time.Duration(0)
+	if v := os.Getenv("JWT_CLOCK_SKEW"); v != "" {
+		jwtClockSkew, err = time.ParseDuration(v)
+		if err != nil {
+			return nil, err
+		}
+	}
+	//未设置时使用默认值，设置为0时不检查
+	passwordHistorySize := 0
+	if v := os.Getenv("PASSWORD_HISTORY_SIZE"); v != "" {
+		passwordHistorySize, err = strconv.Atoi(v)
+		if err != nil {
+			return nil, err
+		}
+		if passwordHistorySize == 0 {
+			passwordHistorySize = -1
+		}
+	}
+	options := &services.AccountServiceOptions{
+		Dev:                   os.Getenv("ENV") == "dev",
+		JwtAlgorithm:          os.Getenv("JWT_ALGORITHM"),
+		JwtClockSkew:          jwtClockSkew,
+		JwtKeyEncryptionKey:   os.Getenv("JWT_KEY_ENCRYPTION_KEY"),
+		PasswordHashAlgorithm: os.Getenv("PASSWORD_HASH_ALGORITHM"),
+
+		PasswordHistorySize:       passwordHistorySize,
+		PasswordBreachedCorpusDir: os.Getenv("PASSWORD_BREACHED_CORPUS_DIR"),
+		PasswordTransportKeyPem:   os.Getenv("PASSWORD_TRANSPORT_PRIVATE_KEY"),
+		PasswordTransportRequired: os.Getenv("PASSWORD_TRANSPORT_REQUIRED") == "true",
+		SmsCodeHmacKey:            os.Getenv("SMS_CODE_HMAC_KEY"),
+		Issuer:                    os.Getenv("OIDC_ISSUER"),
+		AuthorizationEndpoint:     os.Getenv("OIDC_AUTHORIZATION_ENDPOINT"),
+		JanitorDisabled:           os.Getenv("JANITOR_DISABLED") == "true",
+	}
+	//短信服务，fake不实际发送，用于测试及本地开发
+	switch os.Getenv("SMS_PROVIDER") {
+	case "", "aliyun":
+		//未指定配置文件时从环境变量读取
+		options.SmsSender, err = sms.NewAliyunSender(&sms.AliyunOptions{
+			ConfigFile: os.Getenv("SMS_ALIYUN_CONFIG_FILE"),
+			Config: sms.AliyunConfig{
+				AccessKeyId:     os.Getenv("SMS_ALIYUN_ACCESS_KEY_ID"),
+				AccessKeySecret: os.Getenv("SMS_ALIYUN_ACCESS_KEY_SECRET"),
+				SignName:        os.Getenv("SMS_ALIYUN_SIGN_NAME"),
+				TemplateCode:    os.Getenv("SMS_ALIYUN_TEMPLATE_CODE"),
+			},
+		})
+		if err != nil {
+			return nil, err
+		}
+	case "fake":
+		options.SmsSender, err = sms.NewFakeSender(os.Getenv("SMS_FAKE_FILE"))
+		if err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("unknown SMS_PROVIDER %s", os.Getenv("SMS_PROVIDER"))
+	}
+	//图形验证码，未接入时须显式关闭，否则非开发环境启动失败
+	switch os.Getenv("CAPTCHA_PROVIDER") {
+	case "":
+	case "siteverify":
+		options.CaptchaVerifier, err = captcha.NewSiteVerifier(&captcha.SiteVerifyOptions{
+			VerifyUrl: os.Getenv("CAPTCHA_VERIFY_URL"),
+			Secret:    os.Getenv("CAPTCHA_SECRET"),
+			Hostname:  os.Getenv("CAPTCHA_HOSTNAME"),
+		})
+		if err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("unknown CAPTCHA_PROVIDER %s", os.Getenv("CAPTCHA_PROVIDER"))
+	}
+	if os.Getenv("LOGIN_CAPTCHA_DISABLED") == "true" {
+		options.LoginPhoneGuard.CaptchaAfter = -1
+		options.LoginIpGuard.CaptchaAfter = -1
+	}
+	//逗号分隔的operationId，未设置时使用默认列表
+	if v := os.Getenv("STEP_UP_OPERATIONS"); v != "" {
+		options.StepUpOperations = strings.Split(v, ",")
+	}
+	h.service, err = services.NewAccountService(options)
+	if err != nil {
+		return nil, err
+	}
+	err = checkOperations(swaggerSpec, "StepUpOperations", options.StepUpOperations, true)
+	if err != nil {
+		return nil, err
+	}
+	err = checkOperations(swaggerSpec, "ImpersonationForbiddenOperations", options.ImpersonationForbiddenOperations,
+		false)
 	if err != nil {
 		return nil, err
 	}
@@ -31,39 +135,404 @@ func NewAccountHandler() (h *AccountHandler, err error) {
 	return h, nil
 }
 
-func (h *AccountHandler) BearerAuth(token string) (userId interface{}, err error) {
+// 配置的operationId必须存在，否则拼写错误会使限制静默失效；
+// 二次认证依赖会话，对应接口不能允许匿名调用
+func checkOperations(swaggerSpec *loads.Document, name string, operationIds []string, authRequired bool) (
+	err error) {
+
+	for _, operationId := range operationIds {
+		_, _, operation, ok := swaggerSpec.Analyzer.OperationForName(operationId)
+		if !ok {
+			return fmt.Errorf("%s: operation %s not found", name, operationId)
+		}
+		if !authRequired {
+			continue
+		}
+
+		anonymous := len(operation.Security) == 0
+		for _, v := range operation.Security {
+			if len(v) == 0 {
+				anonymous = true
+			}
+		}
+		if anonymous {
+			return fmt.Errorf("%s: operation %s does not require auth", name, operationId)
+		}
+	}
+
+	return nil
+}
+
+func (h *AccountHandler) BearerAuth(token string) (principal interface{}, err error) {
 	if token == "" {
-		return "", nil
+		return nil, nil
 	}
 
-	claims := jwt.StandardClaims{}
-	_, err = jwt.ParseWithClaims(token, &claims, func(t *jwt.Token) (interface{}, error) {
-		return []byte("0123456789"), nil
-	})
+	principal, err = h.service.ParseAccessToken(context.Background(), token)
+	if err != nil {
+		return nil, h.tokenError(err)
+	}
+
+	return principal, nil
+}
+
+// token校验失败返回401，错误信息带有具体原因
+func (h *AccountHandler) tokenError(err error) error {
+	if tokenErr, ok := err.(*services.TokenError); ok {
+		h.logger.Info("tokenError", zap.String("reason", tokenErr.Reason), zap.String("message", tokenErr.Message))
+		return errors.New(http.StatusUnauthorized, "invalid_token: %s", tokenErr.Error())
+	}
+
+	return err
+}
+
+// 可选认证的接口未携带token时principal为nil
+func principalOf(principal interface{}) (p *models.Principal) {
+	p, ok := principal.(*models.Principal)
+	if !ok || p == nil {
+		return &models.Principal{}
+	}
+
+	return p
+}
+
+// 逗号分隔的IP或CIDR
+func parseTrustedProxies(v string) (trustedProxies []*net.IPNet, err error) {
+	for _, item := range strings.Split(v, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		if !strings.Contains(item, "/") {
+			ip := net.ParseIP(item)
+			if ip == nil {
+				return nil, fmt.Errorf("invalid TRUSTED_PROXIES %s", item)
+			}
+			bits := 8 * net.IPv6len
+			if ip.To4() != nil {
+				ip = ip.To4()
+				bits = 8 * net.IPv4len
+			}
+			trustedProxies = append(trustedProxies, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+			continue
+		}
+
+		_, ipNet, err := net.ParseCIDR(item)
+		if err != nil {
+			return nil, fmt.Errorf("invalid TRUSTED_PROXIES %s", item)
+		}
+		trustedProxies = append(trustedProxies, ipNet)
+	}
+
+	return trustedProxies, nil
+}
+
+func (h *AccountHandler) isTrustedProxy(ip net.IP) bool {
+	for _, ipNet := range h.trustedProxies {
+		if ipNet.Contains(ip) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// 只有直接连接的地址是可信代理时才使用转发头，
+// X-Forwarded-For从右往左取第一个非可信代理的地址，左侧的地址可由客户端伪造
+func (h *AccountHandler) clientIp(r *http.Request) (ip string) {
+	ip, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		ip = r.RemoteAddr
+	}
+	remoteIp := net.ParseIP(ip)
+	if remoteIp == nil || !h.isTrustedProxy(remoteIp) {
+		return ip
+	}
+
+	//多个代理各自添加的头合并后按顺序处理
+	forwardedFor := r.Header["X-Forwarded-For"]
+	if len(forwardedFor) > 0 {
+		hops := strings.Split(strings.Join(forwardedFor, ","), ",")
+		for i := len(hops) - 1; i >= 0; i-- {
+			hopIp := net.ParseIP(strings.TrimSpace(hops[i]))
+			if hopIp == nil {
+				break
+			}
+			ip = hopIp.String()
+			if !h.isTrustedProxy(hopIp) {
+				break
+			}
+		}
+
+		return ip
+	}
+
+	realIp := net.ParseIP(strings.TrimSpace(r.Header.Get("X-Real-IP")))
+	if realIp != nil {
+		return realIp.String()
+	}
+
+	return ip
+}
+
+func (h *AccountHandler) deviceInfo(r *http.Request, deviceId *string) (device *models.DeviceInfo) {
+	device = &models.DeviceInfo{}
+	device.ClientIp = h.clientIp(r)
+	if deviceId != nil {
+		device.DeviceId = *deviceId
+	}
+
+	return device
+}
+
+// 接口通过x-principal-types声明允许的调用方，未声明时只允许用户调用；
+// 通过x-scopes声明需要的scope，token须包含全部scope；
+// 配置为敏感操作的接口还要求会话最近完成过认证
+func (h *AccountHandler) AuthorizeRequest(r *http.Request, principal interface{}) (err error) {
+	p, ok := principal.(*models.Principal)
+	if !ok || p == nil {
+		//ClientBasic认证的接口，或可选认证的接口未携带token
+		return nil
+	}
+
+	principalTypes := []string{models.SubjectTypeUser}
+	var scopes []string
+	operationId := ""
+	route := middleware.MatchedRouteFrom(r)
+	if route != nil && route.Operation != nil {
+		operationId = route.Operation.ID
+		if v, ok := route.Operation.Extensions.GetStringSlice("x-principal-types"); ok {
+			principalTypes = v
+		}
+		if v, ok := route.Operation.Extensions.GetStringSlice("x-scopes"); ok {
+			scopes = v
+		}
+	}
+	//须认证的接口都要声明x-scopes，未声明时拒绝，避免新接口遗漏授权检查
+	if len(scopes) == 0 {
+		h.logger.Error("AuthorizeRequest operation without x-scopes", zap.String("operationId", operationId))
+		return errors.New(http.StatusForbidden, "insufficient_scope: 接口未声明scope")
+	}
+
+	err = h.checkPrincipalType(p, principalTypes)
+	if err != nil {
+		return err
+	}
+
+	granted := strings.Fields(p.Scope)
+	for _, v := range scopes {
+		found := false
+		for _, g := range granted {
+			if v == g {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return errors.New(http.StatusForbidden, "insufficient_scope: 需要scope %s", v)
+		}
+	}
+
+	//冒充用户的每次调用都纪录，并拒绝修改密码、手机号及删除帐号等操作
+	if p.IsImpersonated() {
+		err = h.service.CheckImpersonation(rest.NewContext(r), p, operationId)
+		if err != nil {
+			return err
+		}
+	}
+
+	return h.service.CheckStepUp(p, operationId)
+}
+
+func (h *AccountHandler) checkPrincipalType(p *models.Principal, principalTypes []string) (err error) {
+	subjectType := models.SubjectTypeUser
+	if p.IsClient() {
+		subjectType = models.SubjectTypeClient
+	}
+	for _, v := range principalTypes {
+		if v == subjectType {
+			return nil
+		}
+	}
+
+	return errors.New(http.StatusForbidden, "该接口不允许%s调用", subjectType)
+}
+
+func (h *AccountHandler) ClientBasicAuth(clientId string, clientSecret string) (principal interface{}, err error) {
+	err = h.service.ValidateClientCredentials(context.Background(), clientId, clientSecret)
 	if err != nil {
 		return nil, err
 	}
 
-	if claims.Subject == "" {
-		return nil, fmt.Errorf("验证失败： claims.Subject nil")
+	return clientId, nil
+}
+
+// OIDC客户端按标准携带Bearer前缀
+func (h *AccountHandler) OidcBearerAuth(token string) (principal interface{}, err error) {
+	token = strings.TrimSpace(strings.TrimPrefix(token, "Bearer "))
+	if token == "" {
+		return nil, errors.Unauthenticated("OidcBearer")
+	}
+
+	principal, err = h.service.ParseOidcAccessToken(context.Background(), token)
+	if err != nil {
+		return nil, h.tokenError(err)
+	}
+
+	return principal, nil
+}
+
+func (h *AccountHandler) GetOpenidConfiguration(p operations.GetOpenidConfigurationParams) middleware.Responder {
+	configuration, err := h.service.GetOpenidConfiguration(rest.NewContext(p.HTTPRequest))
+	if err != nil {
+		return rest.Wrap(err)
+	}
+
+	return operations.NewGetOpenidConfigurationOK().WithPayload(fromOpenidConfiguration(configuration))
+}
+
+func (h *AccountHandler) GetOidcUserInfo(p operations.GetOidcUserInfoParams, principal interface{}) middleware.Responder {
+	userInfo, err := h.service.GetOidcUserInfo(rest.NewContext(p.HTTPRequest), principalOf(principal))
+	if err != nil {
+		return rest.Wrap(err)
+	}
+
+	return operations.NewGetOidcUserInfoOK().WithPayload(fromOidcUserInfo(userInfo))
+}
+
+func (h *AccountHandler) PostOidcUserInfo(p operations.PostOidcUserInfoParams, principal interface{}) middleware.Responder {
+	userInfo, err := h.service.GetOidcUserInfo(rest.NewContext(p.HTTPRequest), principalOf(principal))
+	if err != nil {
+		return rest.Wrap(err)
+	}
+
+	return operations.NewPostOidcUserInfoOK().WithPayload(fromOidcUserInfo(userInfo))
+}
+
+func (h *AccountHandler) GetJwks(p operations.GetJwksParams) middleware.Responder {
+	keys, err := h.service.GetJwks(rest.NewContext(p.HTTPRequest))
+	if err != nil {
+		return rest.Wrap(err)
+	}
+
+	return operations.NewGetJwksOK().WithPayload(&api.JSONWebKeySet{Keys: fromJsonWebKeyList(keys)})
+}
+
+func (h *AccountHandler) IntrospectToken(p operations.IntrospectTokenParams, clientId interface{}) middleware.Responder {
+	tokenTypeHint := ""
+	if p.TokenTypeHint != nil {
+		tokenTypeHint = *p.TokenTypeHint
+	}
+
+	introspection, err := h.service.IntrospectToken(rest.NewContext(p.HTTPRequest), clientId.(string), p.Token,
+		tokenTypeHint)
+	if err != nil {
+		return rest.Wrap(err)
 	}
 
-	return claims.Subject, nil
+	return operations.NewIntrospectTokenOK().WithPayload(fromTokenIntrospection(introspection))
 }
 
-func (h *AccountHandler) SendSmsCode(p operations.SendSmsCodeParams, userId interface{}) middleware.Responder {
+func (h *AccountHandler) RegisterOauthClient(p operations.RegisterOauthClientParams, principal interface{}) middleware.Responder {
+	oauthClient, err := h.service.RegisterOauthClient(rest.NewContext(p.HTTPRequest), principalOf(principal).UserId,
+		&models.OauthClient{
+			ClientName:   p.ClientName,
+			RedirectUris: p.RedirectUris,
+			IsPublic:     swag.BoolValue(p.IsPublic),
+			Audience:     swag.StringValue(p.Audience),
+			GrantTypes:   p.GrantTypes,
+			Scope:        swag.StringValue(p.Scope),
+		})
+	if err != nil {
+		return rest.Wrap(err)
+	}
+
+	return operations.NewRegisterOauthClientOK().WithPayload(fromOauthClient(oauthClient))
+}
+
+func (h *AccountHandler) Authorize(p operations.AuthorizeParams, principal interface{}) middleware.Responder {
+	result, err := h.service.Authorize(rest.NewContext(p.HTTPRequest), principalOf(principal),
+		&models.AuthorizeParams{
+			ResponseType:        p.ResponseType,
+			ClientId:            p.ClientID,
+			RedirectUri:         swag.StringValue(p.RedirectURI),
+			Scope:               swag.StringValue(p.Scope),
+			State:               swag.StringValue(p.State),
+			CodeChallenge:       swag.StringValue(p.CodeChallenge),
+			CodeChallengeMethod: swag.StringValue(p.CodeChallengeMethod),
+			Nonce:               swag.StringValue(p.Nonce),
+		})
+	if err != nil {
+		return rest.Wrap(err)
+	}
+
+	return operations.NewAuthorizeOK().WithPayload(fromAuthorizeResult(result))
+}
+
+func (h *AccountHandler) ApproveAuthorization(p operations.ApproveAuthorizationParams, principal interface{}) middleware.Responder {
+	result, err := h.service.ApproveAuthorization(rest.NewContext(p.HTTPRequest), principalOf(principal),
+		&models.AuthorizeParams{
+			ResponseType:        p.ResponseType,
+			ClientId:            p.ClientID,
+			RedirectUri:         swag.StringValue(p.RedirectURI),
+			Scope:               swag.StringValue(p.Scope),
+			State:               swag.StringValue(p.State),
+			CodeChallenge:       swag.StringValue(p.CodeChallenge),
+			CodeChallengeMethod: swag.StringValue(p.CodeChallengeMethod),
+			Nonce:               swag.StringValue(p.Nonce),
+		}, p.Approved)
+	if err != nil {
+		return rest.Wrap(err)
+	}
+
+	return operations.NewApproveAuthorizationOK().WithPayload(fromAuthorizeResult(result))
+}
+
+func (h *AccountHandler) OauthToken(p operations.OauthTokenParams) middleware.Responder {
+	//客户端认证可使用http basic或表单参数
+	clientId, clientSecret, ok := p.HTTPRequest.BasicAuth()
+	if !ok {
+		clientId = swag.StringValue(p.ClientID)
+		clientSecret = swag.StringValue(p.ClientSecret)
+	}
+
+	oauthToken, err := h.service.OauthToken(rest.NewContext(p.HTTPRequest), &models.OauthTokenParams{
+		GrantType:    p.GrantType,
+		ClientId:     clientId,
+		ClientSecret: clientSecret,
+		Code:         swag.StringValue(p.Code),
+		RedirectUri:  swag.StringValue(p.RedirectURI),
+		CodeVerifier: swag.StringValue(p.CodeVerifier),
+		RefreshToken: swag.StringValue(p.RefreshToken),
+		Scope:        swag.StringValue(p.Scope),
+
+		SubjectToken:     swag.StringValue(p.SubjectToken),
+		SubjectTokenType: swag.StringValue(p.SubjectTokenType),
+		ActorToken:       swag.StringValue(p.ActorToken),
+		ActorTokenType:   swag.StringValue(p.ActorTokenType),
+	}, h.deviceInfo(p.HTTPRequest, nil))
+	if err != nil {
+		return rest.Wrap(err)
+	}
+
+	return operations.NewOauthTokenOK().WithPayload(fromOauthToken(oauthToken))
+}
+
+func (h *AccountHandler) SendSmsCode(p operations.SendSmsCodeParams, principal interface{}) middleware.Responder {
 	err := h.service.SendSmsCode(rest.NewContext(p.HTTPRequest), &models.SendSmsCodeParams{
-		UserId:      userId.(string),
+		UserId:      principalOf(principal).UserId,
 		Scene:       p.Scene,
 		Phone:       p.Phone,
 		CaptchaId:   p.CaptchaID,
 		CaptchaCode: p.CaptchaCode,
+		Device:      h.deviceInfo(p.HTTPRequest, p.DeviceID),
 	})
 	if err != nil {
-		return middleware.ResponderFunc(func(http.ResponseWriter, runtime.Producer) {
-
-			return
-		})
+		if rateErr, ok := err.(*services.SmsRateLimitError); ok {
+			return operations.NewSendSmsCodeTooManyRequests().
+				WithRetryAfter(rateErr.RetryAfter).WithPayload(fromSmsRateLimitError(rateErr))
+		}
+		return rest.Wrap(err)
 	}
 
 	return operations.NewSendSmsCodeOK()
@@ -76,16 +545,22 @@ func (h *AccountHandler) SendLoginSmsCode(p operations.SendLoginSmsCodeParams) m
 		Phone:       p.Phone,
 		CaptchaId:   p.CaptchaID,
 		CaptchaCode: p.CaptchaCode,
+		Device:      h.deviceInfo(p.HTTPRequest, p.DeviceID),
 	})
 	if err != nil {
+		if rateErr, ok := err.(*services.SmsRateLimitError); ok {
+			return operations.NewSendLoginSmsCodeTooManyRequests().
+				WithRetryAfter(rateErr.RetryAfter).WithPayload(fromSmsRateLimitError(rateErr))
+		}
 		return rest.Wrap(err)
 	}
 
-	return operations.NewSendSmsCodeOK()
+	return operations.NewSendLoginSmsCodeOK()
 }
 
 func (h *AccountHandler) SmsLogin(p operations.SmsLoginParams) middleware.Responder {
-	userToken, err := h.service.SmsLogin(rest.NewContext(p.HTTPRequest), p.Phone, p.SmsCode)
+	userToken, err := h.service.SmsLogin(rest.NewContext(p.HTTPRequest), p.Phone, p.SmsCode,
+		h.deviceInfo(p.HTTPRequest, p.DeviceID))
 	if err != nil {
 		return rest.Wrap(err)
 	}
@@ -94,7 +569,15 @@ func (h *AccountHandler) SmsLogin(p operations.SmsLoginParams) middleware.Respon
 }
 
 func (h *AccountHandler) PhonePasswordLogin(p operations.PhonePasswordLoginParams) middleware.Responder {
-	userToken, err := h.service.PhonePasswordLogin(rest.NewContext(p.HTTPRequest), p.Phone, p.PasswordHash1)
+	ctx := rest.NewContext(p.HTTPRequest)
+	passwordHash1, err := h.service.DecryptPassword(ctx, swag.StringValue(p.PasswordHash1),
+		swag.StringValue(p.PasswordCipher))
+	if err != nil {
+		return rest.Wrap(err)
+	}
+
+	userToken, err := h.service.PhonePasswordLogin(ctx, p.Phone, passwordHash1,
+		h.deviceInfo(p.HTTPRequest, p.DeviceID), swag.StringValue(p.CaptchaID), swag.StringValue(p.CaptchaCode))
 	if err != nil {
 		return rest.Wrap(err)
 	}
@@ -102,17 +585,29 @@ func (h *AccountHandler) PhonePasswordLogin(p operations.PhonePasswordLoginParam
 	return operations.NewPhonePasswordLoginOK().WithPayload(fromUserToken(userToken))
 }
 
-func (h *AccountHandler) Logout(p operations.LogoutParams, userId interface{}) middleware.Responder {
-	err := h.service.Logout(rest.NewContext(p.HTTPRequest), userId.(string))
+func (h *AccountHandler) Logout(p operations.LogoutParams, principal interface{}) middleware.Responder {
+	sessionCount, err := h.service.Logout(rest.NewContext(p.HTTPRequest), principalOf(principal),
+		swag.BoolValue(p.All))
+	if err != nil {
+		return rest.Wrap(err)
+	}
+
+	return operations.NewLogoutOK().WithPayload(&api.LogoutResponse{SessionCount: &sessionCount})
+}
+
+func (h *AccountHandler) ForceLogout(p operations.ForceLogoutParams, principal interface{}) middleware.Responder {
+	sessionCount, err := h.service.ForceLogout(rest.NewContext(p.HTTPRequest), principalOf(principal).ClientId,
+		p.UserID)
 	if err != nil {
 		return rest.Wrap(err)
 	}
 
-	return operations.NewLogoutOK()
+	return operations.NewForceLogoutOK().WithPayload(&api.LogoutResponse{SessionCount: &sessionCount})
 }
 
 func (h *AccountHandler) RefreshToken(p operations.RefreshTokenParams) middleware.Responder {
-	userToken, err := h.service.RefreshToken(rest.NewContext(p.HTTPRequest), p.RefreshToken)
+	userToken, err := h.service.RefreshToken(rest.NewContext(p.HTTPRequest), p.RefreshToken,
+		h.deviceInfo(p.HTTPRequest, nil))
 	if err != nil {
 		return rest.Wrap(err)
 	}
@@ -120,6 +615,54 @@ func (h *AccountHandler) RefreshToken(p operations.RefreshTokenParams) middlewar
 	return operations.NewRefreshTokenOK().WithPayload(fromUserToken(userToken))
 }
 
+func (h *AccountHandler) ListSessions(p operations.ListSessionsParams, principal interface{}) middleware.Responder {
+	sessions, err := h.service.ListSessions(rest.NewContext(p.HTTPRequest), principalOf(principal))
+	if err != nil {
+		return rest.Wrap(err)
+	}
+
+	return operations.NewListSessionsOK().WithPayload(fromUserSessionList(sessions))
+}
+
+func (h *AccountHandler) RevokeSession(p operations.RevokeSessionParams, principal interface{}) middleware.Responder {
+	err := h.service.RevokeSession(rest.NewContext(p.HTTPRequest), principalOf(principal).UserId, p.SessionID)
+	if err != nil {
+		return rest.Wrap(err)
+	}
+
+	return operations.NewRevokeSessionOK()
+}
+
+func (h *AccountHandler) RevokeAllSessions(p operations.RevokeAllSessionsParams, principal interface{}) middleware.Responder {
+	err := h.service.RevokeAllSessions(rest.NewContext(p.HTTPRequest), principalOf(principal).UserId)
+	if err != nil {
+		return rest.Wrap(err)
+	}
+
+	return operations.NewRevokeAllSessionsOK()
+}
+
+func (h *AccountHandler) StepUp(p operations.StepUpParams, principal interface{}) middleware.Responder {
+	ctx := rest.NewContext(p.HTTPRequest)
+	passwordHash1 := ""
+	if p.PasswordHash1 != nil || p.PasswordCipher != nil {
+		var err error
+		passwordHash1, err = h.service.DecryptPassword(ctx, swag.StringValue(p.PasswordHash1),
+			swag.StringValue(p.PasswordCipher))
+		if err != nil {
+			return rest.Wrap(err)
+		}
+	}
+
+	accessToken, err := h.service.StepUp(ctx, principalOf(principal), swag.StringValue(p.SmsCode), passwordHash1,
+		swag.StringValue(p.CaptchaID), swag.StringValue(p.CaptchaCode))
+	if err != nil {
+		return rest.Wrap(err)
+	}
+
+	return operations.NewStepUpOK().WithPayload(&api.StepUpResponse{AccessToken: &accessToken})
+}
+
 func (h *AccountHandler) OauthState(p operations.OauthStateParams) middleware.Responder {
 	state, err := h.service.OauthState(rest.NewContext(p.HTTPRequest))
 	if err != nil {
@@ -146,7 +689,14 @@ func (h *AccountHandler) OauthJump(p operations.OauthJumpParams) middleware.Resp
 }
 
 func (h *AccountHandler) ResetPassword(p operations.ResetPasswordParams) middleware.Responder {
-	err := h.service.ResetPassword(rest.NewContext(p.HTTPRequest), p.Phone, p.SmsCode, p.NewPasswordHash1)
+	ctx := rest.NewContext(p.HTTPRequest)
+	newPasswordHash1, err := h.service.DecryptPassword(ctx, swag.StringValue(p.NewPasswordHash1),
+		swag.StringValue(p.NewPasswordCipher))
+	if err != nil {
+		return rest.Wrap(err)
+	}
+
+	err = h.service.ResetPassword(ctx, p.Phone, p.SmsCode, newPasswordHash1)
 	if err != nil {
 		return rest.Wrap(err)
 	}
@@ -154,8 +704,70 @@ func (h *AccountHandler) ResetPassword(p operations.ResetPasswordParams) middlew
 	return operations.NewResetPasswordOK()
 }
 
-func (h *AccountHandler) GetUserInfo(p operations.GetUserInfoParams, userId interface{}) middleware.Responder {
-	userInfo, err := h.service.GetUserInfo(rest.NewContext(p.HTTPRequest), userId.(string))
+func (h *AccountHandler) PasswordChallenge(p operations.PasswordChallengeParams) middleware.Responder {
+	challenge, err := h.service.GetPasswordChallenge(rest.NewContext(p.HTTPRequest))
+	if err != nil {
+		return rest.Wrap(err)
+	}
+
+	return operations.NewPasswordChallengeOK().WithPayload(fromPasswordChallenge(challenge))
+}
+
+func (h *AccountHandler) CheckPassword(p operations.CheckPasswordParams, principal interface{}) middleware.Responder {
+	ctx := rest.NewContext(p.HTTPRequest)
+	newPasswordHash1, err := h.service.DecryptPassword(ctx, "", p.NewPasswordCipher)
+	if err != nil {
+		return rest.Wrap(err)
+	}
+
+	violations, err := h.service.CheckPassword(ctx, principalOf(principal).UserId, newPasswordHash1)
+	if err != nil {
+		return rest.Wrap(err)
+	}
+
+	return operations.NewCheckPasswordOK().WithPayload(fromPasswordViolationList(violations))
+}
+
+func (h *AccountHandler) SetPassword(p operations.SetPasswordParams, principal interface{}) middleware.Responder {
+	ctx := rest.NewContext(p.HTTPRequest)
+	newPasswordHash1, err := h.service.DecryptPassword(ctx, swag.StringValue(p.NewPasswordHash1),
+		swag.StringValue(p.NewPasswordCipher))
+	if err != nil {
+		return rest.Wrap(err)
+	}
+
+	err = h.service.SetPassword(ctx, principalOf(principal), newPasswordHash1)
+	if err != nil {
+		return rest.Wrap(err)
+	}
+
+	return operations.NewSetPasswordOK()
+}
+
+func (h *AccountHandler) ChangePassword(p operations.ChangePasswordParams, principal interface{}) middleware.Responder {
+	ctx := rest.NewContext(p.HTTPRequest)
+	oldPasswordHash1, err := h.service.DecryptPassword(ctx, swag.StringValue(p.OldPasswordHash1),
+		swag.StringValue(p.OldPasswordCipher))
+	if err != nil {
+		return rest.Wrap(err)
+	}
+	newPasswordHash1, err := h.service.DecryptPassword(ctx, swag.StringValue(p.NewPasswordHash1),
+		swag.StringValue(p.NewPasswordCipher))
+	if err != nil {
+		return rest.Wrap(err)
+	}
+
+	err = h.service.ChangePassword(ctx, principalOf(principal), oldPasswordHash1, newPasswordHash1,
+		swag.StringValue(p.CaptchaID), swag.StringValue(p.CaptchaCode))
+	if err != nil {
+		return rest.Wrap(err)
+	}
+
+	return operations.NewChangePasswordOK()
+}
+
+func (h *AccountHandler) GetUserInfo(p operations.GetUserInfoParams, principal interface{}) middleware.Responder {
+	userInfo, err := h.service.GetUserInfo(rest.NewContext(p.HTTPRequest), principalOf(principal).UserId)
 	if err != nil {
 		return rest.Wrap(err)
 	}
@@ -163,8 +775,8 @@ func (h *AccountHandler) GetUserInfo(p operations.GetUserInfoParams, userId inte
 	return operations.NewGetUserInfoOK().WithPayload(fromUserInfo(userInfo))
 }
 
-func (h *AccountHandler) SetUserName(p operations.SetUserNameParams, userId interface{}) middleware.Responder {
-	err := h.service.SetUserName(rest.NewContext(p.HTTPRequest), userId.(string), p.UserName)
+func (h *AccountHandler) SetUserName(p operations.SetUserNameParams, principal interface{}) middleware.Responder {
+	err := h.service.SetUserName(rest.NewContext(p.HTTPRequest), principalOf(principal).UserId, p.UserName)
 	if err != nil {
 		return rest.Wrap(err)
 	}
@@ -172,8 +784,8 @@ func (h *AccountHandler) SetUserName(p operations.SetUserNameParams, userId inte
 	return operations.NewSetUserNameOK()
 }
 
-func (h *AccountHandler) SetUserIcon(p operations.SetUserIconParams, userId interface{}) middleware.Responder {
-	err := h.service.SetUserIcon(rest.NewContext(p.HTTPRequest), userId.(string), p.UserIcon)
+func (h *AccountHandler) SetUserIcon(p operations.SetUserIconParams, principal interface{}) middleware.Responder {
+	err := h.service.SetUserIcon(rest.NewContext(p.HTTPRequest), principalOf(principal).UserId, p.UserIcon)
 	if err != nil {
 		return rest.Wrap(err)
 	}
@@ -181,8 +793,8 @@ func (h *AccountHandler) SetUserIcon(p operations.SetUserIconParams, userId inte
 	return operations.NewSetUserIconOK()
 }
 
-func (h *AccountHandler) GetAccountInfo(p operations.GetAccountInfoParams, userId interface{}) middleware.Responder {
-	accountInfo, err := h.service.GetAccountInfo(rest.NewContext(p.HTTPRequest), userId.(string))
+func (h *AccountHandler) GetAccountInfo(p operations.GetAccountInfoParams, principal interface{}) middleware.Responder {
+	accountInfo, err := h.service.GetAccountInfo(rest.NewContext(p.HTTPRequest), principalOf(principal).UserId)
 	if err != nil {
 		return rest.Wrap(err)
 	}
@@ -190,8 +802,8 @@ func (h *AccountHandler) GetAccountInfo(p operations.GetAccountInfoParams, userI
 	return operations.NewGetAccountInfoOK().WithPayload(fromAccountInfo(accountInfo))
 }
 
-func (h *AccountHandler) BindPhone(p operations.BindPhoneParams, userId interface{}) middleware.Responder {
-	err := h.service.BindPhone(rest.NewContext(p.HTTPRequest), userId.(string), p.Phone, p.SmsCode)
+func (h *AccountHandler) BindPhone(p operations.BindPhoneParams, principal interface{}) middleware.Responder {
+	err := h.service.BindPhone(rest.NewContext(p.HTTPRequest), principalOf(principal).UserId, p.Phone, p.SmsCode)
 	if err != nil {
 		return rest.Wrap(err)
 	}
@@ -199,8 +811,8 @@ func (h *AccountHandler) BindPhone(p operations.BindPhoneParams, userId interfac
 	return operations.NewBindPhoneOK()
 }
 
-func (h *AccountHandler) UnbindPhone(p operations.UnbindPhoneParams, userId interface{}) middleware.Responder {
-	err := h.service.BindPhone(rest.NewContext(p.HTTPRequest), userId.(string), p.Phone, p.SmsCode)
+func (h *AccountHandler) UnbindPhone(p operations.UnbindPhoneParams, principal interface{}) middleware.Responder {
+	err := h.service.UnbindPhone(rest.NewContext(p.HTTPRequest), principalOf(principal).UserId, p.Phone, p.SmsCode)
 	if err != nil {
 		return rest.Wrap(err)
 	}
@@ -208,8 +820,8 @@ func (h *AccountHandler) UnbindPhone(p operations.UnbindPhoneParams, userId inte
 	return operations.NewUnbindPhoneOK()
 }
 
-func (h *AccountHandler) BindOauthAccount(p operations.BindOauthAccountParams, userId interface{}) middleware.Responder {
-	err := h.service.BindOauthAccount(rest.NewContext(p.HTTPRequest), userId.(string))
+func (h *AccountHandler) BindOauthAccount(p operations.BindOauthAccountParams, principal interface{}) middleware.Responder {
+	err := h.service.BindOauthAccount(rest.NewContext(p.HTTPRequest), principalOf(principal).UserId)
 	if err != nil {
 		return rest.Wrap(err)
 	}
@@ -217,8 +829,8 @@ func (h *AccountHandler) BindOauthAccount(p operations.BindOauthAccountParams, u
 	return operations.NewBindOauthAccountOK()
 }
 
-func (h *AccountHandler) UnbindOauthAccount(p operations.UnbindOauthAccountParams, userId interface{}) middleware.Responder {
-	err := h.service.UnbindOauthAccount(rest.NewContext(p.HTTPRequest), userId.(string))
+func (h *AccountHandler) UnbindOauthAccount(p operations.UnbindOauthAccountParams, principal interface{}) middleware.Responder {
+	err := h.service.UnbindOauthAccount(rest.NewContext(p.HTTPRequest), principalOf(principal).UserId)
 	if err != nil {
 		return rest.Wrap(err)
 	}
@@ -226,7 +838,7 @@ func (h *AccountHandler) UnbindOauthAccount(p operations.UnbindOauthAccountParam
 	return operations.NewUnbindOauthAccountOK()
 }
 
-func (h *AccountHandler) GetOperationList(p operations.GetOperationListParams, userId interface{}) middleware.Responder {
+func (h *AccountHandler) GetOperationList(p operations.GetOperationListParams, principal interface{}) middleware.Responder {
 	query := &models.OperationQuery{}
 	if p.OperationType != nil {
 		query.OperationType = *p.OperationType
@@ -238,7 +850,7 @@ func (h *AccountHandler) GetOperationList(p operations.GetOperationListParams, u
 		query.PageSize = *p.PageSize
 	}
 
-	items, nextPageToken, err := h.service.GetOperationList(rest.NewContext(p.HTTPRequest), userId.(string), query)
+	items, nextPageToken, err := h.service.GetOperationList(rest.NewContext(p.HTTPRequest), principalOf(principal).UserId, query)
 	if err != nil {
 		return rest.Wrap(err)
 	}
@@ -0,0 +1,59 @@
+package handler
+
+import (
+	"net/http/httptest"
+	"testing"
+)
+
+func TestParseTrustedProxies(t *testing.T) {
+	trustedProxies, err := parseTrustedProxies(" 10.0.0.0/8, 192.168.1.1 ,,::1")
+	if err != nil || len(trustedProxies) != 3 {
+		t.Fatalf("parseTrustedProxies() = %v, %v", trustedProxies, err)
+	}
+
+	for _, v := range []string{"10.0.0.256", "10.0.0.0/33", "proxy"} {
+		_, err = parseTrustedProxies(v)
+		if err == nil {
+			t.Errorf("parseTrustedProxies(%s) = nil, want error", v)
+		}
+	}
+}
+
+func TestClientIp(t *testing.T) {
+	trustedProxies, err := parseTrustedProxies("10.0.0.0/8")
+	if err != nil {
+		t.Fatal(err)
+	}
+	h := &AccountHandler{trustedProxies: trustedProxies}
+
+	tests := []struct {
+		name         string
+		remoteAddr   string
+		forwardedFor []string
+		realIp       string
+		want         string
+	}{
+		{"direct", "1.2.3.4:5000", nil, "", "1.2.3.4"},
+		{"untrusted ignores headers", "1.2.3.4:5000", []string{"5.6.7.8"}, "5.6.7.8", "1.2.3.4"},
+		{"trusted proxy", "10.0.0.1:5000", []string{"5.6.7.8"}, "", "5.6.7.8"},
+		{"spoofed left hops", "10.0.0.1:5000", []string{"9.9.9.9, 5.6.7.8, 10.0.0.2"}, "", "5.6.7.8"},
+		{"multiple headers", "10.0.0.1:5000", []string{"9.9.9.9", "5.6.7.8"}, "", "5.6.7.8"},
+		{"all hops trusted", "10.0.0.1:5000", []string{"10.0.0.3, 10.0.0.2"}, "", "10.0.0.3"},
+		{"invalid hop", "10.0.0.1:5000", []string{"bad, 10.0.0.2"}, "", "10.0.0.2"},
+		{"real ip", "10.0.0.1:5000", nil, "5.6.7.8", "5.6.7.8"},
+		{"no headers", "10.0.0.1:5000", nil, "", "10.0.0.1"},
+	}
+	for _, tt := range tests {
+		r := httptest.NewRequest("POST", "/login", nil)
+		r.RemoteAddr = tt.remoteAddr
+		for _, v := range tt.forwardedFor {
+			r.Header.Add("X-Forwarded-For", v)
+		}
+		if tt.realIp != "" {
+			r.Header.Set("X-Real-IP", tt.realIp)
+		}
+		if got := h.clientIp(r); got != tt.want {
+			t.Errorf("%s: clientIp() = %s, want %s", tt.name, got, tt.want)
+		}
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"github.com/NeuronAccount/account/cmd/account-api/handler"
 	"github.com/NeuronFramework/rest"
 	"github.com/go-openapi/loads"
+	"github.com/go-openapi/runtime"
 	"net/http"
 )
 
@@ -16,7 +17,7 @@ func main() {
 			return nil, err
 		}
 
-		h, err := handler.NewAccountHandler()
+		h, err := handler.NewAccountHandler(swaggerSpec)
 		if err != nil {
 			return nil, err
 		}
@@ -24,15 +25,36 @@ func main() {
 		api := operations.NewAccountAPI(swaggerSpec)
 		api.ServeError = rest.ServeError
 		api.BearerAuth = h.BearerAuth
+		api.ClientBasicAuth = h.ClientBasicAuth
+		api.OidcBearerAuth = h.OidcBearerAuth
+		api.APIAuthorizer = runtime.AuthorizerFunc(h.AuthorizeRequest)
+		api.GetJwksHandler = operations.GetJwksHandlerFunc(h.GetJwks)
+		api.GetOpenidConfigurationHandler = operations.GetOpenidConfigurationHandlerFunc(h.GetOpenidConfiguration)
+		api.IntrospectTokenHandler = operations.IntrospectTokenHandlerFunc(h.IntrospectToken)
+		api.RegisterOauthClientHandler = operations.RegisterOauthClientHandlerFunc(h.RegisterOauthClient)
+		api.AuthorizeHandler = operations.AuthorizeHandlerFunc(h.Authorize)
+		api.ApproveAuthorizationHandler = operations.ApproveAuthorizationHandlerFunc(h.ApproveAuthorization)
+		api.OauthTokenHandler = operations.OauthTokenHandlerFunc(h.OauthToken)
+		api.GetOidcUserInfoHandler = operations.GetOidcUserInfoHandlerFunc(h.GetOidcUserInfo)
+		api.PostOidcUserInfoHandler = operations.PostOidcUserInfoHandlerFunc(h.PostOidcUserInfo)
 		api.SendSmsCodeHandler = operations.SendSmsCodeHandlerFunc(h.SendSmsCode)
 		api.SendLoginSmsCodeHandler = operations.SendLoginSmsCodeHandlerFunc(h.SendLoginSmsCode)
 		api.SmsLoginHandler = operations.SmsLoginHandlerFunc(h.SmsLogin)
 		api.PhonePasswordLoginHandler = operations.PhonePasswordLoginHandlerFunc(h.PhonePasswordLogin)
 		api.LogoutHandler = operations.LogoutHandlerFunc(h.Logout)
 		api.RefreshTokenHandler = operations.RefreshTokenHandlerFunc(h.RefreshToken)
+		api.ListSessionsHandler = operations.ListSessionsHandlerFunc(h.ListSessions)
+		api.RevokeSessionHandler = operations.RevokeSessionHandlerFunc(h.RevokeSession)
+		api.RevokeAllSessionsHandler = operations.RevokeAllSessionsHandlerFunc(h.RevokeAllSessions)
+		api.ForceLogoutHandler = operations.ForceLogoutHandlerFunc(h.ForceLogout)
+		api.StepUpHandler = operations.StepUpHandlerFunc(h.StepUp)
 		api.OauthStateHandler = operations.OauthStateHandlerFunc(h.OauthState)
 		api.OauthJumpHandler = operations.OauthJumpHandlerFunc(h.OauthJump)
 		api.ResetPasswordHandler = operations.ResetPasswordHandlerFunc(h.ResetPassword)
+		api.PasswordChallengeHandler = operations.PasswordChallengeHandlerFunc(h.PasswordChallenge)
+		api.CheckPasswordHandler = operations.CheckPasswordHandlerFunc(h.CheckPassword)
+		api.SetPasswordHandler = operations.SetPasswordHandlerFunc(h.SetPassword)
+		api.ChangePasswordHandler = operations.ChangePasswordHandlerFunc(h.ChangePassword)
 		api.GetUserInfoHandler = operations.GetUserInfoHandlerFunc(h.GetUserInfo)
 		api.SetUserNameHandler = operations.SetUserNameHandlerFunc(h.SetUserName)
 		api.SetUserIconHandler = operations.SetUserIconHandlerFunc(h.SetUserIcon)
@@ -0,0 +1,18 @@
+package models
+
+const (
+	JwtAlgorithmRS256 = "RS256"
+	JwtAlgorithmES256 = "ES256"
+)
+
+type JsonWebKey struct {
+	Kty string
+	Use string
+	Alg string
+	Kid string
+	N   string
+	E   string
+	Crv string
+	X   string
+	Y   string
+}
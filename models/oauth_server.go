@@ -0,0 +1,88 @@
+package models
+
+const (
+	OauthResponseTypeCode = "code"
+
+	OauthGrantTypeAuthorizationCode = "authorization_code"
+	OauthGrantTypeRefreshToken      = "refresh_token"
+	OauthGrantTypeClientCredentials = "client_credentials"
+	OauthGrantTypeTokenExchange     = "urn:ietf:params:oauth:grant-type:token-exchange"
+
+	//RFC 8693定义的token类型，user_id为本服务扩展，表示subject_token为用户Id
+	OauthTokenTypeAccessToken = "urn:ietf:params:oauth:token-type:access_token"
+	OauthTokenTypeUserId      = "urn:neuron-account:params:oauth:token-type:user_id"
+
+	PkceMethodS256 = "S256"
+
+	OauthAuthorizationCodeExpireSeconds = 60 //授权码有效期1分钟
+
+	OauthTokenTypeBearer = "Bearer"
+)
+
+// RFC 6749定义的错误码，授权端点通过重定向返回给客户端
+const (
+	OauthErrorInvalidRequest          = "invalid_request"
+	OauthErrorInvalidClient           = "invalid_client"
+	OauthErrorInvalidGrant            = "invalid_grant"
+	OauthErrorUnauthorizedClient      = "unauthorized_client"
+	OauthErrorUnsupportedGrantType    = "unsupported_grant_type"
+	OauthErrorUnsupportedResponseType = "unsupported_response_type"
+	OauthErrorAccessDenied            = "access_denied"
+	OauthErrorInvalidScope            = "invalid_scope"
+)
+
+type OauthClient struct {
+	ClientId     string
+	ClientSecret string //仅注册时返回
+	ClientName   string
+	RedirectUris []string
+	IsPublic     bool
+	Audience     string
+	GrantTypes   []string //为空时为authorization_code和refresh_token
+	Scope        string   //client_credentials可申请的scope
+}
+
+type AuthorizeParams struct {
+	ResponseType        string
+	ClientId            string
+	RedirectUri         string
+	Scope               string
+	State               string
+	CodeChallenge       string
+	CodeChallengeMethod string
+	Nonce               string
+}
+
+type AuthorizeResult struct {
+	ConsentRequired bool //需要用户确认授权，此时RedirectUri为空
+	ClientName      string
+	Scope           string
+	RedirectUri     string //携带授权码或错误信息的回调地址
+}
+
+type OauthTokenParams struct {
+	GrantType    string
+	ClientId     string
+	ClientSecret string
+	Code         string
+	RedirectUri  string
+	CodeVerifier string
+	RefreshToken string
+	Scope        string
+
+	SubjectToken     string
+	SubjectTokenType string
+	ActorToken       string
+	ActorTokenType   string
+}
+
+type OauthToken struct {
+	AccessToken  string
+	TokenType    string
+	ExpiresIn    int64
+	RefreshToken string
+	Scope        string
+	IdToken      string //scope包含openid时返回
+
+	IssuedTokenType string //token exchange时返回
+}
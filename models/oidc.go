@@ -0,0 +1,41 @@
+package models
+
+const (
+	OidcScopeOpenid  = "openid"
+	OidcScopeProfile = "profile"
+	OidcScopePhone   = "phone"
+)
+
+const OidcIdTokenExpireSeconds = 60 * 60 //id_token有效期1小时
+
+// RFC 8176定义的认证方式
+const (
+	AmrSms = "sms"
+	AmrPwd = "pwd"
+)
+
+type OpenidConfiguration struct {
+	Issuer                            string
+	AuthorizationEndpoint             string
+	TokenEndpoint                     string
+	UserinfoEndpoint                  string
+	JwksUri                           string
+	IntrospectionEndpoint             string
+	ScopesSupported                   []string
+	ResponseTypesSupported            []string
+	GrantTypesSupported               []string
+	SubjectTypesSupported             []string
+	IdTokenSigningAlgValuesSupported  []string
+	TokenEndpointAuthMethodsSupported []string
+	CodeChallengeMethodsSupported     []string
+	ClaimsSupported                   []string
+}
+
+type OidcUserInfo struct {
+	Subject             string
+	Name                string
+	PreferredUsername   string
+	Picture             string
+	PhoneNumber         string
+	PhoneNumberVerified bool
+}
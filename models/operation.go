@@ -6,11 +6,26 @@ const (
 	OperationSendSmsCode        = "SEND_SMS_CODE"
 	OperationSmsLogin           = "SMS_LOGIN"
 	OperationPhonePasswordLogin = "PHONE_PASSWORD_LOGIN"
+	OperationLoginLockout       = "LOGIN_LOCKOUT"
+	OperationLoginUnlock        = "LOGIN_UNLOCK"
 	OperationLogout             = "LOGOUT"
+	OperationLogoutAll          = "LOGOUT_ALL"
+	OperationForceLogout        = "FORCE_LOGOUT"
 	OperationBindPhone          = "BIND_PHONE"
 	OperationUnbindPhone        = "UNBIND_PHONE"
 	OperationResetPassword      = "RESET_PASSWORD"
+	OperationSetPassword        = "SET_PASSWORD"
+	OperationChangePassword     = "CHANGE_PASSWORD"
 	OperationRemoveAccount      = "REMOVE_ACCOUNT"
+	OperationRefreshTokenReuse  = "REFRESH_TOKEN_REUSE"
+	OperationRevokeSession      = "REVOKE_SESSION"
+	OperationRevokeAllSessions  = "REVOKE_ALL_SESSIONS"
+	OperationOauthConsent       = "OAUTH_CONSENT"
+	OperationOauthCodeReuse     = "OAUTH_CODE_REUSE"
+	OperationStepUp             = "STEP_UP"
+	OperationImpersonate        = "IMPERSONATE"
+	OperationImpersonatedCall   = "IMPERSONATED_CALL"
+	OperationImpersonatedDenied = "IMPERSONATED_DENIED"
 )
 
 type AccountOperation struct {
@@ -22,6 +37,8 @@ type AccountOperation struct {
 	PhoneEncrypted string
 	SmsScene       string
 	OtherUserId    string
+	ActorId        string //冒充用户操作时为管理员标识
+	Detail         string
 }
 
 type OperationQuery struct {
@@ -0,0 +1,7 @@
+package models
+
+// 密码哈希以PHC字符串格式存储，算法及参数随哈希一起保存，参数升级后登录时重新哈希
+const (
+	PasswordHashArgon2id = "argon2id"
+	PasswordHashBcrypt   = "bcrypt"
+)
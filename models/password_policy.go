@@ -0,0 +1,17 @@
+package models
+
+// 密码不符合策略的原因，UI按Code提示用户
+const (
+	PasswordTooShort          = "PasswordTooShort"
+	PasswordTooLong           = "PasswordTooLong"
+	PasswordTooFewCharClasses = "PasswordTooFewCharClasses"
+	PasswordContainsPhone     = "PasswordContainsPhone"
+	PasswordContainsUserName  = "PasswordContainsUserName"
+	PasswordBreached          = "PasswordBreached"
+	PasswordReused            = "PasswordReused"
+)
+
+type PasswordViolation struct {
+	Code    string
+	Message string
+}
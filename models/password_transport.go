@@ -0,0 +1,14 @@
+package models
+
+// 密码加密传输：客户端获取一次性nonce及服务端公钥，
+// 用RSA-OAEP-256加密{"nonce":"...","password":"..."}，密文以不带填充的base64url提交
+const (
+	PasswordTransportAlgorithm     = "RSA-OAEP-256"
+	PasswordChallengeExpireSeconds = 5 * 60
+)
+
+type PasswordChallenge struct {
+	Nonce     string
+	ExpiresIn int64
+	Key       *JsonWebKey
+}
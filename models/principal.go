@@ -0,0 +1,27 @@
+package models
+
+import "time"
+
+const (
+	SubjectTypeUser   = "user"
+	SubjectTypeClient = "client" //服务间调用，token的主体是客户端本身
+)
+
+type Principal struct {
+	SubjectType string
+	UserId      string //服务间调用时为空
+	SessionId   string
+	ClientId    string //第三方客户端或服务的token才有
+	Scope       string
+	AuthTime    time.Time //会话最近一次认证的时间，服务间调用时为零值
+	Amr         []string
+	ActorId     string //管理员冒充用户时为管理员的用户Id或客户端Id
+}
+
+func (p *Principal) IsClient() bool {
+	return p.SubjectType == SubjectTypeClient
+}
+
+func (p *Principal) IsImpersonated() bool {
+	return p.ActorId != ""
+}
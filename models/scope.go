@@ -0,0 +1,39 @@
+package models
+
+import "strings"
+
+// 接口在swagger.json中通过x-scopes声明需要的scope
+const (
+	ScopeAccountRead    = "account:read"
+	ScopeAccountWrite   = "account:write"
+	ScopeOperationsRead = "operations:read"
+	ScopeClientsWrite   = "clients:write"
+	ScopeOauthAuthorize = "oauth:authorize"
+
+	ScopeUsersImpersonate = "users:impersonate" //管理客户端通过token exchange冒充用户
+	ScopeUsersLogout      = "users:logout"      //管理客户端强制用户退出登录
+)
+
+// 第一方登录获得的scope，确认授权和注册客户端只能由用户本人在第一方应用中完成
+var FirstPartyScope = strings.Join([]string{
+	ScopeAccountRead,
+	ScopeAccountWrite,
+	ScopeOperationsRead,
+	ScopeClientsWrite,
+	ScopeOauthAuthorize,
+}, " ")
+
+// 冒充用户得到的token可以申请的scope，不能授权第三方或注册客户端
+var ImpersonationScope = strings.Join([]string{
+	ScopeAccountRead,
+	ScopeAccountWrite,
+	ScopeOperationsRead,
+}, " ")
+
+// 第三方客户端可以申请的scope，users:impersonate等服务权限只能由运维直接分配；
+// 第三方token的audience不是本服务，不能申请访问本服务API的account:read等scope
+var ThirdPartyScope = strings.Join([]string{
+	OidcScopeOpenid,
+	OidcScopeProfile,
+	OidcScopePhone,
+}, " ")
@@ -2,12 +2,14 @@ package models
 
 const SmsCodeValidSeconds = 5 * 60 //验证码有效期5分钟
 const SmsCodeLength = 4            //验证码长度为4
+const SmsCodeMaxAttempts = 5       //每个验证码最多校验5次
 
 const (
 	SmsSceneSmsLogin      = "SMS_LOGIN"
 	SmsSceneResetPassword = "RESET_PASSWORD"
 	SmsSceneBindPhone     = "BIND_PHONE"
 	SmsSceneUnbindPhone   = "UNBIND_PHONE"
+	SmsSceneStepUp        = "STEP_UP" //敏感操作前重新认证，发送到已绑定的手机号
 )
 
 type SendSmsCodeParams struct {
@@ -16,4 +18,5 @@ type SendSmsCodeParams struct {
 	Phone       string
 	CaptchaId   string
 	CaptchaCode string
+	Device      *DeviceInfo
 }
@@ -0,0 +1,18 @@
+package models
+
+const (
+	TokenTypeHintAccessToken  = "access_token"
+	TokenTypeHintRefreshToken = "refresh_token"
+)
+
+type TokenIntrospection struct {
+	Active    bool
+	Subject   string
+	ExpiresAt int64
+	IssuedAt  int64
+	Scope     string
+	ClientId  string
+	Audience  string
+	TokenType string
+	TokenId   string
+}
@@ -0,0 +1,18 @@
+package models
+
+import "time"
+
+type DeviceInfo struct {
+	DeviceId string
+	ClientIp string
+}
+
+type UserSession struct {
+	SessionId    string
+	DeviceId     string
+	UserAgent    string
+	ClientIp     string
+	CreateTime   time.Time
+	LastSeenTime time.Time
+	IsCurrent    bool
+}
@@ -0,0 +1,92 @@
+package captcha
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func newTestSiteVerifier(t *testing.T, hostname string, handler http.HandlerFunc) *SiteVerifier {
+	server := httptest.NewTLSServer(handler)
+	t.Cleanup(server.Close)
+
+	v, err := NewSiteVerifier(&SiteVerifyOptions{VerifyUrl: server.URL, Secret: "secret", Hostname: hostname})
+	if err != nil {
+		t.Fatal(err)
+	}
+	v.client = server.Client()
+
+	return v
+}
+
+func TestSiteVerifierVerifyCaptcha(t *testing.T) {
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		if r.PostFormValue("secret") != "secret" {
+			w.Write([]byte(`{"success":false,"error-codes":["invalid-input-secret"]}`))
+			return
+		}
+		if r.PostFormValue("response") != "good-token" {
+			w.Write([]byte(`{"success":false,"error-codes":["invalid-input-response"]}`))
+			return
+		}
+		w.Write([]byte(`{"success":true,"hostname":"account.example.com"}`))
+	}
+
+	tests := []struct {
+		name        string
+		hostname    string
+		captchaCode string
+		want        bool
+	}{
+		{"valid", "", "good-token", true},
+		{"valid hostname", "account.example.com", "good-token", true},
+		{"invalid token", "", "bad-token", false},
+		{"empty token", "", "", false},
+		{"other hostname", "other.example.com", "good-token", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := newTestSiteVerifier(t, tt.hostname, handler)
+			ok, err := v.VerifyCaptcha(context.Background(), "", tt.captchaCode)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if ok != tt.want {
+				t.Errorf("VerifyCaptcha() = %v, want %v", ok, tt.want)
+			}
+		})
+	}
+}
+
+func TestSiteVerifierServerError(t *testing.T) {
+	v := newTestSiteVerifier(t, "", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+
+	ok, err := v.VerifyCaptcha(context.Background(), "", "good-token")
+	if err == nil || ok {
+		t.Errorf("VerifyCaptcha() = %v, %v, want error", ok, err)
+	}
+}
+
+func TestNewSiteVerifierOptions(t *testing.T) {
+	tests := []struct {
+		name    string
+		options SiteVerifyOptions
+	}{
+		{"no url", SiteVerifyOptions{Secret: "secret"}},
+		{"http url", SiteVerifyOptions{VerifyUrl: "http://example.com/siteverify", Secret: "secret"}},
+		{"no secret", SiteVerifyOptions{VerifyUrl: "https://example.com/siteverify"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewSiteVerifier(&tt.options)
+			if err == nil {
+				t.Errorf("NewSiteVerifier() accepted %+v", tt.options)
+			}
+		})
+	}
+}
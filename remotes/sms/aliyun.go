@@ -1,6 +1,7 @@
 package sms
 
 import (
+	"context"
 	"crypto"
 	"crypto/hmac"
 	"encoding/base64"
@@ -14,12 +15,11 @@ import (
 	"net/url"
 	"sort"
 	"strings"
+	"sync"
 	"time"
 )
 
 const aliyunSmsUrl = "http://dysmsapi.aliyuncs.com"
-const accessKey = "GVI7f5CudVDQ9tIqvCMAB8HPmI39UD"
-const queryAccessKeyId = "LTAIiOpcTYrEjWqX"
 const queryTimestampFormat = "2006-01-02T15:04:05Z"
 const queryFormat = "JSON"
 const querySignatureMethod = "HMAC-SHA1"
@@ -27,8 +27,6 @@ const querySignatureVersion = "1.0"
 const queryAction = "SendSms"
 const queryVersion = "2017-05-25"
 const queryRegionId = "cn-hangzhou"
-const querySignName = "火星基地"
-const queryTemplateCode = "SMS_127158405"
 const queryTemplateParam = "{\"code\":\"%s\"}"
 
 type KV struct {
@@ -59,18 +57,52 @@ type AliyunSmsResponse struct {
 	BizId     string
 }
 
-type Service struct {
-	logger *zap.Logger
+// 阿里云短信
+type AliyunSender struct {
+	logger  *zap.Logger
+	options *AliyunOptions
+
+	configMutex   sync.RWMutex
+	config        *AliyunConfig
+	configModTime time.Time
 }
 
-func New() (s *Service, err error) {
-	s = &Service{}
+func NewAliyunSender(options *AliyunOptions) (s *AliyunSender, err error) {
+	s = &AliyunSender{}
 	s.logger = log.TypedLogger(s)
+	s.options = options
+	s.options.setDefaults()
+
+	if s.options.ConfigFile == "" {
+		config := s.options.Config
+		err = config.validate()
+		if err != nil {
+			return nil, err
+		}
+		s.config = &config
+
+		return s, nil
+	}
+
+	err = s.reloadConfig()
+	if err != nil {
+		return nil, err
+	}
+	go s.runConfigReload()
 
 	return s, nil
 }
 
-func (s *Service) encodeUrl(str string) (r string) {
+// 日志中只保留手机号前3位和后4位
+func maskPhone(phone string) string {
+	if len(phone) < 7 {
+		return "****"
+	}
+
+	return phone[:3] + "****" + phone[len(phone)-4:]
+}
+
+func (s *AliyunSender) encodeUrl(str string) (r string) {
 	r = url.QueryEscape(str)
 	r = strings.Replace(r, "+", "%20", -1)
 	r = strings.Replace(r, "*", "%2A", -1)
@@ -79,8 +111,8 @@ func (s *Service) encodeUrl(str string) (r string) {
 	return r
 }
 
-func (s *Service) signature(stringToSign string) (signature string, err error) {
-	hmacSha1 := hmac.New(crypto.SHA1.New, []byte(accessKey+"&"))
+func (s *AliyunSender) signature(accessKeySecret string, stringToSign string) (signature string, err error) {
+	hmacSha1 := hmac.New(crypto.SHA1.New, []byte(accessKeySecret+"&"))
 	_, err = hmacSha1.Write([]byte(stringToSign))
 	if err != nil {
 		return "", err
@@ -90,9 +122,11 @@ func (s *Service) signature(stringToSign string) (signature string, err error) {
 	return base64.StdEncoding.EncodeToString(sig), nil
 }
 
-func (s *Service) buildUrl(phone string, smsCode string, smsCodeId string) (urlString string, err error) {
+func (s *AliyunSender) buildUrl(config *AliyunConfig, phone string, smsCode string, smsCodeId string) (
+	urlString string, err error) {
+
 	query := KVArray{}
-	query = append(query, &KV{K: "AccessKeyId", V: queryAccessKeyId})
+	query = append(query, &KV{K: "AccessKeyId", V: config.AccessKeyId})
 	query = append(query, &KV{K: "Timestamp", V: time.Now().UTC().Format(queryTimestampFormat)})
 	query = append(query, &KV{K: "Format", V: queryFormat})
 	query = append(query, &KV{K: "SignatureMethod", V: querySignatureMethod})
@@ -103,8 +137,8 @@ func (s *Service) buildUrl(phone string, smsCode string, smsCodeId string) (urlS
 	query = append(query, &KV{K: "Version", V: queryVersion})
 	query = append(query, &KV{K: "RegionId", V: queryRegionId})
 	query = append(query, &KV{K: "PhoneNumbers", V: phone})
-	query = append(query, &KV{K: "SignName", V: querySignName})
-	query = append(query, &KV{K: "TemplateCode", V: queryTemplateCode})
+	query = append(query, &KV{K: "SignName", V: config.SignName})
+	query = append(query, &KV{K: "TemplateCode", V: config.TemplateCode})
 	query = append(query, &KV{K: "TemplateParam", V: fmt.Sprintf(queryTemplateParam, smsCode)})
 	query = append(query, &KV{K: "OutId", V: smsCodeId})
 
@@ -118,7 +152,7 @@ func (s *Service) buildUrl(phone string, smsCode string, smsCodeId string) (urlS
 	sortedQueryString := strings.Join(queryStrings, "&")
 
 	stringToSign := "GET" + "&" + s.encodeUrl("/") + "&" + s.encodeUrl(sortedQueryString)
-	signature, err := s.signature(stringToSign)
+	signature, err := s.signature(config.AccessKeySecret, stringToSign)
 	if err != nil {
 		return "", err
 	}
@@ -128,7 +162,7 @@ func (s *Service) buildUrl(phone string, smsCode string, smsCodeId string) (urlS
 	return urlString, nil
 }
 
-func (s *Service) wrapError(code string, message string) (err error) {
+func (s *AliyunSender) wrapError(code string, message string) (err error) {
 	switch code {
 	case "OK":
 		return nil
@@ -141,33 +175,42 @@ func (s *Service) wrapError(code string, message string) (err error) {
 	}
 }
 
-func (s *Service) SendSms(phone string, smsCode string, smsCodeId string) (requestId string, err error) {
-	urlString, err := s.buildUrl(phone, smsCode, smsCodeId)
+func (s *AliyunSender) SendSms(ctx context.Context, phone string, smsCode string, smsCodeId string) (
+	requestId string, err error) {
+
+	//url中包含签名、手机号及验证码，不能写入日志
+	urlString, err := s.buildUrl(s.getConfig(), phone, smsCode, smsCodeId)
 	if err != nil {
 		return "", err
 	}
 
-	s.logger.Info("SendSms", zap.String("url", urlString))
-
 	req, err := http.NewRequest("GET", urlString, nil)
 	if err != nil {
 		return "", err
 	}
 
 	c := &http.Client{}
-	resp, err := c.Do(req)
+	resp, err := c.Do(req.WithContext(ctx))
 	if err != nil {
+		//url.Error中包含完整的url
+		if urlErr, ok := err.(*url.Error); ok {
+			return "", urlErr.Err
+		}
 		return "", err
 	}
+	defer resp.Body.Close()
 
 	smsResponse := AliyunSmsResponse{}
 	decoder := json.NewDecoder(resp.Body)
-	decoder.Decode(&smsResponse)
+	err = decoder.Decode(&smsResponse)
 	if err != nil {
 		return "", err
 	}
 
-	s.logger.Info("SendSms", zap.Any("resp", smsResponse))
+	s.logger.Info("SendSms",
+		zap.String("phone", maskPhone(phone)),
+		zap.String("requestId", smsResponse.RequestId),
+		zap.String("code", smsResponse.Code))
 
 	return smsResponse.RequestId, s.wrapError(smsResponse.Code, smsResponse.Message)
 }
@@ -0,0 +1,100 @@
+package sms
+
+import (
+	"encoding/json"
+	"fmt"
+	"go.uber.org/zap"
+	"io/ioutil"
+	"os"
+	"time"
+)
+
+// 阿里云短信的密钥及模板，不能写入日志
+type AliyunConfig struct {
+	AccessKeyId     string `json:"accessKeyId"`
+	AccessKeySecret string `json:"accessKeySecret"`
+	SignName        string `json:"signName"`
+	TemplateCode    string `json:"templateCode"`
+}
+
+func (c *AliyunConfig) validate() (err error) {
+	switch {
+	case c.AccessKeyId == "":
+		return fmt.Errorf("阿里云短信配置缺少accessKeyId")
+	case c.AccessKeySecret == "":
+		return fmt.Errorf("阿里云短信配置缺少accessKeySecret")
+	case c.SignName == "":
+		return fmt.Errorf("阿里云短信配置缺少signName")
+	case c.TemplateCode == "":
+		return fmt.Errorf("阿里云短信配置缺少templateCode")
+	}
+
+	return nil
+}
+
+type AliyunOptions struct {
+	Config         AliyunConfig  //ConfigFile为空时使用
+	ConfigFile     string        //JSON格式的配置文件，如挂载的密钥，修改后自动重新加载
+	ReloadInterval time.Duration //检查ConfigFile是否修改的间隔
+}
+
+func (o *AliyunOptions) setDefaults() {
+	if o.ReloadInterval == 0 {
+		o.ReloadInterval = time.Minute
+	}
+}
+
+func (s *AliyunSender) getConfig() *AliyunConfig {
+	s.configMutex.RLock()
+	defer s.configMutex.RUnlock()
+
+	return s.config
+}
+
+// 文件修改时间变化时重新加载，新配置无效时继续使用原配置
+func (s *AliyunSender) reloadConfig() (err error) {
+	fileInfo, err := os.Stat(s.options.ConfigFile)
+	if err != nil {
+		return err
+	}
+	if s.getConfig() != nil && fileInfo.ModTime().Equal(s.configModTime) {
+		return nil
+	}
+
+	data, err := ioutil.ReadFile(s.options.ConfigFile)
+	if err != nil {
+		return err
+	}
+	config := &AliyunConfig{}
+	err = json.Unmarshal(data, config)
+	if err != nil {
+		return fmt.Errorf("阿里云短信配置文件格式错误")
+	}
+	err = config.validate()
+	if err != nil {
+		return err
+	}
+
+	s.configMutex.Lock()
+	s.config = config
+	s.configModTime = fileInfo.ModTime()
+	s.configMutex.Unlock()
+
+	s.logger.Info("reloadConfig",
+		zap.String("file", s.options.ConfigFile),
+		zap.Time("modTime", fileInfo.ModTime()))
+
+	return nil
+}
+
+func (s *AliyunSender) runConfigReload() {
+	ticker := time.NewTicker(s.options.ReloadInterval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		err := s.reloadConfig()
+		if err != nil {
+			s.logger.Error("runConfigReload", zap.Error(err))
+		}
+	}
+}
@@ -0,0 +1,121 @@
+package sms
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/NeuronFramework/log"
+	"github.com/NeuronFramework/rand"
+	"go.uber.org/zap"
+	"os"
+	"sync"
+	"time"
+)
+
+// 内存中最多保留的短信数量
+const fakeMaxMessages = 1000
+
+type FakeMessage struct {
+	RequestId string    `json:"requestId"`
+	Phone     string    `json:"phone"`
+	SmsCode   string    `json:"smsCode"`
+	SmsCodeId string    `json:"smsCodeId"`
+	SendTime  time.Time `json:"sendTime"`
+}
+
+// 不实际发送，只纪录短信，供测试及本地开发使用。
+// filePath不为空时每条短信以一行JSON追加到文件
+type FakeSender struct {
+	logger   *zap.Logger
+	filePath string
+
+	mutex    sync.Mutex
+	messages []*FakeMessage
+}
+
+func NewFakeSender(filePath string) (s *FakeSender, err error) {
+	s = &FakeSender{}
+	s.logger = log.TypedLogger(s)
+	s.filePath = filePath
+
+	if s.filePath != "" {
+		f, err := os.OpenFile(s.filePath, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+		if err != nil {
+			return nil, err
+		}
+		f.Close()
+	}
+
+	return s, nil
+}
+
+func (s *FakeSender) appendFile(m *FakeMessage) (err error) {
+	data, err := json.Marshal(m)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.OpenFile(s.filePath, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	_, err = f.Write(append(data, '\n'))
+	return err
+}
+
+func (s *FakeSender) SendSms(ctx context.Context, phone string, smsCode string, smsCodeId string) (
+	requestId string, err error) {
+
+	m := &FakeMessage{
+		RequestId: "fake-" + rand.NextHex(8),
+		Phone:     phone,
+		SmsCode:   smsCode,
+		SmsCodeId: smsCodeId,
+		SendTime:  time.Now(),
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.messages = append(s.messages, m)
+	if len(s.messages) > fakeMaxMessages {
+		s.messages = s.messages[len(s.messages)-fakeMaxMessages:]
+	}
+
+	if s.filePath != "" {
+		err = s.appendFile(m)
+		if err != nil {
+			return "", err
+		}
+	} else {
+		//仅用于本地开发，没有文件时从日志查看验证码
+		s.logger.Info("SendSms", zap.String("phone", phone), zap.String("smsCode", smsCode))
+	}
+
+	return m.RequestId, nil
+}
+
+func (s *FakeSender) Messages() (messages []*FakeMessage) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	messages = make([]*FakeMessage, len(s.messages))
+	copy(messages, s.messages)
+
+	return messages
+}
+
+// 发送到该手机号的最后一条短信，没有时返回nil
+func (s *FakeSender) LastMessage(phone string) *FakeMessage {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	for i := len(s.messages) - 1; i >= 0; i-- {
+		if s.messages[i].Phone == phone {
+			return s.messages[i]
+		}
+	}
+
+	return nil
+}
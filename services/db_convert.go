@@ -4,6 +4,7 @@ import (
 	"github.com/NeuronAccount/account/models"
 	"github.com/NeuronAccount/account/storages/neuron_account_db"
 	"strconv"
+	"strings"
 )
 
 func fromOperation(p *neuron_account_db.AccountOperation) (r *models.AccountOperation) {
@@ -20,6 +21,8 @@ func fromOperation(p *neuron_account_db.AccountOperation) (r *models.AccountOper
 	r.PhoneEncrypted = p.PhoneEncrypted
 	r.SmsScene = p.SmsScene
 	r.OtherUserId = p.OtherUserId
+	r.ActorId = p.ActorId
+	r.Detail = p.Detail
 
 	return r
 }
@@ -49,6 +52,8 @@ func toOperation(p *models.AccountOperation) (r *neuron_account_db.AccountOperat
 	r.PhoneEncrypted = p.PhoneEncrypted
 	r.SmsScene = string(p.SmsScene)
 	r.OtherUserId = p.OtherUserId
+	r.ActorId = p.ActorId
+	r.Detail = p.Detail
 
 	return r
 }
@@ -93,3 +98,68 @@ func fromOauthAccountList(p []*neuron_account_db.OauthAccount) (r []*models.Oaut
 
 	return r
 }
+
+func fromUserSession(p *neuron_account_db.UserSession) (r *models.UserSession) {
+	if p == nil {
+		return nil
+	}
+
+	r = &models.UserSession{}
+	r.SessionId = p.SessionId
+	r.DeviceId = p.DeviceId
+	r.UserAgent = p.UserAgent
+	r.ClientIp = p.ClientIp
+	r.CreateTime = p.CreateTime
+	r.LastSeenTime = p.LastSeenTime
+
+	return r
+}
+
+func fromUserSessionList(p []*neuron_account_db.UserSession) (r []*models.UserSession) {
+	if p == nil {
+		return nil
+	}
+
+	r = make([]*models.UserSession, len(p))
+	for i, v := range p {
+		r[i] = fromUserSession(v)
+	}
+
+	return r
+}
+
+// 回调地址以空格分隔存储，地址本身不含空格
+func splitRedirectUris(p string) (r []string) {
+	return strings.Fields(p)
+}
+
+func joinRedirectUris(p []string) (r string) {
+	return strings.Join(p, " ")
+}
+
+// 未指定时为授权码模式
+func splitGrantTypes(p string) (r []string) {
+	r = strings.Fields(p)
+	if len(r) == 0 {
+		return []string{models.OauthGrantTypeAuthorizationCode, models.OauthGrantTypeRefreshToken}
+	}
+
+	return r
+}
+
+func fromOauthClient(p *neuron_account_db.OauthClient) (r *models.OauthClient) {
+	if p == nil {
+		return nil
+	}
+
+	r = &models.OauthClient{}
+	r.ClientId = p.ClientId
+	r.ClientName = p.ClientName
+	r.RedirectUris = splitRedirectUris(p.RedirectUris)
+	r.IsPublic = p.IsPublic != 0
+	r.Audience = p.Audience
+	r.GrantTypes = splitGrantTypes(p.GrantTypes)
+	r.Scope = p.Scope
+
+	return r
+}
@@ -1,61 +1,287 @@
 package services
 
 import (
-	"github.com/NeuronAccount/account/remotes/sms"
+	"context"
+	"fmt"
+	"github.com/NeuronAccount/account/models"
+	"github.com/NeuronAccount/account/storages/counter"
 	"github.com/NeuronAccount/account/storages/neuron_account_db"
 	"github.com/NeuronFramework/log"
 	"github.com/NeuronFramework/rand"
-	"github.com/dgrijalva/jwt-go"
 	"go.uber.org/zap"
+	"os"
 	"strings"
+	"sync"
 	"time"
 )
 
 type AccountServiceOptions struct {
+	Dev bool //本地开发环境，允许不配置图形验证码等，不能用于生产
+
+	JwtAlgorithm          string        //签名算法，RS256或ES256
+	JwtKeyRotateInterval  time.Duration //每个密钥用于签名的时长
+	JwtKeyOverlap         time.Duration //新密钥提前发布及旧密钥签名结束后继续用于验证的时长
+	JwtKeyRefreshInterval time.Duration //检查轮换及重新加载密钥的间隔
+	JwtClockSkew          time.Duration //校验exp、nbf、iat时允许的时钟误差
+	JwtKeyEncryptionKey   string        //加密存储签名私钥，base64编码的32字节，只有开发环境可为空
+
+	Issuer                string //OIDC issuer，即本服务API的根地址
+	AuthorizationEndpoint string //用户确认授权的页面地址
+
+	PasswordHashAlgorithm string //新密码使用的算法，argon2id或bcrypt
+	PasswordArgon2Memory  uint32 //KiB
+	PasswordArgon2Time    uint32
+	PasswordArgon2Threads uint8
+	PasswordBcryptCost    int
+
+	PasswordMinLength         int
+	PasswordMaxLength         int
+	PasswordMinCharClasses    int    //大写、小写、数字、符号中至少包含的种类
+	PasswordHistorySize       int    //不能与最近几次的密码相同，含当前密码，为0时使用默认值5，小于0时不检查
+	PasswordBreachedCorpusDir string //泄露密码库目录，按SHA-1前5位分文件，为空时不检查
+	PasswordCheckHourlyMax    int64  //每个用户每小时调用CheckPassword的次数，小于0时不限制
+
+	PasswordTransportKeyPem   string //密码加密传输的RSA私钥，PKCS8 PEM格式，只有开发环境可为空
+	PasswordTransportRequired bool   //为true时不再接受未加密的passwordHash1
+
+	CounterStore    counter.Store //失败次数、密码传输nonce等计数，默认为多实例共享的数据库实现
+	CaptchaVerifier CaptchaVerifier
+	SmsSender       SmsSender
+	LoginPhoneGuard LoginGuardOptions   //密码登录按手机号限制
+	LoginIpGuard    LoginGuardOptions   //密码登录按IP限制
+	SmsPhoneLimit   SmsRateLimitOptions //按手机号限制短信发送
+	SmsSceneLimit   SmsRateLimitOptions //按手机号及场景限制
+	SmsIpLimit      SmsRateLimitOptions
+	SmsDeviceLimit  SmsRateLimitOptions
+	SmsCodeHmacKey  string //存储验证码哈希的密钥，只有开发环境可为空
+
+	RefreshTokenSlidingLifetime  time.Duration //RefreshToken未使用时的有效期，每次轮换重新计算
+	RefreshTokenAbsoluteLifetime time.Duration //同一token族从登录开始的最长有效期
+
+	AccessTokenAudience               string        //本服务API的audience，其它audience的token不能访问本服务
+	AccessTokenRevocationSyncInterval time.Duration //同步其它实例撤销的AccessToken的间隔
+
+	StepUpMaxAge       time.Duration //敏感操作要求会话在该时长内完成过认证
+	StepUpOperations   []string      //需要二次认证的接口，即swagger中须认证的operationId
+	StepUpSmsHourlyMax int64         //每个用户每小时通过短信验证码二次认证的次数，小于0时不限制
+
+	ImpersonationForbiddenOperations []string //冒充用户的token不能调用的接口
+
+	JanitorDisabled         bool          //不在本实例运行过期数据清理
+	JanitorLockLease        time.Duration //清理任务的锁租约，多实例中只有持有者执行清理
+	JanitorAccessToken      JanitorTableOptions
+	JanitorRefreshToken     JanitorTableOptions
+	JanitorUserSession      JanitorTableOptions
+	JanitorSmsCode          JanitorTableOptions
+	JanitorOauthState       JanitorTableOptions
+	JanitorAccountOperation JanitorTableOptions
+	JanitorCounter          JanitorTableOptions
+
+	JanitorOauthAuthorizationCode JanitorTableOptions
+}
+
+func (o *AccountServiceOptions) setDefaults() {
+	if o.JwtAlgorithm == "" {
+		o.JwtAlgorithm = models.JwtAlgorithmRS256
+	}
+	if o.Issuer == "" {
+		o.Issuer = "http://localhost:8083/api/v1/accounts"
+	}
+	if o.AuthorizationEndpoint == "" {
+		o.AuthorizationEndpoint = o.Issuer + "/oauth2/authorize"
+	}
+	if o.JwtKeyRotateInterval == 0 {
+		o.JwtKeyRotateInterval = time.Hour * 24 * 7
+	}
+	if o.JwtKeyOverlap == 0 {
+		o.JwtKeyOverlap = time.Hour * 24
+	}
+	if o.JwtKeyRefreshInterval == 0 {
+		o.JwtKeyRefreshInterval = time.Minute
+	}
+	if o.JwtClockSkew == 0 {
+		o.JwtClockSkew = time.Second * 30
+	}
+	if o.PasswordHashAlgorithm == "" {
+		o.PasswordHashAlgorithm = models.PasswordHashArgon2id
+	}
+	if o.PasswordArgon2Memory == 0 {
+		o.PasswordArgon2Memory = 64 * 1024
+	}
+	if o.PasswordArgon2Time == 0 {
+		o.PasswordArgon2Time = 3
+	}
+	if o.PasswordArgon2Threads == 0 {
+		o.PasswordArgon2Threads = 2
+	}
+	if o.PasswordBcryptCost == 0 {
+		o.PasswordBcryptCost = 12
+	}
+	if o.PasswordMinLength == 0 {
+		o.PasswordMinLength = 8
+	}
+	if o.PasswordMaxLength == 0 {
+		o.PasswordMaxLength = 128
+	}
+	if o.PasswordMinCharClasses == 0 {
+		o.PasswordMinCharClasses = 2
+	}
+	if o.PasswordHistorySize == 0 {
+		o.PasswordHistorySize = 5
+	}
+	if o.PasswordCheckHourlyMax == 0 {
+		o.PasswordCheckHourlyMax = 30
+	}
+	o.LoginPhoneGuard.setDefaults(3, 10, time.Second)
+	o.LoginIpGuard.setDefaults(20, 100, time.Millisecond*100)
+	o.SmsPhoneLimit.setDefaults(time.Minute, 5, 10)
+	o.SmsSceneLimit.setDefaults(time.Minute, 3, 5)
+	o.SmsIpLimit.setDefaults(-1, 20, 100) //同一出口IP可能有多个用户，不限制间隔
+	o.SmsDeviceLimit.setDefaults(time.Second*10, 10, 20)
+	if o.RefreshTokenSlidingLifetime == 0 {
+		o.RefreshTokenSlidingLifetime = time.Hour * 24 * 14
+	}
+	if o.RefreshTokenAbsoluteLifetime == 0 {
+		o.RefreshTokenAbsoluteLifetime = time.Hour * 24 * 90
+	}
+	if o.AccessTokenAudience == "" {
+		o.AccessTokenAudience = "neuron-account"
+	}
+	if o.AccessTokenRevocationSyncInterval == 0 {
+		o.AccessTokenRevocationSyncInterval = time.Second * 5
+	}
+	if o.StepUpMaxAge == 0 {
+		o.StepUpMaxAge = time.Minute * 5
+	}
+	if o.StepUpOperations == nil {
+		o.StepUpOperations = []string{
+			"UnbindPhone", "UnbindOauthAccount", "SetPassword", "ChangePassword",
+		}
+	}
+	if o.StepUpSmsHourlyMax == 0 {
+		o.StepUpSmsHourlyMax = 10
+	}
+	if o.ImpersonationForbiddenOperations == nil {
+		o.ImpersonationForbiddenOperations = []string{
+			"SetPassword", "ChangePassword", "SendSmsCode", "BindPhone", "UnbindPhone",
+			"UnbindOauthAccount", "StepUp", "Logout", "RevokeSession", "RevokeAllSessions",
+		}
+	}
+	if o.JanitorLockLease == 0 {
+		o.JanitorLockLease = time.Minute * 2
+	}
+	o.JanitorAccessToken.setDefaults(time.Minute*10, time.Hour)
+	o.JanitorRefreshToken.setDefaults(time.Hour, time.Hour*24*7)
+	o.JanitorUserSession.setDefaults(time.Hour, time.Hour*24)
+	o.JanitorSmsCode.setDefaults(time.Minute*10, time.Hour*24)
+	o.JanitorOauthState.setDefaults(time.Minute*10, time.Hour*24)
+	o.JanitorAccountOperation.setDefaults(time.Hour, time.Hour*24*180)
+	o.JanitorCounter.setDefaults(time.Minute*10, time.Hour)
+	o.JanitorOauthAuthorizationCode.setDefaults(time.Minute*10, time.Hour*24)
 }
 
 type AccountService struct {
 	logger     *zap.Logger
+	instanceId string
 	options    *AccountServiceOptions
 	accountDB  *neuron_account_db.DB
-	smsService *sms.Service
+
+	jwtKeysMutex    sync.RWMutex
+	jwtKeys         []*jwtKey
+	jwtKeysLoadTime time.Time
+
+	jwtKeyEncryptionKey  []byte
+	passwordTransportKey *passwordTransportKey
+	smsCodeHmacKey       []byte
+
+	revokedAccessTokensMutex    sync.RWMutex
+	revokedAccessTokens         map[string]time.Time
+	revokedAccessTokensSyncTime time.Time
 }
 
 func NewAccountService(options *AccountServiceOptions) (s *AccountService, err error) {
 	s = &AccountService{}
 	s.logger = log.TypedLogger(s)
 	s.options = options
+	s.options.setDefaults()
+	hostname, _ := os.Hostname()
+	s.instanceId = hostname + "-" + rand.NextHex(8)
 	s.accountDB, err = neuron_account_db.NewDB()
 	if err != nil {
 		return nil, err
 	}
-	s.smsService, err = sms.New()
+	if s.options.SmsSender == nil {
+		return nil, fmt.Errorf("未配置SmsSender")
+	}
+	if s.options.CounterStore == nil {
+		s.options.CounterStore = counter.NewDBStore(s.accountDB)
+	}
+	//密码传输的nonce等须在各实例间共享，内存实现只用于开发环境
+	if _, ok := s.options.CounterStore.(*counter.MemoryStore); ok && !s.options.Dev {
+		return nil, fmt.Errorf("CounterStore不能使用内存实现")
+	}
+	err = s.checkCaptchaVerifier()
 	if err != nil {
 		return nil, err
 	}
 
-	return s, nil
-}
+	if s.options.PasswordBreachedCorpusDir != "" {
+		_, err = os.Stat(s.options.PasswordBreachedCorpusDir)
+		if err != nil {
+			return nil, err
+		}
+	}
 
-func (s *AccountService) generateJwt(accountId string) (tokenString string, err error) {
-	expiresTime := time.Now().Add(time.Hour)
-	userToken := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.StandardClaims{
-		Id:        rand.NextHex(16),
-		Subject:   accountId,
-		ExpiresAt: expiresTime.Unix(),
-	})
-	tokenString, err = userToken.SignedString([]byte("0123456789"))
+	s.passwordTransportKey, err = s.loadPasswordTransportKey()
 	if err != nil {
-		return "", err
+		return nil, err
+	}
+
+	s.smsCodeHmacKey, err = s.loadSmsCodeHmacKey()
+	if err != nil {
+		return nil, err
+	}
+	err = s.migrateSmsCodes(context.Background())
+	if err != nil {
+		return nil, err
 	}
 
-	return tokenString, nil
+	s.jwtKeyEncryptionKey, err = s.loadJwtKeyEncryptionKey()
+	if err != nil {
+		return nil, err
+	}
+	err = s.rotateJwtKeys(context.Background())
+	if err != nil {
+		return nil, err
+	}
+	err = s.waitForSigningJwtKey(context.Background())
+	if err != nil {
+		return nil, err
+	}
+	go s.runJwtKeyRotation()
+
+	s.revokedAccessTokens = make(map[string]time.Time)
+	err = s.syncRevokedAccessTokens(context.Background())
+	if err != nil {
+		return nil, err
+	}
+	go s.runRevokedAccessTokensSync()
+
+	if !s.options.JanitorDisabled {
+		s.startJanitor()
+	}
+
+	return s, nil
 }
 
 func (s *AccountService) encryptPhone(phone string) (phoneEncrypted string, err error) {
 	return phone, nil
 }
 
+func (s *AccountService) decryptPhone(phoneEncrypted string) (phone string, err error) {
+	return phoneEncrypted, nil
+}
+
 func (s *AccountService) maskPhone(phone string) (phoneMasked string) {
 	l := len(phone)
 	if l <= 6 {
@@ -86,7 +312,3 @@ func (s *AccountService) maskString(p string, nPrefix int, nSuffix int) (r strin
 
 	return p[0:nPrefix] + strings.Repeat("*", l-nPrefix-nSuffix) + p[l-nSuffix-1:l-1]
 }
-
-func (s *AccountService) calcPasswordHash(passwordHash1 string) (passwordHash2 string, err error) {
-	return passwordHash1, nil
-}
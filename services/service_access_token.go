@@ -0,0 +1,112 @@
+package services
+
+import (
+	"context"
+	"github.com/NeuronAccount/account/storages/neuron_account_db"
+	"github.com/NeuronFramework/rest"
+	"go.uber.org/zap"
+	"time"
+)
+
+// 已撤销且尚未过期的AccessToken，jti -> 过期时间
+func (s *AccountService) addRevokedAccessTokens(dbAccessTokenList []*neuron_account_db.AccessToken) {
+	s.revokedAccessTokensMutex.Lock()
+	defer s.revokedAccessTokensMutex.Unlock()
+
+	for _, v := range dbAccessTokenList {
+		s.revokedAccessTokens[v.Jti] = v.ExpireTime
+	}
+}
+
+func (s *AccountService) isAccessTokenRevoked(jti string) (revoked bool) {
+	s.revokedAccessTokensMutex.RLock()
+	defer s.revokedAccessTokensMutex.RUnlock()
+
+	_, revoked = s.revokedAccessTokens[jti]
+
+	return revoked
+}
+
+// 增量同步的起始时间，多往前取一个同步间隔，避免实例间时钟误差漏掉记录；首次同步时为零值，加载全部
+func (s *AccountService) revokedAccessTokensSyncFrom() (from time.Time) {
+	if s.revokedAccessTokensSyncTime.IsZero() {
+		return time.Time{}
+	}
+
+	return s.revokedAccessTokensSyncTime.Add(-s.options.AccessTokenRevocationSyncInterval)
+}
+
+// 合并新撤销的token，并清理已过期的缓存项
+func (s *AccountService) mergeRevokedAccessTokens(dbAccessTokenList []*neuron_account_db.AccessToken, now time.Time) {
+	s.addRevokedAccessTokens(dbAccessTokenList)
+
+	s.revokedAccessTokensMutex.Lock()
+	for jti, expireTime := range s.revokedAccessTokens {
+		if !expireTime.After(now) {
+			delete(s.revokedAccessTokens, jti)
+		}
+	}
+	s.revokedAccessTokensMutex.Unlock()
+
+	s.revokedAccessTokensSyncTime = now
+}
+
+// 增量加载其它实例撤销的token
+func (s *AccountService) syncRevokedAccessTokens(ctx context.Context) (err error) {
+	now := time.Now()
+
+	query := s.accountDB.AccessToken.Query().IsRevokedEqual(1).And().ExpireTimeGreater(now)
+	if from := s.revokedAccessTokensSyncFrom(); !from.IsZero() {
+		query = query.And().UpdateTimeGreaterEqual(from)
+	}
+	dbAccessTokenList, err := query.SelectList(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	s.mergeRevokedAccessTokens(dbAccessTokenList, now)
+
+	return nil
+}
+
+func (s *AccountService) runRevokedAccessTokensSync() {
+	ticker := time.NewTicker(s.options.AccessTokenRevocationSyncInterval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		err := s.syncRevokedAccessTokens(context.Background())
+		if err != nil {
+			s.logger.Error("runRevokedAccessTokensSync", zap.Error(err))
+		}
+	}
+}
+
+// 撤销会话下的AccessToken，sessionId为空时撤销用户全部的AccessToken
+func (s *AccountService) revokeAccessTokens(ctx *rest.Context, userId string, sessionId string) (err error) {
+	query := s.accountDB.AccessToken.Query().UserIdEqual(userId)
+	if sessionId != "" {
+		query = query.And().SessionIdEqual(sessionId)
+	}
+	dbAccessTokenList, err := query.And().IsRevokedEqual(0).And().ExpireTimeGreater(time.Now()).
+		SelectList(ctx, nil)
+	if err != nil {
+		return err
+	}
+	if len(dbAccessTokenList) == 0 {
+		return nil
+	}
+
+	//本实例立即生效，其它实例在下一次同步时生效
+	s.addRevokedAccessTokens(dbAccessTokenList)
+
+	query = s.accountDB.AccessToken.Query().UserIdEqual(userId)
+	if sessionId != "" {
+		query = query.And().SessionIdEqual(sessionId)
+	}
+	_, err = query.And().IsRevokedEqual(0).SetIsRevoked(1).Update(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
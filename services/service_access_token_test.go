@@ -0,0 +1,85 @@
+package services
+
+import (
+	"github.com/NeuronAccount/account/storages/neuron_account_db"
+	"testing"
+	"time"
+)
+
+func TestRevokedAccessTokensSyncFrom(t *testing.T) {
+	s := &AccountService{options: &AccountServiceOptions{AccessTokenRevocationSyncInterval: time.Second * 5}}
+	if from := s.revokedAccessTokensSyncFrom(); !from.IsZero() {
+		t.Errorf("revokedAccessTokensSyncFrom() first sync = %v, want zero", from)
+	}
+
+	syncTime := time.Now()
+	s.revokedAccessTokensSyncTime = syncTime
+	if from := s.revokedAccessTokensSyncFrom(); !from.Equal(syncTime.Add(-time.Second * 5)) {
+		t.Errorf("revokedAccessTokensSyncFrom() = %v, want %v", from, syncTime.Add(-time.Second*5))
+	}
+}
+
+func TestMergeRevokedAccessTokens(t *testing.T) {
+	s := &AccountService{options: &AccountServiceOptions{}, revokedAccessTokens: make(map[string]time.Time)}
+	now := time.Now()
+
+	//本实例撤销的token立即生效
+	s.addRevokedAccessTokens([]*neuron_account_db.AccessToken{
+		{Jti: "local", ExpireTime: now.Add(time.Minute)},
+		{Jti: "local-expiring", ExpireTime: now.Add(time.Second)},
+	})
+	if !s.isAccessTokenRevoked("local") || s.isAccessTokenRevoked("remote") {
+		t.Fatalf("isAccessTokenRevoked() before sync = %v", s.revokedAccessTokens)
+	}
+
+	//同步其它实例撤销的token，清理同步时已过期的缓存项
+	s.mergeRevokedAccessTokens([]*neuron_account_db.AccessToken{
+		{Jti: "remote", ExpireTime: now.Add(time.Minute)},
+	}, now.Add(time.Second*2))
+
+	tests := []struct {
+		jti  string
+		want bool
+	}{
+		{"local", true},
+		{"remote", true},
+		{"local-expiring", false},
+		{"unknown", false},
+	}
+	for _, tt := range tests {
+		if got := s.isAccessTokenRevoked(tt.jti); got != tt.want {
+			t.Errorf("isAccessTokenRevoked(%s) = %v, want %v", tt.jti, got, tt.want)
+		}
+	}
+	if !s.revokedAccessTokensSyncTime.Equal(now.Add(time.Second * 2)) {
+		t.Errorf("revokedAccessTokensSyncTime = %v", s.revokedAccessTokensSyncTime)
+	}
+}
+
+// 缓存中已撤销的jti在解析时被拒绝，其它token不受影响
+func TestParseRevokedAccessToken(t *testing.T) {
+	s := newJwtTestService(t)
+	newClaims := func() *accessTokenClaims {
+		claims := &accessTokenClaims{}
+		claims.Subject = "u1"
+		claims.Audience = s.options.AccessTokenAudience
+		claims.SessionId = "s1"
+		return claims
+	}
+	revokedClaims := newClaims()
+	revokedToken := signTestAccessToken(t, s, revokedClaims)
+	validToken := signTestAccessToken(t, s, newClaims())
+
+	s.mergeRevokedAccessTokens([]*neuron_account_db.AccessToken{
+		{Jti: revokedClaims.Id, ExpireTime: time.Now().Add(time.Minute)},
+	}, time.Now())
+
+	_, err := s.ParseAccessToken(nil, revokedToken)
+	if tokenErr, ok := err.(*TokenError); !ok || tokenErr.Reason != TokenErrorRevoked {
+		t.Errorf("ParseAccessToken() revoked = %v, want %s", err, TokenErrorRevoked)
+	}
+	_, err = s.ParseAccessToken(nil, validToken)
+	if err != nil {
+		t.Errorf("ParseAccessToken() valid = %v", err)
+	}
+}
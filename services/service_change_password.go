@@ -0,0 +1,143 @@
+package services
+
+import (
+	"fmt"
+	"github.com/NeuronAccount/account/models"
+	"github.com/NeuronAccount/account/storages/neuron_account_db"
+	"github.com/NeuronFramework/rest"
+)
+
+func (s *AccountService) validateUserNewPassword(ctx *rest.Context, userId string, newPasswordHash1 string) (err error) {
+	phone, userName, err := s.userPasswordContext(ctx, userId)
+	if err != nil {
+		return err
+	}
+
+	return s.validateNewPassword(newPasswordHash1, phone, userName)
+}
+
+// 更新密码并结束当前会话以外的全部会话，返回结束的会话数量
+func (s *AccountService) updatePassword(ctx *rest.Context, principal *models.Principal,
+	dbUserInfo *neuron_account_db.UserInfo, newPasswordHash1 string) (sessionCount int64, err error) {
+
+	err = s.checkPasswordReuse(ctx, dbUserInfo, newPasswordHash1)
+	if err != nil {
+		return 0, err
+	}
+
+	passwordHash2, err := s.calcPasswordHash(newPasswordHash1)
+	if err != nil {
+		return 0, err
+	}
+
+	//旧密码不变时才更新，避免并发修改
+	result, err := s.accountDB.UserInfo.Query().
+		UserIdEqual(dbUserInfo.UserId).And().PasswordHashEqual(dbUserInfo.PasswordHash).
+		SetPasswordHash(passwordHash2).Update(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	affectedRows, err := result.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+	if affectedRows != 1 {
+		return 0, rest.Unknown(fmt.Sprintf("更新失败，影响行数%d", affectedRows))
+	}
+	s.addPasswordHistory(ctx, dbUserInfo.UserId, passwordHash2)
+
+	return s.revokeOtherSessions(ctx, principal.UserId, principal.SessionId)
+}
+
+// 短信注册的用户没有密码，登录后设置初始密码
+func (s *AccountService) SetPassword(ctx *rest.Context, principal *models.Principal, newPasswordHash1 string) (err error) {
+	err = s.validateUserNewPassword(ctx, principal.UserId, newPasswordHash1)
+	if err != nil {
+		return err
+	}
+
+	dbUserInfo, err := s.accountDB.UserInfo.Query().UserIdEqual(principal.UserId).Select(ctx, nil)
+	if err != nil {
+		return err
+	}
+	if dbUserInfo == nil {
+		return rest.NotFound("帐号不存在")
+	}
+	if dbUserInfo.PasswordHash != "" {
+		return rest.BadRequest("PasswordAlreadySet", "已设置密码，请使用修改密码")
+	}
+
+	sessionCount, err := s.updatePassword(ctx, principal, dbUserInfo, newPasswordHash1)
+	if err != nil {
+		return err
+	}
+
+	//操作纪录
+	s.addOperation(ctx, &models.AccountOperation{
+		OperationType: models.OperationSetPassword,
+		UserId:        principal.UserId,
+		Detail:        sessionCountDetail(sessionCount),
+	})
+
+	return nil
+}
+
+func (s *AccountService) ChangePassword(ctx *rest.Context, principal *models.Principal,
+	oldPasswordHash1 string, newPasswordHash1 string, captchaId string, captchaCode string) (err error) {
+
+	err = s.validateUserNewPassword(ctx, principal.UserId, newPasswordHash1)
+	if err != nil {
+		return err
+	}
+
+	dbUserInfo, err := s.accountDB.UserInfo.Query().UserIdEqual(principal.UserId).Select(ctx, nil)
+	if err != nil {
+		return err
+	}
+	if dbUserInfo == nil {
+		return rest.NotFound("帐号不存在")
+	}
+	if dbUserInfo.PasswordHash == "" {
+		return rest.BadRequest("PasswordNotSet", "尚未设置密码")
+	}
+
+	//与密码登录相同，防止用盗取的token暴力破解原密码
+	guards := []*loginGuard{{name: "user", key: principal.UserId, options: &s.options.LoginPhoneGuard,
+		resetOnSuccess: true}}
+	err = s.reserveLoginAttempt(ctx, guards, captchaId, captchaCode)
+	if err != nil {
+		return err
+	}
+
+	ok, _, err := s.verifyPasswordHash(oldPasswordHash1, dbUserInfo.PasswordHash)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		err = s.addLoginFailure(ctx, guards, principal.UserId, "")
+		if err != nil {
+			return err
+		}
+
+		return rest.BadRequest("AuthorizationFailed", "原密码不正确")
+	}
+
+	err = s.addLoginSuccess(ctx, guards)
+	if err != nil {
+		return err
+	}
+
+	sessionCount, err := s.updatePassword(ctx, principal, dbUserInfo, newPasswordHash1)
+	if err != nil {
+		return err
+	}
+
+	//操作纪录
+	s.addOperation(ctx, &models.AccountOperation{
+		OperationType: models.OperationChangePassword,
+		UserId:        principal.UserId,
+		Detail:        sessionCountDetail(sessionCount),
+	})
+
+	return nil
+}
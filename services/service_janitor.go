@@ -0,0 +1,269 @@
+package services
+
+import (
+	"context"
+	"github.com/NeuronAccount/account/models"
+	"github.com/NeuronAccount/account/storages/counter"
+	"go.uber.org/zap"
+	"time"
+)
+
+const janitorLockName = "janitor"
+
+type JanitorTableOptions struct {
+	Interval  time.Duration //清理间隔
+	BatchSize int64         //每批删除的最大行数，避免长时间锁表
+	Retention time.Duration //过期后继续保留的时长，操作纪录为创建后保留的时长
+}
+
+func (o *JanitorTableOptions) setDefaults(interval time.Duration, retention time.Duration) {
+	if o.Interval == 0 {
+		o.Interval = interval
+	}
+	if o.BatchSize == 0 {
+		o.BatchSize = 1000
+	}
+	if o.Retention == 0 {
+		o.Retention = retention
+	}
+}
+
+type janitorTask struct {
+	name    string
+	options *JanitorTableOptions
+	//删除一批截止时间之前的数据，返回删除的行数
+	purge func(ctx context.Context, cutoff time.Time, batchSize int64) (deleted int64, err error)
+}
+
+func (s *AccountService) janitorTasks() (tasks []*janitorTask) {
+	tasks = []*janitorTask{
+		{name: "access_token", options: &s.options.JanitorAccessToken, purge: s.purgeAccessTokens},
+		{name: "refresh_token", options: &s.options.JanitorRefreshToken, purge: s.purgeRefreshTokens},
+		{name: "user_session", options: &s.options.JanitorUserSession, purge: s.purgeUserSessions},
+		{name: "sms_code", options: &s.options.JanitorSmsCode, purge: s.purgeSmsCodes},
+		{name: "oauth_state", options: &s.options.JanitorOauthState, purge: s.purgeOauthStates},
+		{name: "oauth_authorization_code", options: &s.options.JanitorOauthAuthorizationCode,
+			purge: s.purgeOauthAuthorizationCodes},
+		{name: "account_operation", options: &s.options.JanitorAccountOperation, purge: s.purgeAccountOperations},
+	}
+	if store, ok := s.options.CounterStore.(*counter.DBStore); ok {
+		tasks = append(tasks, &janitorTask{name: "counter", options: &s.options.JanitorCounter, purge: store.Purge})
+	}
+
+	return tasks
+}
+
+func (s *AccountService) purgeAccessTokens(ctx context.Context, cutoff time.Time, batchSize int64) (
+	deleted int64, err error) {
+
+	dbAccessTokenList, err := s.accountDB.AccessToken.Query().ExpireTimeLess(cutoff).
+		OrderById(true).Limit(0, batchSize).SelectList(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	if len(dbAccessTokenList) == 0 {
+		return 0, nil
+	}
+
+	result, err := s.accountDB.AccessToken.Query().
+		IdLessEqual(dbAccessTokenList[len(dbAccessTokenList)-1].Id).And().ExpireTimeLess(cutoff).
+		Delete(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.RowsAffected()
+}
+
+// 已轮换的token保留到会话过期，用于重用检测
+func (s *AccountService) purgeRefreshTokens(ctx context.Context, cutoff time.Time, batchSize int64) (
+	deleted int64, err error) {
+
+	dbRefreshTokenList, err := s.accountDB.RefreshToken.Query().SessionExpireTimeLess(cutoff).
+		OrderById(true).Limit(0, batchSize).SelectList(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	if len(dbRefreshTokenList) == 0 {
+		return 0, nil
+	}
+
+	result, err := s.accountDB.RefreshToken.Query().
+		IdLessEqual(dbRefreshTokenList[len(dbRefreshTokenList)-1].Id).And().SessionExpireTimeLess(cutoff).
+		Delete(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.RowsAffected()
+}
+
+func (s *AccountService) purgeUserSessions(ctx context.Context, cutoff time.Time, batchSize int64) (
+	deleted int64, err error) {
+
+	dbUserSessionList, err := s.accountDB.UserSession.Query().ExpireTimeLess(cutoff).
+		OrderById(true).Limit(0, batchSize).SelectList(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	if len(dbUserSessionList) == 0 {
+		return 0, nil
+	}
+
+	result, err := s.accountDB.UserSession.Query().
+		IdLessEqual(dbUserSessionList[len(dbUserSessionList)-1].Id).And().ExpireTimeLess(cutoff).
+		Delete(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.RowsAffected()
+}
+
+// 验证码没有过期时间字段，按创建时间加有效期计算
+func (s *AccountService) purgeSmsCodes(ctx context.Context, cutoff time.Time, batchSize int64) (
+	deleted int64, err error) {
+
+	createTimeCutoff := cutoff.Add(-time.Second * models.SmsCodeValidSeconds)
+	dbSmsCodeList, err := s.accountDB.SmsCode.Query().CreateTimeLess(createTimeCutoff).
+		OrderById(true).Limit(0, batchSize).SelectList(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	if len(dbSmsCodeList) == 0 {
+		return 0, nil
+	}
+
+	result, err := s.accountDB.SmsCode.Query().
+		IdLessEqual(dbSmsCodeList[len(dbSmsCodeList)-1].Id).And().CreateTimeLess(createTimeCutoff).
+		Delete(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.RowsAffected()
+}
+
+func (s *AccountService) purgeOauthStates(ctx context.Context, cutoff time.Time, batchSize int64) (
+	deleted int64, err error) {
+
+	dbOauthStateList, err := s.accountDB.OauthState.Query().CreateTimeLess(cutoff).
+		OrderById(true).Limit(0, batchSize).SelectList(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	if len(dbOauthStateList) == 0 {
+		return 0, nil
+	}
+
+	result, err := s.accountDB.OauthState.Query().
+		IdLessEqual(dbOauthStateList[len(dbOauthStateList)-1].Id).And().CreateTimeLess(cutoff).
+		Delete(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.RowsAffected()
+}
+
+func (s *AccountService) purgeOauthAuthorizationCodes(ctx context.Context, cutoff time.Time, batchSize int64) (
+	deleted int64, err error) {
+
+	dbAuthorizationCodeList, err := s.accountDB.OauthAuthorizationCode.Query().ExpireTimeLess(cutoff).
+		OrderById(true).Limit(0, batchSize).SelectList(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	if len(dbAuthorizationCodeList) == 0 {
+		return 0, nil
+	}
+
+	result, err := s.accountDB.OauthAuthorizationCode.Query().
+		IdLessEqual(dbAuthorizationCodeList[len(dbAuthorizationCodeList)-1].Id).And().ExpireTimeLess(cutoff).
+		Delete(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.RowsAffected()
+}
+
+func (s *AccountService) purgeAccountOperations(ctx context.Context, cutoff time.Time, batchSize int64) (
+	deleted int64, err error) {
+
+	dbAccountOperationList, err := s.accountDB.AccountOperation.Query().CreateTimeLess(cutoff).
+		OrderById(true).Limit(0, batchSize).SelectList(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	if len(dbAccountOperationList) == 0 {
+		return 0, nil
+	}
+
+	result, err := s.accountDB.AccountOperation.Query().
+		IdLessEqual(dbAccountOperationList[len(dbAccountOperationList)-1].Id).And().CreateTimeLess(cutoff).
+		Delete(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.RowsAffected()
+}
+
+// 分批删除直到不足一批，每批之前确认仍持有锁，避免租约过期后与新的持有者同时清理
+func purgeInBatches(ctx context.Context, task *janitorTask, cutoff time.Time,
+	acquireLock func(ctx context.Context) (acquired bool, err error)) (total int64, err error) {
+
+	for {
+		acquired, err := acquireLock(ctx)
+		if err != nil {
+			return total, err
+		}
+		if !acquired {
+			return total, nil
+		}
+
+		deleted, err := task.purge(ctx, cutoff, task.options.BatchSize)
+		if err != nil {
+			return total, err
+		}
+		total += deleted
+		if deleted < task.options.BatchSize {
+			return total, nil
+		}
+	}
+}
+
+func (s *AccountService) runJanitorTask(ctx context.Context, task *janitorTask) (err error) {
+	cutoff := time.Now().Add(-task.options.Retention)
+
+	total, err := purgeInBatches(ctx, task, cutoff, func(ctx context.Context) (acquired bool, err error) {
+		return s.acquireServiceLock(ctx, janitorLockName, s.options.JanitorLockLease)
+	})
+	if total > 0 {
+		s.logger.Info("runJanitorTask",
+			zap.String("table", task.name),
+			zap.Time("cutoff", cutoff),
+			zap.Int64("deleted", total))
+	}
+
+	return err
+}
+
+func (s *AccountService) runJanitor(task *janitorTask) {
+	ticker := time.NewTicker(task.options.Interval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		err := s.runJanitorTask(context.Background(), task)
+		if err != nil {
+			s.logger.Error("runJanitor", zap.String("table", task.name), zap.Error(err))
+		}
+	}
+}
+
+func (s *AccountService) startJanitor() {
+	for _, task := range s.janitorTasks() {
+		go s.runJanitor(task)
+	}
+}
@@ -0,0 +1,124 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"github.com/NeuronAccount/account/storages/counter"
+	"testing"
+	"time"
+)
+
+// 按顺序返回每批删除的行数，纪录每次调用的参数
+type fakePurge struct {
+	batches    []int64
+	err        error
+	calls      int
+	cutoffs    []time.Time
+	batchSizes []int64
+}
+
+func (p *fakePurge) purge(ctx context.Context, cutoff time.Time, batchSize int64) (deleted int64, err error) {
+	p.cutoffs = append(p.cutoffs, cutoff)
+	p.batchSizes = append(p.batchSizes, batchSize)
+	if p.calls >= len(p.batches) {
+		return 0, p.err
+	}
+	deleted = p.batches[p.calls]
+	p.calls++
+
+	return deleted, nil
+}
+
+// 前n次获取锁成功，之后失去锁
+func lockHeldFor(n int) func(ctx context.Context) (bool, error) {
+	calls := 0
+	return func(ctx context.Context) (bool, error) {
+		calls++
+		return calls <= n, nil
+	}
+}
+
+func TestPurgeInBatches(t *testing.T) {
+	cutoff := time.Now().Add(-time.Hour)
+	lockErr := errors.New("lock")
+	purgeErr := errors.New("purge")
+
+	tests := []struct {
+		name       string
+		batches    []int64
+		purgeErr   error
+		lock       func(ctx context.Context) (bool, error)
+		wantTotal  int64
+		wantCalls  int
+		wantErr    error
+		wantPurges int
+	}{
+		{"nothing to delete", []int64{0}, nil, lockHeldFor(10), 0, 1, nil, 1},
+		{"single partial batch", []int64{30}, nil, lockHeldFor(10), 30, 1, nil, 1},
+		{"until partial batch", []int64{100, 100, 30}, nil, lockHeldFor(10), 230, 3, nil, 3},
+		{"full batches then empty", []int64{100, 100, 0}, nil, lockHeldFor(10), 200, 3, nil, 3},
+		{"lock not held", []int64{100}, nil, lockHeldFor(0), 0, 0, nil, 0},
+		{"lock lost between batches", []int64{100, 100, 100}, nil, lockHeldFor(2), 200, 2, nil, 2},
+		{"lock error", []int64{100}, nil, func(ctx context.Context) (bool, error) { return false, lockErr },
+			0, 0, lockErr, 0},
+		{"purge error", []int64{100}, purgeErr, lockHeldFor(10), 100, 1, purgeErr, 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &fakePurge{batches: tt.batches, err: tt.purgeErr}
+			task := &janitorTask{name: "test", options: &JanitorTableOptions{BatchSize: 100}, purge: p.purge}
+
+			total, err := purgeInBatches(context.Background(), task, cutoff, tt.lock)
+			if err != tt.wantErr {
+				t.Errorf("purgeInBatches() err = %v, want %v", err, tt.wantErr)
+			}
+			if total != tt.wantTotal {
+				t.Errorf("purgeInBatches() total = %d, want %d", total, tt.wantTotal)
+			}
+			if p.calls != tt.wantCalls || len(p.cutoffs) != tt.wantPurges {
+				t.Errorf("purge calls = %d/%d, want %d/%d", p.calls, len(p.cutoffs), tt.wantCalls, tt.wantPurges)
+			}
+			for i := range p.cutoffs {
+				if !p.cutoffs[i].Equal(cutoff) || p.batchSizes[i] != 100 {
+					t.Errorf("purge(%v, %d), want (%v, 100)", p.cutoffs[i], p.batchSizes[i], cutoff)
+				}
+			}
+		})
+	}
+}
+
+func TestJanitorTasks(t *testing.T) {
+	names := func(tasks []*janitorTask) map[string]*janitorTask {
+		m := make(map[string]*janitorTask)
+		for _, v := range tasks {
+			m[v.name] = v
+		}
+		return m
+	}
+
+	s := &AccountService{options: &AccountServiceOptions{CounterStore: counter.NewMemoryStore()}}
+	s.options.setDefaults()
+	tasks := names(s.janitorTasks())
+	for _, name := range []string{"access_token", "refresh_token", "user_session", "sms_code", "oauth_state",
+		"oauth_authorization_code", "account_operation"} {
+		task, ok := tasks[name]
+		if !ok {
+			t.Errorf("janitorTasks() missing %s", name)
+			continue
+		}
+		if task.options.Interval <= 0 || task.options.BatchSize <= 0 || task.options.Retention <= 0 {
+			t.Errorf("janitorTasks() %s options = %+v", name, task.options)
+		}
+	}
+	//内存计数自行清理过期项
+	if _, ok := tasks["counter"]; ok {
+		t.Errorf("janitorTasks() includes counter for MemoryStore")
+	}
+
+	s = &AccountService{options: &AccountServiceOptions{CounterStore: counter.NewDBStore(nil)}}
+	s.options.setDefaults()
+	if _, ok := names(s.janitorTasks())["counter"]; !ok {
+		t.Errorf("janitorTasks() missing counter for DBStore")
+	}
+}
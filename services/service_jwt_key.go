@@ -0,0 +1,482 @@
+package services
+
+import (
+	"context"
+	"crypto"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	cryptoRand "crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"github.com/NeuronAccount/account/models"
+	"github.com/NeuronAccount/account/storages/neuron_account_db"
+	"github.com/NeuronFramework/rand"
+	"github.com/NeuronFramework/rest"
+	"github.com/dgrijalva/jwt-go"
+	"go.uber.org/zap"
+	"math/big"
+	"strings"
+	"time"
+)
+
+const (
+	jwtKeyReloadMinInterval = time.Second * 10 //未知kid触发重新加载的最小间隔
+	jwtKeyRotationLockName  = "jwt_key_rotation"
+	jwtKeyStartupWait       = time.Second * 10 //启动时等待持有锁的实例发布首个密钥
+	jwtPrivateKeyPrefix     = "aes256gcm$"     //私钥用JwtKeyEncryptionKey加密后存储
+)
+
+type jwtKey struct {
+	kid           string
+	algorithm     string
+	signingMethod jwt.SigningMethod
+	privateKey    crypto.Signer
+	publicKey     crypto.PublicKey
+	signBeginTime time.Time
+	signEndTime   time.Time
+	expireTime    time.Time
+}
+
+func (s *AccountService) generateJwtKey(algorithm string) (privateKeyPem string, publicKeyPem string, err error) {
+	var privateKey crypto.Signer
+	switch algorithm {
+	case models.JwtAlgorithmRS256:
+		privateKey, err = rsa.GenerateKey(cryptoRand.Reader, 2048)
+	case models.JwtAlgorithmES256:
+		privateKey, err = ecdsa.GenerateKey(elliptic.P256(), cryptoRand.Reader)
+	default:
+		return "", "", fmt.Errorf("不支持的签名算法%s", algorithm)
+	}
+	if err != nil {
+		return "", "", err
+	}
+
+	privateKeyDer, err := x509.MarshalPKCS8PrivateKey(privateKey)
+	if err != nil {
+		return "", "", err
+	}
+	publicKeyDer, err := x509.MarshalPKIXPublicKey(privateKey.Public())
+	if err != nil {
+		return "", "", err
+	}
+
+	privateKeyPem = string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: privateKeyDer}))
+	publicKeyPem = string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicKeyDer}))
+
+	return privateKeyPem, publicKeyPem, nil
+}
+
+// 密钥为base64编码的32字节，只有开发环境可不配置，此时私钥明文存储
+func (s *AccountService) loadJwtKeyEncryptionKey() (key []byte, err error) {
+	if s.options.JwtKeyEncryptionKey == "" {
+		if !s.options.Dev {
+			return nil, fmt.Errorf("未配置JwtKeyEncryptionKey")
+		}
+		s.logger.Warn("loadJwtKeyEncryptionKey 开发环境未配置密钥，签名私钥明文存储")
+		return nil, nil
+	}
+
+	key, err = base64.StdEncoding.DecodeString(s.options.JwtKeyEncryptionKey)
+	if err != nil || len(key) != 32 {
+		return nil, fmt.Errorf("JwtKeyEncryptionKey须为base64编码的32字节")
+	}
+
+	return key, nil
+}
+
+func (s *AccountService) newJwtKeyAead() (aead cipher.AEAD, err error) {
+	block, err := aes.NewCipher(s.jwtKeyEncryptionKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}
+
+// kid作为附加数据，密文不能挪用到其它密钥
+func (s *AccountService) encryptJwtPrivateKey(kid string, privateKeyPem string) (encrypted string, err error) {
+	if s.jwtKeyEncryptionKey == nil {
+		return privateKeyPem, nil
+	}
+
+	aead, err := s.newJwtKeyAead()
+	if err != nil {
+		return "", err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	_, err = cryptoRand.Read(nonce)
+	if err != nil {
+		return "", err
+	}
+	sealed := aead.Seal(nonce, nonce, []byte(privateKeyPem), []byte(kid))
+
+	return jwtPrivateKeyPrefix + base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+// 兼容升级前明文存储的私钥
+func (s *AccountService) decryptJwtPrivateKey(dbJwtKey *neuron_account_db.JwtKey) (privateKeyPem string, err error) {
+	if !strings.HasPrefix(dbJwtKey.PrivateKey, jwtPrivateKeyPrefix) {
+		return dbJwtKey.PrivateKey, nil
+	}
+	if s.jwtKeyEncryptionKey == nil {
+		return "", fmt.Errorf("私钥已加密，未配置JwtKeyEncryptionKey")
+	}
+
+	sealed, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(dbJwtKey.PrivateKey, jwtPrivateKeyPrefix))
+	if err != nil {
+		return "", fmt.Errorf("私钥密文格式错误")
+	}
+	aead, err := s.newJwtKeyAead()
+	if err != nil {
+		return "", err
+	}
+	if len(sealed) < aead.NonceSize() {
+		return "", fmt.Errorf("私钥密文格式错误")
+	}
+	plaintext, err := aead.Open(nil, sealed[:aead.NonceSize()], sealed[aead.NonceSize():], []byte(dbJwtKey.Kid))
+	if err != nil {
+		return "", fmt.Errorf("私钥解密失败")
+	}
+
+	return string(plaintext), nil
+}
+
+func (s *AccountService) parseJwtKey(dbJwtKey *neuron_account_db.JwtKey) (key *jwtKey, err error) {
+	key = &jwtKey{}
+	key.kid = dbJwtKey.Kid
+	key.algorithm = dbJwtKey.Algorithm
+	key.signBeginTime = dbJwtKey.SignBeginTime
+	key.signEndTime = dbJwtKey.SignEndTime
+	key.expireTime = dbJwtKey.ExpireTime
+
+	switch dbJwtKey.Algorithm {
+	case models.JwtAlgorithmRS256:
+		key.signingMethod = jwt.SigningMethodRS256
+	case models.JwtAlgorithmES256:
+		key.signingMethod = jwt.SigningMethodES256
+	default:
+		return nil, fmt.Errorf("不支持的签名算法%s", dbJwtKey.Algorithm)
+	}
+
+	privateKeyPem, err := s.decryptJwtPrivateKey(dbJwtKey)
+	if err != nil {
+		return nil, err
+	}
+	privateKeyBlock, _ := pem.Decode([]byte(privateKeyPem))
+	if privateKeyBlock == nil {
+		return nil, fmt.Errorf("私钥格式错误")
+	}
+	privateKey, err := x509.ParsePKCS8PrivateKey(privateKeyBlock.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	signer, ok := privateKey.(crypto.Signer)
+	if !ok {
+		return nil, fmt.Errorf("私钥类型错误")
+	}
+	key.privateKey = signer
+	key.publicKey = signer.Public()
+
+	return key, nil
+}
+
+// 加载尚未过期的密钥，按开始签名时间倒序
+func (s *AccountService) loadJwtKeys(ctx context.Context) (err error) {
+	dbJwtKeyList, err := s.accountDB.JwtKey.Query().
+		ExpireTimeGreater(time.Now()).
+		OrderBySignBeginTime(false).OrderById(false).
+		SelectList(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	keys := make([]*jwtKey, 0, len(dbJwtKeyList))
+	for _, v := range dbJwtKeyList {
+		key, err := s.parseJwtKey(v)
+		if err != nil {
+			s.logger.Error("loadJwtKeys", zap.String("kid", v.Kid), zap.Error(err))
+			continue
+		}
+		keys = append(keys, key)
+	}
+
+	s.jwtKeysMutex.Lock()
+	s.jwtKeys = keys
+	s.jwtKeysLoadTime = time.Now()
+	s.jwtKeysMutex.Unlock()
+
+	return nil
+}
+
+// 升级前明文存储的私钥在配置密钥后加密，只在私钥未被并发修改时更新
+func (s *AccountService) encryptJwtKeys(ctx context.Context) (err error) {
+	if s.jwtKeyEncryptionKey == nil {
+		return nil
+	}
+
+	dbJwtKeyList, err := s.accountDB.JwtKey.Query().ExpireTimeGreater(time.Now()).SelectList(ctx, nil)
+	if err != nil {
+		return err
+	}
+	for _, v := range dbJwtKeyList {
+		if strings.HasPrefix(v.PrivateKey, jwtPrivateKeyPrefix) {
+			continue
+		}
+
+		encrypted, err := s.encryptJwtPrivateKey(v.Kid, v.PrivateKey)
+		if err != nil {
+			return err
+		}
+		_, err = s.accountDB.JwtKey.Query().IdEqual(v.Id).And().PrivateKeyEqual(v.PrivateKey).
+			SetPrivateKey(encrypted).Update(ctx, nil)
+		if err != nil {
+			return err
+		}
+		s.logger.Info("encryptJwtKeys", zap.String("kid", v.Kid))
+	}
+
+	return nil
+}
+
+// 最新密钥的签名期即将结束时，提前一个重叠窗口发布下一个密钥，
+// 旧密钥在签名期结束后仍在重叠窗口内用于验证；
+// 多实例时只有持有锁的实例发布，其它实例只重新加载
+func (s *AccountService) rotateJwtKeys(ctx context.Context) (err error) {
+	acquired, err := s.acquireServiceLock(ctx, jwtKeyRotationLockName, s.options.JwtKeyRefreshInterval*3)
+	if err != nil {
+		return err
+	}
+	if !acquired {
+		return s.loadJwtKeys(ctx)
+	}
+
+	err = s.encryptJwtKeys(ctx)
+	if err != nil {
+		return err
+	}
+	err = s.loadJwtKeys(ctx)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	latestSignEndTime := time.Time{}
+	s.jwtKeysMutex.RLock()
+	for _, v := range s.jwtKeys {
+		if v.signEndTime.After(latestSignEndTime) {
+			latestSignEndTime = v.signEndTime
+		}
+	}
+	s.jwtKeysMutex.RUnlock()
+
+	if latestSignEndTime.Sub(now) > s.options.JwtKeyOverlap {
+		return nil
+	}
+
+	privateKeyPem, publicKeyPem, err := s.generateJwtKey(s.options.JwtAlgorithm)
+	if err != nil {
+		return err
+	}
+
+	signBeginTime := latestSignEndTime
+	if signBeginTime.Before(now) {
+		signBeginTime = now
+	}
+	dbJwtKey := &neuron_account_db.JwtKey{}
+	dbJwtKey.Kid = rand.NextHex(16)
+	dbJwtKey.Algorithm = s.options.JwtAlgorithm
+	dbJwtKey.PrivateKey, err = s.encryptJwtPrivateKey(dbJwtKey.Kid, privateKeyPem)
+	if err != nil {
+		return err
+	}
+	dbJwtKey.PublicKey = publicKeyPem
+	dbJwtKey.SignBeginTime = signBeginTime
+	dbJwtKey.SignEndTime = signBeginTime.Add(s.options.JwtKeyRotateInterval)
+	dbJwtKey.ExpireTime = dbJwtKey.SignEndTime.Add(s.options.JwtKeyOverlap)
+	_, err = s.accountDB.JwtKey.Query().Insert(ctx, nil, dbJwtKey)
+	if err != nil {
+		return err
+	}
+
+	s.logger.Info("rotateJwtKeys",
+		zap.String("kid", dbJwtKey.Kid),
+		zap.Time("signBeginTime", dbJwtKey.SignBeginTime),
+		zap.Time("signEndTime", dbJwtKey.SignEndTime))
+
+	return s.loadJwtKeys(ctx)
+}
+
+// 首次部署时可能由其它实例发布密钥
+func (s *AccountService) waitForSigningJwtKey(ctx context.Context) (err error) {
+	deadline := time.Now().Add(jwtKeyStartupWait)
+	for s.getSigningJwtKey() == nil {
+		if time.Now().After(deadline) {
+			return fmt.Errorf("没有可用的签名密钥")
+		}
+		time.Sleep(time.Second)
+
+		err = s.rotateJwtKeys(ctx)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *AccountService) runJwtKeyRotation() {
+	ticker := time.NewTicker(s.options.JwtKeyRefreshInterval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		err := s.rotateJwtKeys(context.Background())
+		if err != nil {
+			s.logger.Error("runJwtKeyRotation", zap.Error(err))
+		}
+	}
+}
+
+func (s *AccountService) getSigningJwtKey() (key *jwtKey) {
+	now := time.Now()
+
+	s.jwtKeysMutex.RLock()
+	defer s.jwtKeysMutex.RUnlock()
+
+	for _, v := range s.jwtKeys {
+		if !v.signBeginTime.After(now) && v.signEndTime.After(now) {
+			return v
+		}
+	}
+
+	return nil
+}
+
+func (s *AccountService) findJwtKey(kid string) (key *jwtKey) {
+	now := time.Now()
+
+	s.jwtKeysMutex.RLock()
+	defer s.jwtKeysMutex.RUnlock()
+
+	for _, v := range s.jwtKeys {
+		if v.kid == kid && v.expireTime.After(now) {
+			return v
+		}
+	}
+
+	return nil
+}
+
+func (s *AccountService) getVerifyingJwtKey(ctx context.Context, kid string) (key *jwtKey, err error) {
+	key = s.findJwtKey(kid)
+	if key != nil {
+		return key, nil
+	}
+
+	//其它实例可能刚发布了新密钥
+	s.jwtKeysMutex.RLock()
+	loadTime := s.jwtKeysLoadTime
+	s.jwtKeysMutex.RUnlock()
+	if time.Now().Sub(loadTime) < jwtKeyReloadMinInterval {
+		return nil, fmt.Errorf("未知的kid%s", kid)
+	}
+
+	err = s.loadJwtKeys(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	key = s.findJwtKey(kid)
+	if key == nil {
+		return nil, fmt.Errorf("未知的kid%s", kid)
+	}
+
+	return key, nil
+}
+
+func (s *AccountService) signJwt(claims jwt.Claims) (tokenString string, err error) {
+	key := s.getSigningJwtKey()
+	if key == nil {
+		return "", rest.Unknown("签名密钥不可用")
+	}
+
+	jwtToken := jwt.NewWithClaims(key.signingMethod, claims)
+	jwtToken.Header["kid"] = key.kid
+
+	return jwtToken.SignedString(key.privateKey)
+}
+
+// 只校验签名，标准声明由validateStandardClaims按时钟误差校验
+func (s *AccountService) parseJwt(ctx context.Context, tokenString string, claims jwt.Claims) (err error) {
+	parser := &jwt.Parser{SkipClaimsValidation: true}
+	_, err = parser.ParseWithClaims(tokenString, claims, func(t *jwt.Token) (interface{}, error) {
+		kid, _ := t.Header["kid"].(string)
+		if kid == "" {
+			return nil, fmt.Errorf("kid为空")
+		}
+
+		key, err := s.getVerifyingJwtKey(ctx, kid)
+		if err != nil {
+			return nil, err
+		}
+
+		if t.Method.Alg() != key.signingMethod.Alg() {
+			return nil, fmt.Errorf("签名算法不匹配%s", t.Method.Alg())
+		}
+
+		return key.publicKey, nil
+	})
+	if err != nil {
+		if validationErr, ok := err.(*jwt.ValidationError); ok &&
+			validationErr.Errors&jwt.ValidationErrorMalformed != 0 {
+			return newTokenError(TokenErrorMalformed, "token格式错误")
+		}
+
+		return newTokenError(TokenErrorInvalidSignature, "%v", err)
+	}
+
+	return nil
+}
+
+func (s *AccountService) toJsonWebKey(key *jwtKey) (r *models.JsonWebKey) {
+	r = &models.JsonWebKey{}
+	r.Use = "sig"
+	r.Alg = key.algorithm
+	r.Kid = key.kid
+
+	switch publicKey := key.publicKey.(type) {
+	case *rsa.PublicKey:
+		r.Kty = "RSA"
+		r.N = base64.RawURLEncoding.EncodeToString(publicKey.N.Bytes())
+		r.E = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(publicKey.E)).Bytes())
+	case *ecdsa.PublicKey:
+		byteSize := (publicKey.Curve.Params().BitSize + 7) / 8
+		r.Kty = "EC"
+		r.Crv = publicKey.Curve.Params().Name
+		r.X = base64.RawURLEncoding.EncodeToString(publicKey.X.FillBytes(make([]byte, byteSize)))
+		r.Y = base64.RawURLEncoding.EncodeToString(publicKey.Y.FillBytes(make([]byte, byteSize)))
+	}
+
+	return r
+}
+
+func (s *AccountService) GetJwks(ctx *rest.Context) (keys []*models.JsonWebKey, err error) {
+	now := time.Now()
+
+	s.jwtKeysMutex.RLock()
+	defer s.jwtKeysMutex.RUnlock()
+
+	//包含已发布但尚未开始签名的密钥，便于验证方提前缓存
+	keys = make([]*models.JsonWebKey, 0, len(s.jwtKeys))
+	for _, v := range s.jwtKeys {
+		if v.expireTime.After(now) {
+			keys = append(keys, s.toJsonWebKey(v))
+		}
+	}
+
+	return keys, nil
+}
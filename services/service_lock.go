@@ -0,0 +1,42 @@
+package services
+
+import (
+	"context"
+	"github.com/NeuronAccount/account/storages/neuron_account_db"
+	"github.com/NeuronFramework/sql/wrap"
+	"time"
+)
+
+// 基于数据库的租约锁，持有者需在租约到期前续约，
+// 持有者宕机后租约到期，其它实例可接管
+func (s *AccountService) acquireServiceLock(ctx context.Context, lockName string, lease time.Duration) (
+	acquired bool, err error) {
+
+	now := time.Now()
+	dbServiceLock := &neuron_account_db.ServiceLock{}
+	dbServiceLock.LockName = lockName
+	dbServiceLock.Owner = s.instanceId
+	dbServiceLock.ExpireTime = now.Add(lease)
+	_, err = s.accountDB.ServiceLock.Query().Insert(ctx, nil, dbServiceLock)
+	if err == nil {
+		return true, nil
+	}
+	if err != wrap.ErrDuplicated {
+		return false, err
+	}
+
+	//已持有则续约，已过期则接管
+	result, err := s.accountDB.ServiceLock.Query().
+		LockNameEqual(lockName).And().
+		Left().OwnerEqual(s.instanceId).Or().ExpireTimeLess(now).Right().
+		SetOwner(s.instanceId).SetExpireTime(now.Add(lease)).Update(ctx, nil)
+	if err != nil {
+		return false, err
+	}
+	affectedRows, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return affectedRows == 1, nil
+}
@@ -11,7 +11,7 @@ import (
 
 const CreateUserInfoMaxRetryCount = 10
 
-func (s *AccountService) SmsLogin(ctx *rest.Context, phone string, smsCode string) (
+func (s *AccountService) SmsLogin(ctx *rest.Context, phone string, smsCode string, device *models.DeviceInfo) (
 	userToken *models.UserToken, err error) {
 
 	//加密手机号
@@ -72,7 +72,7 @@ func (s *AccountService) SmsLogin(ctx *rest.Context, phone string, smsCode strin
 	}
 
 	//创建token
-	userToken, err = s.createUserToken(ctx, dbPhoneAccount.UserId)
+	userToken, err = s.createUserToken(ctx, dbPhoneAccount.UserId, device, []string{models.AmrSms})
 	if err != nil {
 		return nil, err
 	}
@@ -87,8 +87,8 @@ func (s *AccountService) SmsLogin(ctx *rest.Context, phone string, smsCode strin
 	return userToken, nil
 }
 
-func (s *AccountService) PhonePasswordLogin(ctx *rest.Context, phone string, passwordHash1 string) (
-	userToken *models.UserToken, err error) {
+func (s *AccountService) PhonePasswordLogin(ctx *rest.Context, phone string, passwordHash1 string, device *models.DeviceInfo,
+	captchaId string, captchaCode string) (userToken *models.UserToken, err error) {
 
 	//加密手机号
 	phoneEncrypted, err := s.encryptPhone(phone)
@@ -96,7 +96,9 @@ func (s *AccountService) PhonePasswordLogin(ctx *rest.Context, phone string, pas
 		return nil, err
 	}
 
-	passwordHash2, err := s.calcPasswordHash(passwordHash1)
+	//防止暴力破解，按手机号和IP限制失败次数
+	guards := s.loginGuards(phoneEncrypted, device)
+	err = s.reserveLoginAttempt(ctx, guards, captchaId, captchaCode)
 	if err != nil {
 		return nil, err
 	}
@@ -107,6 +109,11 @@ func (s *AccountService) PhonePasswordLogin(ctx *rest.Context, phone string, pas
 		return nil, err
 	}
 	if dbPhoneAccount == nil {
+		err = s.addLoginFailure(ctx, guards, "", phoneEncrypted)
+		if err != nil {
+			return nil, err
+		}
+
 		return nil, rest.NotFound("手机号尚未注册")
 	}
 
@@ -115,16 +122,30 @@ func (s *AccountService) PhonePasswordLogin(ctx *rest.Context, phone string, pas
 	if err != nil {
 		return nil, err
 	}
-	if dbPhoneAccount == nil {
+	if dbUserInfo == nil {
 		return nil, errors.NotFound("内部错误，手机帐号不存在")
 	}
 
-	if dbUserInfo.PasswordHash != passwordHash2 {
+	ok, err := s.checkUserPassword(ctx, dbUserInfo, passwordHash1)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		err = s.addLoginFailure(ctx, guards, dbPhoneAccount.UserId, phoneEncrypted)
+		if err != nil {
+			return nil, err
+		}
+
 		return nil, rest.BadRequest("AuthorizationFailed", "密码不正确")
 	}
 
+	err = s.addLoginSuccess(ctx, guards)
+	if err != nil {
+		return nil, err
+	}
+
 	//创建token
-	userToken, err = s.createUserToken(ctx, dbPhoneAccount.UserId)
+	userToken, err = s.createUserToken(ctx, dbPhoneAccount.UserId, device, []string{models.AmrPwd})
 	if err != nil {
 		return nil, err
 	}
@@ -136,5 +157,5 @@ func (s *AccountService) PhonePasswordLogin(ctx *rest.Context, phone string, pas
 		PhoneEncrypted: phoneEncrypted,
 	})
 
-	return nil, nil
+	return userToken, nil
 }
@@ -0,0 +1,334 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"github.com/NeuronAccount/account/models"
+	"github.com/NeuronFramework/rest"
+	"go.uber.org/zap"
+	"time"
+)
+
+// 图形验证码校验，如remotes/captcha中的siteverify实现
+type CaptchaVerifier interface {
+	VerifyCaptcha(ctx context.Context, captchaId string, captchaCode string) (ok bool, err error)
+}
+
+type LoginGuardOptions struct {
+	Window          time.Duration //失败计数的窗口，从第一次失败开始计算
+	CaptchaAfter    int64         //失败次数达到后需要图形验证码，小于0时不要求
+	LockoutAfter    int64         //失败次数达到后锁定
+	LockoutDuration time.Duration
+	BackoffBase     time.Duration //每次失败后需等待BackoffBase*2^(失败次数-1)才能再次尝试
+	BackoffMax      time.Duration
+}
+
+func (o *LoginGuardOptions) setDefaults(captchaAfter int64, lockoutAfter int64, backoffBase time.Duration) {
+	if o.Window == 0 {
+		o.Window = time.Hour
+	}
+	if o.CaptchaAfter == 0 {
+		o.CaptchaAfter = captchaAfter
+	}
+	if o.LockoutAfter == 0 {
+		o.LockoutAfter = lockoutAfter
+	}
+	if o.LockoutDuration == 0 {
+		o.LockoutDuration = time.Minute * 30
+	}
+	if o.BackoffBase == 0 {
+		o.BackoffBase = backoffBase
+	}
+	if o.BackoffMax == 0 {
+		o.BackoffMax = time.Minute * 5
+	}
+}
+
+// 按手机号、IP或用户分别计数，attempts为本次请求预占后的尝试次数
+type loginGuard struct {
+	name           string
+	key            string
+	options        *LoginGuardOptions
+	resetOnSuccess bool //成功后清除计数，否则只归还本次预占
+
+	gated      bool
+	reserved   bool
+	attempts   int64
+	expireTime time.Time
+}
+
+func (g *loginGuard) failuresKey() string {
+	return "login_failures:" + g.name + ":" + g.key
+}
+
+func (g *loginGuard) lockKey() string {
+	return "login_lock:" + g.name + ":" + g.key
+}
+
+func (g *loginGuard) backoffKey() string {
+	return "login_backoff:" + g.name + ":" + g.key
+}
+
+func (g *loginGuard) backoffDelay(failures int64) (delay time.Duration) {
+	delay = g.options.BackoffBase
+	for i := int64(1); i < failures && delay < g.options.BackoffMax; i++ {
+		delay *= 2
+	}
+	if delay > g.options.BackoffMax {
+		delay = g.options.BackoffMax
+	}
+
+	return delay
+}
+
+// IP的计数成功后不清除，避免用自己的帐号重置
+func (s *AccountService) loginGuards(phoneEncrypted string, device *models.DeviceInfo) (guards []*loginGuard) {
+	guards = []*loginGuard{{name: "phone", key: phoneEncrypted, options: &s.options.LoginPhoneGuard, resetOnSuccess: true}}
+	if device.ClientIp != "" {
+		guards = append(guards, &loginGuard{name: "ip", key: device.ClientIp, options: &s.options.LoginIpGuard})
+	}
+
+	return guards
+}
+
+// 要求图形验证码时必须配置CaptchaVerifier，开发环境可不配置
+func (s *AccountService) checkCaptchaVerifier() (err error) {
+	if s.options.CaptchaVerifier != nil {
+		return nil
+	}
+	if s.options.LoginPhoneGuard.CaptchaAfter < 0 && s.options.LoginIpGuard.CaptchaAfter < 0 {
+		return nil
+	}
+	if !s.options.Dev {
+		return fmt.Errorf("已配置CaptchaAfter但未配置CaptchaVerifier")
+	}
+
+	s.logger.Warn("checkCaptchaVerifier 开发环境未配置CaptchaVerifier，不校验图形验证码")
+	return nil
+}
+
+func secondsUntil(t time.Time) int64 {
+	seconds := int64(time.Until(t)/time.Second) + 1
+	if seconds < 1 {
+		seconds = 1
+	}
+
+	return seconds
+}
+
+// 校验密码前预占一次尝试，并发请求各自得到不同的计数，按计数决定锁定及是否需要图形验证码；
+// backoff计数同时作为闸门，同一手机号或IP同时只有一个请求在校验密码
+func (s *AccountService) reserveLoginAttempt(ctx *rest.Context, guards []*loginGuard, captchaId string,
+	captchaCode string) (err error) {
+
+	defer func() {
+		if err != nil {
+			s.releaseLoginAttempt(ctx, guards)
+		}
+	}()
+
+	captchaRequired := false
+	for _, g := range guards {
+		locked, expireTime, err := s.options.CounterStore.Get(ctx, g.lockKey())
+		if err != nil {
+			return err
+		}
+		if locked > 0 {
+			return rest.BadRequest("LoginLocked",
+				fmt.Sprintf("尝试次数过多，请%d分钟后再试", (secondsUntil(expireTime)+59)/60))
+		}
+
+		backoff, expireTime, err := s.options.CounterStore.Incr(ctx, g.backoffKey(), g.backoffDelay(1))
+		if err != nil {
+			return err
+		}
+		if backoff > 1 {
+			return rest.BadRequest("LoginTooFrequent", fmt.Sprintf("请%d秒后再试", secondsUntil(expireTime)))
+		}
+		g.gated = true
+
+		g.attempts, g.expireTime, err = s.options.CounterStore.Incr(ctx, g.failuresKey(), g.options.Window)
+		if err != nil {
+			return err
+		}
+		g.reserved = true
+		if g.attempts > g.options.LockoutAfter {
+			err = s.lockLogin(ctx, g)
+			if err != nil {
+				return err
+			}
+			return rest.BadRequest("LoginLocked",
+				fmt.Sprintf("尝试次数过多，请%d分钟后再试", (int64(g.options.LockoutDuration/time.Second)+59)/60))
+		}
+
+		//之前的失败次数达到CaptchaAfter
+		if g.options.CaptchaAfter >= 0 && g.attempts > g.options.CaptchaAfter {
+			captchaRequired = true
+		}
+	}
+
+	if !captchaRequired || s.options.CaptchaVerifier == nil {
+		return nil
+	}
+	if captchaCode == "" {
+		return rest.BadRequest("CaptchaRequired", "请输入图形验证码")
+	}
+	ok, err := s.options.CaptchaVerifier.VerifyCaptcha(ctx, captchaId, captchaCode)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return rest.BadRequest("InvalidCaptcha", "图形验证码错误")
+	}
+
+	return nil
+}
+
+// 归还预占的尝试并打开闸门，计数已被其它请求修改时不回退
+func (s *AccountService) releaseLoginAttempt(ctx *rest.Context, guards []*loginGuard) {
+	for _, g := range guards {
+		if g.reserved {
+			g.reserved = false
+			count, _, err := s.options.CounterStore.Get(ctx, g.failuresKey())
+			if err == nil && count == g.attempts {
+				if count > 1 {
+					err = s.options.CounterStore.Set(ctx, g.failuresKey(), count-1, time.Until(g.expireTime))
+				} else {
+					err = s.options.CounterStore.Delete(ctx, g.failuresKey())
+				}
+			}
+			if err != nil {
+				s.logger.Error("releaseLoginAttempt", zap.String("guard", g.name), zap.Error(err))
+			}
+		}
+
+		if g.gated {
+			g.gated = false
+			err := s.options.CounterStore.Delete(ctx, g.backoffKey())
+			if err != nil {
+				s.logger.Error("releaseLoginAttempt", zap.String("guard", g.name), zap.Error(err))
+			}
+		}
+	}
+}
+
+// 调用方已预占本次尝试，锁定时删除计数
+func (s *AccountService) lockLogin(ctx *rest.Context, g *loginGuard) (err error) {
+	err = s.options.CounterStore.Set(ctx, g.lockKey(), 1, g.options.LockoutDuration)
+	if err != nil {
+		return err
+	}
+	err = s.options.CounterStore.Delete(ctx, g.failuresKey())
+	if err != nil {
+		return err
+	}
+	err = s.options.CounterStore.Delete(ctx, g.backoffKey())
+	if err != nil {
+		return err
+	}
+	g.reserved = false
+	g.gated = false
+
+	return nil
+}
+
+// 预占的尝试失败，按计数设置等待时间，达到次数后锁定
+func (s *AccountService) addLoginFailure(ctx *rest.Context, guards []*loginGuard, userId string, phoneEncrypted string) (
+	err error) {
+
+	for _, g := range guards {
+		if !g.reserved {
+			continue
+		}
+
+		if g.attempts < g.options.LockoutAfter {
+			err = s.options.CounterStore.Set(ctx, g.backoffKey(), 1, g.backoffDelay(g.attempts))
+			if err != nil {
+				return err
+			}
+			g.reserved = false
+			g.gated = false
+			continue
+		}
+
+		err = s.lockLogin(ctx, g)
+		if err != nil {
+			return err
+		}
+
+		s.logger.Warn("addLoginFailure lockout",
+			zap.String("guard", g.name),
+			zap.String("key", g.key),
+			zap.Int64("failures", g.attempts))
+
+		//操作纪录，按IP锁定时不关联用户
+		operation := &models.AccountOperation{
+			OperationType: models.OperationLoginLockout,
+			Detail:        fmt.Sprintf("%s=%s failures=%d", g.name, g.key, g.attempts),
+		}
+		if g.name != "ip" {
+			operation.UserId = userId
+			operation.PhoneEncrypted = phoneEncrypted
+			operation.Detail = fmt.Sprintf("%s failures=%d", g.name, g.attempts)
+		}
+		s.addOperation(ctx, operation)
+	}
+
+	return nil
+}
+
+// 预占的尝试成功
+func (s *AccountService) addLoginSuccess(ctx *rest.Context, guards []*loginGuard) (err error) {
+	for _, g := range guards {
+		if !g.resetOnSuccess {
+			continue
+		}
+
+		err = s.clearLoginGuard(ctx, g)
+		if err != nil {
+			return err
+		}
+		g.reserved = false
+		g.gated = false
+	}
+	s.releaseLoginAttempt(ctx, guards)
+
+	return nil
+}
+
+func (s *AccountService) clearLoginGuard(ctx *rest.Context, g *loginGuard) (err error) {
+	err = s.options.CounterStore.Delete(ctx, g.failuresKey())
+	if err != nil {
+		return err
+	}
+
+	return s.options.CounterStore.Delete(ctx, g.backoffKey())
+}
+
+// 通过短信验证码重置密码后解除该手机号的锁定
+func (s *AccountService) unlockLogin(ctx *rest.Context, userId string, phoneEncrypted string) (err error) {
+	g := &loginGuard{name: "phone", key: phoneEncrypted, options: &s.options.LoginPhoneGuard}
+	locked, _, err := s.options.CounterStore.Get(ctx, g.lockKey())
+	if err != nil {
+		return err
+	}
+
+	err = s.options.CounterStore.Delete(ctx, g.lockKey())
+	if err != nil {
+		return err
+	}
+	err = s.clearLoginGuard(ctx, g)
+	if err != nil {
+		return err
+	}
+
+	if locked > 0 {
+		s.addOperation(ctx, &models.AccountOperation{
+			OperationType:  models.OperationLoginUnlock,
+			UserId:         userId,
+			PhoneEncrypted: phoneEncrypted,
+		})
+	}
+
+	return nil
+}
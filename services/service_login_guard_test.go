@@ -0,0 +1,236 @@
+package services
+
+import (
+	"context"
+	"github.com/NeuronAccount/account/models"
+	"github.com/NeuronAccount/account/storages/counter"
+	"github.com/NeuronFramework/rest"
+	"go.uber.org/zap"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+const (
+	loginTestPhone = "13800000000"
+	loginTestIp    = "192.0.2.1"
+)
+
+type fakeCaptchaVerifier struct {
+	calls int
+}
+
+func (v *fakeCaptchaVerifier) VerifyCaptcha(ctx context.Context, captchaId string, captchaCode string) (
+	ok bool, err error) {
+
+	v.calls++
+	return captchaCode == "right", nil
+}
+
+func newLoginGuardTestService() (s *AccountService, ctx *rest.Context, captcha *fakeCaptchaVerifier) {
+	captcha = &fakeCaptchaVerifier{}
+	s = &AccountService{logger: zap.NewNop(), options: &AccountServiceOptions{
+		CounterStore:    counter.NewMemoryStore(),
+		CaptchaVerifier: captcha,
+		LoginPhoneGuard: LoginGuardOptions{
+			Window:          time.Hour,
+			CaptchaAfter:    2,
+			LockoutAfter:    3,
+			LockoutDuration: time.Hour,
+			BackoffBase:     time.Millisecond * 50,
+			BackoffMax:      time.Millisecond * 50,
+		},
+		LoginIpGuard: LoginGuardOptions{
+			Window:          time.Hour,
+			CaptchaAfter:    -1,
+			LockoutAfter:    100,
+			LockoutDuration: time.Hour,
+			BackoffBase:     time.Millisecond,
+			BackoffMax:      time.Millisecond,
+		},
+	}}
+
+	return s, rest.NewContext(httptest.NewRequest("POST", "/login", nil)), captcha
+}
+
+func newTestLoginGuards(s *AccountService) []*loginGuard {
+	return s.loginGuards(loginTestPhone, &models.DeviceInfo{ClientIp: loginTestIp})
+}
+
+func loginGuardCount(t *testing.T, s *AccountService, key string) int64 {
+	count, _, err := s.options.CounterStore.Get(context.Background(), key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return count
+}
+
+func setLoginFailures(t *testing.T, s *AccountService, g *loginGuard, failures int64) {
+	if failures == 0 {
+		return
+	}
+	err := s.options.CounterStore.Set(context.Background(), g.failuresKey(), failures, g.options.Window)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestLoginGuardThresholds(t *testing.T) {
+	tests := []struct {
+		name          string
+		failures      int64 //之前按手机号纪录的失败次数
+		captchaCode   string
+		wantErr       bool
+		wantCaptcha   bool
+		wantLocked    bool
+		wantFailures  int64 //请求结束后按手机号的计数
+		wantReserved  bool
+		wantAttempts  int64
+		wantIpPending bool
+	}{
+		{"first attempt", 0, "", false, false, false, 1, true, 1, true},
+		{"below captcha threshold", 1, "", false, false, false, 2, true, 2, true},
+		{"captcha required", 2, "", true, false, false, 2, false, 0, false},
+		{"wrong captcha", 2, "wrong", true, true, false, 2, false, 0, false},
+		{"right captcha", 2, "right", false, true, false, 3, true, 3, true},
+		{"over lockout threshold", 3, "right", true, false, true, 0, false, 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, ctx, captcha := newLoginGuardTestService()
+			guards := newTestLoginGuards(s)
+			setLoginFailures(t, s, guards[0], tt.failures)
+
+			err := s.reserveLoginAttempt(ctx, guards, "captcha-id", tt.captchaCode)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("reserveLoginAttempt() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if (captcha.calls > 0) != tt.wantCaptcha {
+				t.Errorf("captcha calls = %d, want verified %v", captcha.calls, tt.wantCaptcha)
+			}
+			if locked := loginGuardCount(t, s, guards[0].lockKey()) > 0; locked != tt.wantLocked {
+				t.Errorf("locked = %v, want %v", locked, tt.wantLocked)
+			}
+			if failures := loginGuardCount(t, s, guards[0].failuresKey()); failures != tt.wantFailures {
+				t.Errorf("failures = %d, want %d", failures, tt.wantFailures)
+			}
+			if guards[0].reserved != tt.wantReserved || (tt.wantReserved && guards[0].attempts != tt.wantAttempts) {
+				t.Errorf("reserved = %v attempts = %d, want %v %d",
+					guards[0].reserved, guards[0].attempts, tt.wantReserved, tt.wantAttempts)
+			}
+			//被拒绝的请求归还IP的预占
+			if ipPending := loginGuardCount(t, s, guards[1].failuresKey()) > 0; ipPending != tt.wantIpPending {
+				t.Errorf("ip pending = %v, want %v", ipPending, tt.wantIpPending)
+			}
+		})
+	}
+}
+
+// 同一手机号同时只有一个请求在校验密码，计数不会被并发请求重复使用
+func TestLoginGuardConcurrentAttempts(t *testing.T) {
+	s, ctx, _ := newLoginGuardTestService()
+
+	first := newTestLoginGuards(s)
+	err := s.reserveLoginAttempt(ctx, first, "", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	second := newTestLoginGuards(s)
+	err = s.reserveLoginAttempt(ctx, second, "", "")
+	if err == nil {
+		t.Fatal("reserveLoginAttempt() concurrent attempt error = nil")
+	}
+	if failures := loginGuardCount(t, s, first[0].failuresKey()); failures != 1 {
+		t.Errorf("failures = %d, want 1", failures)
+	}
+
+	err = s.addLoginFailure(ctx, first, "user1", loginTestPhone)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if backoff := loginGuardCount(t, s, first[0].backoffKey()); backoff != 1 {
+		t.Errorf("backoff = %d, want 1", backoff)
+	}
+}
+
+// 失败后需等待BackoffBase*2^(失败次数-1)才能再次尝试
+func TestLoginGuardBackoff(t *testing.T) {
+	s, ctx, _ := newLoginGuardTestService()
+
+	guards := newTestLoginGuards(s)
+	err := s.reserveLoginAttempt(ctx, guards, "", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = s.addLoginFailure(ctx, guards, "user1", loginTestPhone)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = s.reserveLoginAttempt(ctx, newTestLoginGuards(s), "", "")
+	if err == nil {
+		t.Fatal("reserveLoginAttempt() during backoff error = nil")
+	}
+
+	time.Sleep(s.options.LoginPhoneGuard.BackoffMax + time.Millisecond*20)
+	guards = newTestLoginGuards(s)
+	err = s.reserveLoginAttempt(ctx, guards, "", "")
+	if err != nil {
+		t.Fatalf("reserveLoginAttempt() after backoff error = %v", err)
+	}
+	if guards[0].attempts != 2 {
+		t.Errorf("attempts = %d, want 2", guards[0].attempts)
+	}
+}
+
+func TestLoginGuardBackoffDelay(t *testing.T) {
+	g := &loginGuard{options: &LoginGuardOptions{BackoffBase: time.Second, BackoffMax: time.Second * 5}}
+	tests := []struct {
+		failures int64
+		want     time.Duration
+	}{
+		{1, time.Second},
+		{2, time.Second * 2},
+		{3, time.Second * 4},
+		{4, time.Second * 5},
+		{100, time.Second * 5},
+	}
+
+	for _, tt := range tests {
+		if got := g.backoffDelay(tt.failures); got != tt.want {
+			t.Errorf("backoffDelay(%d) = %v, want %v", tt.failures, got, tt.want)
+		}
+	}
+}
+
+// 成功后清除手机号的计数，IP只归还本次预占
+func TestLoginGuardSuccess(t *testing.T) {
+	s, ctx, _ := newLoginGuardTestService()
+
+	guards := newTestLoginGuards(s)
+	setLoginFailures(t, s, guards[0], 1)
+	setLoginFailures(t, s, guards[1], 5)
+	err := s.reserveLoginAttempt(ctx, guards, "", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = s.addLoginSuccess(ctx, guards)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if failures := loginGuardCount(t, s, guards[0].failuresKey()); failures != 0 {
+		t.Errorf("phone failures = %d, want 0", failures)
+	}
+	if failures := loginGuardCount(t, s, guards[1].failuresKey()); failures != 5 {
+		t.Errorf("ip failures = %d, want 5", failures)
+	}
+	for _, g := range guards {
+		if backoff := loginGuardCount(t, s, g.backoffKey()); backoff != 0 {
+			t.Errorf("%s backoff = %d, want 0", g.name, backoff)
+		}
+	}
+}
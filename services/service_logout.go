@@ -5,16 +5,28 @@ import (
 	"github.com/NeuronFramework/rest"
 )
 
-func (s *AccountService) Logout(ctx *rest.Context, userId string) (err error) {
-	_, err = s.accountDB.RefreshToken.Query().UserIdEqual(userId).Delete(ctx, nil)
+// all为true时退出全部设备，返回结束的会话数量
+func (s *AccountService) Logout(ctx *rest.Context, principal *models.Principal, all bool) (sessionCount int64, err error) {
+	operationType := models.OperationLogout
+	if all {
+		operationType = models.OperationLogoutAll
+		sessionCount, err = s.revokeAllSessions(ctx, principal.UserId)
+	} else if principal.SessionId != "" {
+		//只退出当前会话
+		sessionCount, err = s.revokeSession(ctx, principal.UserId, principal.SessionId)
+	} else {
+		//没有会话信息的token退出全部会话
+		sessionCount, err = s.revokeAllSessions(ctx, principal.UserId)
+	}
 	if err != nil {
-		return err
+		return 0, err
 	}
 
 	s.addOperation(ctx, &models.AccountOperation{
-		OperationType: models.OperationLogout,
-		UserId:        userId,
+		OperationType: operationType,
+		UserId:        principal.UserId,
+		Detail:        sessionCountDetail(sessionCount),
 	})
 
-	return nil
+	return sessionCount, nil
 }
@@ -0,0 +1,290 @@
+package services
+
+import (
+	"github.com/NeuronAccount/account/models"
+	"github.com/NeuronAccount/account/storages/neuron_account_db"
+	"github.com/NeuronFramework/rand"
+	"github.com/NeuronFramework/rest"
+	"github.com/NeuronFramework/sql/wrap"
+	"go.uber.org/zap"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const CreateAuthorizationCodeMaxRetry = 10
+
+type authorizeRequest struct {
+	client      *neuron_account_db.OauthClient
+	redirectUri string
+	//非空时通过重定向返回给客户端
+	errorCode        string
+	errorDescription string
+}
+
+func splitScope(scope string) (scopes []string) {
+	return strings.Fields(scope)
+}
+
+// requested中的每个scope都已在granted中
+func isScopeSubset(requested string, granted string) bool {
+	grantedScopes := splitScope(granted)
+	for _, v := range splitScope(requested) {
+		found := false
+		for _, g := range grantedScopes {
+			if v == g {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	return true
+}
+
+func mergeScope(a string, b string) (scope string) {
+	scopes := splitScope(a)
+	for _, v := range splitScope(b) {
+		if !isScopeSubset(v, strings.Join(scopes, " ")) {
+			scopes = append(scopes, v)
+		}
+	}
+
+	return strings.Join(scopes, " ")
+}
+
+func appendRedirectQuery(redirectUri string, values map[string]string) (r string) {
+	u, err := url.Parse(redirectUri)
+	if err != nil {
+		return redirectUri
+	}
+
+	q := u.Query()
+	for k, v := range values {
+		if v != "" {
+			q.Set(k, v)
+		}
+	}
+	u.RawQuery = q.Encode()
+
+	return u.String()
+}
+
+// 客户端或回调地址无效时直接返回错误，不能重定向到未经验证的地址；
+// 其它错误通过重定向告知客户端
+func (s *AccountService) checkAuthorizeRequest(ctx *rest.Context, params *models.AuthorizeParams) (
+	req *authorizeRequest, err error) {
+
+	dbOauthClient, err := s.getOauthClient(ctx, params.ClientId)
+	if err != nil {
+		return nil, err
+	}
+
+	req = &authorizeRequest{}
+	req.client = dbOauthClient
+	req.redirectUri = params.RedirectUri
+	if req.redirectUri == "" {
+		//只注册了一个回调地址时可以省略
+		redirectUris := splitRedirectUris(dbOauthClient.RedirectUris)
+		if len(redirectUris) != 1 {
+			return nil, rest.BadRequest(models.OauthErrorInvalidRequest, "缺少回调地址")
+		}
+		req.redirectUri = redirectUris[0]
+	} else if !s.isRedirectUriRegistered(dbOauthClient, req.redirectUri) {
+		return nil, rest.BadRequest(models.OauthErrorInvalidRequest, "回调地址未注册")
+	}
+
+	if !s.isGrantTypeAllowed(dbOauthClient, models.OauthGrantTypeAuthorizationCode) {
+		req.errorCode = models.OauthErrorUnauthorizedClient
+		req.errorDescription = "client is not allowed to use authorization_code"
+		return req, nil
+	}
+
+	if params.ResponseType != models.OauthResponseTypeCode {
+		req.errorCode = models.OauthErrorUnsupportedResponseType
+		req.errorDescription = "response_type must be code"
+		return req, nil
+	}
+
+	//所有客户端都必须使用PKCE
+	if params.CodeChallenge == "" {
+		req.errorCode = models.OauthErrorInvalidRequest
+		req.errorDescription = "code_challenge required"
+		return req, nil
+	}
+	if params.CodeChallengeMethod != models.PkceMethodS256 {
+		req.errorCode = models.OauthErrorInvalidRequest
+		req.errorDescription = "code_challenge_method must be S256"
+		return req, nil
+	}
+
+	if !isScopeSubset(params.Scope, models.ThirdPartyScope) {
+		req.errorCode = models.OauthErrorInvalidScope
+		req.errorDescription = "requested scope is not allowed"
+		return req, nil
+	}
+
+	return req, nil
+}
+
+func (s *AccountService) errorRedirect(req *authorizeRequest, params *models.AuthorizeParams) (
+	result *models.AuthorizeResult) {
+
+	return &models.AuthorizeResult{
+		RedirectUri: appendRedirectQuery(req.redirectUri, map[string]string{
+			"error":             req.errorCode,
+			"error_description": req.errorDescription,
+			"state":             params.State,
+		}),
+	}
+}
+
+// 授权码纪录用户登录当前会话的认证时间和方式，换取token时写入id_token
+func (s *AccountService) issueAuthorizationCode(ctx *rest.Context, principal *models.Principal, req *authorizeRequest,
+	params *models.AuthorizeParams) (result *models.AuthorizeResult, err error) {
+
+	userId := principal.UserId
+	authTime := time.Now()
+	amr := ""
+	if principal.SessionId != "" {
+		dbUserSession, err := s.accountDB.UserSession.Query().SessionIdEqual(principal.SessionId).Select(ctx, nil)
+		if err != nil {
+			return nil, err
+		}
+		if dbUserSession != nil {
+			authTime = dbUserSession.AuthTime
+			amr = dbUserSession.Amr
+		}
+	}
+
+	for i := 0; i < CreateAuthorizationCodeMaxRetry; i++ {
+		dbAuthorizationCode := &neuron_account_db.OauthAuthorizationCode{}
+		dbAuthorizationCode.Code = rand.NextHex(32)
+		dbAuthorizationCode.ClientId = req.client.ClientId
+		dbAuthorizationCode.UserId = userId
+		dbAuthorizationCode.RedirectUri = params.RedirectUri //换取token时须与授权请求中的参数一致
+		dbAuthorizationCode.Scope = params.Scope
+		dbAuthorizationCode.CodeChallenge = params.CodeChallenge
+		dbAuthorizationCode.CodeChallengeMethod = params.CodeChallengeMethod
+		dbAuthorizationCode.Nonce = params.Nonce
+		dbAuthorizationCode.AuthTime = authTime
+		dbAuthorizationCode.Amr = amr
+		dbAuthorizationCode.IsUsed = 0
+		dbAuthorizationCode.ExpireTime = time.Now().Add(time.Second * models.OauthAuthorizationCodeExpireSeconds)
+		_, err = s.accountDB.OauthAuthorizationCode.Query().Insert(ctx, nil, dbAuthorizationCode)
+		if err != nil {
+			if err == wrap.ErrDuplicated {
+				s.logger.Warn("issueAuthorizationCode Insert ErrDuplicated",
+					zap.String("clientId", req.client.ClientId),
+					zap.String("userId", userId))
+				continue
+			}
+
+			return nil, err
+		}
+
+		return &models.AuthorizeResult{
+			ClientName: req.client.ClientName,
+			Scope:      params.Scope,
+			RedirectUri: appendRedirectQuery(req.redirectUri, map[string]string{
+				"code":  dbAuthorizationCode.Code,
+				"state": params.State,
+			}),
+		}, nil
+	}
+
+	return nil, rest.Unknown("服务器正忙，请稍后再试")
+}
+
+func (s *AccountService) saveOauthConsent(ctx *rest.Context, userId string, clientId string, scope string) (err error) {
+	dbOauthConsent, err := s.accountDB.OauthConsent.Query().
+		UserIdEqual(userId).And().ClientIdEqual(clientId).Select(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	if dbOauthConsent == nil {
+		dbOauthConsent = &neuron_account_db.OauthConsent{}
+		dbOauthConsent.UserId = userId
+		dbOauthConsent.ClientId = clientId
+		dbOauthConsent.Scope = scope
+		_, err = s.accountDB.OauthConsent.Query().Insert(ctx, nil, dbOauthConsent)
+		if err != nil && err != wrap.ErrDuplicated {
+			return err
+		}
+		if err == nil {
+			return nil
+		}
+	}
+
+	//已授权过，合并新的scope
+	_, err = s.accountDB.OauthConsent.Query().UserIdEqual(userId).And().ClientIdEqual(clientId).
+		SetScope(mergeScope(dbOauthConsent.Scope, scope)).Update(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// 用户已授权过所请求的scope时直接签发授权码，否则需要用户确认
+func (s *AccountService) Authorize(ctx *rest.Context, principal *models.Principal, params *models.AuthorizeParams) (
+	result *models.AuthorizeResult, err error) {
+
+	req, err := s.checkAuthorizeRequest(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+	if req.errorCode != "" {
+		return s.errorRedirect(req, params), nil
+	}
+
+	dbOauthConsent, err := s.accountDB.OauthConsent.Query().
+		UserIdEqual(principal.UserId).And().ClientIdEqual(req.client.ClientId).Select(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	if dbOauthConsent == nil || !isScopeSubset(params.Scope, dbOauthConsent.Scope) {
+		return &models.AuthorizeResult{
+			ConsentRequired: true,
+			ClientName:      req.client.ClientName,
+			Scope:           params.Scope,
+		}, nil
+	}
+
+	return s.issueAuthorizationCode(ctx, principal, req, params)
+}
+
+func (s *AccountService) ApproveAuthorization(ctx *rest.Context, principal *models.Principal, params *models.AuthorizeParams,
+	approved bool) (result *models.AuthorizeResult, err error) {
+
+	req, err := s.checkAuthorizeRequest(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+	if req.errorCode != "" {
+		return s.errorRedirect(req, params), nil
+	}
+
+	if !approved {
+		req.errorCode = models.OauthErrorAccessDenied
+		req.errorDescription = "user denied the request"
+		return s.errorRedirect(req, params), nil
+	}
+
+	err = s.saveOauthConsent(ctx, principal.UserId, req.client.ClientId, params.Scope)
+	if err != nil {
+		return nil, err
+	}
+
+	//操作纪录
+	s.addOperation(ctx, &models.AccountOperation{
+		OperationType: models.OperationOauthConsent,
+		UserId:        principal.UserId,
+	})
+
+	return s.issueAuthorizationCode(ctx, principal, req, params)
+}
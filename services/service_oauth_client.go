@@ -0,0 +1,274 @@
+package services
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"github.com/NeuronAccount/account/models"
+	"github.com/NeuronAccount/account/storages/neuron_account_db"
+	"github.com/NeuronFramework/rand"
+	"github.com/NeuronFramework/rest"
+	"github.com/NeuronFramework/sql/wrap"
+	"go.uber.org/zap"
+	"net/url"
+	"strings"
+)
+
+func (s *AccountService) calcClientSecretHash(clientSecret string) (clientSecretHash string) {
+	sum := sha256.Sum256([]byte(clientSecret))
+	return hex.EncodeToString(sum[:])
+}
+
+func (s *AccountService) ValidateClientCredentials(ctx context.Context, clientId string, clientSecret string) (err error) {
+	if clientId == "" || clientSecret == "" {
+		return rest.BadRequest("InvalidClient", "客户端认证失败")
+	}
+
+	dbOauthClient, err := s.accountDB.OauthClient.Query().ClientIdEqual(clientId).Select(ctx, nil)
+	if err != nil {
+		return err
+	}
+	if dbOauthClient == nil {
+		return rest.BadRequest("InvalidClient", "客户端认证失败")
+	}
+
+	clientSecretHash := s.calcClientSecretHash(clientSecret)
+	if subtle.ConstantTimeCompare([]byte(clientSecretHash), []byte(dbOauthClient.ClientSecretHash)) != 1 {
+		return rest.BadRequest("InvalidClient", "客户端认证失败")
+	}
+
+	return nil
+}
+
+const CreateOauthClientMaxRetry = 10
+
+// 回调地址须为不含fragment的绝对地址，除本机调试外必须使用https，
+// 原生应用可使用自定义scheme
+func (s *AccountService) validateRedirectUri(redirectUri string) (err error) {
+	u, err := url.Parse(redirectUri)
+	if err != nil || !u.IsAbs() {
+		return rest.InvalidParam("回调地址格式错误")
+	}
+	if u.Fragment != "" {
+		return rest.InvalidParam("回调地址不能包含fragment")
+	}
+	if u.Scheme == "http" {
+		hostname := u.Hostname()
+		if hostname != "localhost" && hostname != "127.0.0.1" && hostname != "::1" {
+			return rest.InvalidParam("回调地址必须使用https")
+		}
+	}
+	if (u.Scheme == "http" || u.Scheme == "https") && u.Host == "" {
+		return rest.InvalidParam("回调地址格式错误")
+	}
+
+	return nil
+}
+
+// 客户端的token会发给audience对应的服务，不能使用本服务及其它用户客户端的audience，
+// 未指定时使用clientId
+func (s *AccountService) validateAudience(ctx *rest.Context, userId string, audience string) (err error) {
+	if audience == "" {
+		return nil
+	}
+	if audience == s.options.AccessTokenAudience {
+		return rest.InvalidParam("不能使用本服务的audience")
+	}
+
+	dbOauthClient, err := s.accountDB.OauthClient.Query().ClientIdEqual(audience).Select(ctx, nil)
+	if err != nil {
+		return err
+	}
+	if dbOauthClient != nil && dbOauthClient.OwnerUserId != userId {
+		return rest.InvalidParam("audience已被其它客户端使用")
+	}
+
+	dbOauthClient, err = s.accountDB.OauthClient.Query().
+		AudienceEqual(audience).And().OwnerUserIdNotEqual(userId).Select(ctx, nil)
+	if err != nil {
+		return err
+	}
+	if dbOauthClient != nil {
+		return rest.InvalidParam("audience已被其它客户端使用")
+	}
+
+	return nil
+}
+
+// 回调地址必须与注册的地址完全一致
+func (s *AccountService) isRedirectUriRegistered(dbOauthClient *neuron_account_db.OauthClient, redirectUri string) bool {
+	for _, v := range splitRedirectUris(dbOauthClient.RedirectUris) {
+		if v == redirectUri {
+			return true
+		}
+	}
+
+	return false
+}
+
+// 未指定audience时以clientId作为audience
+func (s *AccountService) clientAudience(dbOauthClient *neuron_account_db.OauthClient) (audience string) {
+	if dbOauthClient.Audience != "" {
+		return dbOauthClient.Audience
+	}
+
+	return dbOauthClient.ClientId
+}
+
+func (s *AccountService) isGrantTypeAllowed(dbOauthClient *neuron_account_db.OauthClient, grantType string) bool {
+	for _, v := range splitGrantTypes(dbOauthClient.GrantTypes) {
+		if v == grantType {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (s *AccountService) validateGrantTypes(params *models.OauthClient) (err error) {
+	for _, v := range params.GrantTypes {
+		switch v {
+		case models.OauthGrantTypeAuthorizationCode:
+			if len(params.RedirectUris) == 0 {
+				return rest.InvalidParam("回调地址不能为空")
+			}
+		case models.OauthGrantTypeRefreshToken:
+		case models.OauthGrantTypeClientCredentials:
+			//公开客户端没有密钥，不能代表自身调用服务
+			if params.IsPublic {
+				return rest.InvalidParam("公开客户端不能使用client_credentials")
+			}
+		case models.OauthGrantTypeTokenExchange:
+			return rest.InvalidParam("token exchange只能由运维分配")
+		default:
+			return rest.InvalidParam("不支持的授权类型" + v)
+		}
+	}
+
+	return nil
+}
+
+func (s *AccountService) getOauthClient(ctx context.Context, clientId string) (
+	oauthClient *neuron_account_db.OauthClient, err error) {
+
+	if clientId == "" {
+		return nil, rest.BadRequest(models.OauthErrorInvalidClient, "客户端不存在")
+	}
+
+	dbOauthClient, err := s.accountDB.OauthClient.Query().ClientIdEqual(clientId).Select(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	if dbOauthClient == nil {
+		return nil, rest.BadRequest(models.OauthErrorInvalidClient, "客户端不存在")
+	}
+
+	return dbOauthClient, nil
+}
+
+// 公开客户端（如单页应用、原生应用）无法保存密钥，只校验clientId，由PKCE保证安全
+func (s *AccountService) authenticateOauthClient(ctx context.Context, clientId string, clientSecret string) (
+	oauthClient *neuron_account_db.OauthClient, err error) {
+
+	dbOauthClient, err := s.getOauthClient(ctx, clientId)
+	if err != nil {
+		return nil, err
+	}
+
+	if dbOauthClient.IsPublic != 0 {
+		if clientSecret != "" {
+			return nil, rest.BadRequest(models.OauthErrorInvalidClient, "客户端认证失败")
+		}
+
+		return dbOauthClient, nil
+	}
+
+	clientSecretHash := s.calcClientSecretHash(clientSecret)
+	if clientSecret == "" ||
+		subtle.ConstantTimeCompare([]byte(clientSecretHash), []byte(dbOauthClient.ClientSecretHash)) != 1 {
+		return nil, rest.BadRequest(models.OauthErrorInvalidClient, "客户端认证失败")
+	}
+
+	return dbOauthClient, nil
+}
+
+func (s *AccountService) clientGrantOf(ctx context.Context, clientId string, scope string) (
+	grant *clientGrant, err error) {
+
+	dbOauthClient, err := s.getOauthClient(ctx, clientId)
+	if err != nil {
+		return nil, err
+	}
+
+	return &clientGrant{
+		clientId: dbOauthClient.ClientId,
+		audience: s.clientAudience(dbOauthClient),
+		scope:    scope,
+	}, nil
+}
+
+func (s *AccountService) RegisterOauthClient(ctx *rest.Context, userId string, params *models.OauthClient) (
+	oauthClient *models.OauthClient, err error) {
+
+	if params.ClientName == "" {
+		return nil, rest.InvalidParam("客户端名称不能为空")
+	}
+	if len(params.GrantTypes) == 0 {
+		params.GrantTypes = splitGrantTypes("")
+	}
+	err = s.validateGrantTypes(params)
+	if err != nil {
+		return nil, err
+	}
+	if !isScopeSubset(params.Scope, models.ThirdPartyScope) {
+		return nil, rest.InvalidParam("不允许申请的scope")
+	}
+	for _, v := range params.RedirectUris {
+		err = s.validateRedirectUri(v)
+		if err != nil {
+			return nil, err
+		}
+	}
+	err = s.validateAudience(ctx, userId, params.Audience)
+	if err != nil {
+		return nil, err
+	}
+
+	clientSecret := ""
+	dbOauthClient := &neuron_account_db.OauthClient{}
+	dbOauthClient.ClientName = params.ClientName
+	dbOauthClient.OwnerUserId = userId
+	dbOauthClient.RedirectUris = joinRedirectUris(params.RedirectUris)
+	dbOauthClient.Audience = params.Audience
+	dbOauthClient.GrantTypes = strings.Join(params.GrantTypes, " ")
+	dbOauthClient.Scope = params.Scope
+	if params.IsPublic {
+		dbOauthClient.IsPublic = 1
+	} else {
+		clientSecret = rand.NextHex(32)
+		dbOauthClient.ClientSecretHash = s.calcClientSecretHash(clientSecret)
+	}
+
+	for i := 0; i < CreateOauthClientMaxRetry; i++ {
+		dbOauthClient.ClientId = rand.NextHex(16)
+		_, err = s.accountDB.OauthClient.Query().Insert(ctx, nil, dbOauthClient)
+		if err != nil {
+			if err == wrap.ErrDuplicated {
+				s.logger.Warn("RegisterOauthClient Insert ErrDuplicated",
+					zap.String("clientId", dbOauthClient.ClientId))
+				continue
+			}
+
+			return nil, err
+		}
+
+		//密钥只保存hash，仅此时返回明文
+		oauthClient = fromOauthClient(dbOauthClient)
+		oauthClient.ClientSecret = clientSecret
+
+		return oauthClient, nil
+	}
+
+	return nil, rest.Unknown("服务器正忙，请稍后再试")
+}
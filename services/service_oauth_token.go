@@ -0,0 +1,222 @@
+package services
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"github.com/NeuronAccount/account/models"
+	"github.com/NeuronAccount/account/storages/neuron_account_db"
+	"github.com/NeuronFramework/rest"
+	"go.uber.org/zap"
+	"time"
+)
+
+// RFC 7636，code_verifier为43到128个字符
+func verifyPkce(codeChallenge string, codeChallengeMethod string, codeVerifier string) bool {
+	if len(codeVerifier) < 43 || len(codeVerifier) > 128 {
+		return false
+	}
+	if codeChallengeMethod != models.PkceMethodS256 {
+		return false
+	}
+
+	sum := sha256.Sum256([]byte(codeVerifier))
+	expected := base64.RawURLEncoding.EncodeToString(sum[:])
+
+	return subtle.ConstantTimeCompare([]byte(expected), []byte(codeChallenge)) == 1
+}
+
+// 授权码被重复使用，说明可能已泄露，撤销用它换取的会话
+func (s *AccountService) revokeAuthorizationCode(ctx *rest.Context, dbAuthorizationCode *neuron_account_db.OauthAuthorizationCode) (
+	err error) {
+
+	if dbAuthorizationCode.SessionId != "" {
+		_, err = s.revokeSession(ctx, dbAuthorizationCode.UserId, dbAuthorizationCode.SessionId)
+		if err != nil {
+			return err
+		}
+	}
+
+	s.logger.Warn("revokeAuthorizationCode",
+		zap.String("clientId", dbAuthorizationCode.ClientId),
+		zap.String("userId", dbAuthorizationCode.UserId))
+
+	//操作纪录
+	s.addOperation(ctx, &models.AccountOperation{
+		OperationType: models.OperationOauthCodeReuse,
+		UserId:        dbAuthorizationCode.UserId,
+	})
+
+	return nil
+}
+
+func (s *AccountService) exchangeAuthorizationCode(ctx *rest.Context, dbOauthClient *neuron_account_db.OauthClient,
+	params *models.OauthTokenParams, device *models.DeviceInfo) (oauthToken *models.OauthToken, err error) {
+
+	if params.Code == "" {
+		return nil, rest.BadRequest(models.OauthErrorInvalidRequest, "缺少授权码")
+	}
+
+	dbAuthorizationCode, err := s.accountDB.OauthAuthorizationCode.Query().CodeEqual(params.Code).Select(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	if dbAuthorizationCode == nil || dbAuthorizationCode.ClientId != dbOauthClient.ClientId {
+		return nil, rest.BadRequest(models.OauthErrorInvalidGrant, "授权码无效")
+	}
+	if dbAuthorizationCode.IsUsed != 0 {
+		err = s.revokeAuthorizationCode(ctx, dbAuthorizationCode)
+		if err != nil {
+			return nil, err
+		}
+
+		return nil, rest.BadRequest(models.OauthErrorInvalidGrant, "授权码已使用")
+	}
+	if time.Now().After(dbAuthorizationCode.ExpireTime) {
+		return nil, rest.BadRequest(models.OauthErrorInvalidGrant, "授权码已过期")
+	}
+	if dbAuthorizationCode.RedirectUri != params.RedirectUri {
+		return nil, rest.BadRequest(models.OauthErrorInvalidGrant, "回调地址不匹配")
+	}
+	if !verifyPkce(dbAuthorizationCode.CodeChallenge, dbAuthorizationCode.CodeChallengeMethod, params.CodeVerifier) {
+		return nil, rest.BadRequest(models.OauthErrorInvalidGrant, "code_verifier校验失败")
+	}
+
+	//标记为已使用，影响行数为0说明被并发使用
+	result, err := s.accountDB.OauthAuthorizationCode.Query().
+		IdEqual(dbAuthorizationCode.Id).And().IsUsedEqual(0).
+		SetIsUsed(1).Update(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	affectedRows, err := result.RowsAffected()
+	if err != nil {
+		return nil, err
+	}
+	if affectedRows != 1 {
+		return nil, rest.BadRequest(models.OauthErrorInvalidGrant, "授权码已使用")
+	}
+
+	//每次授权创建一个新的会话
+	dbUserSession, err := s.createSession(ctx, dbAuthorizationCode.UserId, device,
+		dbAuthorizationCode.AuthTime, splitAmr(dbAuthorizationCode.Amr))
+	if err != nil {
+		return nil, err
+	}
+
+	//纪录换取的会话，授权码被重复使用时撤销
+	_, err = s.accountDB.OauthAuthorizationCode.Query().IdEqual(dbAuthorizationCode.Id).
+		SetSessionId(dbUserSession.SessionId).Update(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	grant := &clientGrant{
+		clientId: dbOauthClient.ClientId,
+		audience: s.clientAudience(dbOauthClient),
+		scope:    dbAuthorizationCode.Scope,
+	}
+	userToken, err := s.issueUserToken(ctx, dbUserSession, grant)
+	if err != nil {
+		return nil, err
+	}
+
+	oauthToken = &models.OauthToken{
+		AccessToken:  userToken.AccessToken,
+		TokenType:    models.OauthTokenTypeBearer,
+		ExpiresIn:    models.UserAccessTokenExpireSeconds,
+		RefreshToken: userToken.RefreshToken,
+		Scope:        grant.scope,
+	}
+
+	if isScopeSubset(models.OidcScopeOpenid, grant.scope) {
+		oauthToken.IdToken, err = s.createIdToken(dbUserSession, dbOauthClient.ClientId, dbAuthorizationCode.Nonce)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return oauthToken, nil
+}
+
+// 客户端以自身身份获取token，未指定scope时为注册时允许的全部scope
+func (s *AccountService) clientCredentialsToken(ctx *rest.Context, dbOauthClient *neuron_account_db.OauthClient,
+	params *models.OauthTokenParams) (oauthToken *models.OauthToken, err error) {
+
+	//公开客户端注册时不允许该授权类型，这里再次确认
+	if dbOauthClient.IsPublic != 0 {
+		return nil, rest.BadRequest(models.OauthErrorUnauthorizedClient, "公开客户端不能使用client_credentials")
+	}
+
+	scope := params.Scope
+	if scope == "" {
+		scope = dbOauthClient.Scope
+	}
+	if !isScopeSubset(scope, dbOauthClient.Scope) {
+		return nil, rest.BadRequest(models.OauthErrorInvalidScope, "申请的scope超出允许范围")
+	}
+
+	accessToken, err := s.createClientAccessToken(ctx, dbOauthClient.ClientId, scope)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.OauthToken{
+		AccessToken: accessToken,
+		TokenType:   models.OauthTokenTypeBearer,
+		ExpiresIn:   models.UserAccessTokenExpireSeconds,
+		Scope:       scope,
+	}, nil
+}
+
+func (s *AccountService) OauthToken(ctx *rest.Context, params *models.OauthTokenParams, device *models.DeviceInfo) (
+	oauthToken *models.OauthToken, err error) {
+
+	dbOauthClient, err := s.authenticateOauthClient(ctx, params.ClientId, params.ClientSecret)
+	if err != nil {
+		return nil, err
+	}
+
+	if !s.isGrantTypeAllowed(dbOauthClient, params.GrantType) {
+		switch params.GrantType {
+		case models.OauthGrantTypeAuthorizationCode, models.OauthGrantTypeRefreshToken,
+			models.OauthGrantTypeClientCredentials, models.OauthGrantTypeTokenExchange:
+			return nil, rest.BadRequest(models.OauthErrorUnauthorizedClient, "客户端不允许使用该授权类型")
+		default:
+			return nil, rest.BadRequest(models.OauthErrorUnsupportedGrantType, "不支持的授权类型")
+		}
+	}
+
+	switch params.GrantType {
+	case models.OauthGrantTypeAuthorizationCode:
+		return s.exchangeAuthorizationCode(ctx, dbOauthClient, params, device)
+	case models.OauthGrantTypeRefreshToken:
+		userToken, dbRefreshToken, err := s.rotateRefreshToken(ctx, params.RefreshToken, device, dbOauthClient.ClientId)
+		if err != nil {
+			return nil, err
+		}
+
+		oauthToken = &models.OauthToken{
+			AccessToken:  userToken.AccessToken,
+			TokenType:    models.OauthTokenTypeBearer,
+			ExpiresIn:    models.UserAccessTokenExpireSeconds,
+			RefreshToken: userToken.RefreshToken,
+			Scope:        dbRefreshToken.Scope,
+		}
+
+		if isScopeSubset(models.OidcScopeOpenid, dbRefreshToken.Scope) {
+			oauthToken.IdToken, err = s.refreshIdToken(ctx, dbRefreshToken)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		return oauthToken, nil
+	case models.OauthGrantTypeClientCredentials:
+		return s.clientCredentialsToken(ctx, dbOauthClient, params)
+	case models.OauthGrantTypeTokenExchange:
+		return s.exchangeToken(ctx, dbOauthClient, params)
+	default:
+		return nil, rest.BadRequest(models.OauthErrorUnsupportedGrantType, "不支持的授权类型")
+	}
+}
@@ -0,0 +1,38 @@
+package services
+
+import (
+	"github.com/NeuronAccount/account/models"
+	"strings"
+	"testing"
+)
+
+func TestVerifyPkce(t *testing.T) {
+	//RFC 7636 附录B的示例
+	const verifier = "dBjftJeZ4CVP-mB92K27uhbUJU1p1r_wW1gFWFOEjXk"
+	const challenge = "E9Melhoa2OwvFrEMTJguCHaoeK1t8URWbuGJSstw-cM"
+
+	tests := []struct {
+		name      string
+		challenge string
+		method    string
+		verifier  string
+		want      bool
+	}{
+		{"rfc example", challenge, models.PkceMethodS256, verifier, true},
+		{"wrong verifier", challenge, models.PkceMethodS256, verifier[:42] + "Y", false},
+		{"plain method", verifier, "plain", verifier, false},
+		{"empty method", challenge, "", verifier, false},
+		{"verifier too short", challenge, models.PkceMethodS256, verifier[:42], false},
+		{"verifier too long", challenge, models.PkceMethodS256, strings.Repeat("a", 129), false},
+		{"empty challenge", "", models.PkceMethodS256, verifier, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := verifyPkce(tt.challenge, tt.method, tt.verifier)
+			if got != tt.want {
+				t.Errorf("verifyPkce() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,158 @@
+package services
+
+import (
+	"context"
+	"github.com/NeuronAccount/account/models"
+	"github.com/NeuronAccount/account/storages/neuron_account_db"
+	"github.com/NeuronFramework/rest"
+	"github.com/dgrijalva/jwt-go"
+	"strings"
+	"time"
+)
+
+type idTokenClaims struct {
+	jwt.StandardClaims
+	Nonce     string   `json:"nonce,omitempty"`
+	AuthTime  int64    `json:"auth_time,omitempty"`
+	Amr       []string `json:"amr,omitempty"`
+	SessionId string   `json:"sid,omitempty"`
+}
+
+func splitAmr(amr string) (r []string) {
+	return strings.Fields(amr)
+}
+
+func (s *AccountService) createIdToken(dbUserSession *neuron_account_db.UserSession, clientId string, nonce string) (
+	idToken string, err error) {
+
+	now := time.Now()
+	claims := &idTokenClaims{}
+	claims.Issuer = s.options.Issuer
+	claims.Subject = dbUserSession.UserId
+	claims.Audience = clientId
+	claims.IssuedAt = now.Unix()
+	claims.NotBefore = now.Unix()
+	claims.ExpiresAt = now.Add(time.Second * models.OidcIdTokenExpireSeconds).Unix()
+	claims.Nonce = nonce
+	claims.AuthTime = dbUserSession.AuthTime.Unix()
+	claims.Amr = splitAmr(dbUserSession.Amr)
+	claims.SessionId = dbUserSession.SessionId
+
+	return s.signJwt(claims)
+}
+
+// 刷新时重新签发的id_token不带nonce
+func (s *AccountService) refreshIdToken(ctx *rest.Context, dbRefreshToken *neuron_account_db.RefreshToken) (
+	idToken string, err error) {
+
+	dbUserSession, err := s.accountDB.UserSession.Query().SessionIdEqual(dbRefreshToken.SessionId).Select(ctx, nil)
+	if err != nil {
+		return "", err
+	}
+	if dbUserSession == nil {
+		return "", rest.NotFound("Token已失效，请重新登录")
+	}
+
+	return s.createIdToken(dbUserSession, dbRefreshToken.ClientId, "")
+}
+
+// 供/userinfo使用，接受发给任意客户端的token，但必须包含openid授权
+func (s *AccountService) ParseOidcAccessToken(ctx context.Context, accessToken string) (principal *models.Principal, err error) {
+	claims, err := s.parseAccessTokenClaims(ctx, accessToken, "")
+	if err != nil {
+		return nil, err
+	}
+
+	if claims.ClientId == "" || claims.SubjectType == models.SubjectTypeClient ||
+		!isScopeSubset(models.OidcScopeOpenid, claims.Scope) {
+		return nil, newTokenError(TokenErrorInsufficientScope, "未授权openid")
+	}
+
+	return s.principalOf(claims), nil
+}
+
+func (s *AccountService) GetOpenidConfiguration(ctx *rest.Context) (configuration *models.OpenidConfiguration, err error) {
+	return &models.OpenidConfiguration{
+		Issuer:                 s.options.Issuer,
+		AuthorizationEndpoint:  s.options.AuthorizationEndpoint,
+		TokenEndpoint:          s.options.Issuer + "/oauth2/token",
+		UserinfoEndpoint:       s.options.Issuer + "/oauth2/userinfo",
+		JwksUri:                s.options.Issuer + "/.well-known/jwks.json",
+		IntrospectionEndpoint:  s.options.Issuer + "/oauth2/introspect",
+		ScopesSupported:        splitScope(models.ThirdPartyScope),
+		ResponseTypesSupported: []string{models.OauthResponseTypeCode},
+		GrantTypesSupported: []string{
+			models.OauthGrantTypeAuthorizationCode,
+			models.OauthGrantTypeRefreshToken,
+			models.OauthGrantTypeClientCredentials,
+			models.OauthGrantTypeTokenExchange,
+		},
+		SubjectTypesSupported:             []string{"public"},
+		IdTokenSigningAlgValuesSupported:  []string{s.options.JwtAlgorithm},
+		TokenEndpointAuthMethodsSupported: []string{"client_secret_basic", "client_secret_post", "none"},
+		CodeChallengeMethodsSupported:     []string{models.PkceMethodS256},
+		ClaimsSupported: []string{
+			"sub", "iss", "aud", "exp", "iat", "auth_time", "nonce", "amr", "sid",
+			"name", "preferred_username", "picture", "phone_number", "phone_number_verified",
+		},
+	}, nil
+}
+
+// 手机号按E.164格式返回，未带国家码的按中国大陆手机号处理，无法识别时返回false
+func toE164Phone(phone string) (e164 string, ok bool) {
+	digits := strings.TrimPrefix(phone, "+")
+	if digits == "" || len(digits) > 15 {
+		return "", false
+	}
+	for _, c := range digits {
+		if c < '0' || c > '9' {
+			return "", false
+		}
+	}
+
+	if strings.HasPrefix(phone, "+") {
+		return phone, true
+	}
+	if len(digits) == 11 && digits[0] == '1' {
+		return "+86" + digits, true
+	}
+
+	return "", false
+}
+
+// 按授权的scope返回标准claims，手机号为完整号码，只在授权phone时返回
+func (s *AccountService) GetOidcUserInfo(ctx *rest.Context, principal *models.Principal) (
+	userInfo *models.OidcUserInfo, err error) {
+
+	accountInfo, err := s.GetAccountInfo(ctx, principal.UserId)
+	if err != nil {
+		return nil, err
+	}
+
+	userInfo = &models.OidcUserInfo{}
+	userInfo.Subject = accountInfo.UserId
+	if isScopeSubset(models.OidcScopeProfile, principal.Scope) {
+		userInfo.Name = accountInfo.UserName
+		userInfo.PreferredUsername = accountInfo.UserName
+		userInfo.Picture = accountInfo.UserIcon
+	}
+	if isScopeSubset(models.OidcScopePhone, principal.Scope) {
+		dbPhoneAccount, err := s.accountDB.PhoneAccount.Query().UserIdEqual(principal.UserId).Select(ctx, nil)
+		if err != nil {
+			return nil, err
+		}
+		if dbPhoneAccount != nil {
+			phone, err := s.decryptPhone(dbPhoneAccount.PhoneEncrypted)
+			if err != nil {
+				return nil, err
+			}
+			//绑定手机号须通过短信验证码，故为已验证
+			if e164, ok := toE164Phone(phone); ok {
+				userInfo.PhoneNumber = e164
+				userInfo.PhoneNumberVerified = true
+			}
+		}
+	}
+
+	return userInfo, nil
+}
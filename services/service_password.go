@@ -0,0 +1,180 @@
+package services
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"fmt"
+	"github.com/NeuronAccount/account/models"
+	"github.com/NeuronAccount/account/storages/neuron_account_db"
+	"github.com/NeuronFramework/rest"
+	"go.uber.org/zap"
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/bcrypt"
+	"strings"
+)
+
+const (
+	argon2SaltLength = 16
+	argon2KeyLength  = 32
+)
+
+type argon2Params struct {
+	memory  uint32
+	time    uint32
+	threads uint8
+}
+
+func (s *AccountService) argon2Params() *argon2Params {
+	return &argon2Params{
+		memory:  s.options.PasswordArgon2Memory,
+		time:    s.options.PasswordArgon2Time,
+		threads: s.options.PasswordArgon2Threads,
+	}
+}
+
+// $argon2id$v=19$m=65536,t=3,p=2$salt$hash，salt和hash为不带填充的base64
+func encodeArgon2id(params *argon2Params, salt []byte, key []byte) string {
+	return fmt.Sprintf("$argon2id$v=%d$m=%d,t=%d,p=%d$%s$%s",
+		argon2.Version, params.memory, params.time, params.threads,
+		base64.RawStdEncoding.EncodeToString(salt), base64.RawStdEncoding.EncodeToString(key))
+}
+
+func decodeArgon2id(encoded string) (params *argon2Params, salt []byte, key []byte, err error) {
+	parts := strings.Split(encoded, "$")
+	if len(parts) != 6 || parts[1] != models.PasswordHashArgon2id {
+		return nil, nil, nil, fmt.Errorf("argon2id哈希格式错误")
+	}
+
+	version := 0
+	_, err = fmt.Sscanf(parts[2], "v=%d", &version)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if version != argon2.Version {
+		return nil, nil, nil, fmt.Errorf("不支持的argon2版本%d", version)
+	}
+
+	params = &argon2Params{}
+	_, err = fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &params.memory, &params.time, &params.threads)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	salt, err = base64.RawStdEncoding.DecodeString(parts[4])
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	key, err = base64.RawStdEncoding.DecodeString(parts[5])
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	return params, salt, key, nil
+}
+
+// 客户端提交的passwordHash1再加盐哈希后存储
+func (s *AccountService) calcPasswordHash(passwordHash1 string) (passwordHash2 string, err error) {
+	switch s.options.PasswordHashAlgorithm {
+	case models.PasswordHashArgon2id:
+		salt := make([]byte, argon2SaltLength)
+		_, err = rand.Read(salt)
+		if err != nil {
+			return "", err
+		}
+
+		params := s.argon2Params()
+		key := argon2.IDKey([]byte(passwordHash1), salt, params.time, params.memory, params.threads, argon2KeyLength)
+
+		return encodeArgon2id(params, salt, key), nil
+	case models.PasswordHashBcrypt:
+		hash, err := bcrypt.GenerateFromPassword([]byte(passwordHash1), s.options.PasswordBcryptCost)
+		if err != nil {
+			return "", err
+		}
+
+		return string(hash), nil
+	default:
+		return "", fmt.Errorf("不支持的密码哈希算法%s", s.options.PasswordHashAlgorithm)
+	}
+}
+
+// 校验密码，needRehash表示存储的哈希算法或参数与当前配置不一致
+func (s *AccountService) verifyPasswordHash(passwordHash1 string, passwordHash2 string) (
+	ok bool, needRehash bool, err error) {
+
+	if passwordHash2 == "" {
+		return false, false, nil
+	}
+
+	switch {
+	case strings.HasPrefix(passwordHash2, "$"+models.PasswordHashArgon2id+"$"):
+		params, salt, key, err := decodeArgon2id(passwordHash2)
+		if err != nil {
+			return false, false, err
+		}
+
+		actual := argon2.IDKey([]byte(passwordHash1), salt, params.time, params.memory, params.threads,
+			uint32(len(key)))
+		if subtle.ConstantTimeCompare(actual, key) != 1 {
+			return false, false, nil
+		}
+
+		needRehash = s.options.PasswordHashAlgorithm != models.PasswordHashArgon2id ||
+			*params != *s.argon2Params() || len(salt) != argon2SaltLength || len(key) != argon2KeyLength
+
+		return true, needRehash, nil
+	case strings.HasPrefix(passwordHash2, "$2a$") || strings.HasPrefix(passwordHash2, "$2b$") ||
+		strings.HasPrefix(passwordHash2, "$2y$"):
+		err = bcrypt.CompareHashAndPassword([]byte(passwordHash2), []byte(passwordHash1))
+		if err == bcrypt.ErrMismatchedHashAndPassword {
+			return false, false, nil
+		}
+		if err != nil {
+			return false, false, err
+		}
+
+		cost, err := bcrypt.Cost([]byte(passwordHash2))
+		if err != nil {
+			return false, false, err
+		}
+		needRehash = s.options.PasswordHashAlgorithm != models.PasswordHashBcrypt || cost != s.options.PasswordBcryptCost
+
+		return true, needRehash, nil
+	default:
+		//升级前未哈希存储的密码，校验通过后重新哈希
+		ok = subtle.ConstantTimeCompare([]byte(passwordHash1), []byte(passwordHash2)) == 1
+
+		return ok, ok, nil
+	}
+}
+
+// 校验用户密码，通过且哈希参数已升级时重新哈希，重新哈希失败不影响本次校验
+func (s *AccountService) checkUserPassword(ctx *rest.Context, dbUserInfo *neuron_account_db.UserInfo,
+	passwordHash1 string) (ok bool, err error) {
+
+	ok, needRehash, err := s.verifyPasswordHash(passwordHash1, dbUserInfo.PasswordHash)
+	if err != nil {
+		return false, err
+	}
+	if !ok || !needRehash {
+		return ok, nil
+	}
+
+	passwordHash2, err := s.calcPasswordHash(passwordHash1)
+	if err != nil {
+		s.logger.Error("checkUserPassword calcPasswordHash", zap.Error(err))
+		return true, nil
+	}
+
+	//只在密码未被并发修改时更新
+	_, err = s.accountDB.UserInfo.Query().
+		UserIdEqual(dbUserInfo.UserId).And().PasswordHashEqual(dbUserInfo.PasswordHash).
+		SetPasswordHash(passwordHash2).Update(ctx, nil)
+	if err != nil {
+		s.logger.Error("checkUserPassword rehash", zap.String("userId", dbUserInfo.UserId), zap.Error(err))
+		return true, nil
+	}
+
+	return true, nil
+}
@@ -0,0 +1,84 @@
+package services
+
+import (
+	"fmt"
+	"github.com/NeuronAccount/account/models"
+	"github.com/NeuronAccount/account/storages/neuron_account_db"
+	"github.com/NeuronFramework/rest"
+	"go.uber.org/zap"
+)
+
+// 新密码不能与当前密码及最近用过的密码相同，历史表中最新一条通常即当前密码
+func (s *AccountService) checkPasswordReuse(ctx *rest.Context, dbUserInfo *neuron_account_db.UserInfo,
+	newPasswordHash1 string) (err error) {
+
+	if s.options.PasswordHistorySize < 0 {
+		return nil
+	}
+
+	dbPasswordHistoryList, err := s.accountDB.PasswordHistory.Query().
+		UserIdEqual(dbUserInfo.UserId).OrderById(false).Limit(0, int64(s.options.PasswordHistorySize)).
+		SelectList(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	passwordHashList := make([]string, 0, len(dbPasswordHistoryList)+1)
+	if dbUserInfo.PasswordHash != "" {
+		passwordHashList = append(passwordHashList, dbUserInfo.PasswordHash)
+	}
+	for _, v := range dbPasswordHistoryList {
+		if len(passwordHashList) >= s.options.PasswordHistorySize {
+			break
+		}
+		if v.PasswordHash != dbUserInfo.PasswordHash {
+			passwordHashList = append(passwordHashList, v.PasswordHash)
+		}
+	}
+
+	for _, passwordHash2 := range passwordHashList {
+		ok, _, err := s.verifyPasswordHash(newPasswordHash1, passwordHash2)
+		if err != nil {
+			return err
+		}
+		if ok {
+			return rest.BadRequest(models.PasswordReused,
+				fmt.Sprintf("不能使用最近%d次用过的密码", s.options.PasswordHistorySize))
+		}
+	}
+
+	return nil
+}
+
+// 密码已更新，纪录失败不影响本次修改，只保留最近PasswordHistorySize条
+func (s *AccountService) addPasswordHistory(ctx *rest.Context, userId string, passwordHash2 string) {
+	if s.options.PasswordHistorySize < 0 {
+		return
+	}
+
+	_, err := s.accountDB.PasswordHistory.Query().Insert(ctx, nil, &neuron_account_db.PasswordHistory{
+		UserId:       userId,
+		PasswordHash: passwordHash2,
+	})
+	if err != nil {
+		s.logger.Error("addPasswordHistory Insert", zap.String("userId", userId), zap.Error(err))
+		return
+	}
+
+	dbOldest, err := s.accountDB.PasswordHistory.Query().
+		UserIdEqual(userId).OrderById(false).Limit(int64(s.options.PasswordHistorySize), 1).
+		Select(ctx, nil)
+	if err != nil {
+		s.logger.Error("addPasswordHistory Select", zap.String("userId", userId), zap.Error(err))
+		return
+	}
+	if dbOldest == nil {
+		return
+	}
+
+	_, err = s.accountDB.PasswordHistory.Query().
+		UserIdEqual(userId).And().IdLessEqual(dbOldest.Id).Delete(ctx, nil)
+	if err != nil {
+		s.logger.Error("addPasswordHistory Delete", zap.String("userId", userId), zap.Error(err))
+	}
+}
@@ -0,0 +1,44 @@
+package services
+
+import (
+	"github.com/NeuronAccount/account/models"
+	"github.com/NeuronAccount/account/storages/neuron_account_db"
+	"testing"
+)
+
+func TestPasswordHistorySizeDefaults(t *testing.T) {
+	tests := []struct {
+		size int
+		want int
+	}{
+		{0, 5},
+		{3, 3},
+		{-1, -1},
+	}
+
+	for _, tt := range tests {
+		o := &AccountServiceOptions{PasswordHistorySize: tt.size}
+		o.setDefaults()
+		if o.PasswordHistorySize != tt.want {
+			t.Errorf("setDefaults() PasswordHistorySize %d = %d, want %d", tt.size, o.PasswordHistorySize, tt.want)
+		}
+	}
+}
+
+// 关闭时不查询历史表，可以重复使用当前密码
+func TestPasswordHistoryDisabled(t *testing.T) {
+	s := newPasswordTestService(models.PasswordHashArgon2id)
+	s.options.PasswordHistorySize = -1
+
+	passwordHash2, err := s.calcPasswordHash("password-hash1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	dbUserInfo := &neuron_account_db.UserInfo{UserId: "u1", PasswordHash: passwordHash2}
+
+	err = s.checkPasswordReuse(nil, dbUserInfo, "password-hash1")
+	if err != nil {
+		t.Errorf("checkPasswordReuse() = %v, want nil", err)
+	}
+	s.addPasswordHistory(nil, dbUserInfo.UserId, passwordHash2)
+}
@@ -0,0 +1,194 @@
+package services
+
+import (
+	"bufio"
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"github.com/NeuronAccount/account/models"
+	"github.com/NeuronFramework/rest"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+	"unicode"
+)
+
+const breachedPrefixLength = 5
+
+func passwordCharClasses(password string) (n int) {
+	var upper, lower, digit, symbol bool
+	for _, c := range password {
+		switch {
+		case unicode.IsUpper(c):
+			upper = true
+		case unicode.IsLower(c):
+			lower = true
+		case unicode.IsDigit(c):
+			digit = true
+		default:
+			symbol = true
+		}
+	}
+
+	for _, v := range []bool{upper, lower, digit, symbol} {
+		if v {
+			n++
+		}
+	}
+
+	return n
+}
+
+// k-anonymity格式的泄露密码库，文件名为SHA-1的前5位，每行为后35位及出现次数，如
+// 0018A45C4D1DEF81644B54AB7F969B88D65:10
+func (s *AccountService) isPasswordBreached(password string) (breached bool, err error) {
+	if s.options.PasswordBreachedCorpusDir == "" {
+		return false, nil
+	}
+
+	sum := sha1.Sum([]byte(password))
+	hash := strings.ToUpper(hex.EncodeToString(sum[:]))
+	prefix, suffix := hash[:breachedPrefixLength], hash[breachedPrefixLength:]
+
+	f, err := os.Open(filepath.Join(s.options.PasswordBreachedCorpusDir, prefix))
+	if os.IsNotExist(err) {
+		f, err = os.Open(filepath.Join(s.options.PasswordBreachedCorpusDir, prefix+".txt"))
+	}
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if i := strings.IndexByte(line, ':'); i >= 0 {
+			line = line[:i]
+		}
+		if strings.EqualFold(line, suffix) {
+			return true, nil
+		}
+	}
+
+	return false, scanner.Err()
+}
+
+// 按策略检查密码，返回全部不符合的项；phone和userName为空时不检查
+func (s *AccountService) checkPasswordPolicy(password string, phone string, userName string) (
+	violations []*models.PasswordViolation, err error) {
+
+	length := len([]rune(password))
+	if length < s.options.PasswordMinLength {
+		violations = append(violations, &models.PasswordViolation{
+			Code:    models.PasswordTooShort,
+			Message: fmt.Sprintf("密码不能少于%d位", s.options.PasswordMinLength),
+		})
+	}
+	if length > s.options.PasswordMaxLength {
+		violations = append(violations, &models.PasswordViolation{
+			Code:    models.PasswordTooLong,
+			Message: fmt.Sprintf("密码不能多于%d位", s.options.PasswordMaxLength),
+		})
+	}
+	if passwordCharClasses(password) < s.options.PasswordMinCharClasses {
+		violations = append(violations, &models.PasswordViolation{
+			Code:    models.PasswordTooFewCharClasses,
+			Message: fmt.Sprintf("密码须包含大写字母、小写字母、数字、符号中的至少%d种", s.options.PasswordMinCharClasses),
+		})
+	}
+
+	lowerPassword := strings.ToLower(password)
+	if phone != "" && strings.Contains(lowerPassword, phone) {
+		violations = append(violations, &models.PasswordViolation{
+			Code:    models.PasswordContainsPhone,
+			Message: "密码不能包含手机号",
+		})
+	}
+	if userName != "" && strings.Contains(lowerPassword, strings.ToLower(userName)) {
+		violations = append(violations, &models.PasswordViolation{
+			Code:    models.PasswordContainsUserName,
+			Message: "密码不能包含用户名",
+		})
+	}
+
+	breached, err := s.isPasswordBreached(password)
+	if err != nil {
+		return nil, err
+	}
+	if breached {
+		violations = append(violations, &models.PasswordViolation{
+			Code:    models.PasswordBreached,
+			Message: "该密码已在泄露的密码库中出现，请更换",
+		})
+	}
+
+	return violations, nil
+}
+
+// 修改密码前的检查，返回第一个不符合的项，错误码即违规代码
+func (s *AccountService) validateNewPassword(password string, phone string, userName string) (err error) {
+	if password == "" {
+		return rest.InvalidParam("密码不能为空")
+	}
+
+	violations, err := s.checkPasswordPolicy(password, phone, userName)
+	if err != nil {
+		return err
+	}
+	if len(violations) > 0 {
+		return rest.BadRequest(violations[0].Code, violations[0].Message)
+	}
+
+	return nil
+}
+
+// 已登录用户从帐号获取手机号和用户名
+func (s *AccountService) userPasswordContext(ctx *rest.Context, userId string) (phone string, userName string, err error) {
+	dbUserInfo, err := s.accountDB.UserInfo.Query().UserIdEqual(userId).Select(ctx, nil)
+	if err != nil {
+		return "", "", err
+	}
+	if dbUserInfo != nil {
+		userName = dbUserInfo.UserName
+	}
+
+	dbPhoneAccount, err := s.accountDB.PhoneAccount.Query().UserIdEqual(userId).Select(ctx, nil)
+	if err != nil {
+		return "", "", err
+	}
+	if dbPhoneAccount != nil {
+		phone, err = s.decryptPhone(dbPhoneAccount.PhoneEncrypted)
+		if err != nil {
+			return "", "", err
+		}
+	}
+
+	return phone, userName, nil
+}
+
+// 供UI在提交前检查密码，须登录，按用户限制次数，避免用于批量查询泄露密码库
+func (s *AccountService) CheckPassword(ctx *rest.Context, userId string, password string) (
+	violations []*models.PasswordViolation, err error) {
+
+	if s.options.PasswordCheckHourlyMax >= 0 {
+		count, expireTime, err := s.options.CounterStore.Incr(ctx, "password_check:"+userId, time.Hour)
+		if err != nil {
+			return nil, err
+		}
+		if count > s.options.PasswordCheckHourlyMax {
+			return nil, rest.BadRequest("PasswordCheckTooFrequent",
+				fmt.Sprintf("检查过于频繁，请%d分钟后再试", (secondsUntil(expireTime)+59)/60))
+		}
+	}
+
+	phone, userName, err := s.userPasswordContext(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.checkPasswordPolicy(password, phone, userName)
+}
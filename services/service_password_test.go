@@ -0,0 +1,110 @@
+package services
+
+import (
+	"bytes"
+	"github.com/NeuronAccount/account/models"
+	"golang.org/x/crypto/bcrypt"
+	"testing"
+)
+
+// 测试使用较小的参数，避免哈希耗时
+func newPasswordTestService(algorithm string) *AccountService {
+	return &AccountService{options: &AccountServiceOptions{
+		PasswordHashAlgorithm: algorithm,
+		PasswordArgon2Memory:  1024,
+		PasswordArgon2Time:    1,
+		PasswordArgon2Threads: 1,
+		PasswordBcryptCost:    bcrypt.MinCost,
+	}}
+}
+
+func TestArgon2idEncodeDecode(t *testing.T) {
+	params := &argon2Params{memory: 65536, time: 3, threads: 2}
+	salt := []byte("0123456789abcdef")
+	key := bytes.Repeat([]byte{0xab}, argon2KeyLength)
+
+	encoded := encodeArgon2id(params, salt, key)
+	const want = "$argon2id$v=19$m=65536,t=3,p=2$MDEyMzQ1Njc4OWFiY2RlZg$q6urq6urq6urq6urq6urq6urq6urq6urq6urq6urq6s"
+	if encoded != want {
+		t.Fatalf("encodeArgon2id() = %s, want %s", encoded, want)
+	}
+
+	decodedParams, decodedSalt, decodedKey, err := decodeArgon2id(encoded)
+	if err != nil {
+		t.Fatalf("decodeArgon2id() error = %v", err)
+	}
+	if *decodedParams != *params || !bytes.Equal(decodedSalt, salt) || !bytes.Equal(decodedKey, key) {
+		t.Errorf("decodeArgon2id() = %+v %x %x", decodedParams, decodedSalt, decodedKey)
+	}
+
+	invalid := []string{
+		"",
+		"$argon2i$v=19$m=65536,t=3,p=2$MDEyMzQ1Njc4OWFiY2RlZg$q6ur",
+		"$argon2id$v=18$m=65536,t=3,p=2$MDEyMzQ1Njc4OWFiY2RlZg$q6ur",
+		"$argon2id$v=19$m=x,t=3,p=2$MDEyMzQ1Njc4OWFiY2RlZg$q6ur",
+		"$argon2id$v=19$m=65536,t=3,p=2$!!!$q6ur",
+		"$argon2id$v=19$m=65536,t=3,p=2$MDEyMzQ1Njc4OWFiY2RlZg",
+	}
+	for _, v := range invalid {
+		_, _, _, err = decodeArgon2id(v)
+		if err == nil {
+			t.Errorf("decodeArgon2id(%q) error = nil", v)
+		}
+	}
+}
+
+func TestVerifyPasswordHash(t *testing.T) {
+	const password = "hash1-of-password"
+
+	argon2Service := newPasswordTestService(models.PasswordHashArgon2id)
+	current, err := argon2Service.calcPasswordHash(password)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	//参数调整前生成的哈希
+	oldParamsService := newPasswordTestService(models.PasswordHashArgon2id)
+	oldParamsService.options.PasswordArgon2Memory = 2048
+	oldParams, err := oldParamsService.calcPasswordHash(password)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	bcryptService := newPasswordTestService(models.PasswordHashBcrypt)
+	bcryptHash, err := bcryptService.calcPasswordHash(password)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name           string
+		service        *AccountService
+		password       string
+		passwordHash2  string
+		wantOk         bool
+		wantNeedRehash bool
+	}{
+		{"argon2id current params", argon2Service, password, current, true, false},
+		{"argon2id wrong password", argon2Service, "wrong", current, false, false},
+		{"argon2id old params", argon2Service, password, oldParams, true, true},
+		{"bcrypt upgraded to argon2id", argon2Service, password, bcryptHash, true, true},
+		{"bcrypt wrong password", argon2Service, "wrong", bcryptHash, false, false},
+		{"bcrypt current cost", bcryptService, password, bcryptHash, true, false},
+		{"argon2id downgraded to bcrypt", bcryptService, password, current, true, true},
+		{"legacy plaintext", argon2Service, password, password, true, true},
+		{"legacy plaintext wrong password", argon2Service, "wrong", password, false, false},
+		{"password not set", argon2Service, password, "", false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ok, needRehash, err := tt.service.verifyPasswordHash(tt.password, tt.passwordHash2)
+			if err != nil {
+				t.Fatalf("verifyPasswordHash() error = %v", err)
+			}
+			if ok != tt.wantOk || needRehash != tt.wantNeedRehash {
+				t.Errorf("verifyPasswordHash() = %v, %v, want %v, %v", ok, needRehash, tt.wantOk, tt.wantNeedRehash)
+			}
+		})
+	}
+}
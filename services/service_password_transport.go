@@ -0,0 +1,151 @@
+package services
+
+import (
+	"context"
+	cryptoRand "crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"github.com/NeuronAccount/account/models"
+	"github.com/NeuronFramework/rand"
+	"github.com/NeuronFramework/rest"
+	"time"
+)
+
+type passwordTransportKey struct {
+	kid        string
+	privateKey *rsa.PrivateKey
+}
+
+type passwordTransportPlaintext struct {
+	Nonce    string `json:"nonce"`
+	Password string `json:"password"`
+}
+
+// 多实例部署时须配置相同的私钥，否则在其它实例获取的nonce无法解密
+func (s *AccountService) loadPasswordTransportKey() (key *passwordTransportKey, err error) {
+	var privateKey *rsa.PrivateKey
+	if s.options.PasswordTransportKeyPem == "" {
+		//多实例时各实例的临时密钥不同，只有开发环境允许
+		if !s.options.Dev {
+			return nil, fmt.Errorf("未配置PasswordTransportKeyPem")
+		}
+		s.logger.Warn("loadPasswordTransportKey 开发环境未配置私钥，使用本实例生成的临时密钥")
+		privateKey, err = rsa.GenerateKey(cryptoRand.Reader, 2048)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		block, _ := pem.Decode([]byte(s.options.PasswordTransportKeyPem))
+		if block == nil {
+			return nil, fmt.Errorf("密码传输私钥格式错误")
+		}
+		parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		rsaKey, ok := parsed.(*rsa.PrivateKey)
+		if !ok {
+			return nil, fmt.Errorf("密码传输私钥须为RSA密钥")
+		}
+		privateKey = rsaKey
+	}
+
+	publicKeyDer, err := x509.MarshalPKIXPublicKey(&privateKey.PublicKey)
+	if err != nil {
+		return nil, err
+	}
+	sum := sha256.Sum256(publicKeyDer)
+
+	return &passwordTransportKey{
+		kid:        hex.EncodeToString(sum[:8]),
+		privateKey: privateKey,
+	}, nil
+}
+
+func passwordChallengeKey(nonce string) string {
+	return "password_challenge:" + nonce
+}
+
+func (s *AccountService) GetPasswordChallenge(ctx *rest.Context) (challenge *models.PasswordChallenge, err error) {
+	nonce := rand.NextHex(16)
+	err = s.options.CounterStore.Set(ctx, passwordChallengeKey(nonce), 1,
+		time.Second*models.PasswordChallengeExpireSeconds)
+	if err != nil {
+		return nil, err
+	}
+
+	key := s.toJsonWebKey(&jwtKey{
+		kid:       s.passwordTransportKey.kid,
+		algorithm: models.PasswordTransportAlgorithm,
+		publicKey: &s.passwordTransportKey.privateKey.PublicKey,
+	})
+	key.Use = "enc"
+
+	return &models.PasswordChallenge{
+		Nonce:     nonce,
+		ExpiresIn: models.PasswordChallengeExpireSeconds,
+		Key:       key,
+	}, nil
+}
+
+// nonce只能使用一次，计数从签发时的1加到2才有效，重放的nonce计数大于2
+func (s *AccountService) consumePasswordChallenge(ctx context.Context, nonce string) (err error) {
+	count, _, err := s.options.CounterStore.Incr(ctx, passwordChallengeKey(nonce),
+		time.Second*models.PasswordChallengeExpireSeconds)
+	if err != nil {
+		return err
+	}
+	if count == 1 {
+		//未签发的nonce，删除计数避免再次提交时被当作有效
+		err = s.options.CounterStore.Delete(ctx, passwordChallengeKey(nonce))
+		if err != nil {
+			return err
+		}
+	}
+	if count != 2 {
+		return rest.BadRequest("InvalidPasswordChallenge", "密码加密参数已失效，请重试")
+	}
+
+	return nil
+}
+
+// 优先使用加密传输的密码；未要求加密传输时兼容直接提交的passwordHash1
+func (s *AccountService) DecryptPassword(ctx *rest.Context, password string, passwordCipher string) (
+	plaintext string, err error) {
+
+	if passwordCipher == "" {
+		if s.options.PasswordTransportRequired {
+			return "", rest.BadRequest("PasswordCipherRequired", "密码须加密传输")
+		}
+
+		return password, nil
+	}
+
+	ciphertext, err := base64.RawURLEncoding.DecodeString(passwordCipher)
+	if err != nil {
+		return "", rest.InvalidParam("密码密文格式错误")
+	}
+	decrypted, err := rsa.DecryptOAEP(sha256.New(), nil, s.passwordTransportKey.privateKey, ciphertext, nil)
+	if err != nil {
+		return "", rest.InvalidParam("密码密文解密失败")
+	}
+
+	p := &passwordTransportPlaintext{}
+	err = json.Unmarshal(decrypted, p)
+	if err != nil || p.Nonce == "" {
+		return "", rest.InvalidParam("密码密文格式错误")
+	}
+
+	err = s.consumePasswordChallenge(ctx, p.Nonce)
+	if err != nil {
+		return "", err
+	}
+
+	return p.Password, nil
+}
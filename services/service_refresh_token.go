@@ -2,17 +2,137 @@ package services
 
 import (
 	"github.com/NeuronAccount/account/models"
+	"github.com/NeuronAccount/account/storages/neuron_account_db"
 	"github.com/NeuronFramework/rest"
+	"go.uber.org/zap"
+	"time"
 )
 
-func (s *AccountService) RefreshToken(ctx *rest.Context, refreshToken string) (userToken *models.UserToken, err error) {
+func (s *AccountService) revokeRefreshTokenFamily(ctx *rest.Context, userId string, sessionId string) (err error) {
+	//token族即登录会话，整个会话失效
+	_, err = s.revokeSession(ctx, userId, sessionId)
+	if err != nil {
+		return err
+	}
+
+	s.logger.Warn("revokeRefreshTokenFamily",
+		zap.String("userId", userId),
+		zap.String("sessionId", sessionId))
+
+	//操作纪录
+	s.addOperation(ctx, &models.AccountOperation{
+		OperationType: models.OperationRefreshTokenReuse,
+		UserId:        userId,
+	})
+
+	return nil
+}
+
+// clientId为空表示第一方登录的token，token只能由签发时的客户端使用；
+// reused表示token已轮换过又被使用，调用方需撤销整个token族
+func checkRefreshToken(dbRefreshToken *neuron_account_db.RefreshToken, clientId string, now time.Time) (
+	reused bool, err error) {
+
+	if dbRefreshToken == nil || dbRefreshToken.ClientId != clientId {
+		return false, rest.NotFound("Token已失效，请重新登录")
+	}
+	if dbRefreshToken.IsRotated != 0 {
+		return true, rest.BadRequest("RefreshTokenReused", "Token已失效，请重新登录")
+	}
+	if now.After(dbRefreshToken.ExpireTime) || now.After(dbRefreshToken.SessionExpireTime) {
+		return false, rest.NotFound("Token已过期，请重新登录")
+	}
+
+	return false, nil
+}
+
+func (s *AccountService) rotateRefreshToken(ctx *rest.Context, refreshToken string, device *models.DeviceInfo,
+	clientId string) (userToken *models.UserToken, refreshTokenInfo *neuron_account_db.RefreshToken, err error) {
+
 	dbRefreshToken, err := s.accountDB.RefreshToken.Query().RefreshTokenEqual(refreshToken).Select(ctx, nil)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
+	}
+	//已轮换过的token再次使用，说明token可能被盗，整个token族失效
+	reused, err := checkRefreshToken(dbRefreshToken, clientId, time.Now())
+	if reused {
+		revokeErr := s.revokeRefreshTokenFamily(ctx, dbRefreshToken.UserId, dbRefreshToken.SessionId)
+		if revokeErr != nil {
+			return nil, nil, revokeErr
+		}
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	//标记为已轮换，影响行数为0说明被并发使用
+	result, err := s.accountDB.RefreshToken.Query().
+		IdEqual(dbRefreshToken.Id).And().IsRotatedEqual(0).
+		SetIsRotated(1).Update(ctx, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	affectedRows, err := result.RowsAffected()
+	if err != nil {
+		return nil, nil, err
 	}
-	if dbRefreshToken == nil {
-		return nil, rest.NotFound("Token已失效，请重新登录")
+	if affectedRows != 1 {
+		err = s.revokeRefreshTokenFamily(ctx, dbRefreshToken.UserId, dbRefreshToken.SessionId)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		return nil, nil, rest.BadRequest("RefreshTokenReused", "Token已失效，请重新登录")
+	}
+
+	var grant *clientGrant
+	if dbRefreshToken.ClientId != "" {
+		grant, err = s.clientGrantOf(ctx, dbRefreshToken.ClientId, dbRefreshToken.Scope)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	//AccessToken沿用会话的认证时间，刷新不算重新认证
+	dbUserSession, err := s.accountDB.UserSession.Query().SessionIdEqual(dbRefreshToken.SessionId).Select(ctx, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	if dbUserSession == nil {
+		return nil, nil, rest.NotFound("Token已失效，请重新登录")
+	}
+
+	//创建AccessToken
+	accessToken, err := s.createAccessToken(ctx, dbUserSession, grant)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	//同一token族内轮换RefreshToken
+	newRefreshToken, err := s.insertRefreshToken(ctx,
+		dbRefreshToken.UserId, dbRefreshToken.SessionId, dbRefreshToken.SessionExpireTime, grant)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	err = s.touchSession(ctx, dbRefreshToken.SessionId, device)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return &models.UserToken{
+		AccessToken:  accessToken,
+		RefreshToken: newRefreshToken,
+	}, dbRefreshToken, nil
+}
+
+func (s *AccountService) RefreshToken(ctx *rest.Context, refreshToken string, device *models.DeviceInfo) (
+	userToken *models.UserToken, err error) {
+
+	userToken, _, err = s.rotateRefreshToken(ctx, refreshToken, device, "")
+	if err != nil {
+		return nil, err
 	}
 
-	return s.createUserToken(ctx, dbRefreshToken.UserId)
+	return userToken, nil
 }
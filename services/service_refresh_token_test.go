@@ -0,0 +1,68 @@
+package services
+
+import (
+	"github.com/NeuronAccount/account/storages/neuron_account_db"
+	"testing"
+	"time"
+)
+
+func TestCheckRefreshToken(t *testing.T) {
+	now := time.Now()
+	valid := func() *neuron_account_db.RefreshToken {
+		return &neuron_account_db.RefreshToken{
+			ClientId:          "client1",
+			ExpireTime:        now.Add(time.Hour),
+			SessionExpireTime: now.Add(time.Hour * 24),
+		}
+	}
+
+	tests := []struct {
+		name         string
+		refreshToken func() *neuron_account_db.RefreshToken
+		clientId     string
+		wantReused   bool
+		wantErr      bool
+	}{
+		{"valid", valid, "client1", false, false},
+		{"not found", func() *neuron_account_db.RefreshToken { return nil }, "client1", false, true},
+		{"other client", valid, "client2", false, true},
+		{"first party token used by client", func() *neuron_account_db.RefreshToken {
+			rt := valid()
+			rt.ClientId = ""
+			return rt
+		}, "client1", false, true},
+		{"rotated token reused", func() *neuron_account_db.RefreshToken {
+			rt := valid()
+			rt.IsRotated = 1
+			return rt
+		}, "client1", true, true},
+		{"rotated and expired token reused", func() *neuron_account_db.RefreshToken {
+			rt := valid()
+			rt.IsRotated = 1
+			rt.ExpireTime = now.Add(-time.Minute)
+			return rt
+		}, "client1", true, true},
+		{"expired", func() *neuron_account_db.RefreshToken {
+			rt := valid()
+			rt.ExpireTime = now.Add(-time.Minute)
+			return rt
+		}, "client1", false, true},
+		{"session expired", func() *neuron_account_db.RefreshToken {
+			rt := valid()
+			rt.SessionExpireTime = now.Add(-time.Minute)
+			return rt
+		}, "client1", false, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reused, err := checkRefreshToken(tt.refreshToken(), tt.clientId, now)
+			if reused != tt.wantReused {
+				t.Errorf("reused = %v, want %v", reused, tt.wantReused)
+			}
+			if (err != nil) != tt.wantErr {
+				t.Errorf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -11,10 +11,21 @@ func (s *AccountService) RemoveAccount(ctx *rest.Context, userId string) (err er
 		return rest.InvalidParam("userId不能为空")
 	}
 
+	//AccessToken只标记撤销不删除，撤销记录需保留到token过期，供各实例同步，之后由清理任务删除
+	err = s.revokeAccessTokens(ctx, userId, "")
+	if err != nil {
+		return err
+	}
+
+	err = s.removeOwnedOauthClients(ctx, userId)
+	if err != nil {
+		return err
+	}
+
 	return s.accountDB.TransactionReadCommitted(ctx, false, func(tx *wrap.Tx) (err error) {
 		dbPhoneAccount, err := s.accountDB.PhoneAccount.Query().UserIdEqual(userId).Select(ctx, nil)
 		if err != nil {
-			return nil
+			return err
 		}
 		if dbPhoneAccount != nil && dbPhoneAccount.PhoneEncrypted != "" {
 			_, err = s.accountDB.SmsCode.Query().PhoneEncryptedEqual(dbPhoneAccount.PhoneEncrypted).Delete(ctx, tx)
@@ -28,12 +39,22 @@ func (s *AccountService) RemoveAccount(ctx *rest.Context, userId string) (err er
 			}
 		}
 
-		_, err = s.accountDB.AccessToken.Query().UserIdEqual(userId).Delete(ctx, tx)
+		_, err = s.accountDB.RefreshToken.Query().UserIdEqual(userId).Delete(ctx, tx)
+		if err != nil {
+			return err
+		}
+
+		_, err = s.accountDB.UserSession.Query().UserIdEqual(userId).Delete(ctx, tx)
 		if err != nil {
 			return err
 		}
 
-		_, err = s.accountDB.RefreshToken.Query().UserIdEqual(userId).Delete(ctx, tx)
+		_, err = s.accountDB.OauthAuthorizationCode.Query().UserIdEqual(userId).Delete(ctx, tx)
+		if err != nil {
+			return err
+		}
+
+		_, err = s.accountDB.OauthConsent.Query().UserIdEqual(userId).Delete(ctx, tx)
 		if err != nil {
 			return err
 		}
@@ -43,6 +64,11 @@ func (s *AccountService) RemoveAccount(ctx *rest.Context, userId string) (err er
 			return err
 		}
 
+		_, err = s.accountDB.PasswordHistory.Query().UserIdEqual(userId).Delete(ctx, tx)
+		if err != nil {
+			return err
+		}
+
 		_, err = s.accountDB.UserInfo.Query().UserIdEqual(userId).Delete(ctx, tx)
 		if err != nil {
 			return err
@@ -63,12 +89,57 @@ func (s *AccountService) RemoveAccount(ctx *rest.Context, userId string) (err er
 			return err
 		}
 
-		//操作纪录
+		//操作纪录，未绑定手机号时dbPhoneAccount为nil
 		s.addOperation(ctx, &models.AccountOperation{
 			OperationType: models.OperationRemoveAccount,
-			UserId:        dbPhoneAccount.UserId,
+			UserId:        userId,
 		})
 
 		return nil
 	})
 }
+
+// 删除用户注册的第三方客户端，结束通过这些客户端授权的会话；
+// 客户端凭据换取的token不关联会话，在过期前仍有效
+func (s *AccountService) removeOwnedOauthClients(ctx *rest.Context, userId string) (err error) {
+	dbOauthClientList, err := s.accountDB.OauthClient.Query().OwnerUserIdEqual(userId).SelectList(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	for _, dbOauthClient := range dbOauthClientList {
+		dbRefreshTokenList, err := s.accountDB.RefreshToken.Query().
+			ClientIdEqual(dbOauthClient.ClientId).SelectList(ctx, nil)
+		if err != nil {
+			return err
+		}
+		revoked := make(map[string]bool)
+		for _, v := range dbRefreshTokenList {
+			if revoked[v.SessionId] {
+				continue
+			}
+			_, err = s.revokeSession(ctx, v.UserId, v.SessionId)
+			if err != nil {
+				return err
+			}
+			revoked[v.SessionId] = true
+		}
+
+		_, err = s.accountDB.OauthAuthorizationCode.Query().ClientIdEqual(dbOauthClient.ClientId).Delete(ctx, nil)
+		if err != nil {
+			return err
+		}
+
+		_, err = s.accountDB.OauthConsent.Query().ClientIdEqual(dbOauthClient.ClientId).Delete(ctx, nil)
+		if err != nil {
+			return err
+		}
+
+		_, err = s.accountDB.OauthClient.Query().IdEqual(dbOauthClient.Id).Delete(ctx, nil)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
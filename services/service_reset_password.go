@@ -15,14 +15,8 @@ func (s *AccountService) ResetPassword(ctx *rest.Context, phone string, smsCode
 		return err
 	}
 
-	//hash密码
-	passwordHash2, err := s.calcPasswordHash(newPasswordHash1)
-	if err != nil {
-		return err
-	}
-
-	//校验验证码
-	err = s.validateSmsCode(ctx, models.SmsSceneResetPassword, phoneEncrypted, smsCode, "")
+	//校验验证码，密码检查通过后才标记为已使用，以便密码不符合要求时重新输入
+	dbSmsCode, err := s.checkSmsCode(ctx, models.SmsSceneResetPassword, phoneEncrypted, smsCode, "")
 	if err != nil {
 		return err
 	}
@@ -36,6 +30,40 @@ func (s *AccountService) ResetPassword(ctx *rest.Context, phone string, smsCode
 		return errors.NotFound("该手机号尚未注册")
 	}
 
+	//检查密码策略
+	_, userName, err := s.userPasswordContext(ctx, dbPhoneAccount.UserId)
+	if err != nil {
+		return err
+	}
+	err = s.validateNewPassword(newPasswordHash1, phone, userName)
+	if err != nil {
+		return err
+	}
+
+	//不能重复使用最近的密码
+	dbUserInfo, err := s.accountDB.UserInfo.Query().UserIdEqual(dbPhoneAccount.UserId).Select(ctx, nil)
+	if err != nil {
+		return err
+	}
+	if dbUserInfo == nil {
+		return rest.NotFound("帐号不存在")
+	}
+	err = s.checkPasswordReuse(ctx, dbUserInfo, newPasswordHash1)
+	if err != nil {
+		return err
+	}
+
+	err = s.consumeSmsCode(ctx, dbSmsCode)
+	if err != nil {
+		return err
+	}
+
+	//hash密码
+	passwordHash2, err := s.calcPasswordHash(newPasswordHash1)
+	if err != nil {
+		return err
+	}
+
 	//更新密码
 	result, err := s.accountDB.UserInfo.Query().UserIdEqual(dbPhoneAccount.UserId).
 		SetPasswordHash(passwordHash2).Update(ctx, nil)
@@ -49,6 +77,19 @@ func (s *AccountService) ResetPassword(ctx *rest.Context, phone string, smsCode
 	if affectedRows != 1 {
 		return rest.Unknown(fmt.Sprintf("更新失败，影响行数%d", affectedRows))
 	}
+	s.addPasswordHistory(ctx, dbPhoneAccount.UserId, passwordHash2)
+
+	//密码已重置，所有已登录的会话失效
+	_, err = s.revokeAllSessions(ctx, dbPhoneAccount.UserId)
+	if err != nil {
+		return err
+	}
+
+	//已通过短信验证手机号，解除密码登录的锁定
+	err = s.unlockLogin(ctx, dbPhoneAccount.UserId, phoneEncrypted)
+	if err != nil {
+		return err
+	}
 
 	//操作纪录
 	s.addOperation(ctx, &models.AccountOperation{
@@ -1,34 +1,41 @@
 package services
 
 import (
+	"context"
 	"github.com/NeuronAccount/account/models"
 	"github.com/NeuronAccount/account/storages/neuron_account_db"
 	"github.com/NeuronFramework/rand"
 	"github.com/NeuronFramework/rest"
-	"os"
 	"time"
 )
 
+// 短信发送，由部署方选择实现，如阿里云或测试用的sms.FakeSender
+type SmsSender interface {
+	SendSms(ctx context.Context, phone string, smsCode string, smsCodeId string) (requestId string, err error)
+}
+
 func (s *AccountService) SendSmsCode(ctx *rest.Context, p *models.SendSmsCodeParams) (err error) {
 	phoneEncrypted, err := s.encryptPhone(p.Phone)
 	if err != nil {
 		return err
 	}
 
+	err = s.checkSmsRateLimits(ctx, s.smsRateLimits(p, phoneEncrypted))
+	if err != nil {
+		return err
+	}
+
 	smsCode := rand.NextNumberFixedLength(models.SmsCodeLength)
-	if os.Getenv("ENV") == "DEV" {
-		smsCode = "1234"
-	} else {
-		_, err = s.smsService.SendSms(p.Phone, smsCode, "")
-		if err != nil {
-			return err
-		}
+	_, err = s.options.SmsSender.SendSms(ctx, p.Phone, smsCode, "")
+	if err != nil {
+		return err
 	}
 
 	dbSmsCode := &neuron_account_db.SmsCode{}
 	dbSmsCode.SmsScene = string(p.Scene)
 	dbSmsCode.PhoneEncrypted = phoneEncrypted
-	dbSmsCode.SmsCode = smsCode
+	dbSmsCode.SmsCode = s.hashSmsCode(dbSmsCode.SmsScene, phoneEncrypted, smsCode)
+	dbSmsCode.IsHashed = 1
 	dbSmsCode.UserId = p.UserId
 	_, err = s.accountDB.SmsCode.Query().Insert(ctx, nil, dbSmsCode)
 	if err != nil {
@@ -44,30 +51,108 @@ func (s *AccountService) SendSmsCode(ctx *rest.Context, p *models.SendSmsCodePar
 	return nil
 }
 
-func (s *AccountService) validateSmsCode(
+// 占用一次校验机会前检查验证码是否仍可使用
+func checkSmsCodeUsable(dbSmsCode *neuron_account_db.SmsCode, now time.Time) (err error) {
+	if dbSmsCode == nil {
+		return rest.BadRequest("InvalidSmsCode", "验证码错误")
+	}
+	if dbSmsCode.IsUsed != 0 {
+		return rest.BadRequest("InvalidSmsCode", "验证码已使用，请重新获取")
+	}
+	if dbSmsCode.Attempts >= models.SmsCodeMaxAttempts {
+		return rest.BadRequest("InvalidSmsCode", "验证码错误次数过多，请重新获取")
+	}
+	if now.Sub(dbSmsCode.CreateTime).Seconds() > models.SmsCodeValidSeconds {
+		return rest.BadRequest("InvalidSmsCode", "验证码已过期")
+	}
+
+	return nil
+}
+
+// 校验最新的验证码，不标记为已使用；每次校验先占用一次尝试次数，达到次数后该验证码失效
+func (s *AccountService) checkSmsCode(
 	ctx *rest.Context,
 	scene string,
 	phoneEncrypted string,
 	smsCode string,
 	userId string) (
-	err error) {
+	dbSmsCode *neuron_account_db.SmsCode, err error) {
 
-	dbSmsCode, err := s.accountDB.SmsCode.Query().
+	dbSmsCode, err = s.accountDB.SmsCode.Query().
 		SmsSceneEqual(scene).
 		And().PhoneEncryptedEqual(phoneEncrypted).
 		And().UserIdEqual(userId).
 		OrderById(false).Select(ctx, nil)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	if dbSmsCode == nil || dbSmsCode.SmsCode != smsCode {
-		return rest.BadRequest("InvalidSmsCode", "验证码错误")
+	//计数未被并发修改时才占用成功，否则重新读取，每次失败都说明其它请求已占用一次，最多重试SmsCodeMaxAttempts次
+	for {
+		err = checkSmsCodeUsable(dbSmsCode, time.Now())
+		if err != nil {
+			return nil, err
+		}
+
+		result, err := s.accountDB.SmsCode.Query().
+			IdEqual(dbSmsCode.Id).And().AttemptsEqual(dbSmsCode.Attempts).
+			SetAttempts(dbSmsCode.Attempts+1).Update(ctx, nil)
+		if err != nil {
+			return nil, err
+		}
+		affectedRows, err := result.RowsAffected()
+		if err != nil {
+			return nil, err
+		}
+		if affectedRows == 1 {
+			break
+		}
+
+		dbSmsCode, err = s.accountDB.SmsCode.Query().IdEqual(dbSmsCode.Id).Select(ctx, nil)
+		if err != nil {
+			return nil, err
+		}
 	}
 
-	if time.Now().Sub(dbSmsCode.CreateTime).Seconds() > models.SmsCodeValidSeconds {
-		return rest.BadRequest("InvalidSmsCode", "验证码已过期")
+	if !s.verifySmsCode(dbSmsCode, smsCode) {
+		return nil, rest.BadRequest("InvalidSmsCode", "验证码错误")
+	}
+
+	return dbSmsCode, nil
+}
+
+// 标记为已使用，影响行数为0说明被并发使用
+func (s *AccountService) consumeSmsCode(ctx *rest.Context, dbSmsCode *neuron_account_db.SmsCode) (err error) {
+	result, err := s.accountDB.SmsCode.Query().
+		IdEqual(dbSmsCode.Id).And().IsUsedEqual(0).
+		SetIsUsed(1).Update(ctx, nil)
+	if err != nil {
+		return err
+	}
+	affectedRows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affectedRows != 1 {
+		return rest.BadRequest("InvalidSmsCode", "验证码已使用，请重新获取")
 	}
 
 	return nil
 }
+
+// 校验通过后验证码即失效，只能使用一次
+func (s *AccountService) validateSmsCode(
+	ctx *rest.Context,
+	scene string,
+	phoneEncrypted string,
+	smsCode string,
+	userId string) (
+	err error) {
+
+	dbSmsCode, err := s.checkSmsCode(ctx, scene, phoneEncrypted, smsCode, userId)
+	if err != nil {
+		return err
+	}
+
+	return s.consumeSmsCode(ctx, dbSmsCode)
+}
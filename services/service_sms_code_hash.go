@@ -0,0 +1,110 @@
+package services
+
+import (
+	"context"
+	"crypto/hmac"
+	cryptoRand "crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"fmt"
+	"github.com/NeuronAccount/account/storages/neuron_account_db"
+	"go.uber.org/zap"
+	"strings"
+	"time"
+)
+
+const (
+	smsCodeHashPrefix         = "hmac-sha256$"
+	smsCodeMigrationBatchSize = 500
+	smsCodeMigrationLockName  = "sms_code_migration"
+	smsCodeMigrationLockLease = time.Minute * 5
+)
+
+// 多实例部署时须配置相同的密钥，否则在其它实例发送的验证码无法校验；
+// 只有开发环境允许不配置，使用本实例生成的临时密钥
+func (s *AccountService) loadSmsCodeHmacKey() (key []byte, err error) {
+	if s.options.SmsCodeHmacKey != "" {
+		return []byte(s.options.SmsCodeHmacKey), nil
+	}
+	if !s.options.Dev {
+		return nil, fmt.Errorf("未配置SmsCodeHmacKey")
+	}
+
+	s.logger.Warn("loadSmsCodeHmacKey 开发环境未配置密钥，使用本实例生成的临时密钥")
+	key = make([]byte, 32)
+	_, err = cryptoRand.Read(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return key, nil
+}
+
+// 验证码与场景、手机号一起计算HMAC，数据库中的哈希不能用于其它场景或手机号
+func (s *AccountService) hashSmsCode(scene string, phoneEncrypted string, smsCode string) string {
+	mac := hmac.New(sha256.New, s.smsCodeHmacKey)
+	mac.Write([]byte(scene + "\n" + phoneEncrypted + "\n" + smsCode))
+
+	return smsCodeHashPrefix + hex.EncodeToString(mac.Sum(nil))
+}
+
+// 兼容升级前明文存储且尚未迁移的验证码，明文为纯数字，不会有哈希前缀
+func (s *AccountService) verifySmsCode(dbSmsCode *neuron_account_db.SmsCode, smsCode string) bool {
+	expected := dbSmsCode.SmsCode
+	if strings.HasPrefix(expected, smsCodeHashPrefix) {
+		smsCode = s.hashSmsCode(dbSmsCode.SmsScene, dbSmsCode.PhoneEncrypted, smsCode)
+	}
+
+	return subtle.ConstantTimeCompare([]byte(expected), []byte(smsCode)) == 1
+}
+
+// 启动时将升级前明文存储的验证码改为哈希，只处理is_hashed为0的行，
+// 多实例时只有持有锁的实例执行，只在验证码未被并发修改时更新
+func (s *AccountService) migrateSmsCodes(ctx context.Context) (err error) {
+	lastId := uint64(0)
+	migrated := 0
+	for {
+		//每批续约，未持有锁时由其它实例迁移
+		acquired, err := s.acquireServiceLock(ctx, smsCodeMigrationLockName, smsCodeMigrationLockLease)
+		if err != nil {
+			return err
+		}
+		if !acquired {
+			return nil
+		}
+
+		dbSmsCodeList, err := s.accountDB.SmsCode.Query().
+			IsHashedEqual(0).And().IdGreater(lastId).
+			OrderById(true).Limit(0, smsCodeMigrationBatchSize).SelectList(ctx, nil)
+		if err != nil {
+			return err
+		}
+
+		for _, v := range dbSmsCodeList {
+			lastId = v.Id
+			smsCodeHash := v.SmsCode
+			if !strings.HasPrefix(smsCodeHash, smsCodeHashPrefix) {
+				smsCodeHash = s.hashSmsCode(v.SmsScene, v.PhoneEncrypted, v.SmsCode)
+			}
+
+			_, err = s.accountDB.SmsCode.Query().
+				IdEqual(v.Id).And().IsHashedEqual(0).And().SmsCodeEqual(v.SmsCode).
+				SetSmsCode(smsCodeHash).SetIsHashed(1).Update(ctx, nil)
+			if err != nil {
+				return err
+			}
+			migrated++
+		}
+
+		if len(dbSmsCodeList) < smsCodeMigrationBatchSize {
+			break
+		}
+	}
+
+	if migrated > 0 {
+		s.logger.Info("migrateSmsCodes", zap.Int("migrated", migrated))
+	}
+
+	return nil
+}
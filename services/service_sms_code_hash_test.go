@@ -0,0 +1,73 @@
+package services
+
+import (
+	"github.com/NeuronAccount/account/storages/neuron_account_db"
+	"strings"
+	"testing"
+)
+
+const (
+	smsCodeTestScene = "LOGIN"
+	smsCodeTestPhone = "phone-encrypted"
+	smsCodeTestCode  = "123456"
+)
+
+func newSmsCodeHashTestService(key string) *AccountService {
+	return &AccountService{smsCodeHmacKey: []byte(key)}
+}
+
+func TestHashSmsCode(t *testing.T) {
+	s := newSmsCodeHashTestService("test-key")
+	hash := s.hashSmsCode(smsCodeTestScene, smsCodeTestPhone, smsCodeTestCode)
+	if !strings.HasPrefix(hash, smsCodeHashPrefix) {
+		t.Fatalf("hashSmsCode() = %s, want prefix %s", hash, smsCodeHashPrefix)
+	}
+	if strings.Contains(hash, smsCodeTestCode) {
+		t.Errorf("hashSmsCode() = %s contains the plain code", hash)
+	}
+	if hash != s.hashSmsCode(smsCodeTestScene, smsCodeTestPhone, smsCodeTestCode) {
+		t.Errorf("hashSmsCode() is not deterministic")
+	}
+}
+
+func TestVerifySmsCode(t *testing.T) {
+	s := newSmsCodeHashTestService("test-key")
+	hashed := &neuron_account_db.SmsCode{
+		SmsScene:       smsCodeTestScene,
+		PhoneEncrypted: smsCodeTestPhone,
+		SmsCode:        s.hashSmsCode(smsCodeTestScene, smsCodeTestPhone, smsCodeTestCode),
+	}
+
+	tests := []struct {
+		name    string
+		s       *AccountService
+		smsCode *neuron_account_db.SmsCode
+		code    string
+		want    bool
+	}{
+		{"match", s, hashed, smsCodeTestCode, true},
+		{"wrong code", s, hashed, "654321", false},
+		{"hash as code", s, hashed, hashed.SmsCode, false},
+		{"other scene", s, &neuron_account_db.SmsCode{
+			SmsScene: "RESET_PASSWORD", PhoneEncrypted: smsCodeTestPhone, SmsCode: hashed.SmsCode},
+			smsCodeTestCode, false},
+		{"other phone", s, &neuron_account_db.SmsCode{
+			SmsScene: smsCodeTestScene, PhoneEncrypted: "other-phone", SmsCode: hashed.SmsCode},
+			smsCodeTestCode, false},
+		{"other key", newSmsCodeHashTestService("other-key"), hashed, smsCodeTestCode, false},
+		{"legacy plain", s, &neuron_account_db.SmsCode{
+			SmsScene: smsCodeTestScene, PhoneEncrypted: smsCodeTestPhone, SmsCode: smsCodeTestCode},
+			smsCodeTestCode, true},
+		{"legacy plain wrong code", s, &neuron_account_db.SmsCode{
+			SmsScene: smsCodeTestScene, PhoneEncrypted: smsCodeTestPhone, SmsCode: smsCodeTestCode},
+			"654321", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.s.verifySmsCode(tt.smsCode, tt.code); got != tt.want {
+				t.Errorf("verifySmsCode() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,56 @@
+package services
+
+import (
+	"github.com/NeuronAccount/account/models"
+	"github.com/NeuronAccount/account/storages/neuron_account_db"
+	"testing"
+	"time"
+)
+
+func TestCheckSmsCodeUsable(t *testing.T) {
+	now := time.Now()
+	newSmsCode := func(isUsed int32, attempts int32, age time.Duration) *neuron_account_db.SmsCode {
+		return &neuron_account_db.SmsCode{IsUsed: isUsed, Attempts: attempts, CreateTime: now.Add(-age)}
+	}
+
+	tests := []struct {
+		name    string
+		smsCode *neuron_account_db.SmsCode
+		wantErr bool
+	}{
+		{"not sent", nil, true},
+		{"fresh", newSmsCode(0, 0, 0), false},
+		{"used", newSmsCode(1, 0, 0), true},
+		{"last attempt", newSmsCode(0, models.SmsCodeMaxAttempts-1, 0), false},
+		{"attempts exhausted", newSmsCode(0, models.SmsCodeMaxAttempts, 0), true},
+		{"attempts over max", newSmsCode(0, models.SmsCodeMaxAttempts+1, 0), true},
+		{"about to expire", newSmsCode(0, 0, time.Second*(models.SmsCodeValidSeconds-1)), false},
+		{"expired", newSmsCode(0, 0, time.Second*(models.SmsCodeValidSeconds+1)), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkSmsCodeUsable(tt.smsCode, now)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("checkSmsCodeUsable() = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// 每次校验占用一次尝试，错误的验证码也计数，用完后正确的验证码也不能使用
+func TestSmsCodeAttemptCounting(t *testing.T) {
+	dbSmsCode := &neuron_account_db.SmsCode{CreateTime: time.Now()}
+	allowed := 0
+	for i := 0; i < models.SmsCodeMaxAttempts*2; i++ {
+		if checkSmsCodeUsable(dbSmsCode, time.Now()) != nil {
+			break
+		}
+		dbSmsCode.Attempts++
+		allowed++
+	}
+
+	if allowed != models.SmsCodeMaxAttempts {
+		t.Errorf("allowed attempts = %d, want %d", allowed, models.SmsCodeMaxAttempts)
+	}
+}
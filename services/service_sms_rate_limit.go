@@ -0,0 +1,115 @@
+package services
+
+import (
+	"fmt"
+	"github.com/NeuronAccount/account/models"
+	"github.com/NeuronFramework/rest"
+	"time"
+)
+
+const SmsRateLimitedErrorCode = "SmsRateLimited"
+
+// 超过发送频率限制，RetryAfter为需等待的秒数
+type SmsRateLimitError struct {
+	Limit      string //phone、scene、ip、device
+	RetryAfter int64
+}
+
+func (e *SmsRateLimitError) Message() string {
+	return fmt.Sprintf("发送过于频繁，请%d秒后再试", e.RetryAfter)
+}
+
+func (e *SmsRateLimitError) Error() string {
+	return SmsRateLimitedErrorCode + ": " + e.Message()
+}
+
+type SmsRateLimitOptions struct {
+	Interval  time.Duration //两次发送的最小间隔，小于0时不限制
+	HourlyMax int64         //每小时最多发送次数，小于0时不限制
+	DailyMax  int64         //每天最多发送次数，小于0时不限制
+}
+
+func (o *SmsRateLimitOptions) setDefaults(interval time.Duration, hourlyMax int64, dailyMax int64) {
+	if o.Interval == 0 {
+		o.Interval = interval
+	}
+	if o.HourlyMax == 0 {
+		o.HourlyMax = hourlyMax
+	}
+	if o.DailyMax == 0 {
+		o.DailyMax = dailyMax
+	}
+}
+
+type smsRateCounter struct {
+	key string
+	ttl time.Duration
+	max int64
+}
+
+// 分别按手机号、手机号+场景、IP、设备计数，每项有间隔、小时、天三个计数
+type smsRateLimit struct {
+	name    string
+	key     string
+	options *SmsRateLimitOptions
+}
+
+func (l *smsRateLimit) counters() (counters []*smsRateCounter) {
+	prefix := "sms_limit:" + l.name + ":" + l.key
+	if l.options.Interval > 0 {
+		counters = append(counters, &smsRateCounter{key: prefix + ":interval", ttl: l.options.Interval, max: 1})
+	}
+	if l.options.HourlyMax >= 0 {
+		counters = append(counters, &smsRateCounter{key: prefix + ":hour", ttl: time.Hour, max: l.options.HourlyMax})
+	}
+	if l.options.DailyMax >= 0 {
+		counters = append(counters, &smsRateCounter{key: prefix + ":day", ttl: time.Hour * 24, max: l.options.DailyMax})
+	}
+
+	return counters
+}
+
+func (s *AccountService) smsRateLimits(p *models.SendSmsCodeParams, phoneEncrypted string) (limits []*smsRateLimit) {
+	limits = []*smsRateLimit{
+		{name: "phone", key: phoneEncrypted, options: &s.options.SmsPhoneLimit},
+		{name: "scene", key: p.Scene + ":" + phoneEncrypted, options: &s.options.SmsSceneLimit},
+	}
+	if p.Device != nil && p.Device.ClientIp != "" {
+		limits = append(limits, &smsRateLimit{name: "ip", key: p.Device.ClientIp, options: &s.options.SmsIpLimit})
+	}
+	if p.Device != nil && p.Device.DeviceId != "" {
+		limits = append(limits, &smsRateLimit{name: "device", key: p.Device.DeviceId, options: &s.options.SmsDeviceLimit})
+	}
+
+	return limits
+}
+
+// 先检查全部计数，都未超过时再计数，超过限制的请求不计数；
+// 并发时计数后仍可能超过，此时拒绝但保留计数
+func (s *AccountService) checkSmsRateLimits(ctx *rest.Context, limits []*smsRateLimit) (err error) {
+	for _, l := range limits {
+		for _, c := range l.counters() {
+			count, expireTime, err := s.options.CounterStore.Get(ctx, c.key)
+			if err != nil {
+				return err
+			}
+			if count >= c.max {
+				return &SmsRateLimitError{Limit: l.name, RetryAfter: secondsUntil(expireTime)}
+			}
+		}
+	}
+
+	for _, l := range limits {
+		for _, c := range l.counters() {
+			count, expireTime, err := s.options.CounterStore.Incr(ctx, c.key, c.ttl)
+			if err != nil {
+				return err
+			}
+			if count > c.max {
+				return &SmsRateLimitError{Limit: l.name, RetryAfter: secondsUntil(expireTime)}
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,130 @@
+package services
+
+import (
+	"github.com/NeuronAccount/account/models"
+	"github.com/NeuronAccount/account/storages/counter"
+	"github.com/NeuronFramework/rest"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestSmsRateLimitCounters(t *testing.T) {
+	tests := []struct {
+		name     string
+		options  SmsRateLimitOptions
+		wantKeys []string
+		wantMax  []int64
+	}{
+		{"all", SmsRateLimitOptions{Interval: time.Minute, HourlyMax: 5, DailyMax: 10},
+			[]string{"sms_limit:phone:p:interval", "sms_limit:phone:p:hour", "sms_limit:phone:p:day"},
+			[]int64{1, 5, 10}},
+		{"no interval", SmsRateLimitOptions{Interval: -1, HourlyMax: 5, DailyMax: 10},
+			[]string{"sms_limit:phone:p:hour", "sms_limit:phone:p:day"}, []int64{5, 10}},
+		{"disabled", SmsRateLimitOptions{Interval: -1, HourlyMax: -1, DailyMax: -1}, nil, nil},
+		{"zero max blocks", SmsRateLimitOptions{Interval: -1, HourlyMax: 0, DailyMax: -1},
+			[]string{"sms_limit:phone:p:hour"}, []int64{0}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := &smsRateLimit{name: "phone", key: "p", options: &tt.options}
+			counters := l.counters()
+			if len(counters) != len(tt.wantKeys) {
+				t.Fatalf("counters() len = %d, want %d", len(counters), len(tt.wantKeys))
+			}
+			for i, c := range counters {
+				if c.key != tt.wantKeys[i] || c.max != tt.wantMax[i] {
+					t.Errorf("counters()[%d] = %s %d, want %s %d", i, c.key, c.max, tt.wantKeys[i], tt.wantMax[i])
+				}
+			}
+		})
+	}
+}
+
+func newSmsRateLimitTestService() *AccountService {
+	return &AccountService{options: &AccountServiceOptions{
+		CounterStore:   counter.NewMemoryStore(),
+		SmsPhoneLimit:  SmsRateLimitOptions{Interval: -1, HourlyMax: 3, DailyMax: 4},
+		SmsSceneLimit:  SmsRateLimitOptions{Interval: -1, HourlyMax: 2, DailyMax: -1},
+		SmsIpLimit:     SmsRateLimitOptions{Interval: -1, HourlyMax: 5, DailyMax: -1},
+		SmsDeviceLimit: SmsRateLimitOptions{Interval: time.Hour, HourlyMax: -1, DailyMax: -1},
+	}}
+}
+
+func TestCheckSmsRateLimits(t *testing.T) {
+	type send struct {
+		scene     string
+		phone     string
+		ip        string
+		deviceId  string
+		wantLimit string //为空表示允许发送
+	}
+
+	tests := []struct {
+		name  string
+		sends []send
+	}{
+		{"scene limit", []send{
+			{"login", "p1", "", "", ""},
+			{"login", "p1", "", "", ""},
+			{"login", "p1", "", "", "scene"},
+		}},
+		{"phone limit across scenes", []send{
+			{"login", "p1", "", "", ""},
+			{"login", "p1", "", "", ""},
+			{"bind", "p1", "", "", ""},
+			{"reset", "p1", "", "", "phone"},
+		}},
+		{"rejected requests do not count", []send{
+			{"login", "p1", "", "", ""},
+			{"login", "p1", "", "", ""},
+			{"login", "p1", "", "", "scene"},
+			{"login", "p1", "", "", "scene"},
+			{"bind", "p1", "", "", ""},
+		}},
+		{"ip limit across phones", []send{
+			{"login", "p1", "ip1", "", ""},
+			{"login", "p2", "ip1", "", ""},
+			{"login", "p3", "ip1", "", ""},
+			{"login", "p4", "ip1", "", ""},
+			{"login", "p5", "ip1", "", ""},
+			{"login", "p6", "ip1", "", "ip"},
+			{"login", "p6", "ip2", "", ""},
+		}},
+		{"device interval", []send{
+			{"login", "p1", "", "d1", ""},
+			{"login", "p2", "", "d1", "device"},
+			{"login", "p2", "", "d2", ""},
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newSmsRateLimitTestService()
+			ctx := rest.NewContext(httptest.NewRequest("POST", "/sms", nil))
+			for i, v := range tt.sends {
+				p := &models.SendSmsCodeParams{Scene: v.scene, Phone: v.phone,
+					Device: &models.DeviceInfo{ClientIp: v.ip, DeviceId: v.deviceId}}
+				err := s.checkSmsRateLimits(ctx, s.smsRateLimits(p, v.phone))
+				if v.wantLimit == "" {
+					if err != nil {
+						t.Fatalf("send %d: checkSmsRateLimits() = %v, want nil", i, err)
+					}
+					continue
+				}
+
+				rateLimitErr, ok := err.(*SmsRateLimitError)
+				if !ok {
+					t.Fatalf("send %d: checkSmsRateLimits() = %v, want *SmsRateLimitError", i, err)
+				}
+				if rateLimitErr.Limit != v.wantLimit {
+					t.Errorf("send %d: limit = %s, want %s", i, rateLimitErr.Limit, v.wantLimit)
+				}
+				if rateLimitErr.RetryAfter <= 0 {
+					t.Errorf("send %d: RetryAfter = %d", i, rateLimitErr.RetryAfter)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,160 @@
+package services
+
+import (
+	"fmt"
+	"github.com/NeuronAccount/account/models"
+	"github.com/NeuronFramework/rest"
+	"github.com/go-openapi/errors"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// RFC 9470定义的错误码，客户端收到后应调用StepUp重新认证
+const StepUpErrorCode = "insufficient_user_authentication"
+
+func (s *AccountService) isStepUpOperation(operationId string) bool {
+	for _, v := range s.options.StepUpOperations {
+		if v == operationId {
+			return true
+		}
+	}
+
+	return false
+}
+
+// 敏感操作要求会话最近完成过认证，通过RefreshToken续期不算重新认证
+func (s *AccountService) CheckStepUp(principal *models.Principal, operationId string) (err error) {
+	if !s.isStepUpOperation(operationId) {
+		return nil
+	}
+
+	if !principal.AuthTime.IsZero() && time.Since(principal.AuthTime) <= s.options.StepUpMaxAge {
+		return nil
+	}
+
+	return errors.New(http.StatusUnauthorized, "%s: 该操作需要在%d分钟内重新认证",
+		StepUpErrorCode, int64(s.options.StepUpMaxAge/time.Minute))
+}
+
+// 在当前会话中重新认证，更新会话的认证时间并签发新的AccessToken；
+// 不签发RefreshToken，避免同一token族出现两个有效的RefreshToken，之后刷新得到的AccessToken也沿用新的认证时间
+func (s *AccountService) StepUp(ctx *rest.Context, principal *models.Principal, smsCode string, passwordHash1 string,
+	captchaId string, captchaCode string) (accessToken string, err error) {
+
+	//第三方客户端应通过授权页面重新登录
+	if principal.ClientId != "" || principal.SessionId == "" {
+		return "", rest.BadRequest("StepUpNotAllowed", "当前token不支持重新认证")
+	}
+
+	var amr []string
+	if smsCode != "" {
+		err = s.checkStepUpSmsLimit(ctx, principal.UserId)
+		if err != nil {
+			return "", err
+		}
+
+		//验证码只能发送到已绑定的手机号
+		dbPhoneAccount, err := s.accountDB.PhoneAccount.Query().UserIdEqual(principal.UserId).Select(ctx, nil)
+		if err != nil {
+			return "", err
+		}
+		if dbPhoneAccount == nil {
+			return "", rest.BadRequest("PhoneNotBinded", "尚未绑定手机号")
+		}
+
+		err = s.validateSmsCode(ctx, models.SmsSceneStepUp, dbPhoneAccount.PhoneEncrypted, smsCode, principal.UserId)
+		if err != nil {
+			return "", err
+		}
+		amr = []string{models.AmrSms}
+	} else if passwordHash1 != "" {
+		dbUserInfo, err := s.accountDB.UserInfo.Query().UserIdEqual(principal.UserId).Select(ctx, nil)
+		if err != nil {
+			return "", err
+		}
+		if dbUserInfo == nil {
+			return "", rest.NotFound("帐号不存在")
+		}
+
+		//与修改密码共用按用户的计数，防止用盗取的token暴力破解密码
+		guards := []*loginGuard{{name: "user", key: principal.UserId, options: &s.options.LoginPhoneGuard,
+			resetOnSuccess: true}}
+		err = s.reserveLoginAttempt(ctx, guards, captchaId, captchaCode)
+		if err != nil {
+			return "", err
+		}
+
+		ok, err := s.checkUserPassword(ctx, dbUserInfo, passwordHash1)
+		if err != nil {
+			s.releaseLoginAttempt(ctx, guards)
+			return "", err
+		}
+		if !ok {
+			err = s.addLoginFailure(ctx, guards, principal.UserId, "")
+			if err != nil {
+				return "", err
+			}
+
+			return "", rest.BadRequest("AuthorizationFailed", "密码不正确")
+		}
+
+		err = s.addLoginSuccess(ctx, guards)
+		if err != nil {
+			return "", err
+		}
+		amr = []string{models.AmrPwd}
+	} else {
+		return "", rest.InvalidParam("验证码和密码不能都为空")
+	}
+
+	dbUserSession, err := s.accountDB.UserSession.Query().
+		UserIdEqual(principal.UserId).And().SessionIdEqual(principal.SessionId).Select(ctx, nil)
+	if err != nil {
+		return "", err
+	}
+	if dbUserSession == nil {
+		return "", rest.NotFound("会话不存在")
+	}
+
+	//更新会话的认证时间
+	now := time.Now()
+	_, err = s.accountDB.UserSession.Query().SessionIdEqual(dbUserSession.SessionId).
+		SetAuthTime(now).SetAmr(strings.Join(amr, " ")).Update(ctx, nil)
+	if err != nil {
+		return "", err
+	}
+	dbUserSession.AuthTime = now
+	dbUserSession.Amr = strings.Join(amr, " ")
+
+	accessToken, err = s.createAccessToken(ctx, dbUserSession, nil)
+	if err != nil {
+		return "", err
+	}
+
+	//操作纪录
+	s.addOperation(ctx, &models.AccountOperation{
+		OperationType: models.OperationStepUp,
+		UserId:        principal.UserId,
+	})
+
+	return accessToken, nil
+}
+
+// 按用户限制短信验证码二次认证的次数，验证码本身的错误次数由checkSmsCode限制
+func (s *AccountService) checkStepUpSmsLimit(ctx *rest.Context, userId string) (err error) {
+	if s.options.StepUpSmsHourlyMax < 0 {
+		return nil
+	}
+
+	count, expireTime, err := s.options.CounterStore.Incr(ctx, "step_up_sms:"+userId, time.Hour)
+	if err != nil {
+		return err
+	}
+	if count > s.options.StepUpSmsHourlyMax {
+		return rest.BadRequest("StepUpTooFrequent",
+			fmt.Sprintf("认证过于频繁，请%d分钟后再试", (secondsUntil(expireTime)+59)/60))
+	}
+
+	return nil
+}
@@ -0,0 +1,126 @@
+package services
+
+import (
+	"github.com/NeuronAccount/account/models"
+	"github.com/NeuronAccount/account/storages/counter"
+	"testing"
+	"time"
+)
+
+func TestCheckStepUp(t *testing.T) {
+	s := newJwtTestService(t)
+	now := time.Now()
+
+	tests := []struct {
+		name        string
+		operationId string
+		authTime    time.Time
+		wantErr     bool
+	}{
+		{"not sensitive", "GetUserInfo", time.Time{}, false},
+		{"no auth time", "UnbindPhone", time.Time{}, true},
+		{"just authenticated", "UnbindPhone", now, false},
+		{"within max age", "ChangePassword", now.Add(-s.options.StepUpMaxAge + time.Second*10), false},
+		{"after max age", "ChangePassword", now.Add(-s.options.StepUpMaxAge - time.Second), true},
+		{"long ago", "SetPassword", now.Add(-time.Hour * 24), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := s.CheckStepUp(&models.Principal{UserId: "u1", SessionId: "s1", AuthTime: tt.authTime},
+				tt.operationId)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("CheckStepUp() = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// auth_time经token传递到principal，刷新得到的token沿用会话的认证时间
+func TestCheckStepUpAuthTimeClaim(t *testing.T) {
+	s := newJwtTestService(t)
+
+	parse := func(authTime time.Time) *models.Principal {
+		claims := &accessTokenClaims{}
+		claims.Subject = "u1"
+		claims.Audience = s.options.AccessTokenAudience
+		claims.SessionId = "s1"
+		claims.AuthTime = authTime.Unix()
+		claims.Amr = []string{models.AmrSms}
+		principal, err := s.ParseAccessToken(nil, signTestAccessToken(t, s, claims))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		return principal
+	}
+
+	err := s.CheckStepUp(parse(time.Now()), "UnbindPhone")
+	if err != nil {
+		t.Errorf("CheckStepUp() recent auth_time = %v, want nil", err)
+	}
+	err = s.CheckStepUp(parse(time.Now().Add(-time.Hour)), "UnbindPhone")
+	if err == nil {
+		t.Errorf("CheckStepUp() old auth_time = nil, want error")
+	}
+}
+
+func TestStepUpOperationsDefaults(t *testing.T) {
+	s := &AccountService{options: &AccountServiceOptions{}}
+	s.options.setDefaults()
+	for _, operationId := range []string{"UnbindPhone", "UnbindOauthAccount", "SetPassword", "ChangePassword"} {
+		if !s.isStepUpOperation(operationId) {
+			t.Errorf("isStepUpOperation(%s) = false, want true", operationId)
+		}
+	}
+
+	//配置为空列表时不要求二次认证
+	s = &AccountService{options: &AccountServiceOptions{StepUpOperations: []string{}}}
+	s.options.setDefaults()
+	if s.isStepUpOperation("UnbindPhone") {
+		t.Errorf("isStepUpOperation(UnbindPhone) = true with empty StepUpOperations")
+	}
+}
+
+// 第三方客户端及不关联会话的token不能重新认证，在校验凭据前拒绝
+func TestStepUpNotAllowed(t *testing.T) {
+	s := newJwtTestService(t)
+
+	principals := []*models.Principal{
+		{UserId: "u1", SessionId: "s1", ClientId: "partner"},
+		{UserId: "u1"},
+	}
+	for _, principal := range principals {
+		accessToken, err := s.StepUp(nil, principal, "123456", "", "", "")
+		if err == nil || accessToken != "" {
+			t.Errorf("StepUp(%+v) = %s, %v, want error", principal, accessToken, err)
+		}
+	}
+}
+
+func TestStepUpSmsLimit(t *testing.T) {
+	s := &AccountService{options: &AccountServiceOptions{
+		CounterStore:       counter.NewMemoryStore(),
+		StepUpSmsHourlyMax: 3,
+	}}
+
+	for i := 0; i < 3; i++ {
+		err := s.checkStepUpSmsLimit(nil, "u1")
+		if err != nil {
+			t.Fatalf("checkStepUpSmsLimit() attempt %d = %v", i+1, err)
+		}
+	}
+	if s.checkStepUpSmsLimit(nil, "u1") == nil {
+		t.Errorf("checkStepUpSmsLimit() attempt 4 = nil, want error")
+	}
+	if err := s.checkStepUpSmsLimit(nil, "u2"); err != nil {
+		t.Errorf("checkStepUpSmsLimit() other user = %v, want nil", err)
+	}
+
+	s.options.StepUpSmsHourlyMax = -1
+	for i := 0; i < 10; i++ {
+		if err := s.checkStepUpSmsLimit(nil, "u1"); err != nil {
+			t.Fatalf("checkStepUpSmsLimit() disabled = %v", err)
+		}
+	}
+}
@@ -0,0 +1,147 @@
+package services
+
+import (
+	"github.com/NeuronAccount/account/models"
+	"github.com/NeuronAccount/account/storages/neuron_account_db"
+	"github.com/NeuronFramework/rest"
+	"github.com/go-openapi/errors"
+	"go.uber.org/zap"
+	"net/http"
+)
+
+// 管理员的身份，actor_token为管理员在该客户端登录获得的token，未携带时为客户端本身
+func (s *AccountService) exchangeActor(ctx *rest.Context, dbOauthClient *neuron_account_db.OauthClient,
+	params *models.OauthTokenParams) (actor *actorClaims, err error) {
+
+	if params.ActorToken == "" {
+		return &actorClaims{
+			Subject:     dbOauthClient.ClientId,
+			SubjectType: models.SubjectTypeClient,
+		}, nil
+	}
+
+	if params.ActorTokenType != models.OauthTokenTypeAccessToken {
+		return nil, rest.BadRequest(models.OauthErrorInvalidRequest, "不支持的actor_token_type")
+	}
+
+	claims, err := s.parseAccessTokenClaims(ctx, params.ActorToken, "")
+	if err != nil {
+		return nil, rest.BadRequest(models.OauthErrorInvalidGrant, "actor_token无效")
+	}
+	if claims.SubjectType == models.SubjectTypeClient || claims.Act != nil ||
+		claims.ClientId != dbOauthClient.ClientId {
+		return nil, rest.BadRequest(models.OauthErrorInvalidGrant, "actor_token不是该客户端的用户token")
+	}
+
+	return &actorClaims{
+		Subject:  claims.Subject,
+		ClientId: dbOauthClient.ClientId,
+	}, nil
+}
+
+// RFC 8693，管理客户端以用户身份获取token，用于客服查看用户所见，
+// token不关联会话也不签发RefreshToken，过期后需重新申请
+func (s *AccountService) exchangeToken(ctx *rest.Context, dbOauthClient *neuron_account_db.OauthClient,
+	params *models.OauthTokenParams) (oauthToken *models.OauthToken, err error) {
+
+	//只有运维分配了冒充权限的机密客户端可以使用
+	if dbOauthClient.IsPublic != 0 || !isScopeSubset(models.ScopeUsersImpersonate, dbOauthClient.Scope) {
+		return nil, rest.BadRequest(models.OauthErrorUnauthorizedClient, "客户端没有冒充用户的权限")
+	}
+
+	if params.SubjectToken == "" || params.SubjectTokenType != models.OauthTokenTypeUserId {
+		return nil, rest.BadRequest(models.OauthErrorInvalidRequest, "subject_token须为用户Id")
+	}
+
+	scope := params.Scope
+	if scope == "" {
+		scope = models.ImpersonationScope
+	}
+	if !isScopeSubset(scope, models.ImpersonationScope) {
+		return nil, rest.BadRequest(models.OauthErrorInvalidScope, "申请的scope超出允许范围")
+	}
+
+	dbUserInfo, err := s.accountDB.UserInfo.Query().UserIdEqual(params.SubjectToken).Select(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	if dbUserInfo == nil {
+		return nil, rest.BadRequest(models.OauthErrorInvalidGrant, "用户不存在")
+	}
+
+	actor, err := s.exchangeActor(ctx, dbOauthClient, params)
+	if err != nil {
+		return nil, err
+	}
+
+	claims := &accessTokenClaims{}
+	claims.Subject = dbUserInfo.UserId
+	claims.Audience = s.options.AccessTokenAudience
+	claims.ClientId = dbOauthClient.ClientId
+	claims.Scope = scope
+	claims.Act = actor
+	accessToken, err := s.signAccessToken(ctx, claims, dbUserInfo.UserId, "")
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("exchangeToken",
+		zap.String("clientId", dbOauthClient.ClientId),
+		zap.String("actorId", actor.Subject),
+		zap.String("userId", dbUserInfo.UserId))
+
+	//操作纪录
+	s.addOperation(ctx, &models.AccountOperation{
+		OperationType: models.OperationImpersonate,
+		UserId:        dbUserInfo.UserId,
+		ActorId:       actor.Subject,
+		Detail:        "client_id=" + dbOauthClient.ClientId + " scope=" + scope,
+	})
+
+	return &models.OauthToken{
+		AccessToken:     accessToken,
+		TokenType:       models.OauthTokenTypeBearer,
+		ExpiresIn:       models.UserAccessTokenExpireSeconds,
+		Scope:           scope,
+		IssuedTokenType: models.OauthTokenTypeAccessToken,
+	}, nil
+}
+
+func (s *AccountService) isImpersonationForbidden(operationId string) bool {
+	for _, v := range s.options.ImpersonationForbiddenOperations {
+		if v == operationId {
+			return true
+		}
+	}
+
+	return false
+}
+
+// 冒充用户的token每次调用接口都纪录操作，禁止的接口返回403
+func (s *AccountService) CheckImpersonation(ctx *rest.Context, principal *models.Principal, operationId string) (
+	err error) {
+
+	if s.isImpersonationForbidden(operationId) {
+		s.addOperation(ctx, &models.AccountOperation{
+			OperationType: models.OperationImpersonatedDenied,
+			UserId:        principal.UserId,
+			ActorId:       principal.ActorId,
+			Detail:        operationId,
+		})
+
+		return errors.New(http.StatusForbidden, "impersonation_forbidden: 冒充用户时不允许调用%s", operationId)
+	}
+
+	//纪录失败时拒绝调用，避免出现没有纪录的操作
+	err = s.addOperation(ctx, &models.AccountOperation{
+		OperationType: models.OperationImpersonatedCall,
+		UserId:        principal.UserId,
+		ActorId:       principal.ActorId,
+		Detail:        operationId,
+	})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,204 @@
+package services
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	cryptoRand "crypto/rand"
+	"github.com/NeuronAccount/account/models"
+	"github.com/NeuronAccount/account/storages/neuron_account_db"
+	"github.com/NeuronFramework/rand"
+	"github.com/dgrijalva/jwt-go"
+	"testing"
+	"time"
+)
+
+const jwtTestIssuer = "https://account.example.com"
+
+// 使用内存中的ES256密钥，签发和校验token不访问数据库
+func newJwtTestService(t *testing.T) *AccountService {
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), cryptoRand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	now := time.Now()
+	s := &AccountService{options: &AccountServiceOptions{Issuer: jwtTestIssuer}}
+	s.options.setDefaults()
+	s.jwtKeys = []*jwtKey{{
+		kid:           "test-kid",
+		algorithm:     models.JwtAlgorithmES256,
+		signingMethod: jwt.SigningMethodES256,
+		privateKey:    privateKey,
+		publicKey:     privateKey.Public(),
+		signBeginTime: now.Add(-time.Hour),
+		signEndTime:   now.Add(time.Hour),
+		expireTime:    now.Add(time.Hour * 2),
+	}}
+	s.jwtKeysLoadTime = now
+	s.revokedAccessTokens = make(map[string]time.Time)
+
+	return s
+}
+
+// 与signAccessToken相同的标准声明，不写入数据库
+func signTestAccessToken(t *testing.T, s *AccountService, claims *accessTokenClaims) string {
+	now := time.Now()
+	claims.Issuer = s.options.Issuer
+	claims.IssuedAt = now.Unix()
+	claims.NotBefore = now.Unix()
+	claims.ExpiresAt = now.Add(time.Minute).Unix()
+	claims.Id = rand.NextHex(16)
+	accessToken, err := s.signJwt(claims)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return accessToken
+}
+
+func newTestUserClaims(userId string, clientId string) *accessTokenClaims {
+	claims := &accessTokenClaims{}
+	claims.Subject = userId
+	claims.Audience = "admin-console"
+	claims.ClientId = clientId
+
+	return claims
+}
+
+func TestExchangeTokenClientChecks(t *testing.T) {
+	s := newJwtTestService(t)
+	admin := &neuron_account_db.OauthClient{ClientId: "admin", Scope: models.ScopeUsersImpersonate}
+	valid := models.OauthTokenParams{SubjectToken: "u1", SubjectTokenType: models.OauthTokenTypeUserId}
+
+	tests := []struct {
+		name   string
+		client *neuron_account_db.OauthClient
+		params func(p *models.OauthTokenParams)
+	}{
+		{"public client", &neuron_account_db.OauthClient{ClientId: "spa", IsPublic: 1,
+			Scope: models.ScopeUsersImpersonate}, func(p *models.OauthTokenParams) {}},
+		{"client without impersonate scope", &neuron_account_db.OauthClient{ClientId: "svc",
+			Scope: models.ScopeUsersLogout}, func(p *models.OauthTokenParams) {}},
+		{"empty subject", admin, func(p *models.OauthTokenParams) { p.SubjectToken = "" }},
+		{"subject is a token", admin, func(p *models.OauthTokenParams) {
+			p.SubjectTokenType = models.OauthTokenTypeAccessToken
+		}},
+		{"scope clients:write", admin, func(p *models.OauthTokenParams) { p.Scope = models.ScopeClientsWrite }},
+		{"scope oauth:authorize", admin, func(p *models.OauthTokenParams) { p.Scope = models.ScopeOauthAuthorize }},
+		{"scope users:impersonate", admin, func(p *models.OauthTokenParams) {
+			p.Scope = models.ScopeAccountRead + " " + models.ScopeUsersImpersonate
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			params := valid
+			tt.params(&params)
+			//被拒绝的请求在查询用户之前返回
+			oauthToken, err := s.exchangeToken(nil, tt.client, &params)
+			if err == nil || oauthToken != nil {
+				t.Errorf("exchangeToken() = %+v, %v, want error", oauthToken, err)
+			}
+		})
+	}
+}
+
+func TestExchangeActor(t *testing.T) {
+	s := newJwtTestService(t)
+	admin := &neuron_account_db.OauthClient{ClientId: "admin", Scope: models.ScopeUsersImpersonate}
+
+	//未携带actor_token时操作者为客户端本身
+	actor, err := s.exchangeActor(nil, admin, &models.OauthTokenParams{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if actor.Subject != "admin" || actor.SubjectType != models.SubjectTypeClient {
+		t.Errorf("exchangeActor() = %+v, want client admin", actor)
+	}
+
+	agentToken := signTestAccessToken(t, s, newTestUserClaims("agent1", "admin"))
+	actor, err = s.exchangeActor(nil, admin, &models.OauthTokenParams{
+		ActorToken: agentToken, ActorTokenType: models.OauthTokenTypeAccessToken})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if actor.Subject != "agent1" || actor.SubjectType != "" || actor.ClientId != "admin" {
+		t.Errorf("exchangeActor() = %+v, want user agent1 of admin", actor)
+	}
+
+	clientClaims := newTestUserClaims("admin", "admin")
+	clientClaims.SubjectType = models.SubjectTypeClient
+	impersonatedClaims := newTestUserClaims("u2", "admin")
+	impersonatedClaims.Act = &actorClaims{Subject: "agent2", ClientId: "admin"}
+	revokedClaims := newTestUserClaims("agent1", "admin")
+	revokedToken := signTestAccessToken(t, s, revokedClaims)
+	s.revokedAccessTokens[revokedClaims.Id] = time.Now().Add(time.Minute)
+
+	tests := []struct {
+		name      string
+		token     string
+		tokenType string
+	}{
+		{"wrong token type", agentToken, models.OauthTokenTypeUserId},
+		{"malformed token", "not-a-jwt", models.OauthTokenTypeAccessToken},
+		{"user of other client", signTestAccessToken(t, s, newTestUserClaims("agent1", "other")),
+			models.OauthTokenTypeAccessToken},
+		{"first party user", signTestAccessToken(t, s, newTestUserClaims("agent1", "")),
+			models.OauthTokenTypeAccessToken},
+		{"client token", signTestAccessToken(t, s, clientClaims), models.OauthTokenTypeAccessToken},
+		{"impersonated token", signTestAccessToken(t, s, impersonatedClaims), models.OauthTokenTypeAccessToken},
+		{"revoked token", revokedToken, models.OauthTokenTypeAccessToken},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actor, err := s.exchangeActor(nil, admin, &models.OauthTokenParams{
+				ActorToken: tt.token, ActorTokenType: tt.tokenType})
+			if err == nil || actor != nil {
+				t.Errorf("exchangeActor() = %+v, %v, want error", actor, err)
+			}
+		})
+	}
+}
+
+// 冒充得到的token可以访问本服务，principal中带有实际操作者
+func TestImpersonatedPrincipal(t *testing.T) {
+	s := newJwtTestService(t)
+	claims := &accessTokenClaims{}
+	claims.Subject = "u1"
+	claims.Audience = s.options.AccessTokenAudience
+	claims.ClientId = "admin"
+	claims.Scope = models.ImpersonationScope
+	claims.Act = &actorClaims{Subject: "agent1", ClientId: "admin"}
+
+	principal, err := s.ParseAccessToken(nil, signTestAccessToken(t, s, claims))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !principal.IsImpersonated() || principal.UserId != "u1" || principal.ActorId != "agent1" {
+		t.Errorf("ParseAccessToken() = %+v, want u1 impersonated by agent1", principal)
+	}
+	if principal.SessionId != "" {
+		t.Errorf("ParseAccessToken() SessionId = %s, want empty", principal.SessionId)
+	}
+}
+
+func TestImpersonationForbiddenOperations(t *testing.T) {
+	s := &AccountService{options: &AccountServiceOptions{}}
+	s.options.setDefaults()
+
+	forbidden := []string{"SetPassword", "ChangePassword", "SendSmsCode", "BindPhone", "UnbindPhone",
+		"UnbindOauthAccount", "StepUp", "Logout", "RevokeSession", "RevokeAllSessions"}
+	for _, operationId := range forbidden {
+		if !s.isImpersonationForbidden(operationId) {
+			t.Errorf("isImpersonationForbidden(%s) = false, want true", operationId)
+		}
+	}
+
+	allowed := []string{"GetUserInfo", "GetAccountInfo", "GetOperationList", "ListSessions"}
+	for _, operationId := range allowed {
+		if s.isImpersonationForbidden(operationId) {
+			t.Errorf("isImpersonationForbidden(%s) = true, want false", operationId)
+		}
+	}
+}
@@ -0,0 +1,116 @@
+package services
+
+import (
+	"github.com/NeuronAccount/account/models"
+	"github.com/NeuronFramework/rest"
+	"go.uber.org/zap"
+	"time"
+)
+
+func (s *AccountService) introspectAccessToken(ctx *rest.Context, token string) (
+	introspection *models.TokenIntrospection, err error) {
+
+	claims := &accessTokenClaims{}
+	err = s.parseJwt(ctx, token, claims)
+	if err == nil {
+		err = s.validateStandardClaims(&claims.StandardClaims, "")
+	}
+	if err != nil {
+		s.logger.Info("introspectAccessToken", zap.Error(err))
+		return nil, nil
+	}
+
+	//不存在或已撤销的token视为无效，以数据库为准，不依赖本地缓存
+	dbAccessToken, err := s.accountDB.AccessToken.Query().JtiEqual(claims.Id).Select(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	if dbAccessToken == nil || dbAccessToken.IsRevoked != 0 {
+		return nil, nil
+	}
+
+	return &models.TokenIntrospection{
+		Active:    true,
+		Subject:   claims.Subject,
+		ExpiresAt: claims.ExpiresAt,
+		IssuedAt:  claims.IssuedAt,
+		Scope:     claims.Scope,
+		ClientId:  claims.ClientId,
+		Audience:  claims.Audience,
+		TokenType: "Bearer",
+		TokenId:   claims.Id,
+	}, nil
+}
+
+func (s *AccountService) introspectRefreshToken(ctx *rest.Context, token string) (
+	introspection *models.TokenIntrospection, err error) {
+
+	dbRefreshToken, err := s.accountDB.RefreshToken.Query().RefreshTokenEqual(token).Select(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	if dbRefreshToken == nil || dbRefreshToken.IsRotated != 0 {
+		return nil, nil
+	}
+
+	now := time.Now()
+	if now.After(dbRefreshToken.ExpireTime) || now.After(dbRefreshToken.SessionExpireTime) {
+		return nil, nil
+	}
+
+	return &models.TokenIntrospection{
+		Active:    true,
+		Subject:   dbRefreshToken.UserId,
+		ExpiresAt: dbRefreshToken.ExpireTime.Unix(),
+		IssuedAt:  dbRefreshToken.CreateTime.Unix(),
+		Scope:     dbRefreshToken.Scope,
+		ClientId:  dbRefreshToken.ClientId,
+	}, nil
+}
+
+// 只有token签发给的客户端或token的audience可以查询，其它客户端得到active为false，不能借此获取用户信息；
+// RefreshToken不含audience，只有签发给的客户端可以查询
+func introspectionAllowed(introspection *models.TokenIntrospection, clientId string, clientAudience string) bool {
+	if introspection.ClientId != "" && introspection.ClientId == clientId {
+		return true
+	}
+
+	return introspection.Audience != "" && introspection.Audience == clientAudience
+}
+
+// clientId为调用方，已通过客户端凭据认证
+func (s *AccountService) IntrospectToken(ctx *rest.Context, clientId string, token string, tokenTypeHint string) (
+	introspection *models.TokenIntrospection, err error) {
+
+	dbOauthClient, err := s.getOauthClient(ctx, clientId)
+	if err != nil {
+		return nil, err
+	}
+	clientAudience := s.clientAudience(dbOauthClient)
+
+	//按提示的类型优先查找，找不到再尝试另一种类型
+	lookups := []func(ctx *rest.Context, token string) (*models.TokenIntrospection, error){
+		s.introspectAccessToken,
+		s.introspectRefreshToken,
+	}
+	if tokenTypeHint == models.TokenTypeHintRefreshToken {
+		lookups[0], lookups[1] = lookups[1], lookups[0]
+	}
+
+	for _, lookup := range lookups {
+		introspection, err = lookup(ctx, token)
+		if err != nil {
+			return nil, err
+		}
+		if introspection != nil {
+			if !introspectionAllowed(introspection, clientId, clientAudience) {
+				s.logger.Info("IntrospectToken not allowed",
+					zap.String("clientId", clientId), zap.String("tokenClientId", introspection.ClientId))
+				break
+			}
+			return introspection, nil
+		}
+	}
+
+	return &models.TokenIntrospection{Active: false}, nil
+}
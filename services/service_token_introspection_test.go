@@ -0,0 +1,32 @@
+package services
+
+import (
+	"github.com/NeuronAccount/account/models"
+	"testing"
+)
+
+func TestIntrospectionAllowed(t *testing.T) {
+	tests := []struct {
+		name           string
+		introspection  *models.TokenIntrospection
+		clientId       string
+		clientAudience string
+		want           bool
+	}{
+		{"issued to caller", &models.TokenIntrospection{ClientId: "c1", Audience: "api1"}, "c1", "c1", true},
+		{"caller is audience", &models.TokenIntrospection{ClientId: "c1", Audience: "api1"}, "rs1", "api1", true},
+		{"other client", &models.TokenIntrospection{ClientId: "c1", Audience: "api1"}, "c2", "c2", false},
+		{"first party token", &models.TokenIntrospection{Audience: "neuron-account"}, "c2", "c2", false},
+		{"first party refresh token", &models.TokenIntrospection{}, "c2", "c2", false},
+		{"empty client id", &models.TokenIntrospection{}, "", "", false},
+		{"refresh token of caller", &models.TokenIntrospection{ClientId: "c1"}, "c1", "api1", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := introspectionAllowed(tt.introspection, tt.clientId, tt.clientAudience); got != tt.want {
+				t.Errorf("introspectionAllowed() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
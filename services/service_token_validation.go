@@ -0,0 +1,64 @@
+package services
+
+import (
+	"fmt"
+	"github.com/dgrijalva/jwt-go"
+	"time"
+)
+
+// token校验失败的原因，作为401的错误码返回给客户端
+const (
+	TokenErrorMalformed         = "malformed"
+	TokenErrorInvalidSignature  = "invalid_signature"
+	TokenErrorMissingClaim      = "missing_claim"
+	TokenErrorInvalidIssuer     = "invalid_issuer"
+	TokenErrorInvalidAudience   = "invalid_audience"
+	TokenErrorExpired           = "expired"
+	TokenErrorNotYetValid       = "not_yet_valid"
+	TokenErrorIssuedInFuture    = "issued_in_future"
+	TokenErrorRevoked           = "revoked"
+	TokenErrorInsufficientScope = "insufficient_scope"
+)
+
+type TokenError struct {
+	Reason  string
+	Message string
+}
+
+func (e *TokenError) Error() string {
+	return e.Reason + ": " + e.Message
+}
+
+func newTokenError(reason string, format string, args ...interface{}) *TokenError {
+	return &TokenError{Reason: reason, Message: fmt.Sprintf(format, args...)}
+}
+
+// 校验标准声明，时间相关的声明允许JwtClockSkew的时钟误差；audience为空时不校验
+func (s *AccountService) validateStandardClaims(claims *jwt.StandardClaims, audience string) (err error) {
+	if claims.Issuer != s.options.Issuer {
+		return newTokenError(TokenErrorInvalidIssuer, "issuer不匹配%s", claims.Issuer)
+	}
+	if audience != "" && claims.Audience != audience {
+		return newTokenError(TokenErrorInvalidAudience, "audience不匹配%s", claims.Audience)
+	}
+	if claims.ExpiresAt == 0 {
+		return newTokenError(TokenErrorMissingClaim, "缺少exp")
+	}
+	if claims.IssuedAt == 0 {
+		return newTokenError(TokenErrorMissingClaim, "缺少iat")
+	}
+
+	now := time.Now()
+	skew := s.options.JwtClockSkew
+	if now.After(time.Unix(claims.ExpiresAt, 0).Add(skew)) {
+		return newTokenError(TokenErrorExpired, "token已于%s过期", time.Unix(claims.ExpiresAt, 0).Format(time.RFC3339))
+	}
+	if claims.NotBefore != 0 && now.Add(skew).Before(time.Unix(claims.NotBefore, 0)) {
+		return newTokenError(TokenErrorNotYetValid, "token在%s之后生效", time.Unix(claims.NotBefore, 0).Format(time.RFC3339))
+	}
+	if now.Add(skew).Before(time.Unix(claims.IssuedAt, 0)) {
+		return newTokenError(TokenErrorIssuedInFuture, "签发时间%s晚于当前时间", time.Unix(claims.IssuedAt, 0).Format(time.RFC3339))
+	}
+
+	return nil
+}
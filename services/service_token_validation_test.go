@@ -0,0 +1,75 @@
+package services
+
+import (
+	"github.com/dgrijalva/jwt-go"
+	"testing"
+	"time"
+)
+
+func TestValidateStandardClaims(t *testing.T) {
+	const issuer = "https://account.example.com"
+	const audience = "neuron-account"
+	s := &AccountService{options: &AccountServiceOptions{Issuer: issuer, JwtClockSkew: time.Second * 30}}
+	now := time.Now()
+
+	valid := func() *jwt.StandardClaims {
+		return &jwt.StandardClaims{
+			Issuer:    issuer,
+			Audience:  audience,
+			IssuedAt:  now.Unix(),
+			NotBefore: now.Unix(),
+			ExpiresAt: now.Add(time.Minute).Unix(),
+		}
+	}
+
+	tests := []struct {
+		name       string
+		claims     func(c *jwt.StandardClaims)
+		audience   string
+		wantReason string
+	}{
+		{"valid", func(c *jwt.StandardClaims) {}, audience, ""},
+		{"audience not checked", func(c *jwt.StandardClaims) { c.Audience = "other" }, "", ""},
+		{"wrong issuer", func(c *jwt.StandardClaims) { c.Issuer = "https://evil.example.com" }, audience,
+			TokenErrorInvalidIssuer},
+		{"wrong audience", func(c *jwt.StandardClaims) { c.Audience = "other" }, audience,
+			TokenErrorInvalidAudience},
+		{"missing exp", func(c *jwt.StandardClaims) { c.ExpiresAt = 0 }, audience, TokenErrorMissingClaim},
+		{"missing iat", func(c *jwt.StandardClaims) { c.IssuedAt = 0 }, audience, TokenErrorMissingClaim},
+		{"expired within skew", func(c *jwt.StandardClaims) { c.ExpiresAt = now.Add(-time.Second * 10).Unix() },
+			audience, ""},
+		{"expired beyond skew", func(c *jwt.StandardClaims) { c.ExpiresAt = now.Add(-time.Minute).Unix() },
+			audience, TokenErrorExpired},
+		{"nbf within skew", func(c *jwt.StandardClaims) { c.NotBefore = now.Add(time.Second * 10).Unix() },
+			audience, ""},
+		{"nbf beyond skew", func(c *jwt.StandardClaims) { c.NotBefore = now.Add(time.Minute).Unix() },
+			audience, TokenErrorNotYetValid},
+		{"missing nbf", func(c *jwt.StandardClaims) { c.NotBefore = 0 }, audience, ""},
+		{"iat within skew", func(c *jwt.StandardClaims) { c.IssuedAt = now.Add(time.Second * 10).Unix() },
+			audience, ""},
+		{"iat beyond skew", func(c *jwt.StandardClaims) { c.IssuedAt = now.Add(time.Minute).Unix() },
+			audience, TokenErrorIssuedInFuture},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			claims := valid()
+			tt.claims(claims)
+			err := s.validateStandardClaims(claims, tt.audience)
+			if tt.wantReason == "" {
+				if err != nil {
+					t.Errorf("validateStandardClaims() = %v, want nil", err)
+				}
+				return
+			}
+
+			tokenErr, ok := err.(*TokenError)
+			if !ok {
+				t.Fatalf("validateStandardClaims() = %v, want *TokenError", err)
+			}
+			if tokenErr.Reason != tt.wantReason {
+				t.Errorf("reason = %s, want %s", tokenErr.Reason, tt.wantReason)
+			}
+		})
+	}
+}
@@ -0,0 +1,232 @@
+package services
+
+import (
+	"github.com/NeuronAccount/account/models"
+	"github.com/NeuronAccount/account/storages/neuron_account_db"
+	"github.com/NeuronFramework/rand"
+	"github.com/NeuronFramework/rest"
+	"github.com/NeuronFramework/sql/wrap"
+	"go.uber.org/zap"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const CreateSessionMaxRetry = 10
+
+// authTime和amr为用户实际完成认证的时间和方式，用于id_token
+func (s *AccountService) createSession(ctx *rest.Context, userId string, device *models.DeviceInfo,
+	authTime time.Time, amr []string) (userSession *neuron_account_db.UserSession, err error) {
+
+	//同一设备重新登录，替换该设备之前的会话
+	if device.DeviceId != "" {
+		dbUserSessionList, err := s.accountDB.UserSession.Query().
+			UserIdEqual(userId).And().DeviceIdEqual(device.DeviceId).SelectList(ctx, nil)
+		if err != nil {
+			return nil, err
+		}
+		for _, v := range dbUserSessionList {
+			_, err = s.revokeSession(ctx, userId, v.SessionId)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	now := time.Now()
+	for i := 0; i < CreateSessionMaxRetry; i++ {
+		dbUserSession := &neuron_account_db.UserSession{}
+		dbUserSession.SessionId = rand.NextHex(16)
+		dbUserSession.UserId = userId
+		dbUserSession.DeviceId = device.DeviceId
+		dbUserSession.UserAgent = ctx.UserAgent
+		dbUserSession.ClientIp = device.ClientIp
+		dbUserSession.AuthTime = authTime
+		dbUserSession.Amr = strings.Join(amr, " ")
+		dbUserSession.LastSeenTime = now
+		dbUserSession.ExpireTime = now.Add(s.options.RefreshTokenAbsoluteLifetime)
+		_, err = s.accountDB.UserSession.Query().Insert(ctx, nil, dbUserSession)
+		if err != nil {
+			if err == wrap.ErrDuplicated {
+				s.logger.Warn("createSession Insert ErrDuplicated",
+					zap.String("sessionId", dbUserSession.SessionId),
+					zap.String("userId", userId))
+				continue
+			}
+
+			return nil, err
+		}
+
+		return dbUserSession, nil
+	}
+
+	return nil, rest.Unknown("服务器正忙，请稍后再试")
+}
+
+// 会话仍在使用，刷新最近活动时间
+func (s *AccountService) touchSession(ctx *rest.Context, sessionId string, device *models.DeviceInfo) (err error) {
+	_, err = s.accountDB.UserSession.Query().SessionIdEqual(sessionId).
+		SetLastSeenTime(time.Now()).SetClientIp(device.ClientIp).Update(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// 返回结束的会话数量，会话已不存在时为0
+func (s *AccountService) revokeSession(ctx *rest.Context, userId string, sessionId string) (
+	sessionCount int64, err error) {
+
+	err = s.revokeAccessTokens(ctx, userId, sessionId)
+	if err != nil {
+		return 0, err
+	}
+
+	_, err = s.accountDB.RefreshToken.Query().
+		UserIdEqual(userId).And().SessionIdEqual(sessionId).Delete(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+
+	result, err := s.accountDB.UserSession.Query().
+		UserIdEqual(userId).And().SessionIdEqual(sessionId).Delete(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.RowsAffected()
+}
+
+// 返回结束的会话数量
+func (s *AccountService) revokeAllSessions(ctx *rest.Context, userId string) (sessionCount int64, err error) {
+	err = s.revokeAccessTokens(ctx, userId, "")
+	if err != nil {
+		return 0, err
+	}
+
+	_, err = s.accountDB.RefreshToken.Query().UserIdEqual(userId).Delete(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+
+	result, err := s.accountDB.UserSession.Query().UserIdEqual(userId).Delete(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.RowsAffected()
+}
+
+// 结束当前会话以外的全部会话，返回结束的会话数量
+func (s *AccountService) revokeOtherSessions(ctx *rest.Context, userId string, sessionId string) (
+	sessionCount int64, err error) {
+
+	dbUserSessionList, err := s.accountDB.UserSession.Query().
+		UserIdEqual(userId).And().SessionIdNotEqual(sessionId).SelectList(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	for _, v := range dbUserSessionList {
+		count, err := s.revokeSession(ctx, userId, v.SessionId)
+		if err != nil {
+			return 0, err
+		}
+		sessionCount += count
+	}
+
+	return sessionCount, nil
+}
+
+func (s *AccountService) ListSessions(ctx *rest.Context, principal *models.Principal) (
+	sessions []*models.UserSession, err error) {
+
+	dbUserSessionList, err := s.accountDB.UserSession.Query().
+		UserIdEqual(principal.UserId).And().ExpireTimeGreater(time.Now()).
+		OrderByLastSeenTime(false).SelectList(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	sessions = fromUserSessionList(dbUserSessionList)
+	for _, v := range sessions {
+		v.IsCurrent = v.SessionId == principal.SessionId
+	}
+
+	return sessions, nil
+}
+
+func (s *AccountService) RevokeSession(ctx *rest.Context, userId string, sessionId string) (err error) {
+	dbUserSession, err := s.accountDB.UserSession.Query().
+		UserIdEqual(userId).And().SessionIdEqual(sessionId).Select(ctx, nil)
+	if err != nil {
+		return err
+	}
+	if dbUserSession == nil {
+		return rest.NotFound("会话不存在")
+	}
+
+	_, err = s.revokeSession(ctx, userId, sessionId)
+	if err != nil {
+		return err
+	}
+
+	//操作纪录
+	s.addOperation(ctx, &models.AccountOperation{
+		OperationType: models.OperationRevokeSession,
+		UserId:        userId,
+	})
+
+	return nil
+}
+
+func (s *AccountService) RevokeAllSessions(ctx *rest.Context, userId string) (err error) {
+	sessionCount, err := s.revokeAllSessions(ctx, userId)
+	if err != nil {
+		return err
+	}
+
+	//操作纪录
+	s.addOperation(ctx, &models.AccountOperation{
+		OperationType: models.OperationRevokeAllSessions,
+		UserId:        userId,
+		Detail:        sessionCountDetail(sessionCount),
+	})
+
+	return nil
+}
+
+// 管理员强制用户退出全部设备，actorId为执行操作的管理客户端
+func (s *AccountService) ForceLogout(ctx *rest.Context, actorId string, userId string) (sessionCount int64, err error) {
+	dbUserInfo, err := s.accountDB.UserInfo.Query().UserIdEqual(userId).Select(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	if dbUserInfo == nil {
+		return 0, rest.NotFound("用户不存在")
+	}
+
+	sessionCount, err = s.revokeAllSessions(ctx, userId)
+	if err != nil {
+		return 0, err
+	}
+
+	s.logger.Info("ForceLogout",
+		zap.String("actorId", actorId),
+		zap.String("userId", userId),
+		zap.Int64("sessionCount", sessionCount))
+
+	//操作纪录
+	s.addOperation(ctx, &models.AccountOperation{
+		OperationType: models.OperationForceLogout,
+		UserId:        userId,
+		ActorId:       actorId,
+		Detail:        sessionCountDetail(sessionCount),
+	})
+
+	return sessionCount, nil
+}
+
+func sessionCountDetail(sessionCount int64) string {
+	return "sessions=" + strconv.FormatInt(sessionCount, 10)
+}
@@ -0,0 +1,132 @@
+package services
+
+import (
+	"github.com/NeuronAccount/account/models"
+	"github.com/NeuronAccount/account/storages/neuron_account_db"
+	"github.com/NeuronFramework/log"
+	"github.com/NeuronFramework/rand"
+	"github.com/NeuronFramework/rest"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// 会话相关的测试需要数据库，未设置DB环境变量时跳过
+func newDBTestService(t *testing.T) (s *AccountService, ctx *rest.Context, userId string) {
+	if testing.Short() {
+		t.Skip("skipping database test in short mode")
+	}
+	db, err := neuron_account_db.NewDB()
+	if err != nil {
+		t.Skipf("database unavailable: %v", err)
+	}
+
+	s = &AccountService{accountDB: db, options: &AccountServiceOptions{}}
+	s.logger = log.TypedLogger(s)
+	s.options.setDefaults()
+	s.revokedAccessTokens = make(map[string]time.Time)
+
+	ctx = rest.NewContext(httptest.NewRequest("POST", "/login", nil))
+	userId = "test-" + rand.NextHex(8)
+	t.Cleanup(func() {
+		s.accountDB.AccessToken.Query().UserIdEqual(userId).Delete(ctx, nil)
+		s.accountDB.RefreshToken.Query().UserIdEqual(userId).Delete(ctx, nil)
+		s.accountDB.UserSession.Query().UserIdEqual(userId).Delete(ctx, nil)
+		s.accountDB.AccountOperation.Query().UserIdEqual(userId).Delete(ctx, nil)
+		s.accountDB.UserInfo.Query().UserIdEqual(userId).Delete(ctx, nil)
+	})
+
+	return s, ctx, userId
+}
+
+func createTestSession(t *testing.T, s *AccountService, ctx *rest.Context, userId string, deviceId string) (
+	userSession *neuron_account_db.UserSession) {
+
+	userSession, err := s.createSession(ctx, userId, &models.DeviceInfo{DeviceId: deviceId, ClientIp: "127.0.0.1"},
+		time.Now(), []string{models.AmrSms})
+	if err != nil {
+		t.Fatalf("createSession(%s) = %v", deviceId, err)
+	}
+
+	return userSession
+}
+
+func getTestSession(t *testing.T, s *AccountService, ctx *rest.Context, sessionId string) (
+	userSession *neuron_account_db.UserSession) {
+
+	userSession, err := s.accountDB.UserSession.Query().SessionIdEqual(sessionId).Select(ctx, nil)
+	if err != nil {
+		t.Fatalf("UserSession Select = %v", err)
+	}
+
+	return userSession
+}
+
+// 同一设备重新登录替换之前的会话，其它设备和未带设备id的会话不受影响
+func TestCreateSessionReplacesDevice(t *testing.T) {
+	s, ctx, userId := newDBTestService(t)
+
+	first := createTestSession(t, s, ctx, userId, "device-a")
+	other := createTestSession(t, s, ctx, userId, "device-b")
+	noDevice := createTestSession(t, s, ctx, userId, "")
+	second := createTestSession(t, s, ctx, userId, "device-a")
+
+	if getTestSession(t, s, ctx, first.SessionId) != nil {
+		t.Errorf("session %s on device-a not replaced", first.SessionId)
+	}
+	for _, v := range []*neuron_account_db.UserSession{other, noDevice, second} {
+		if getTestSession(t, s, ctx, v.SessionId) == nil {
+			t.Errorf("session %s on device %q removed", v.SessionId, v.DeviceId)
+		}
+	}
+}
+
+func TestRevokeAllSessionsCount(t *testing.T) {
+	s, ctx, userId := newDBTestService(t)
+
+	createTestSession(t, s, ctx, userId, "device-a")
+	createTestSession(t, s, ctx, userId, "device-b")
+
+	err := s.RevokeAllSessions(ctx, userId)
+	if err != nil {
+		t.Fatalf("RevokeAllSessions() = %v", err)
+	}
+
+	dbOperation, err := s.accountDB.AccountOperation.Query().UserIdEqual(userId).And().
+		OperationTypeEqual(models.OperationRevokeAllSessions).Select(ctx, nil)
+	if err != nil {
+		t.Fatalf("AccountOperation Select = %v", err)
+	}
+	if dbOperation == nil || dbOperation.Detail != sessionCountDetail(2) {
+		t.Errorf("RevokeAllSessions operation = %+v, want detail %s", dbOperation, sessionCountDetail(2))
+	}
+}
+
+func TestForceLogoutCount(t *testing.T) {
+	s, ctx, userId := newDBTestService(t)
+
+	//用户不存在
+	_, err := s.ForceLogout(ctx, "admin-client", userId)
+	if err == nil {
+		t.Fatalf("ForceLogout() unknown user = nil, want error")
+	}
+
+	_, err = s.accountDB.UserInfo.Query().Insert(ctx, nil, &neuron_account_db.UserInfo{UserId: userId})
+	if err != nil {
+		t.Fatalf("UserInfo Insert = %v", err)
+	}
+	createTestSession(t, s, ctx, userId, "device-a")
+	createTestSession(t, s, ctx, userId, "device-b")
+	createTestSession(t, s, ctx, userId, "")
+
+	sessionCount, err := s.ForceLogout(ctx, "admin-client", userId)
+	if err != nil || sessionCount != 3 {
+		t.Fatalf("ForceLogout() = %d, %v, want 3", sessionCount, err)
+	}
+
+	//再次执行时已没有会话
+	sessionCount, err = s.ForceLogout(ctx, "admin-client", userId)
+	if err != nil || sessionCount != 0 {
+		t.Errorf("ForceLogout() again = %d, %v, want 0", sessionCount, err)
+	}
+}
@@ -1,6 +1,7 @@
 package services
 
 import (
+	"context"
 	"github.com/NeuronAccount/account/models"
 	"github.com/NeuronAccount/account/storages/neuron_account_db"
 	"github.com/NeuronFramework/rand"
@@ -13,22 +14,53 @@ import (
 
 const CreateRefreshTokenMaxRetry = 10
 
-// todo use redis or nothing
-func (s *AccountService) createAccessToken(ctx *rest.Context, userId string) (accessToken string, err error) {
+type accessTokenClaims struct {
+	jwt.StandardClaims
+	SubjectType string       `json:"sub_type,omitempty"` //为空表示用户
+	Scope       string       `json:"scope,omitempty"`
+	ClientId    string       `json:"client_id,omitempty"`
+	SessionId   string       `json:"sid,omitempty"`
+	AuthTime    int64        `json:"auth_time,omitempty"` //会话最近一次认证的时间，用于敏感操作的二次认证
+	Amr         []string     `json:"amr,omitempty"`
+	Act         *actorClaims `json:"act,omitempty"` //RFC 8693，管理员冒充用户时的实际操作者
+}
+
+type actorClaims struct {
+	Subject     string `json:"sub"`
+	SubjectType string `json:"sub_type,omitempty"`
+	ClientId    string `json:"client_id,omitempty"`
+}
+
+// 第三方客户端通过授权获得的token，第一方登录时为nil
+type clientGrant struct {
+	clientId string
+	audience string
+	scope    string
+}
+
+// 签发AccessToken并按jti纪录，不保存token本身，userId和sessionId用于撤销
+func (s *AccountService) signAccessToken(ctx *rest.Context, claims *accessTokenClaims, userId string, sessionId string) (
+	accessToken string, err error) {
+
 	//生成AccessToken
-	expiresTime := time.Now().Add(time.Second * models.UserAccessTokenExpireSeconds)
-	jwtToken := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.StandardClaims{
-		Subject:   userId,
-		ExpiresAt: expiresTime.Unix(),
-		Id:        rand.NextNumberFixedLength(16), //防重，ExpiresAt精确到秒
-	})
-	accessToken, err = jwtToken.SignedString([]byte("0123456789"))
+	now := time.Now()
+	expiresTime := now.Add(time.Second * models.UserAccessTokenExpireSeconds)
+	jti := rand.NextHex(16) //防重，ExpiresAt精确到秒；撤销时按jti查找
+	claims.Issuer = s.options.Issuer
+	claims.IssuedAt = now.Unix()
+	claims.NotBefore = now.Unix()
+	claims.ExpiresAt = expiresTime.Unix()
+	claims.Id = jti
+	accessToken, err = s.signJwt(claims)
 	if err != nil {
 		return "", err
 	}
 	dbAccessToken := &neuron_account_db.AccessToken{}
 	dbAccessToken.UserId = userId
-	dbAccessToken.AccessToken = accessToken
+	dbAccessToken.Jti = jti
+	dbAccessToken.SessionId = sessionId
+	dbAccessToken.IsRevoked = 0
+	dbAccessToken.ExpireTime = expiresTime
 	_, err = s.accountDB.AccessToken.Query().Insert(ctx, nil, dbAccessToken)
 	if err != nil {
 		return "", err
@@ -37,52 +69,138 @@ func (s *AccountService) createAccessToken(ctx *rest.Context, userId string) (ac
 	return accessToken, nil
 }
 
-func (s *AccountService) createRefreshToken(ctx *rest.Context, userId string) (refreshToken string, err error) {
+func (s *AccountService) createAccessToken(ctx *rest.Context, dbUserSession *neuron_account_db.UserSession,
+	grant *clientGrant) (accessToken string, err error) {
+
+	claims := &accessTokenClaims{}
+	claims.Subject = dbUserSession.UserId
+	claims.Audience = s.options.AccessTokenAudience
+	claims.SessionId = dbUserSession.SessionId
+	claims.AuthTime = dbUserSession.AuthTime.Unix()
+	claims.Amr = splitAmr(dbUserSession.Amr)
+	claims.Scope = models.FirstPartyScope
+	if grant != nil {
+		claims.Audience = grant.audience
+		claims.ClientId = grant.clientId
+		claims.Scope = grant.scope
+	}
+
+	return s.signAccessToken(ctx, claims, dbUserSession.UserId, dbUserSession.SessionId)
+}
+
+// 服务间调用的token，主体为客户端本身，用于访问本服务的API
+func (s *AccountService) createClientAccessToken(ctx *rest.Context, clientId string, scope string) (
+	accessToken string, err error) {
+
+	claims := &accessTokenClaims{}
+	claims.Subject = clientId
+	claims.SubjectType = models.SubjectTypeClient
+	claims.Audience = s.options.AccessTokenAudience
+	claims.ClientId = clientId
+	claims.Scope = scope
+
+	return s.signAccessToken(ctx, claims, "", "")
+}
+
+// audience为空时接受发给任意audience的token
+func (s *AccountService) parseAccessTokenClaims(ctx context.Context, accessToken string, audience string) (
+	claims *accessTokenClaims, err error) {
+
+	claims = &accessTokenClaims{}
+	err = s.parseJwt(ctx, accessToken, claims)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.validateStandardClaims(&claims.StandardClaims, audience)
+	if err != nil {
+		return nil, err
+	}
+
+	if claims.Subject == "" {
+		return nil, newTokenError(TokenErrorMissingClaim, "缺少sub")
+	}
+
+	if s.isAccessTokenRevoked(claims.Id) {
+		return nil, newTokenError(TokenErrorRevoked, "token已撤销")
+	}
+
+	return claims, nil
+}
+
+func (s *AccountService) ParseAccessToken(ctx context.Context, accessToken string) (principal *models.Principal, err error) {
+	//发给第三方客户端的token只能用于其自身的audience
+	claims, err := s.parseAccessTokenClaims(ctx, accessToken, s.options.AccessTokenAudience)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.principalOf(claims), nil
+}
+
+func (s *AccountService) principalOf(claims *accessTokenClaims) (principal *models.Principal) {
+	principal = &models.Principal{}
+	principal.SessionId = claims.SessionId
+	principal.ClientId = claims.ClientId
+	principal.Scope = claims.Scope
+	if claims.AuthTime != 0 {
+		principal.AuthTime = time.Unix(claims.AuthTime, 0)
+	}
+	principal.Amr = claims.Amr
+	if claims.Act != nil {
+		principal.ActorId = claims.Act.Subject
+	}
+	if claims.SubjectType == models.SubjectTypeClient {
+		principal.SubjectType = models.SubjectTypeClient
+	} else {
+		principal.SubjectType = models.SubjectTypeUser
+		principal.UserId = claims.Subject
+	}
+
+	return principal
+}
+
+// 滑动有效期，不超过会话的绝对有效期
+func (s *AccountService) refreshTokenExpireTime(sessionExpireTime time.Time) (expireTime time.Time) {
+	expireTime = time.Now().Add(s.options.RefreshTokenSlidingLifetime)
+	if expireTime.After(sessionExpireTime) {
+		expireTime = sessionExpireTime
+	}
+
+	return expireTime
+}
+
+// 同一会话内的RefreshToken构成一个token族
+func (s *AccountService) insertRefreshToken(ctx *rest.Context, userId string, sessionId string, sessionExpireTime time.Time,
+	grant *clientGrant) (refreshToken string, err error) {
 
 	for i := 0; i < CreateRefreshTokenMaxRetry; i++ {
 		//生成新token
 		refreshToken := rand.NextHex(16)
 
-		//直接更新，如果影响行数为0再插入
-		result, err := s.accountDB.RefreshToken.Query().UserIdEqual(userId).
-			SetRefreshToken(refreshToken).Update(ctx, nil)
+		dbRefreshToken := &neuron_account_db.RefreshToken{}
+		dbRefreshToken.UserId = userId
+		dbRefreshToken.RefreshToken = refreshToken
+		dbRefreshToken.SessionId = sessionId
+		dbRefreshToken.IsRotated = 0
+		dbRefreshToken.ExpireTime = s.refreshTokenExpireTime(sessionExpireTime)
+		dbRefreshToken.SessionExpireTime = sessionExpireTime
+		if grant != nil {
+			dbRefreshToken.ClientId = grant.clientId
+			dbRefreshToken.Scope = grant.scope
+		}
+		_, err = s.accountDB.RefreshToken.Query().Insert(ctx, nil, dbRefreshToken)
 		if err != nil {
 			//token重复，重新生成
 			if err == wrap.ErrDuplicated {
-				s.logger.Warn("createRefreshToken Update ErrDuplicated",
+				s.logger.Warn("insertRefreshToken Insert ErrDuplicated",
 					zap.String("refreshToken", refreshToken),
 					zap.String("userId", userId))
 				continue
 			}
 
-			return "", nil
-		}
-
-		//获取影响的行数
-		affectRows, err := result.RowsAffected()
-		if err != nil {
 			return "", err
 		}
-		//未更新，插入新纪录
-		if affectRows == 0 {
-			dbRefreshToken := &neuron_account_db.RefreshToken{}
-			dbRefreshToken.UserId = userId
-			dbRefreshToken.RefreshToken = refreshToken
-			_, err = s.accountDB.RefreshToken.Query().Insert(ctx, nil, dbRefreshToken)
-			if err != nil {
-				//UserId重复，到第一步直接更新
-				//RefreshToken重复，到第一步直接更新，影响行数为0继续插入
-				if err == wrap.ErrDuplicated {
-					s.logger.Warn("createRefreshToken Insert ErrDuplicated",
-						zap.String("refreshToken", refreshToken),
-						zap.String("userId", userId))
-					continue
-				}
-
-				return "", err
-			}
-
-		}
 
 		return refreshToken, nil
 	}
@@ -90,16 +208,18 @@ func (s *AccountService) createRefreshToken(ctx *rest.Context, userId string) (r
 	return "", rest.Unknown("服务器正忙，请稍后再试")
 }
 
-func (s *AccountService) createUserToken(ctx *rest.Context, userId string) (userToken *models.UserToken, err error) {
+func (s *AccountService) issueUserToken(ctx *rest.Context, dbUserSession *neuron_account_db.UserSession,
+	grant *clientGrant) (userToken *models.UserToken, err error) {
 
 	//创建AccessToken
-	accessToken, err := s.createAccessToken(ctx, userId)
+	accessToken, err := s.createAccessToken(ctx, dbUserSession, grant)
 	if err != nil {
 		return nil, err
 	}
 
-	//创建ARefreshToken
-	refreshToken, err := s.createRefreshToken(ctx, userId)
+	//创建RefreshToken
+	refreshToken, err := s.insertRefreshToken(ctx,
+		dbUserSession.UserId, dbUserSession.SessionId, dbUserSession.ExpireTime, grant)
 	if err != nil {
 		return nil, err
 	}
@@ -109,3 +229,16 @@ func (s *AccountService) createUserToken(ctx *rest.Context, userId string) (user
 		RefreshToken: refreshToken,
 	}, nil
 }
+
+// 第一方登录，amr为本次登录的认证方式
+func (s *AccountService) createUserToken(ctx *rest.Context, userId string, device *models.DeviceInfo, amr []string) (
+	userToken *models.UserToken, err error) {
+
+	//创建登录会话
+	dbUserSession, err := s.createSession(ctx, userId, device, time.Now(), amr)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.issueUserToken(ctx, dbUserSession, nil)
+}
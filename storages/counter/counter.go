@@ -0,0 +1,21 @@
+package counter
+
+import (
+	"context"
+	"time"
+)
+
+// 带过期时间的计数器，用于登录失败次数、发送频率等限制；
+// 多实例部署时应使用共享存储的实现，否则各实例分别计数
+type Store interface {
+	// 计数加1，key不存在或已过期时从1开始并在ttl后过期，返回计数及过期时间
+	Incr(ctx context.Context, key string, ttl time.Duration) (count int64, expireTime time.Time, err error)
+
+	// key不存在或已过期时count为0
+	Get(ctx context.Context, key string) (count int64, expireTime time.Time, err error)
+
+	// 设置计数并重新开始过期计时
+	Set(ctx context.Context, key string, count int64, ttl time.Duration) (err error)
+
+	Delete(ctx context.Context, key string) (err error)
+}
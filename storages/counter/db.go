@@ -0,0 +1,115 @@
+package counter
+
+import (
+	"context"
+	"fmt"
+	"github.com/NeuronAccount/account/storages/neuron_account_db"
+	"github.com/NeuronFramework/sql/wrap"
+	"time"
+)
+
+// 并发插入同一key时重试的次数
+const dbStoreMaxRetryCount = 3
+
+// 多实例共享的数据库实现，过期的行由清理任务删除
+type DBStore struct {
+	db *neuron_account_db.DB
+}
+
+func NewDBStore(db *neuron_account_db.DB) (s *DBStore) {
+	return &DBStore{db: db}
+}
+
+// 在事务中锁定该key的行后修改，不存在时插入，并发插入冲突时重试
+func (s *DBStore) update(ctx context.Context, key string,
+	f func(dbCounter *neuron_account_db.Counter, now time.Time) (count int64, expireTime time.Time)) (
+	count int64, expireTime time.Time, err error) {
+
+	for i := 0; i < dbStoreMaxRetryCount; i++ {
+		err = s.db.TransactionReadCommitted(ctx, false, func(tx *wrap.Tx) (err error) {
+			dbCounter, err := s.db.Counter.Query().CounterKeyEqual(key).ForUpdate().Select(ctx, tx)
+			if err != nil {
+				return err
+			}
+
+			now := time.Now()
+			count, expireTime = f(dbCounter, now)
+			if dbCounter == nil {
+				_, err = s.db.Counter.Query().Insert(ctx, tx, &neuron_account_db.Counter{
+					CounterKey: key,
+					Count:      count,
+					ExpireTime: expireTime,
+				})
+				return err
+			}
+
+			_, err = s.db.Counter.Query().IdEqual(dbCounter.Id).
+				SetCount(count).SetExpireTime(expireTime).Update(ctx, tx)
+			return err
+		})
+		if err != wrap.ErrDuplicated {
+			return count, expireTime, err
+		}
+	}
+
+	return 0, time.Time{}, fmt.Errorf("counter %s 并发冲突", key)
+}
+
+func (s *DBStore) Incr(ctx context.Context, key string, ttl time.Duration) (
+	count int64, expireTime time.Time, err error) {
+
+	return s.update(ctx, key, func(dbCounter *neuron_account_db.Counter, now time.Time) (int64, time.Time) {
+		if dbCounter == nil || !dbCounter.ExpireTime.After(now) {
+			return 1, now.Add(ttl)
+		}
+
+		return dbCounter.Count + 1, dbCounter.ExpireTime
+	})
+}
+
+func (s *DBStore) Get(ctx context.Context, key string) (count int64, expireTime time.Time, err error) {
+	dbCounter, err := s.db.Counter.Query().CounterKeyEqual(key).Select(ctx, nil)
+	if err != nil {
+		return 0, time.Time{}, err
+	}
+	if dbCounter == nil || !dbCounter.ExpireTime.After(time.Now()) {
+		return 0, time.Time{}, nil
+	}
+
+	return dbCounter.Count, dbCounter.ExpireTime, nil
+}
+
+func (s *DBStore) Set(ctx context.Context, key string, count int64, ttl time.Duration) (err error) {
+	_, _, err = s.update(ctx, key, func(dbCounter *neuron_account_db.Counter, now time.Time) (int64, time.Time) {
+		return count, now.Add(ttl)
+	})
+
+	return err
+}
+
+func (s *DBStore) Delete(ctx context.Context, key string) (err error) {
+	_, err = s.db.Counter.Query().CounterKeyEqual(key).Delete(ctx, nil)
+
+	return err
+}
+
+// 删除一批截止时间之前过期的计数，返回删除的行数
+func (s *DBStore) Purge(ctx context.Context, cutoff time.Time, batchSize int64) (deleted int64, err error) {
+	dbCounterList, err := s.db.Counter.Query().ExpireTimeLess(cutoff).
+		OrderById(true).Limit(0, batchSize).SelectList(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	if len(dbCounterList) == 0 {
+		return 0, nil
+	}
+
+	result, err := s.db.Counter.Query().
+		IdLessEqual(dbCounterList[len(dbCounterList)-1].Id).And().ExpireTimeLess(cutoff).
+		Delete(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.RowsAffected()
+}
@@ -0,0 +1,93 @@
+package counter
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+const memoryStoreSweepInterval = time.Minute
+
+type memoryItem struct {
+	count      int64
+	expireTime time.Time
+}
+
+// 单实例使用的内存实现，过期项在访问时顺带清理
+type MemoryStore struct {
+	mutex     sync.Mutex
+	items     map[string]*memoryItem
+	sweepTime time.Time
+}
+
+func NewMemoryStore() (s *MemoryStore) {
+	return &MemoryStore{
+		items:     make(map[string]*memoryItem),
+		sweepTime: time.Now(),
+	}
+}
+
+// 调用方需持有锁
+func (s *MemoryStore) sweep(now time.Time) {
+	if now.Sub(s.sweepTime) < memoryStoreSweepInterval {
+		return
+	}
+
+	for key, item := range s.items {
+		if !item.expireTime.After(now) {
+			delete(s.items, key)
+		}
+	}
+	s.sweepTime = now
+}
+
+func (s *MemoryStore) Incr(ctx context.Context, key string, ttl time.Duration) (
+	count int64, expireTime time.Time, err error) {
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	now := time.Now()
+	s.sweep(now)
+
+	item, ok := s.items[key]
+	if !ok || !item.expireTime.After(now) {
+		item = &memoryItem{expireTime: now.Add(ttl)}
+		s.items[key] = item
+	}
+	item.count++
+
+	return item.count, item.expireTime, nil
+}
+
+func (s *MemoryStore) Get(ctx context.Context, key string) (count int64, expireTime time.Time, err error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	item, ok := s.items[key]
+	if !ok || !item.expireTime.After(time.Now()) {
+		return 0, time.Time{}, nil
+	}
+
+	return item.count, item.expireTime, nil
+}
+
+func (s *MemoryStore) Set(ctx context.Context, key string, count int64, ttl time.Duration) (err error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	now := time.Now()
+	s.sweep(now)
+	s.items[key] = &memoryItem{count: count, expireTime: now.Add(ttl)}
+
+	return nil
+}
+
+func (s *MemoryStore) Delete(ctx context.Context, key string) (err error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	delete(s.items, key)
+
+	return nil
+}
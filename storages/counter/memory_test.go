@@ -0,0 +1,117 @@
+package counter
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestMemoryStoreIncr(t *testing.T) {
+	ctx := context.Background()
+	s := NewMemoryStore()
+
+	for i := int64(1); i <= 3; i++ {
+		count, expireTime, err := s.Incr(ctx, "k", time.Hour)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if count != i {
+			t.Errorf("Incr() count = %d, want %d", count, i)
+		}
+		if time.Until(expireTime) > time.Hour || time.Until(expireTime) < time.Minute*59 {
+			t.Errorf("Incr() expireTime = %v", expireTime)
+		}
+	}
+
+	//过期时间从第一次计数开始，不因后续计数延长
+	_, first, _ := s.Get(ctx, "k")
+	_, second, _ := s.Incr(ctx, "k", time.Hour*2)
+	if !first.Equal(second) {
+		t.Errorf("Incr() extended expireTime from %v to %v", first, second)
+	}
+}
+
+func TestMemoryStoreExpiry(t *testing.T) {
+	ctx := context.Background()
+	ttl := time.Millisecond * 20
+
+	tests := []struct {
+		name      string
+		prepare   func(s *MemoryStore)
+		wait      time.Duration
+		wantCount int64
+	}{
+		{"incr not expired", func(s *MemoryStore) { s.Incr(ctx, "k", time.Hour) }, 0, 1},
+		{"incr expired", func(s *MemoryStore) { s.Incr(ctx, "k", ttl) }, ttl * 2, 0},
+		{"set not expired", func(s *MemoryStore) { s.Set(ctx, "k", 5, time.Hour) }, 0, 5},
+		{"set expired", func(s *MemoryStore) { s.Set(ctx, "k", 5, ttl) }, ttl * 2, 0},
+		{"set restarts expiry", func(s *MemoryStore) {
+			s.Incr(ctx, "k", ttl)
+			s.Set(ctx, "k", 2, time.Hour)
+		}, ttl * 2, 2},
+		{"deleted", func(s *MemoryStore) {
+			s.Incr(ctx, "k", time.Hour)
+			s.Delete(ctx, "k")
+		}, 0, 0},
+		{"missing", func(s *MemoryStore) {}, 0, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewMemoryStore()
+			tt.prepare(s)
+			time.Sleep(tt.wait)
+
+			count, _, err := s.Get(ctx, "k")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if count != tt.wantCount {
+				t.Errorf("Get() count = %d, want %d", count, tt.wantCount)
+			}
+		})
+	}
+}
+
+// 过期后再计数从1开始，并重新计算过期时间
+func TestMemoryStoreIncrAfterExpiry(t *testing.T) {
+	ctx := context.Background()
+	s := NewMemoryStore()
+	ttl := time.Millisecond * 20
+
+	s.Incr(ctx, "k", ttl)
+	s.Incr(ctx, "k", ttl)
+	time.Sleep(ttl * 2)
+
+	count, expireTime, err := s.Incr(ctx, "k", time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if count != 1 {
+		t.Errorf("Incr() count = %d, want 1", count)
+	}
+	if time.Until(expireTime) < time.Minute*59 {
+		t.Errorf("Incr() expireTime = %v", expireTime)
+	}
+}
+
+// 过期项在清理间隔后被删除
+func TestMemoryStoreSweep(t *testing.T) {
+	s := NewMemoryStore()
+	now := time.Now()
+	s.items["expired"] = &memoryItem{count: 1, expireTime: now.Add(-time.Second)}
+	s.items["alive"] = &memoryItem{count: 1, expireTime: now.Add(time.Hour)}
+
+	s.sweep(now)
+	if len(s.items) != 2 {
+		t.Errorf("sweep() before interval removed items, len = %d", len(s.items))
+	}
+
+	s.sweep(now.Add(memoryStoreSweepInterval))
+	if _, ok := s.items["expired"]; ok {
+		t.Error("sweep() kept expired item")
+	}
+	if _, ok := s.items["alive"]; !ok {
+		t.Error("sweep() removed alive item")
+	}
+}
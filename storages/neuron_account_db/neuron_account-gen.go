@@ -99,11 +99,14 @@ func (q *QueryBase) buildSelectQuery() (queryString string, params []interface{}
 }
 
 type AccessToken struct {
-	Id          uint64 //size=20
-	UserId      string //size=32
-	AccessToken string //size=1024
-	CreateTime  time.Time
-	UpdateTime  time.Time
+	Id         uint64 //size=20
+	UserId     string //size=32
+	Jti        string //size=32
+	SessionId  string //size=32
+	IsRevoked  int32  //size=1
+	ExpireTime time.Time
+	CreateTime time.Time
+	UpdateTime time.Time
 }
 
 type AccessTokenQuery struct {
@@ -200,26 +203,126 @@ func (q *AccessTokenQuery) UserIdIn(items []string) *AccessTokenQuery {
 	return q
 }
 
-func (q *AccessTokenQuery) AccessTokenEqual(v string) *AccessTokenQuery {
-	q.where.WriteString(" access_token=?")
+func (q *AccessTokenQuery) JtiEqual(v string) *AccessTokenQuery {
+	q.where.WriteString(" jti=?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *AccessTokenQuery) JtiNotEqual(v string) *AccessTokenQuery {
+	q.where.WriteString(" jti<>?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *AccessTokenQuery) JtiIn(items []string) *AccessTokenQuery {
+	q.where.WriteString(" jti IN(")
+	q.where.WriteString(wrap.RepeatWithSeparator("?", len(items), ","))
+	q.where.WriteString(")")
+	q.whereParams = append(q.whereParams, items)
+	return q
+}
+
+func (q *AccessTokenQuery) SessionIdEqual(v string) *AccessTokenQuery {
+	q.where.WriteString(" session_id=?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *AccessTokenQuery) SessionIdNotEqual(v string) *AccessTokenQuery {
+	q.where.WriteString(" session_id<>?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *AccessTokenQuery) SessionIdIn(items []string) *AccessTokenQuery {
+	q.where.WriteString(" session_id IN(")
+	q.where.WriteString(wrap.RepeatWithSeparator("?", len(items), ","))
+	q.where.WriteString(")")
+	q.whereParams = append(q.whereParams, items)
+	return q
+}
+
+func (q *AccessTokenQuery) IsRevokedEqual(v int32) *AccessTokenQuery {
+	q.where.WriteString(" is_revoked=?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *AccessTokenQuery) IsRevokedNotEqual(v int32) *AccessTokenQuery {
+	q.where.WriteString(" is_revoked<>?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *AccessTokenQuery) IsRevokedLess(v int32) *AccessTokenQuery {
+	q.where.WriteString(" is_revoked<?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *AccessTokenQuery) IsRevokedLessEqual(v int32) *AccessTokenQuery {
+	q.where.WriteString(" is_revoked<=?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *AccessTokenQuery) IsRevokedGreater(v int32) *AccessTokenQuery {
+	q.where.WriteString(" is_revoked>?")
 	q.whereParams = append(q.whereParams, v)
 	return q
 }
 
-func (q *AccessTokenQuery) AccessTokenNotEqual(v string) *AccessTokenQuery {
-	q.where.WriteString(" access_token<>?")
+func (q *AccessTokenQuery) IsRevokedGreaterEqual(v int32) *AccessTokenQuery {
+	q.where.WriteString(" is_revoked>=?")
 	q.whereParams = append(q.whereParams, v)
 	return q
 }
 
-func (q *AccessTokenQuery) AccessTokenIn(items []string) *AccessTokenQuery {
-	q.where.WriteString(" access_token IN(")
+func (q *AccessTokenQuery) IsRevokedIn(items []int32) *AccessTokenQuery {
+	q.where.WriteString(" is_revoked IN(")
 	q.where.WriteString(wrap.RepeatWithSeparator("?", len(items), ","))
 	q.where.WriteString(")")
 	q.whereParams = append(q.whereParams, items)
 	return q
 }
 
+func (q *AccessTokenQuery) ExpireTimeEqual(v time.Time) *AccessTokenQuery {
+	q.where.WriteString(" expire_time=?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *AccessTokenQuery) ExpireTimeNotEqual(v time.Time) *AccessTokenQuery {
+	q.where.WriteString(" expire_time<>?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *AccessTokenQuery) ExpireTimeLess(v time.Time) *AccessTokenQuery {
+	q.where.WriteString(" expire_time<?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *AccessTokenQuery) ExpireTimeLessEqual(v time.Time) *AccessTokenQuery {
+	q.where.WriteString(" expire_time<=?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *AccessTokenQuery) ExpireTimeGreater(v time.Time) *AccessTokenQuery {
+	q.where.WriteString(" expire_time>?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *AccessTokenQuery) ExpireTimeGreaterEqual(v time.Time) *AccessTokenQuery {
+	q.where.WriteString(" expire_time>=?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
 func (q *AccessTokenQuery) CreateTimeEqual(v time.Time) *AccessTokenQuery {
 	q.where.WriteString(" create_time=?")
 	q.whereParams = append(q.whereParams, v)
@@ -298,6 +401,18 @@ func (q *AccessTokenQuery) GroupByUserId(asc bool) *AccessTokenQuery {
 	return q
 }
 
+func (q *AccessTokenQuery) GroupBySessionId(asc bool) *AccessTokenQuery {
+	q.groupByFields = append(q.groupByFields, "session_id")
+	q.groupByOrders = append(q.groupByOrders, asc)
+	return q
+}
+
+func (q *AccessTokenQuery) GroupByIsRevoked(asc bool) *AccessTokenQuery {
+	q.groupByFields = append(q.groupByFields, "is_revoked")
+	q.groupByOrders = append(q.groupByOrders, asc)
+	return q
+}
+
 func (q *AccessTokenQuery) OrderById(asc bool) *AccessTokenQuery {
 	q.orderByFields = append(q.orderByFields, "id")
 	q.orderByOrders = append(q.orderByOrders, asc)
@@ -310,8 +425,26 @@ func (q *AccessTokenQuery) OrderByUserId(asc bool) *AccessTokenQuery {
 	return q
 }
 
-func (q *AccessTokenQuery) OrderByAccessToken(asc bool) *AccessTokenQuery {
-	q.orderByFields = append(q.orderByFields, "access_token")
+func (q *AccessTokenQuery) OrderByJti(asc bool) *AccessTokenQuery {
+	q.orderByFields = append(q.orderByFields, "jti")
+	q.orderByOrders = append(q.orderByOrders, asc)
+	return q
+}
+
+func (q *AccessTokenQuery) OrderBySessionId(asc bool) *AccessTokenQuery {
+	q.orderByFields = append(q.orderByFields, "session_id")
+	q.orderByOrders = append(q.orderByOrders, asc)
+	return q
+}
+
+func (q *AccessTokenQuery) OrderByIsRevoked(asc bool) *AccessTokenQuery {
+	q.orderByFields = append(q.orderByFields, "is_revoked")
+	q.orderByOrders = append(q.orderByOrders, asc)
+	return q
+}
+
+func (q *AccessTokenQuery) OrderByExpireTime(asc bool) *AccessTokenQuery {
+	q.orderByFields = append(q.orderByFields, "expire_time")
 	q.orderByOrders = append(q.orderByOrders, asc)
 	return q
 }
@@ -357,8 +490,26 @@ func (q *AccessTokenQuery) SetUserId(v string) *AccessTokenQuery {
 	return q
 }
 
-func (q *AccessTokenQuery) SetAccessToken(v string) *AccessTokenQuery {
-	q.updateFields = append(q.updateFields, "access_token")
+func (q *AccessTokenQuery) SetJti(v string) *AccessTokenQuery {
+	q.updateFields = append(q.updateFields, "jti")
+	q.updateParams = append(q.updateParams, v)
+	return q
+}
+
+func (q *AccessTokenQuery) SetSessionId(v string) *AccessTokenQuery {
+	q.updateFields = append(q.updateFields, "session_id")
+	q.updateParams = append(q.updateParams, v)
+	return q
+}
+
+func (q *AccessTokenQuery) SetIsRevoked(v int32) *AccessTokenQuery {
+	q.updateFields = append(q.updateFields, "is_revoked")
+	q.updateParams = append(q.updateParams, v)
+	return q
+}
+
+func (q *AccessTokenQuery) SetExpireTime(v time.Time) *AccessTokenQuery {
+	q.updateFields = append(q.updateFields, "expire_time")
 	q.updateParams = append(q.updateParams, v)
 	return q
 }
@@ -368,6 +519,21 @@ func (q *AccessTokenQuery) DuplicatedUpdateUserId() *AccessTokenQuery {
 	return q
 }
 
+func (q *AccessTokenQuery) DuplicatedUpdateSessionId() *AccessTokenQuery {
+	q.duplicatedUpdateFields = append(q.duplicatedUpdateFields, "session_id=VALUES(session_id)")
+	return q
+}
+
+func (q *AccessTokenQuery) DuplicatedUpdateIsRevoked() *AccessTokenQuery {
+	q.duplicatedUpdateFields = append(q.duplicatedUpdateFields, "is_revoked=VALUES(is_revoked)")
+	return q
+}
+
+func (q *AccessTokenQuery) DuplicatedUpdateExpireTime() *AccessTokenQuery {
+	q.duplicatedUpdateFields = append(q.duplicatedUpdateFields, "expire_time=VALUES(expire_time)")
+	return q
+}
+
 func (q *AccessTokenQuery) GetId() *AccessTokenQuery {
 	q.getFields = append(q.getFields, "id")
 	return q
@@ -378,8 +544,23 @@ func (q *AccessTokenQuery) GetUserId() *AccessTokenQuery {
 	return q
 }
 
-func (q *AccessTokenQuery) GetAccessToken() *AccessTokenQuery {
-	q.getFields = append(q.getFields, "access_token")
+func (q *AccessTokenQuery) GetJti() *AccessTokenQuery {
+	q.getFields = append(q.getFields, "jti")
+	return q
+}
+
+func (q *AccessTokenQuery) GetSessionId() *AccessTokenQuery {
+	q.getFields = append(q.getFields, "session_id")
+	return q
+}
+
+func (q *AccessTokenQuery) GetIsRevoked() *AccessTokenQuery {
+	q.getFields = append(q.getFields, "is_revoked")
+	return q
+}
+
+func (q *AccessTokenQuery) GetExpireTime() *AccessTokenQuery {
+	q.getFields = append(q.getFields, "expire_time")
 	return q
 }
 
@@ -402,7 +583,7 @@ func (q *AccessTokenQuery) Select(ctx context.Context, tx *wrap.Tx) (e *AccessTo
 	queryString, params := q.buildSelectQuery()
 	query := bytes.NewBufferString("")
 	if len(q.getFields) == 0 {
-		query.WriteString("SELECT id,user_id,access_token,create_time,update_time FROM access_token ")
+		query.WriteString("SELECT id,user_id,jti,session_id,is_revoked,expire_time,create_time,update_time FROM access_token ")
 	} else {
 		query.WriteString("SELECT ")
 		query.WriteString(strings.Join(q.getFields, ","))
@@ -411,7 +592,7 @@ func (q *AccessTokenQuery) Select(ctx context.Context, tx *wrap.Tx) (e *AccessTo
 	query.WriteString(queryString)
 	e = &AccessToken{}
 	row := q.dao.db.QueryRow(ctx, tx, query.String(), params...)
-	err = row.Scan(&e.Id, &e.UserId, &e.AccessToken, &e.CreateTime, &e.UpdateTime)
+	err = row.Scan(&e.Id, &e.UserId, &e.Jti, &e.SessionId, &e.IsRevoked, &e.ExpireTime, &e.CreateTime, &e.UpdateTime)
 	if err == wrap.ErrNoRows {
 		return nil, nil
 	}
@@ -423,7 +604,7 @@ func (q *AccessTokenQuery) SelectList(ctx context.Context, tx *wrap.Tx) (list []
 	queryString, params := q.buildSelectQuery()
 	query := bytes.NewBufferString("")
 	if len(q.getFields) == 0 {
-		query.WriteString("SELECT id,user_id,access_token,create_time,update_time FROM access_token ")
+		query.WriteString("SELECT id,user_id,jti,session_id,is_revoked,expire_time,create_time,update_time FROM access_token ")
 	} else {
 		query.WriteString("SELECT ")
 		query.WriteString(strings.Join(q.getFields, ","))
@@ -436,7 +617,7 @@ func (q *AccessTokenQuery) SelectList(ctx context.Context, tx *wrap.Tx) (list []
 	}
 	for rows.Next() {
 		e := AccessToken{}
-		err = rows.Scan(&e.Id, &e.UserId, &e.AccessToken, &e.CreateTime, &e.UpdateTime)
+		err = rows.Scan(&e.Id, &e.UserId, &e.Jti, &e.SessionId, &e.IsRevoked, &e.ExpireTime, &e.CreateTime, &e.UpdateTime)
 		if err != nil {
 			return nil, err
 		}
@@ -502,21 +683,24 @@ func (q *AccessTokenQuery) SelectRows(ctx context.Context, tx *wrap.Tx) (rows *w
 
 func (q *AccessTokenQuery) Insert(ctx context.Context, tx *wrap.Tx, e *AccessToken) (result *wrap.Result, err error) {
 	query := bytes.NewBufferString("")
-	query.WriteString("INSERT INTO access_token (user_id,access_token) VALUES (?,?)")
-	params := []interface{}{e.UserId, e.AccessToken}
+	query.WriteString("INSERT INTO access_token (user_id,jti,session_id,is_revoked,expire_time) VALUES (?,?,?,?,?)")
+	params := []interface{}{e.UserId, e.Jti, e.SessionId, e.IsRevoked, e.ExpireTime}
 	return q.dao.db.Exec(ctx, tx, query.String(), params...)
 }
 
 func (q *AccessTokenQuery) BatchInsert(ctx context.Context, tx *wrap.Tx, list []*AccessToken) (result *wrap.Result, err error) {
 	query := bytes.NewBufferString("")
-	query.WriteString("INSERT INTO access_token (user_id,access_token) VALUES ")
-	query.WriteString(wrap.RepeatWithSeparator("(?,?)", len(list), ","))
-	params := make([]interface{}, len(list)*2)
+	query.WriteString("INSERT INTO access_token (user_id,jti,session_id,is_revoked,expire_time) VALUES ")
+	query.WriteString(wrap.RepeatWithSeparator("(?,?,?,?,?)", len(list), ","))
+	params := make([]interface{}, len(list)*5)
 	offset := 0
 	for _, e := range list {
 		params[offset+0] = e.UserId
-		params[offset+1] = e.AccessToken
-		offset += 2
+		params[offset+1] = e.Jti
+		params[offset+2] = e.SessionId
+		params[offset+3] = e.IsRevoked
+		params[offset+4] = e.ExpireTime
+		offset += 5
 	}
 
 	return q.dao.db.Exec(ctx, tx, query.String(), params...)
@@ -524,33 +708,36 @@ func (q *AccessTokenQuery) BatchInsert(ctx context.Context, tx *wrap.Tx, list []
 
 func (q *AccessTokenQuery) InsertOnDuplicatedKeyUpdate(ctx context.Context, tx *wrap.Tx, e *AccessToken) (result *wrap.Result, err error) {
 	query := bytes.NewBufferString("")
-	query.WriteString("INSERT INTO access_token (user_id,access_token) VALUES (?,?)")
+	query.WriteString("INSERT INTO access_token (user_id,jti,session_id,is_revoked,expire_time) VALUES (?,?,?,?,?)")
 	query.WriteString(" ON DUPLICATED KEY UPDATE ")
 	if len(q.duplicatedUpdateFields) > 0 {
 		query.WriteString(strings.Join(q.duplicatedUpdateFields, ","))
 		query.WriteString(",")
 	}
 	query.WriteString("update_time=now()")
-	params := []interface{}{e.UserId, e.AccessToken}
+	params := []interface{}{e.UserId, e.Jti, e.SessionId, e.IsRevoked, e.ExpireTime}
 	return q.dao.db.Exec(ctx, tx, query.String(), params...)
 }
 
 func (q *AccessTokenQuery) BatchInsertOnDuplicatedKeyUpdate(ctx context.Context, tx *wrap.Tx, list []*AccessToken) (result *wrap.Result, err error) {
 	query := bytes.NewBufferString("")
-	query.WriteString("INSERT INTO access_token (user_id,access_token) VALUES ")
-	query.WriteString(wrap.RepeatWithSeparator("(?,?)", len(list), ","))
+	query.WriteString("INSERT INTO access_token (user_id,jti,session_id,is_revoked,expire_time) VALUES ")
+	query.WriteString(wrap.RepeatWithSeparator("(?,?,?,?,?)", len(list), ","))
 	query.WriteString(" ON DUPLICATED KEY UPDATE")
 	if len(q.duplicatedUpdateFields) > 0 {
 		query.WriteString(strings.Join(q.duplicatedUpdateFields, ","))
 		query.WriteString(",")
 	}
 	query.WriteString("update_time=now()")
-	params := make([]interface{}, len(list)*2)
+	params := make([]interface{}, len(list)*5)
 	offset := 0
 	for _, e := range list {
 		params[offset+0] = e.UserId
-		params[offset+1] = e.AccessToken
-		offset += 2
+		params[offset+1] = e.Jti
+		params[offset+2] = e.SessionId
+		params[offset+3] = e.IsRevoked
+		params[offset+4] = e.ExpireTime
+		offset += 5
 	}
 
 	return q.dao.db.Exec(ctx, tx, query.String(), params...)
@@ -611,6 +798,8 @@ type AccountOperation struct {
 	PhoneEncrypted string //size=32
 	SmsScene       string //size=32
 	OtherUserId    string //size=32
+	ActorId        string //size=64
+	Detail         string //size=256
 	CreateTime     time.Time
 }
 
@@ -808,6 +997,46 @@ func (q *AccountOperationQuery) OtherUserIdIn(items []string) *AccountOperationQ
 	return q
 }
 
+func (q *AccountOperationQuery) ActorIdEqual(v string) *AccountOperationQuery {
+	q.where.WriteString(" actor_id=?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *AccountOperationQuery) ActorIdNotEqual(v string) *AccountOperationQuery {
+	q.where.WriteString(" actor_id<>?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *AccountOperationQuery) ActorIdIn(items []string) *AccountOperationQuery {
+	q.where.WriteString(" actor_id IN(")
+	q.where.WriteString(wrap.RepeatWithSeparator("?", len(items), ","))
+	q.where.WriteString(")")
+	q.whereParams = append(q.whereParams, items)
+	return q
+}
+
+func (q *AccountOperationQuery) DetailEqual(v string) *AccountOperationQuery {
+	q.where.WriteString(" detail=?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *AccountOperationQuery) DetailNotEqual(v string) *AccountOperationQuery {
+	q.where.WriteString(" detail<>?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *AccountOperationQuery) DetailIn(items []string) *AccountOperationQuery {
+	q.where.WriteString(" detail IN(")
+	q.where.WriteString(wrap.RepeatWithSeparator("?", len(items), ","))
+	q.where.WriteString(")")
+	q.whereParams = append(q.whereParams, items)
+	return q
+}
+
 func (q *AccountOperationQuery) CreateTimeEqual(v time.Time) *AccountOperationQuery {
 	q.where.WriteString(" create_time=?")
 	q.whereParams = append(q.whereParams, v)
@@ -880,6 +1109,18 @@ func (q *AccountOperationQuery) GroupByOtherUserId(asc bool) *AccountOperationQu
 	return q
 }
 
+func (q *AccountOperationQuery) GroupByActorId(asc bool) *AccountOperationQuery {
+	q.groupByFields = append(q.groupByFields, "actor_id")
+	q.groupByOrders = append(q.groupByOrders, asc)
+	return q
+}
+
+func (q *AccountOperationQuery) GroupByDetail(asc bool) *AccountOperationQuery {
+	q.groupByFields = append(q.groupByFields, "detail")
+	q.groupByOrders = append(q.groupByOrders, asc)
+	return q
+}
+
 func (q *AccountOperationQuery) OrderById(asc bool) *AccountOperationQuery {
 	q.orderByFields = append(q.orderByFields, "id")
 	q.orderByOrders = append(q.orderByOrders, asc)
@@ -922,6 +1163,18 @@ func (q *AccountOperationQuery) OrderByOtherUserId(asc bool) *AccountOperationQu
 	return q
 }
 
+func (q *AccountOperationQuery) OrderByActorId(asc bool) *AccountOperationQuery {
+	q.orderByFields = append(q.orderByFields, "actor_id")
+	q.orderByOrders = append(q.orderByOrders, asc)
+	return q
+}
+
+func (q *AccountOperationQuery) OrderByDetail(asc bool) *AccountOperationQuery {
+	q.orderByFields = append(q.orderByFields, "detail")
+	q.orderByOrders = append(q.orderByOrders, asc)
+	return q
+}
+
 func (q *AccountOperationQuery) OrderByCreateTime(asc bool) *AccountOperationQuery {
 	q.orderByFields = append(q.orderByFields, "create_time")
 	q.orderByOrders = append(q.orderByOrders, asc)
@@ -987,6 +1240,18 @@ func (q *AccountOperationQuery) SetOtherUserId(v string) *AccountOperationQuery
 	return q
 }
 
+func (q *AccountOperationQuery) SetActorId(v string) *AccountOperationQuery {
+	q.updateFields = append(q.updateFields, "actor_id")
+	q.updateParams = append(q.updateParams, v)
+	return q
+}
+
+func (q *AccountOperationQuery) SetDetail(v string) *AccountOperationQuery {
+	q.updateFields = append(q.updateFields, "detail")
+	q.updateParams = append(q.updateParams, v)
+	return q
+}
+
 func (q *AccountOperationQuery) DuplicatedUpdateUserId() *AccountOperationQuery {
 	q.duplicatedUpdateFields = append(q.duplicatedUpdateFields, "user_id=VALUES(user_id)")
 	return q
@@ -1017,6 +1282,16 @@ func (q *AccountOperationQuery) DuplicatedUpdateOtherUserId() *AccountOperationQ
 	return q
 }
 
+func (q *AccountOperationQuery) DuplicatedUpdateActorId() *AccountOperationQuery {
+	q.duplicatedUpdateFields = append(q.duplicatedUpdateFields, "actor_id=VALUES(actor_id)")
+	return q
+}
+
+func (q *AccountOperationQuery) DuplicatedUpdateDetail() *AccountOperationQuery {
+	q.duplicatedUpdateFields = append(q.duplicatedUpdateFields, "detail=VALUES(detail)")
+	return q
+}
+
 func (q *AccountOperationQuery) GetId() *AccountOperationQuery {
 	q.getFields = append(q.getFields, "id")
 	return q
@@ -1052,6 +1327,16 @@ func (q *AccountOperationQuery) GetOtherUserId() *AccountOperationQuery {
 	return q
 }
 
+func (q *AccountOperationQuery) GetActorId() *AccountOperationQuery {
+	q.getFields = append(q.getFields, "actor_id")
+	return q
+}
+
+func (q *AccountOperationQuery) GetDetail() *AccountOperationQuery {
+	q.getFields = append(q.getFields, "detail")
+	return q
+}
+
 func (q *AccountOperationQuery) GetCreateTime() *AccountOperationQuery {
 	q.getFields = append(q.getFields, "create_time")
 	return q
@@ -1066,7 +1351,7 @@ func (q *AccountOperationQuery) Select(ctx context.Context, tx *wrap.Tx) (e *Acc
 	queryString, params := q.buildSelectQuery()
 	query := bytes.NewBufferString("")
 	if len(q.getFields) == 0 {
-		query.WriteString("SELECT id,user_id,operationType,user_agent,phone_encrypted,sms_scene,other_user_id,create_time FROM account_operation ")
+		query.WriteString("SELECT id,user_id,operationType,user_agent,phone_encrypted,sms_scene,other_user_id,actor_id,detail,create_time FROM account_operation ")
 	} else {
 		query.WriteString("SELECT ")
 		query.WriteString(strings.Join(q.getFields, ","))
@@ -1075,7 +1360,7 @@ func (q *AccountOperationQuery) Select(ctx context.Context, tx *wrap.Tx) (e *Acc
 	query.WriteString(queryString)
 	e = &AccountOperation{}
 	row := q.dao.db.QueryRow(ctx, tx, query.String(), params...)
-	err = row.Scan(&e.Id, &e.UserId, &e.OperationType, &e.UserAgent, &e.PhoneEncrypted, &e.SmsScene, &e.OtherUserId, &e.CreateTime)
+	err = row.Scan(&e.Id, &e.UserId, &e.OperationType, &e.UserAgent, &e.PhoneEncrypted, &e.SmsScene, &e.OtherUserId, &e.ActorId, &e.Detail, &e.CreateTime)
 	if err == wrap.ErrNoRows {
 		return nil, nil
 	}
@@ -1087,7 +1372,7 @@ func (q *AccountOperationQuery) SelectList(ctx context.Context, tx *wrap.Tx) (li
 	queryString, params := q.buildSelectQuery()
 	query := bytes.NewBufferString("")
 	if len(q.getFields) == 0 {
-		query.WriteString("SELECT id,user_id,operationType,user_agent,phone_encrypted,sms_scene,other_user_id,create_time FROM account_operation ")
+		query.WriteString("SELECT id,user_id,operationType,user_agent,phone_encrypted,sms_scene,other_user_id,actor_id,detail,create_time FROM account_operation ")
 	} else {
 		query.WriteString("SELECT ")
 		query.WriteString(strings.Join(q.getFields, ","))
@@ -1100,7 +1385,7 @@ func (q *AccountOperationQuery) SelectList(ctx context.Context, tx *wrap.Tx) (li
 	}
 	for rows.Next() {
 		e := AccountOperation{}
-		err = rows.Scan(&e.Id, &e.UserId, &e.OperationType, &e.UserAgent, &e.PhoneEncrypted, &e.SmsScene, &e.OtherUserId, &e.CreateTime)
+		err = rows.Scan(&e.Id, &e.UserId, &e.OperationType, &e.UserAgent, &e.PhoneEncrypted, &e.SmsScene, &e.OtherUserId, &e.ActorId, &e.Detail, &e.CreateTime)
 		if err != nil {
 			return nil, err
 		}
@@ -1166,16 +1451,16 @@ func (q *AccountOperationQuery) SelectRows(ctx context.Context, tx *wrap.Tx) (ro
 
 func (q *AccountOperationQuery) Insert(ctx context.Context, tx *wrap.Tx, e *AccountOperation) (result *wrap.Result, err error) {
 	query := bytes.NewBufferString("")
-	query.WriteString("INSERT INTO account_operation (user_id,operationType,user_agent,phone_encrypted,sms_scene,other_user_id) VALUES (?,?,?,?,?,?)")
-	params := []interface{}{e.UserId, e.OperationType, e.UserAgent, e.PhoneEncrypted, e.SmsScene, e.OtherUserId}
+	query.WriteString("INSERT INTO account_operation (user_id,operationType,user_agent,phone_encrypted,sms_scene,other_user_id,actor_id,detail) VALUES (?,?,?,?,?,?,?,?)")
+	params := []interface{}{e.UserId, e.OperationType, e.UserAgent, e.PhoneEncrypted, e.SmsScene, e.OtherUserId, e.ActorId, e.Detail}
 	return q.dao.db.Exec(ctx, tx, query.String(), params...)
 }
 
 func (q *AccountOperationQuery) BatchInsert(ctx context.Context, tx *wrap.Tx, list []*AccountOperation) (result *wrap.Result, err error) {
 	query := bytes.NewBufferString("")
-	query.WriteString("INSERT INTO account_operation (user_id,operationType,user_agent,phone_encrypted,sms_scene,other_user_id) VALUES ")
-	query.WriteString(wrap.RepeatWithSeparator("(?,?,?,?,?,?)", len(list), ","))
-	params := make([]interface{}, len(list)*6)
+	query.WriteString("INSERT INTO account_operation (user_id,operationType,user_agent,phone_encrypted,sms_scene,other_user_id,actor_id,detail) VALUES ")
+	query.WriteString(wrap.RepeatWithSeparator("(?,?,?,?,?,?,?,?)", len(list), ","))
+	params := make([]interface{}, len(list)*8)
 	offset := 0
 	for _, e := range list {
 		params[offset+0] = e.UserId
@@ -1184,7 +1469,9 @@ func (q *AccountOperationQuery) BatchInsert(ctx context.Context, tx *wrap.Tx, li
 		params[offset+3] = e.PhoneEncrypted
 		params[offset+4] = e.SmsScene
 		params[offset+5] = e.OtherUserId
-		offset += 6
+		params[offset+6] = e.ActorId
+		params[offset+7] = e.Detail
+		offset += 8
 	}
 
 	return q.dao.db.Exec(ctx, tx, query.String(), params...)
@@ -1237,85 +1524,82 @@ func (dao *AccountOperationDao) Query() *AccountOperationQuery {
 	return q
 }
 
-type OauthAccount struct {
-	Id           uint64 //size=20
-	UserId       string //size=32
-	ProviderId   string //size=32
-	ProviderName string //size=32
-	OpenId       string //size=128
-	UserName     string //size=32
-	UserIcon     string //size=256
-	CreateTime   time.Time
-	UpdateTime   time.Time
+type Counter struct {
+	Id         uint64 //size=20
+	CounterKey string //size=255
+	Count      int64  //size=20
+	ExpireTime time.Time
+	CreateTime time.Time
+	UpdateTime time.Time
 }
 
-type OauthAccountQuery struct {
+type CounterQuery struct {
 	QueryBase
-	dao *OauthAccountDao
+	dao *CounterDao
 }
 
-func (q *OauthAccountQuery) Left() *OauthAccountQuery {
+func (q *CounterQuery) Left() *CounterQuery {
 	q.where.WriteString(" (")
 	return q
 }
 
-func (q *OauthAccountQuery) Right() *OauthAccountQuery {
+func (q *CounterQuery) Right() *CounterQuery {
 	q.where.WriteString(" )")
 	return q
 }
 
-func (q *OauthAccountQuery) And() *OauthAccountQuery {
+func (q *CounterQuery) And() *CounterQuery {
 	q.where.WriteString(" AND")
 	return q
 }
 
-func (q *OauthAccountQuery) Or() *OauthAccountQuery {
+func (q *CounterQuery) Or() *CounterQuery {
 	q.where.WriteString(" OR")
 	return q
 }
 
-func (q *OauthAccountQuery) Not() *OauthAccountQuery {
+func (q *CounterQuery) Not() *CounterQuery {
 	q.where.WriteString(" NOT")
 	return q
 }
 
-func (q *OauthAccountQuery) IdEqual(v uint64) *OauthAccountQuery {
+func (q *CounterQuery) IdEqual(v uint64) *CounterQuery {
 	q.where.WriteString(" id=?")
 	q.whereParams = append(q.whereParams, v)
 	return q
 }
 
-func (q *OauthAccountQuery) IdNotEqual(v uint64) *OauthAccountQuery {
+func (q *CounterQuery) IdNotEqual(v uint64) *CounterQuery {
 	q.where.WriteString(" id<>?")
 	q.whereParams = append(q.whereParams, v)
 	return q
 }
 
-func (q *OauthAccountQuery) IdLess(v uint64) *OauthAccountQuery {
+func (q *CounterQuery) IdLess(v uint64) *CounterQuery {
 	q.where.WriteString(" id<?")
 	q.whereParams = append(q.whereParams, v)
 	return q
 }
 
-func (q *OauthAccountQuery) IdLessEqual(v uint64) *OauthAccountQuery {
+func (q *CounterQuery) IdLessEqual(v uint64) *CounterQuery {
 	q.where.WriteString(" id<=?")
 	q.whereParams = append(q.whereParams, v)
 	return q
 }
 
-func (q *OauthAccountQuery) IdGreater(v uint64) *OauthAccountQuery {
+func (q *CounterQuery) IdGreater(v uint64) *CounterQuery {
 	q.where.WriteString(" id>?")
 	q.whereParams = append(q.whereParams, v)
 	return q
 }
 
-func (q *OauthAccountQuery) IdGreaterEqual(v uint64) *OauthAccountQuery {
+func (q *CounterQuery) IdGreaterEqual(v uint64) *CounterQuery {
 	q.where.WriteString(" id>=?")
 	q.whereParams = append(q.whereParams, v)
 	return q
 }
 
-func (q *OauthAccountQuery) IdIn(items []uint64) *OauthAccountQuery {
+func (q *CounterQuery) IdIn(items []uint64) *CounterQuery {
 	q.where.WriteString(" id IN(")
 	q.where.WriteString(wrap.RepeatWithSeparator("?", len(items), ","))
 	q.where.WriteString(")")
@@ -1323,413 +1607,302 @@ func (q *OauthAccountQuery) IdIn(items []uint64) *OauthAccountQuery {
 	return q
 }
 
-func (q *OauthAccountQuery) UserIdEqual(v string) *OauthAccountQuery {
-	q.where.WriteString(" user_id=?")
+func (q *CounterQuery) CounterKeyEqual(v string) *CounterQuery {
+	q.where.WriteString(" counter_key=?")
 	q.whereParams = append(q.whereParams, v)
 	return q
 }
 
-func (q *OauthAccountQuery) UserIdNotEqual(v string) *OauthAccountQuery {
-	q.where.WriteString(" user_id<>?")
+func (q *CounterQuery) CounterKeyNotEqual(v string) *CounterQuery {
+	q.where.WriteString(" counter_key<>?")
 	q.whereParams = append(q.whereParams, v)
 	return q
 }
 
-func (q *OauthAccountQuery) UserIdIn(items []string) *OauthAccountQuery {
-	q.where.WriteString(" user_id IN(")
+func (q *CounterQuery) CounterKeyIn(items []string) *CounterQuery {
+	q.where.WriteString(" counter_key IN(")
 	q.where.WriteString(wrap.RepeatWithSeparator("?", len(items), ","))
 	q.where.WriteString(")")
 	q.whereParams = append(q.whereParams, items)
 	return q
 }
 
-func (q *OauthAccountQuery) ProviderIdEqual(v string) *OauthAccountQuery {
-	q.where.WriteString(" providerId=?")
+func (q *CounterQuery) CountEqual(v int64) *CounterQuery {
+	q.where.WriteString(" count=?")
 	q.whereParams = append(q.whereParams, v)
 	return q
 }
 
-func (q *OauthAccountQuery) ProviderIdNotEqual(v string) *OauthAccountQuery {
-	q.where.WriteString(" providerId<>?")
+func (q *CounterQuery) CountNotEqual(v int64) *CounterQuery {
+	q.where.WriteString(" count<>?")
 	q.whereParams = append(q.whereParams, v)
 	return q
 }
 
-func (q *OauthAccountQuery) ProviderIdIn(items []string) *OauthAccountQuery {
-	q.where.WriteString(" providerId IN(")
-	q.where.WriteString(wrap.RepeatWithSeparator("?", len(items), ","))
-	q.where.WriteString(")")
-	q.whereParams = append(q.whereParams, items)
+func (q *CounterQuery) CountLess(v int64) *CounterQuery {
+	q.where.WriteString(" count<?")
+	q.whereParams = append(q.whereParams, v)
 	return q
 }
 
-func (q *OauthAccountQuery) ProviderNameEqual(v string) *OauthAccountQuery {
-	q.where.WriteString(" provider_name=?")
+func (q *CounterQuery) CountLessEqual(v int64) *CounterQuery {
+	q.where.WriteString(" count<=?")
 	q.whereParams = append(q.whereParams, v)
 	return q
 }
 
-func (q *OauthAccountQuery) ProviderNameNotEqual(v string) *OauthAccountQuery {
-	q.where.WriteString(" provider_name<>?")
+func (q *CounterQuery) CountGreater(v int64) *CounterQuery {
+	q.where.WriteString(" count>?")
 	q.whereParams = append(q.whereParams, v)
 	return q
 }
 
-func (q *OauthAccountQuery) ProviderNameIn(items []string) *OauthAccountQuery {
-	q.where.WriteString(" provider_name IN(")
+func (q *CounterQuery) CountGreaterEqual(v int64) *CounterQuery {
+	q.where.WriteString(" count>=?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *CounterQuery) CountIn(items []int64) *CounterQuery {
+	q.where.WriteString(" count IN(")
 	q.where.WriteString(wrap.RepeatWithSeparator("?", len(items), ","))
 	q.where.WriteString(")")
 	q.whereParams = append(q.whereParams, items)
 	return q
 }
 
-func (q *OauthAccountQuery) OpenIdEqual(v string) *OauthAccountQuery {
-	q.where.WriteString(" open_id=?")
+func (q *CounterQuery) ExpireTimeEqual(v time.Time) *CounterQuery {
+	q.where.WriteString(" expire_time=?")
 	q.whereParams = append(q.whereParams, v)
 	return q
 }
 
-func (q *OauthAccountQuery) OpenIdNotEqual(v string) *OauthAccountQuery {
-	q.where.WriteString(" open_id<>?")
+func (q *CounterQuery) ExpireTimeNotEqual(v time.Time) *CounterQuery {
+	q.where.WriteString(" expire_time<>?")
 	q.whereParams = append(q.whereParams, v)
 	return q
 }
 
-func (q *OauthAccountQuery) OpenIdIn(items []string) *OauthAccountQuery {
-	q.where.WriteString(" open_id IN(")
-	q.where.WriteString(wrap.RepeatWithSeparator("?", len(items), ","))
-	q.where.WriteString(")")
-	q.whereParams = append(q.whereParams, items)
-	return q
-}
-
-func (q *OauthAccountQuery) UserNameEqual(v string) *OauthAccountQuery {
-	q.where.WriteString(" user_name=?")
+func (q *CounterQuery) ExpireTimeLess(v time.Time) *CounterQuery {
+	q.where.WriteString(" expire_time<?")
 	q.whereParams = append(q.whereParams, v)
 	return q
 }
 
-func (q *OauthAccountQuery) UserNameNotEqual(v string) *OauthAccountQuery {
-	q.where.WriteString(" user_name<>?")
+func (q *CounterQuery) ExpireTimeLessEqual(v time.Time) *CounterQuery {
+	q.where.WriteString(" expire_time<=?")
 	q.whereParams = append(q.whereParams, v)
 	return q
 }
 
-func (q *OauthAccountQuery) UserNameIn(items []string) *OauthAccountQuery {
-	q.where.WriteString(" user_name IN(")
-	q.where.WriteString(wrap.RepeatWithSeparator("?", len(items), ","))
-	q.where.WriteString(")")
-	q.whereParams = append(q.whereParams, items)
-	return q
-}
-
-func (q *OauthAccountQuery) UserIconEqual(v string) *OauthAccountQuery {
-	q.where.WriteString(" user_icon=?")
+func (q *CounterQuery) ExpireTimeGreater(v time.Time) *CounterQuery {
+	q.where.WriteString(" expire_time>?")
 	q.whereParams = append(q.whereParams, v)
 	return q
 }
 
-func (q *OauthAccountQuery) UserIconNotEqual(v string) *OauthAccountQuery {
-	q.where.WriteString(" user_icon<>?")
+func (q *CounterQuery) ExpireTimeGreaterEqual(v time.Time) *CounterQuery {
+	q.where.WriteString(" expire_time>=?")
 	q.whereParams = append(q.whereParams, v)
 	return q
 }
 
-func (q *OauthAccountQuery) UserIconIn(items []string) *OauthAccountQuery {
-	q.where.WriteString(" user_icon IN(")
-	q.where.WriteString(wrap.RepeatWithSeparator("?", len(items), ","))
-	q.where.WriteString(")")
-	q.whereParams = append(q.whereParams, items)
-	return q
-}
-
-func (q *OauthAccountQuery) CreateTimeEqual(v time.Time) *OauthAccountQuery {
+func (q *CounterQuery) CreateTimeEqual(v time.Time) *CounterQuery {
 	q.where.WriteString(" create_time=?")
 	q.whereParams = append(q.whereParams, v)
 	return q
 }
 
-func (q *OauthAccountQuery) CreateTimeNotEqual(v time.Time) *OauthAccountQuery {
+func (q *CounterQuery) CreateTimeNotEqual(v time.Time) *CounterQuery {
 	q.where.WriteString(" create_time<>?")
 	q.whereParams = append(q.whereParams, v)
 	return q
 }
 
-func (q *OauthAccountQuery) CreateTimeLess(v time.Time) *OauthAccountQuery {
+func (q *CounterQuery) CreateTimeLess(v time.Time) *CounterQuery {
 	q.where.WriteString(" create_time<?")
 	q.whereParams = append(q.whereParams, v)
 	return q
 }
 
-func (q *OauthAccountQuery) CreateTimeLessEqual(v time.Time) *OauthAccountQuery {
+func (q *CounterQuery) CreateTimeLessEqual(v time.Time) *CounterQuery {
 	q.where.WriteString(" create_time<=?")
 	q.whereParams = append(q.whereParams, v)
 	return q
 }
 
-func (q *OauthAccountQuery) CreateTimeGreater(v time.Time) *OauthAccountQuery {
+func (q *CounterQuery) CreateTimeGreater(v time.Time) *CounterQuery {
 	q.where.WriteString(" create_time>?")
 	q.whereParams = append(q.whereParams, v)
 	return q
 }
 
-func (q *OauthAccountQuery) CreateTimeGreaterEqual(v time.Time) *OauthAccountQuery {
+func (q *CounterQuery) CreateTimeGreaterEqual(v time.Time) *CounterQuery {
 	q.where.WriteString(" create_time>=?")
 	q.whereParams = append(q.whereParams, v)
 	return q
 }
 
-func (q *OauthAccountQuery) UpdateTimeEqual(v time.Time) *OauthAccountQuery {
+func (q *CounterQuery) UpdateTimeEqual(v time.Time) *CounterQuery {
 	q.where.WriteString(" update_time=?")
 	q.whereParams = append(q.whereParams, v)
 	return q
 }
 
-func (q *OauthAccountQuery) UpdateTimeNotEqual(v time.Time) *OauthAccountQuery {
+func (q *CounterQuery) UpdateTimeNotEqual(v time.Time) *CounterQuery {
 	q.where.WriteString(" update_time<>?")
 	q.whereParams = append(q.whereParams, v)
 	return q
 }
 
-func (q *OauthAccountQuery) UpdateTimeLess(v time.Time) *OauthAccountQuery {
+func (q *CounterQuery) UpdateTimeLess(v time.Time) *CounterQuery {
 	q.where.WriteString(" update_time<?")
 	q.whereParams = append(q.whereParams, v)
 	return q
 }
 
-func (q *OauthAccountQuery) UpdateTimeLessEqual(v time.Time) *OauthAccountQuery {
+func (q *CounterQuery) UpdateTimeLessEqual(v time.Time) *CounterQuery {
 	q.where.WriteString(" update_time<=?")
 	q.whereParams = append(q.whereParams, v)
 	return q
 }
 
-func (q *OauthAccountQuery) UpdateTimeGreater(v time.Time) *OauthAccountQuery {
+func (q *CounterQuery) UpdateTimeGreater(v time.Time) *CounterQuery {
 	q.where.WriteString(" update_time>?")
 	q.whereParams = append(q.whereParams, v)
 	return q
 }
 
-func (q *OauthAccountQuery) UpdateTimeGreaterEqual(v time.Time) *OauthAccountQuery {
+func (q *CounterQuery) UpdateTimeGreaterEqual(v time.Time) *CounterQuery {
 	q.where.WriteString(" update_time>=?")
 	q.whereParams = append(q.whereParams, v)
 	return q
 }
 
-func (q *OauthAccountQuery) GroupByUserId(asc bool) *OauthAccountQuery {
-	q.groupByFields = append(q.groupByFields, "user_id")
-	q.groupByOrders = append(q.groupByOrders, asc)
-	return q
-}
-
-func (q *OauthAccountQuery) GroupByProviderId(asc bool) *OauthAccountQuery {
-	q.groupByFields = append(q.groupByFields, "providerId")
-	q.groupByOrders = append(q.groupByOrders, asc)
-	return q
-}
-
-func (q *OauthAccountQuery) GroupByProviderName(asc bool) *OauthAccountQuery {
-	q.groupByFields = append(q.groupByFields, "provider_name")
-	q.groupByOrders = append(q.groupByOrders, asc)
-	return q
-}
-
-func (q *OauthAccountQuery) GroupByOpenId(asc bool) *OauthAccountQuery {
-	q.groupByFields = append(q.groupByFields, "open_id")
-	q.groupByOrders = append(q.groupByOrders, asc)
-	return q
-}
-
-func (q *OauthAccountQuery) GroupByUserName(asc bool) *OauthAccountQuery {
-	q.groupByFields = append(q.groupByFields, "user_name")
-	q.groupByOrders = append(q.groupByOrders, asc)
-	return q
-}
-
-func (q *OauthAccountQuery) GroupByUserIcon(asc bool) *OauthAccountQuery {
-	q.groupByFields = append(q.groupByFields, "user_icon")
+func (q *CounterQuery) GroupByCount(asc bool) *CounterQuery {
+	q.groupByFields = append(q.groupByFields, "count")
 	q.groupByOrders = append(q.groupByOrders, asc)
 	return q
 }
 
-func (q *OauthAccountQuery) OrderById(asc bool) *OauthAccountQuery {
+func (q *CounterQuery) OrderById(asc bool) *CounterQuery {
 	q.orderByFields = append(q.orderByFields, "id")
 	q.orderByOrders = append(q.orderByOrders, asc)
 	return q
 }
 
-func (q *OauthAccountQuery) OrderByUserId(asc bool) *OauthAccountQuery {
-	q.orderByFields = append(q.orderByFields, "user_id")
-	q.orderByOrders = append(q.orderByOrders, asc)
-	return q
-}
-
-func (q *OauthAccountQuery) OrderByProviderId(asc bool) *OauthAccountQuery {
-	q.orderByFields = append(q.orderByFields, "providerId")
-	q.orderByOrders = append(q.orderByOrders, asc)
-	return q
-}
-
-func (q *OauthAccountQuery) OrderByProviderName(asc bool) *OauthAccountQuery {
-	q.orderByFields = append(q.orderByFields, "provider_name")
-	q.orderByOrders = append(q.orderByOrders, asc)
-	return q
-}
-
-func (q *OauthAccountQuery) OrderByOpenId(asc bool) *OauthAccountQuery {
-	q.orderByFields = append(q.orderByFields, "open_id")
+func (q *CounterQuery) OrderByCounterKey(asc bool) *CounterQuery {
+	q.orderByFields = append(q.orderByFields, "counter_key")
 	q.orderByOrders = append(q.orderByOrders, asc)
 	return q
 }
 
-func (q *OauthAccountQuery) OrderByUserName(asc bool) *OauthAccountQuery {
-	q.orderByFields = append(q.orderByFields, "user_name")
+func (q *CounterQuery) OrderByCount(asc bool) *CounterQuery {
+	q.orderByFields = append(q.orderByFields, "count")
 	q.orderByOrders = append(q.orderByOrders, asc)
 	return q
 }
 
-func (q *OauthAccountQuery) OrderByUserIcon(asc bool) *OauthAccountQuery {
-	q.orderByFields = append(q.orderByFields, "user_icon")
+func (q *CounterQuery) OrderByExpireTime(asc bool) *CounterQuery {
+	q.orderByFields = append(q.orderByFields, "expire_time")
 	q.orderByOrders = append(q.orderByOrders, asc)
 	return q
 }
 
-func (q *OauthAccountQuery) OrderByCreateTime(asc bool) *OauthAccountQuery {
+func (q *CounterQuery) OrderByCreateTime(asc bool) *CounterQuery {
 	q.orderByFields = append(q.orderByFields, "create_time")
 	q.orderByOrders = append(q.orderByOrders, asc)
 	return q
 }
 
-func (q *OauthAccountQuery) OrderByUpdateTime(asc bool) *OauthAccountQuery {
+func (q *CounterQuery) OrderByUpdateTime(asc bool) *CounterQuery {
 	q.orderByFields = append(q.orderByFields, "update_time")
 	q.orderByOrders = append(q.orderByOrders, asc)
 	return q
 }
 
-func (q *OauthAccountQuery) OrderByGroupCount(asc bool) *OauthAccountQuery {
+func (q *CounterQuery) OrderByGroupCount(asc bool) *CounterQuery {
 	q.orderByFields = append(q.orderByFields, "count(*)")
 	q.orderByOrders = append(q.orderByOrders, asc)
 	return q
 }
 
-func (q *OauthAccountQuery) Limit(startIncluded int64, count int64) *OauthAccountQuery {
+func (q *CounterQuery) Limit(startIncluded int64, count int64) *CounterQuery {
 	q.hasLimit = true
 	q.limitStartIncluded = startIncluded
 	q.limitCount = count
 	return q
 }
 
-func (q *OauthAccountQuery) ForUpdate() *OauthAccountQuery {
+func (q *CounterQuery) ForUpdate() *CounterQuery {
 	q.forUpdate = true
 	return q
 }
 
-func (q *OauthAccountQuery) ForShare() *OauthAccountQuery {
+func (q *CounterQuery) ForShare() *CounterQuery {
 	q.forShare = true
 	return q
 }
 
-func (q *OauthAccountQuery) SetUserId(v string) *OauthAccountQuery {
-	q.updateFields = append(q.updateFields, "user_id")
-	q.updateParams = append(q.updateParams, v)
-	return q
-}
-
-func (q *OauthAccountQuery) SetProviderId(v string) *OauthAccountQuery {
-	q.updateFields = append(q.updateFields, "providerId")
-	q.updateParams = append(q.updateParams, v)
-	return q
-}
-
-func (q *OauthAccountQuery) SetProviderName(v string) *OauthAccountQuery {
-	q.updateFields = append(q.updateFields, "provider_name")
-	q.updateParams = append(q.updateParams, v)
-	return q
-}
-
-func (q *OauthAccountQuery) SetOpenId(v string) *OauthAccountQuery {
-	q.updateFields = append(q.updateFields, "open_id")
+func (q *CounterQuery) SetCounterKey(v string) *CounterQuery {
+	q.updateFields = append(q.updateFields, "counter_key")
 	q.updateParams = append(q.updateParams, v)
 	return q
 }
 
-func (q *OauthAccountQuery) SetUserName(v string) *OauthAccountQuery {
-	q.updateFields = append(q.updateFields, "user_name")
+func (q *CounterQuery) SetCount(v int64) *CounterQuery {
+	q.updateFields = append(q.updateFields, "count")
 	q.updateParams = append(q.updateParams, v)
 	return q
 }
 
-func (q *OauthAccountQuery) SetUserIcon(v string) *OauthAccountQuery {
-	q.updateFields = append(q.updateFields, "user_icon")
+func (q *CounterQuery) SetExpireTime(v time.Time) *CounterQuery {
+	q.updateFields = append(q.updateFields, "expire_time")
 	q.updateParams = append(q.updateParams, v)
 	return q
 }
 
-func (q *OauthAccountQuery) DuplicatedUpdateUserId() *OauthAccountQuery {
-	q.duplicatedUpdateFields = append(q.duplicatedUpdateFields, "user_id=VALUES(user_id)")
-	return q
-}
-
-func (q *OauthAccountQuery) DuplicatedUpdateProviderName() *OauthAccountQuery {
-	q.duplicatedUpdateFields = append(q.duplicatedUpdateFields, "provider_name=VALUES(provider_name)")
-	return q
-}
-
-func (q *OauthAccountQuery) DuplicatedUpdateUserName() *OauthAccountQuery {
-	q.duplicatedUpdateFields = append(q.duplicatedUpdateFields, "user_name=VALUES(user_name)")
+func (q *CounterQuery) DuplicatedUpdateCount() *CounterQuery {
+	q.duplicatedUpdateFields = append(q.duplicatedUpdateFields, "count=VALUES(count)")
 	return q
 }
 
-func (q *OauthAccountQuery) DuplicatedUpdateUserIcon() *OauthAccountQuery {
-	q.duplicatedUpdateFields = append(q.duplicatedUpdateFields, "user_icon=VALUES(user_icon)")
+func (q *CounterQuery) DuplicatedUpdateExpireTime() *CounterQuery {
+	q.duplicatedUpdateFields = append(q.duplicatedUpdateFields, "expire_time=VALUES(expire_time)")
 	return q
 }
 
-func (q *OauthAccountQuery) GetId() *OauthAccountQuery {
+func (q *CounterQuery) GetId() *CounterQuery {
 	q.getFields = append(q.getFields, "id")
 	return q
 }
 
-func (q *OauthAccountQuery) GetUserId() *OauthAccountQuery {
-	q.getFields = append(q.getFields, "user_id")
-	return q
-}
-
-func (q *OauthAccountQuery) GetProviderId() *OauthAccountQuery {
-	q.getFields = append(q.getFields, "providerId")
-	return q
-}
-
-func (q *OauthAccountQuery) GetProviderName() *OauthAccountQuery {
-	q.getFields = append(q.getFields, "provider_name")
-	return q
-}
-
-func (q *OauthAccountQuery) GetOpenId() *OauthAccountQuery {
-	q.getFields = append(q.getFields, "open_id")
+func (q *CounterQuery) GetCounterKey() *CounterQuery {
+	q.getFields = append(q.getFields, "counter_key")
 	return q
 }
 
-func (q *OauthAccountQuery) GetUserName() *OauthAccountQuery {
-	q.getFields = append(q.getFields, "user_name")
+func (q *CounterQuery) GetCount() *CounterQuery {
+	q.getFields = append(q.getFields, "count")
 	return q
 }
 
-func (q *OauthAccountQuery) GetUserIcon() *OauthAccountQuery {
-	q.getFields = append(q.getFields, "user_icon")
+func (q *CounterQuery) GetExpireTime() *CounterQuery {
+	q.getFields = append(q.getFields, "expire_time")
 	return q
 }
 
-func (q *OauthAccountQuery) GetCreateTime() *OauthAccountQuery {
+func (q *CounterQuery) GetCreateTime() *CounterQuery {
 	q.getFields = append(q.getFields, "create_time")
 	return q
 }
 
-func (q *OauthAccountQuery) GetUpdateTime() *OauthAccountQuery {
+func (q *CounterQuery) GetUpdateTime() *CounterQuery {
 	q.getFields = append(q.getFields, "update_time")
 	return q
 }
 
-func (q *OauthAccountQuery) Select(ctx context.Context, tx *wrap.Tx) (e *OauthAccount, err error) {
+func (q *CounterQuery) Select(ctx context.Context, tx *wrap.Tx) (e *Counter, err error) {
 	if !q.hasLimit {
 		q.limitCount = 1
 		q.hasLimit = true
@@ -1738,16 +1911,16 @@ func (q *OauthAccountQuery) Select(ctx context.Context, tx *wrap.Tx) (e *OauthAc
 	queryString, params := q.buildSelectQuery()
 	query := bytes.NewBufferString("")
 	if len(q.getFields) == 0 {
-		query.WriteString("SELECT id,user_id,providerId,provider_name,open_id,user_name,user_icon,create_time,update_time FROM oauth_account ")
+		query.WriteString("SELECT id,counter_key,count,expire_time,create_time,update_time FROM counter ")
 	} else {
 		query.WriteString("SELECT ")
 		query.WriteString(strings.Join(q.getFields, ","))
-		query.WriteString(" FROM oauth_account ")
+		query.WriteString(" FROM counter ")
 	}
 	query.WriteString(queryString)
-	e = &OauthAccount{}
+	e = &Counter{}
 	row := q.dao.db.QueryRow(ctx, tx, query.String(), params...)
-	err = row.Scan(&e.Id, &e.UserId, &e.ProviderId, &e.ProviderName, &e.OpenId, &e.UserName, &e.UserIcon, &e.CreateTime, &e.UpdateTime)
+	err = row.Scan(&e.Id, &e.CounterKey, &e.Count, &e.ExpireTime, &e.CreateTime, &e.UpdateTime)
 	if err == wrap.ErrNoRows {
 		return nil, nil
 	}
@@ -1755,15 +1928,15 @@ func (q *OauthAccountQuery) Select(ctx context.Context, tx *wrap.Tx) (e *OauthAc
 	return e, err
 }
 
-func (q *OauthAccountQuery) SelectList(ctx context.Context, tx *wrap.Tx) (list []*OauthAccount, err error) {
+func (q *CounterQuery) SelectList(ctx context.Context, tx *wrap.Tx) (list []*Counter, err error) {
 	queryString, params := q.buildSelectQuery()
 	query := bytes.NewBufferString("")
 	if len(q.getFields) == 0 {
-		query.WriteString("SELECT id,user_id,providerId,provider_name,open_id,user_name,user_icon,create_time,update_time FROM oauth_account ")
+		query.WriteString("SELECT id,counter_key,count,expire_time,create_time,update_time FROM counter ")
 	} else {
 		query.WriteString("SELECT ")
 		query.WriteString(strings.Join(q.getFields, ","))
-		query.WriteString(" FROM oauth_account ")
+		query.WriteString(" FROM counter ")
 	}
 	query.WriteString(queryString)
 	rows, err := q.dao.db.Query(ctx, tx, query.String(), params...)
@@ -1771,8 +1944,8 @@ func (q *OauthAccountQuery) SelectList(ctx context.Context, tx *wrap.Tx) (list [
 		return nil, err
 	}
 	for rows.Next() {
-		e := OauthAccount{}
-		err = rows.Scan(&e.Id, &e.UserId, &e.ProviderId, &e.ProviderName, &e.OpenId, &e.UserName, &e.UserIcon, &e.CreateTime, &e.UpdateTime)
+		e := Counter{}
+		err = rows.Scan(&e.Id, &e.CounterKey, &e.Count, &e.ExpireTime, &e.CreateTime, &e.UpdateTime)
 		if err != nil {
 			return nil, err
 		}
@@ -1786,10 +1959,10 @@ func (q *OauthAccountQuery) SelectList(ctx context.Context, tx *wrap.Tx) (list [
 	return list, nil
 }
 
-func (q *OauthAccountQuery) SelectCount(ctx context.Context, tx *wrap.Tx) (count int64, err error) {
+func (q *CounterQuery) SelectCount(ctx context.Context, tx *wrap.Tx) (count int64, err error) {
 	queryString, params := q.buildSelectQuery()
 	query := bytes.NewBufferString("")
-	query.WriteString("SELECT COUNT(*) FROM oauth_account ")
+	query.WriteString("SELECT COUNT(*) FROM counter ")
 	query.WriteString(queryString)
 	row := q.dao.db.QueryRow(ctx, tx, query.String(), params...)
 	err = row.Scan(&count)
@@ -1797,7 +1970,7 @@ func (q *OauthAccountQuery) SelectCount(ctx context.Context, tx *wrap.Tx) (count
 	return count, err
 }
 
-func (q *OauthAccountQuery) SelectGroupBy(ctx context.Context, tx *wrap.Tx, withCount bool) (rows *wrap.Rows, err error) {
+func (q *CounterQuery) SelectGroupBy(ctx context.Context, tx *wrap.Tx, withCount bool) (rows *wrap.Rows, err error) {
 	queryString, params := q.buildSelectQuery()
 	query := bytes.NewBufferString("")
 	query.WriteString("SELECT ")
@@ -1805,13 +1978,13 @@ func (q *OauthAccountQuery) SelectGroupBy(ctx context.Context, tx *wrap.Tx, with
 	if withCount {
 		query.WriteString(",MachineListCount(*) ")
 	}
-	query.WriteString(" FROM oauth_account ")
+	query.WriteString(" FROM counter ")
 	query.WriteString(queryString)
 
 	return q.dao.db.Query(ctx, tx, query.String(), params...)
 }
 
-func (q *OauthAccountQuery) SelectRow(ctx context.Context, tx *wrap.Tx) (row *wrap.Row) {
+func (q *CounterQuery) SelectRow(ctx context.Context, tx *wrap.Tx) (row *wrap.Row) {
 	if !q.hasLimit {
 		q.limitCount = 1
 		q.hasLimit = true
@@ -1821,90 +1994,84 @@ func (q *OauthAccountQuery) SelectRow(ctx context.Context, tx *wrap.Tx) (row *wr
 	query := bytes.NewBufferString("")
 	query.WriteString("SELECT ")
 	query.WriteString(strings.Join(q.getFields, ","))
-	query.WriteString(" FROM oauth_account ")
+	query.WriteString(" FROM counter ")
 	query.WriteString(queryString)
 	return q.dao.db.QueryRow(ctx, tx, query.String(), params...)
 }
 
-func (q *OauthAccountQuery) SelectRows(ctx context.Context, tx *wrap.Tx) (rows *wrap.Rows, err error) {
+func (q *CounterQuery) SelectRows(ctx context.Context, tx *wrap.Tx) (rows *wrap.Rows, err error) {
 	queryString, params := q.buildSelectQuery()
 	query := bytes.NewBufferString("")
 	query.WriteString("SELECT ")
 	query.WriteString(strings.Join(q.getFields, ","))
-	query.WriteString(" FROM oauth_account ")
+	query.WriteString(" FROM counter ")
 	query.WriteString(queryString)
 	return q.dao.db.Query(ctx, tx, query.String(), params...)
 }
 
-func (q *OauthAccountQuery) Insert(ctx context.Context, tx *wrap.Tx, e *OauthAccount) (result *wrap.Result, err error) {
+func (q *CounterQuery) Insert(ctx context.Context, tx *wrap.Tx, e *Counter) (result *wrap.Result, err error) {
 	query := bytes.NewBufferString("")
-	query.WriteString("INSERT INTO oauth_account (user_id,providerId,provider_name,open_id,user_name,user_icon) VALUES (?,?,?,?,?,?)")
-	params := []interface{}{e.UserId, e.ProviderId, e.ProviderName, e.OpenId, e.UserName, e.UserIcon}
+	query.WriteString("INSERT INTO counter (counter_key,count,expire_time) VALUES (?,?,?)")
+	params := []interface{}{e.CounterKey, e.Count, e.ExpireTime}
 	return q.dao.db.Exec(ctx, tx, query.String(), params...)
 }
 
-func (q *OauthAccountQuery) BatchInsert(ctx context.Context, tx *wrap.Tx, list []*OauthAccount) (result *wrap.Result, err error) {
+func (q *CounterQuery) BatchInsert(ctx context.Context, tx *wrap.Tx, list []*Counter) (result *wrap.Result, err error) {
 	query := bytes.NewBufferString("")
-	query.WriteString("INSERT INTO oauth_account (user_id,providerId,provider_name,open_id,user_name,user_icon) VALUES ")
-	query.WriteString(wrap.RepeatWithSeparator("(?,?,?,?,?,?)", len(list), ","))
-	params := make([]interface{}, len(list)*6)
+	query.WriteString("INSERT INTO counter (counter_key,count,expire_time) VALUES ")
+	query.WriteString(wrap.RepeatWithSeparator("(?,?,?)", len(list), ","))
+	params := make([]interface{}, len(list)*3)
 	offset := 0
 	for _, e := range list {
-		params[offset+0] = e.UserId
-		params[offset+1] = e.ProviderId
-		params[offset+2] = e.ProviderName
-		params[offset+3] = e.OpenId
-		params[offset+4] = e.UserName
-		params[offset+5] = e.UserIcon
-		offset += 6
+		params[offset+0] = e.CounterKey
+		params[offset+1] = e.Count
+		params[offset+2] = e.ExpireTime
+		offset += 3
 	}
 
 	return q.dao.db.Exec(ctx, tx, query.String(), params...)
 }
 
-func (q *OauthAccountQuery) InsertOnDuplicatedKeyUpdate(ctx context.Context, tx *wrap.Tx, e *OauthAccount) (result *wrap.Result, err error) {
+func (q *CounterQuery) InsertOnDuplicatedKeyUpdate(ctx context.Context, tx *wrap.Tx, e *Counter) (result *wrap.Result, err error) {
 	query := bytes.NewBufferString("")
-	query.WriteString("INSERT INTO oauth_account (user_id,providerId,provider_name,open_id,user_name,user_icon) VALUES (?,?,?,?,?,?)")
+	query.WriteString("INSERT INTO counter (counter_key,count,expire_time) VALUES (?,?,?)")
 	query.WriteString(" ON DUPLICATED KEY UPDATE ")
 	if len(q.duplicatedUpdateFields) > 0 {
 		query.WriteString(strings.Join(q.duplicatedUpdateFields, ","))
 		query.WriteString(",")
 	}
 	query.WriteString("update_time=now()")
-	params := []interface{}{e.UserId, e.ProviderId, e.ProviderName, e.OpenId, e.UserName, e.UserIcon}
+	params := []interface{}{e.CounterKey, e.Count, e.ExpireTime}
 	return q.dao.db.Exec(ctx, tx, query.String(), params...)
 }
 
-func (q *OauthAccountQuery) BatchInsertOnDuplicatedKeyUpdate(ctx context.Context, tx *wrap.Tx, list []*OauthAccount) (result *wrap.Result, err error) {
+func (q *CounterQuery) BatchInsertOnDuplicatedKeyUpdate(ctx context.Context, tx *wrap.Tx, list []*Counter) (result *wrap.Result, err error) {
 	query := bytes.NewBufferString("")
-	query.WriteString("INSERT INTO oauth_account (user_id,providerId,provider_name,open_id,user_name,user_icon) VALUES ")
-	query.WriteString(wrap.RepeatWithSeparator("(?,?,?,?,?,?)", len(list), ","))
+	query.WriteString("INSERT INTO counter (counter_key,count,expire_time) VALUES ")
+	query.WriteString(wrap.RepeatWithSeparator("(?,?,?)", len(list), ","))
 	query.WriteString(" ON DUPLICATED KEY UPDATE")
 	if len(q.duplicatedUpdateFields) > 0 {
 		query.WriteString(strings.Join(q.duplicatedUpdateFields, ","))
 		query.WriteString(",")
 	}
 	query.WriteString("update_time=now()")
-	params := make([]interface{}, len(list)*6)
+	params := make([]interface{}, len(list)*3)
 	offset := 0
 	for _, e := range list {
-		params[offset+0] = e.UserId
-		params[offset+1] = e.ProviderId
-		params[offset+2] = e.ProviderName
-		params[offset+3] = e.OpenId
-		params[offset+4] = e.UserName
-		params[offset+5] = e.UserIcon
-		offset += 6
+		params[offset+0] = e.CounterKey
+		params[offset+1] = e.Count
+		params[offset+2] = e.ExpireTime
+		offset += 3
 	}
 
 	return q.dao.db.Exec(ctx, tx, query.String(), params...)
 }
 
-func (q *OauthAccountQuery) Update(ctx context.Context, tx *wrap.Tx) (result *wrap.Result, err error) {
+func (q *CounterQuery) Update(ctx context.Context, tx *wrap.Tx) (result *wrap.Result, err error) {
 	query := bytes.NewBufferString("")
 	var params []interface{}
 	params = append(params, q.updateParams...)
-	query.WriteString("UPDATE oauth_account SET ")
+	query.WriteString("UPDATE counter SET ")
 	updateItems := make([]string, len(q.updateFields))
 	for i, v := range q.updateFields {
 		updateItems[i] = v + "=?"
@@ -1921,108 +2088,112 @@ func (q *OauthAccountQuery) Update(ctx context.Context, tx *wrap.Tx) (result *wr
 	return q.dao.db.Exec(ctx, tx, query.String(), params...)
 }
 
-func (q *OauthAccountQuery) Delete(ctx context.Context, tx *wrap.Tx) (result *wrap.Result, err error) {
-	query := "DELETE FROM oauth_account WHERE " + q.where.String()
+func (q *CounterQuery) Delete(ctx context.Context, tx *wrap.Tx) (result *wrap.Result, err error) {
+	query := "DELETE FROM counter WHERE " + q.where.String()
 	return q.dao.db.Exec(ctx, tx, query, q.whereParams...)
 }
 
-type OauthAccountDao struct {
+type CounterDao struct {
 	logger *zap.Logger
 	db     *DB
 }
 
-func NewOauthAccountDao(db *DB) (t *OauthAccountDao, err error) {
-	t = &OauthAccountDao{}
+func NewCounterDao(db *DB) (t *CounterDao, err error) {
+	t = &CounterDao{}
 	t.logger = log.TypedLogger(t)
 	t.db = db
 
 	return t, nil
 }
 
-func (dao *OauthAccountDao) Query() *OauthAccountQuery {
-	q := &OauthAccountQuery{}
+func (dao *CounterDao) Query() *CounterQuery {
+	q := &CounterQuery{}
 	q.dao = dao
-	q.tableName = "oauth_account"
+	q.tableName = "counter"
 	q.where = bytes.NewBufferString("")
 	return q
 }
 
-type OauthState struct {
-	Id         uint64 //size=20
-	OauthState string //size=128
-	IsUsed     int32  //size=1
-	UserAgent  string //size=256
-	CreateTime time.Time
-	UpdateTime time.Time
+type JwtKey struct {
+	Id            uint64 //size=20
+	Kid           string //size=32
+	Algorithm     string //size=16
+	PrivateKey    string //size=4096
+	PublicKey     string //size=1024
+	SignBeginTime time.Time
+	SignEndTime   time.Time
+	ExpireTime    time.Time
+	CreateTime    time.Time
+	UpdateTime    time.Time
 }
 
-type OauthStateQuery struct {
+type JwtKeyQuery struct {
 	QueryBase
-	dao *OauthStateDao
+	dao *JwtKeyDao
 }
 
-func (q *OauthStateQuery) Left() *OauthStateQuery {
+func (q *JwtKeyQuery) Left() *JwtKeyQuery {
 	q.where.WriteString(" (")
 	return q
 }
 
-func (q *OauthStateQuery) Right() *OauthStateQuery {
+func (q *JwtKeyQuery) Right() *JwtKeyQuery {
 	q.where.WriteString(" )")
 	return q
 }
 
-func (q *OauthStateQuery) And() *OauthStateQuery {
+func (q *JwtKeyQuery) And() *JwtKeyQuery {
 	q.where.WriteString(" AND")
 	return q
 }
 
-func (q *OauthStateQuery) Or() *OauthStateQuery {
+func (q *JwtKeyQuery) Or() *JwtKeyQuery {
 	q.where.WriteString(" OR")
 	return q
 }
 
-func (q *OauthStateQuery) Not() *OauthStateQuery {
+func (q *JwtKeyQuery) Not() *JwtKeyQuery {
 	q.where.WriteString(" NOT")
 	return q
 }
 
-func (q *OauthStateQuery) IdEqual(v uint64) *OauthStateQuery {
+func (q *JwtKeyQuery) IdEqual(v uint64) *JwtKeyQuery {
 	q.where.WriteString(" id=?")
 	q.whereParams = append(q.whereParams, v)
 	return q
 }
 
-func (q *OauthStateQuery) IdNotEqual(v uint64) *OauthStateQuery {
+func (q *JwtKeyQuery) IdNotEqual(v uint64) *JwtKeyQuery {
 	q.where.WriteString(" id<>?")
 	q.whereParams = append(q.whereParams, v)
 	return q
 }
 
-func (q *OauthStateQuery) IdLess(v uint64) *OauthStateQuery {
+func (q *JwtKeyQuery) IdLess(v uint64) *JwtKeyQuery {
 	q.where.WriteString(" id<?")
 	q.whereParams = append(q.whereParams, v)
 	return q
 }
 
-func (q *OauthStateQuery) IdLessEqual(v uint64) *OauthStateQuery {
+func (q *JwtKeyQuery) IdLessEqual(v uint64) *JwtKeyQuery {
 	q.where.WriteString(" id<=?")
 	q.whereParams = append(q.whereParams, v)
 	return q
 }
 
-func (q *OauthStateQuery) IdGreater(v uint64) *OauthStateQuery {
+func (q *JwtKeyQuery) IdGreater(v uint64) *JwtKeyQuery {
 	q.where.WriteString(" id>?")
 	q.whereParams = append(q.whereParams, v)
 	return q
 }
 
-func (q *OauthStateQuery) IdGreaterEqual(v uint64) *OauthStateQuery {
+func (q *JwtKeyQuery) IdGreaterEqual(v uint64) *JwtKeyQuery {
 	q.where.WriteString(" id>=?")
 	q.whereParams = append(q.whereParams, v)
 	return q
 }
 
-func (q *OauthStateQuery) IdIn(items []uint64) *OauthStateQuery {
+func (q *JwtKeyQuery) IdIn(items []uint64) *JwtKeyQuery {
 	q.where.WriteString(" id IN(")
 	q.where.WriteString(wrap.RepeatWithSeparator("?", len(items), ","))
 	q.where.WriteString(")")
@@ -2030,292 +2201,490 @@ func (q *OauthStateQuery) IdIn(items []uint64) *OauthStateQuery {
 	return q
 }
 
-func (q *OauthStateQuery) OauthStateEqual(v string) *OauthStateQuery {
-	q.where.WriteString(" oauth_state=?")
+func (q *JwtKeyQuery) KidEqual(v string) *JwtKeyQuery {
+	q.where.WriteString(" kid=?")
 	q.whereParams = append(q.whereParams, v)
 	return q
 }
 
-func (q *OauthStateQuery) OauthStateNotEqual(v string) *OauthStateQuery {
-	q.where.WriteString(" oauth_state<>?")
+func (q *JwtKeyQuery) KidNotEqual(v string) *JwtKeyQuery {
+	q.where.WriteString(" kid<>?")
 	q.whereParams = append(q.whereParams, v)
 	return q
 }
 
-func (q *OauthStateQuery) OauthStateIn(items []string) *OauthStateQuery {
-	q.where.WriteString(" oauth_state IN(")
+func (q *JwtKeyQuery) KidIn(items []string) *JwtKeyQuery {
+	q.where.WriteString(" kid IN(")
 	q.where.WriteString(wrap.RepeatWithSeparator("?", len(items), ","))
 	q.where.WriteString(")")
 	q.whereParams = append(q.whereParams, items)
 	return q
 }
 
-func (q *OauthStateQuery) IsUsedEqual(v int32) *OauthStateQuery {
-	q.where.WriteString(" is_used=?")
+func (q *JwtKeyQuery) AlgorithmEqual(v string) *JwtKeyQuery {
+	q.where.WriteString(" algorithm=?")
 	q.whereParams = append(q.whereParams, v)
 	return q
 }
 
-func (q *OauthStateQuery) IsUsedNotEqual(v int32) *OauthStateQuery {
-	q.where.WriteString(" is_used<>?")
+func (q *JwtKeyQuery) AlgorithmNotEqual(v string) *JwtKeyQuery {
+	q.where.WriteString(" algorithm<>?")
 	q.whereParams = append(q.whereParams, v)
 	return q
 }
 
-func (q *OauthStateQuery) IsUsedLess(v int32) *OauthStateQuery {
-	q.where.WriteString(" is_used<?")
+func (q *JwtKeyQuery) AlgorithmIn(items []string) *JwtKeyQuery {
+	q.where.WriteString(" algorithm IN(")
+	q.where.WriteString(wrap.RepeatWithSeparator("?", len(items), ","))
+	q.where.WriteString(")")
+	q.whereParams = append(q.whereParams, items)
+	return q
+}
+
+func (q *JwtKeyQuery) PrivateKeyEqual(v string) *JwtKeyQuery {
+	q.where.WriteString(" private_key=?")
 	q.whereParams = append(q.whereParams, v)
 	return q
 }
 
-func (q *OauthStateQuery) IsUsedLessEqual(v int32) *OauthStateQuery {
-	q.where.WriteString(" is_used<=?")
+func (q *JwtKeyQuery) PrivateKeyNotEqual(v string) *JwtKeyQuery {
+	q.where.WriteString(" private_key<>?")
 	q.whereParams = append(q.whereParams, v)
 	return q
 }
 
-func (q *OauthStateQuery) IsUsedGreater(v int32) *OauthStateQuery {
-	q.where.WriteString(" is_used>?")
+func (q *JwtKeyQuery) PrivateKeyIn(items []string) *JwtKeyQuery {
+	q.where.WriteString(" private_key IN(")
+	q.where.WriteString(wrap.RepeatWithSeparator("?", len(items), ","))
+	q.where.WriteString(")")
+	q.whereParams = append(q.whereParams, items)
+	return q
+}
+
+func (q *JwtKeyQuery) PublicKeyEqual(v string) *JwtKeyQuery {
+	q.where.WriteString(" public_key=?")
 	q.whereParams = append(q.whereParams, v)
 	return q
 }
 
-func (q *OauthStateQuery) IsUsedGreaterEqual(v int32) *OauthStateQuery {
-	q.where.WriteString(" is_used>=?")
+func (q *JwtKeyQuery) PublicKeyNotEqual(v string) *JwtKeyQuery {
+	q.where.WriteString(" public_key<>?")
 	q.whereParams = append(q.whereParams, v)
 	return q
 }
 
-func (q *OauthStateQuery) IsUsedIn(items []int32) *OauthStateQuery {
-	q.where.WriteString(" is_used IN(")
+func (q *JwtKeyQuery) PublicKeyIn(items []string) *JwtKeyQuery {
+	q.where.WriteString(" public_key IN(")
 	q.where.WriteString(wrap.RepeatWithSeparator("?", len(items), ","))
 	q.where.WriteString(")")
 	q.whereParams = append(q.whereParams, items)
 	return q
 }
 
-func (q *OauthStateQuery) UserAgentEqual(v string) *OauthStateQuery {
-	q.where.WriteString(" user_agent=?")
+func (q *JwtKeyQuery) SignBeginTimeEqual(v time.Time) *JwtKeyQuery {
+	q.where.WriteString(" sign_begin_time=?")
 	q.whereParams = append(q.whereParams, v)
 	return q
 }
 
-func (q *OauthStateQuery) UserAgentNotEqual(v string) *OauthStateQuery {
-	q.where.WriteString(" user_agent<>?")
+func (q *JwtKeyQuery) SignBeginTimeNotEqual(v time.Time) *JwtKeyQuery {
+	q.where.WriteString(" sign_begin_time<>?")
 	q.whereParams = append(q.whereParams, v)
 	return q
 }
 
-func (q *OauthStateQuery) UserAgentIn(items []string) *OauthStateQuery {
-	q.where.WriteString(" user_agent IN(")
-	q.where.WriteString(wrap.RepeatWithSeparator("?", len(items), ","))
-	q.where.WriteString(")")
-	q.whereParams = append(q.whereParams, items)
+func (q *JwtKeyQuery) SignBeginTimeLess(v time.Time) *JwtKeyQuery {
+	q.where.WriteString(" sign_begin_time<?")
+	q.whereParams = append(q.whereParams, v)
 	return q
 }
 
-func (q *OauthStateQuery) CreateTimeEqual(v time.Time) *OauthStateQuery {
+func (q *JwtKeyQuery) SignBeginTimeLessEqual(v time.Time) *JwtKeyQuery {
+	q.where.WriteString(" sign_begin_time<=?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *JwtKeyQuery) SignBeginTimeGreater(v time.Time) *JwtKeyQuery {
+	q.where.WriteString(" sign_begin_time>?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *JwtKeyQuery) SignBeginTimeGreaterEqual(v time.Time) *JwtKeyQuery {
+	q.where.WriteString(" sign_begin_time>=?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *JwtKeyQuery) SignEndTimeEqual(v time.Time) *JwtKeyQuery {
+	q.where.WriteString(" sign_end_time=?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *JwtKeyQuery) SignEndTimeNotEqual(v time.Time) *JwtKeyQuery {
+	q.where.WriteString(" sign_end_time<>?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *JwtKeyQuery) SignEndTimeLess(v time.Time) *JwtKeyQuery {
+	q.where.WriteString(" sign_end_time<?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *JwtKeyQuery) SignEndTimeLessEqual(v time.Time) *JwtKeyQuery {
+	q.where.WriteString(" sign_end_time<=?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *JwtKeyQuery) SignEndTimeGreater(v time.Time) *JwtKeyQuery {
+	q.where.WriteString(" sign_end_time>?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *JwtKeyQuery) SignEndTimeGreaterEqual(v time.Time) *JwtKeyQuery {
+	q.where.WriteString(" sign_end_time>=?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *JwtKeyQuery) ExpireTimeEqual(v time.Time) *JwtKeyQuery {
+	q.where.WriteString(" expire_time=?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *JwtKeyQuery) ExpireTimeNotEqual(v time.Time) *JwtKeyQuery {
+	q.where.WriteString(" expire_time<>?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *JwtKeyQuery) ExpireTimeLess(v time.Time) *JwtKeyQuery {
+	q.where.WriteString(" expire_time<?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *JwtKeyQuery) ExpireTimeLessEqual(v time.Time) *JwtKeyQuery {
+	q.where.WriteString(" expire_time<=?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *JwtKeyQuery) ExpireTimeGreater(v time.Time) *JwtKeyQuery {
+	q.where.WriteString(" expire_time>?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *JwtKeyQuery) ExpireTimeGreaterEqual(v time.Time) *JwtKeyQuery {
+	q.where.WriteString(" expire_time>=?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *JwtKeyQuery) CreateTimeEqual(v time.Time) *JwtKeyQuery {
 	q.where.WriteString(" create_time=?")
 	q.whereParams = append(q.whereParams, v)
 	return q
 }
 
-func (q *OauthStateQuery) CreateTimeNotEqual(v time.Time) *OauthStateQuery {
+func (q *JwtKeyQuery) CreateTimeNotEqual(v time.Time) *JwtKeyQuery {
 	q.where.WriteString(" create_time<>?")
 	q.whereParams = append(q.whereParams, v)
 	return q
 }
 
-func (q *OauthStateQuery) CreateTimeLess(v time.Time) *OauthStateQuery {
+func (q *JwtKeyQuery) CreateTimeLess(v time.Time) *JwtKeyQuery {
 	q.where.WriteString(" create_time<?")
 	q.whereParams = append(q.whereParams, v)
 	return q
 }
 
-func (q *OauthStateQuery) CreateTimeLessEqual(v time.Time) *OauthStateQuery {
+func (q *JwtKeyQuery) CreateTimeLessEqual(v time.Time) *JwtKeyQuery {
 	q.where.WriteString(" create_time<=?")
 	q.whereParams = append(q.whereParams, v)
 	return q
 }
 
-func (q *OauthStateQuery) CreateTimeGreater(v time.Time) *OauthStateQuery {
+func (q *JwtKeyQuery) CreateTimeGreater(v time.Time) *JwtKeyQuery {
 	q.where.WriteString(" create_time>?")
 	q.whereParams = append(q.whereParams, v)
 	return q
 }
 
-func (q *OauthStateQuery) CreateTimeGreaterEqual(v time.Time) *OauthStateQuery {
+func (q *JwtKeyQuery) CreateTimeGreaterEqual(v time.Time) *JwtKeyQuery {
 	q.where.WriteString(" create_time>=?")
 	q.whereParams = append(q.whereParams, v)
 	return q
 }
 
-func (q *OauthStateQuery) UpdateTimeEqual(v time.Time) *OauthStateQuery {
+func (q *JwtKeyQuery) UpdateTimeEqual(v time.Time) *JwtKeyQuery {
 	q.where.WriteString(" update_time=?")
 	q.whereParams = append(q.whereParams, v)
 	return q
 }
 
-func (q *OauthStateQuery) UpdateTimeNotEqual(v time.Time) *OauthStateQuery {
+func (q *JwtKeyQuery) UpdateTimeNotEqual(v time.Time) *JwtKeyQuery {
 	q.where.WriteString(" update_time<>?")
 	q.whereParams = append(q.whereParams, v)
 	return q
 }
 
-func (q *OauthStateQuery) UpdateTimeLess(v time.Time) *OauthStateQuery {
+func (q *JwtKeyQuery) UpdateTimeLess(v time.Time) *JwtKeyQuery {
 	q.where.WriteString(" update_time<?")
 	q.whereParams = append(q.whereParams, v)
 	return q
 }
 
-func (q *OauthStateQuery) UpdateTimeLessEqual(v time.Time) *OauthStateQuery {
+func (q *JwtKeyQuery) UpdateTimeLessEqual(v time.Time) *JwtKeyQuery {
 	q.where.WriteString(" update_time<=?")
 	q.whereParams = append(q.whereParams, v)
 	return q
 }
 
-func (q *OauthStateQuery) UpdateTimeGreater(v time.Time) *OauthStateQuery {
+func (q *JwtKeyQuery) UpdateTimeGreater(v time.Time) *JwtKeyQuery {
 	q.where.WriteString(" update_time>?")
 	q.whereParams = append(q.whereParams, v)
 	return q
 }
 
-func (q *OauthStateQuery) UpdateTimeGreaterEqual(v time.Time) *OauthStateQuery {
+func (q *JwtKeyQuery) UpdateTimeGreaterEqual(v time.Time) *JwtKeyQuery {
 	q.where.WriteString(" update_time>=?")
 	q.whereParams = append(q.whereParams, v)
 	return q
 }
 
-func (q *OauthStateQuery) GroupByIsUsed(asc bool) *OauthStateQuery {
-	q.groupByFields = append(q.groupByFields, "is_used")
+func (q *JwtKeyQuery) GroupByAlgorithm(asc bool) *JwtKeyQuery {
+	q.groupByFields = append(q.groupByFields, "algorithm")
 	q.groupByOrders = append(q.groupByOrders, asc)
 	return q
 }
 
-func (q *OauthStateQuery) GroupByUserAgent(asc bool) *OauthStateQuery {
-	q.groupByFields = append(q.groupByFields, "user_agent")
+func (q *JwtKeyQuery) GroupByPrivateKey(asc bool) *JwtKeyQuery {
+	q.groupByFields = append(q.groupByFields, "private_key")
 	q.groupByOrders = append(q.groupByOrders, asc)
 	return q
 }
 
-func (q *OauthStateQuery) OrderById(asc bool) *OauthStateQuery {
+func (q *JwtKeyQuery) GroupByPublicKey(asc bool) *JwtKeyQuery {
+	q.groupByFields = append(q.groupByFields, "public_key")
+	q.groupByOrders = append(q.groupByOrders, asc)
+	return q
+}
+
+func (q *JwtKeyQuery) OrderById(asc bool) *JwtKeyQuery {
 	q.orderByFields = append(q.orderByFields, "id")
 	q.orderByOrders = append(q.orderByOrders, asc)
 	return q
 }
 
-func (q *OauthStateQuery) OrderByOauthState(asc bool) *OauthStateQuery {
-	q.orderByFields = append(q.orderByFields, "oauth_state")
+func (q *JwtKeyQuery) OrderByKid(asc bool) *JwtKeyQuery {
+	q.orderByFields = append(q.orderByFields, "kid")
 	q.orderByOrders = append(q.orderByOrders, asc)
 	return q
 }
 
-func (q *OauthStateQuery) OrderByIsUsed(asc bool) *OauthStateQuery {
-	q.orderByFields = append(q.orderByFields, "is_used")
+func (q *JwtKeyQuery) OrderByAlgorithm(asc bool) *JwtKeyQuery {
+	q.orderByFields = append(q.orderByFields, "algorithm")
 	q.orderByOrders = append(q.orderByOrders, asc)
 	return q
 }
 
-func (q *OauthStateQuery) OrderByUserAgent(asc bool) *OauthStateQuery {
-	q.orderByFields = append(q.orderByFields, "user_agent")
+func (q *JwtKeyQuery) OrderByPrivateKey(asc bool) *JwtKeyQuery {
+	q.orderByFields = append(q.orderByFields, "private_key")
 	q.orderByOrders = append(q.orderByOrders, asc)
 	return q
 }
 
-func (q *OauthStateQuery) OrderByCreateTime(asc bool) *OauthStateQuery {
+func (q *JwtKeyQuery) OrderByPublicKey(asc bool) *JwtKeyQuery {
+	q.orderByFields = append(q.orderByFields, "public_key")
+	q.orderByOrders = append(q.orderByOrders, asc)
+	return q
+}
+
+func (q *JwtKeyQuery) OrderBySignBeginTime(asc bool) *JwtKeyQuery {
+	q.orderByFields = append(q.orderByFields, "sign_begin_time")
+	q.orderByOrders = append(q.orderByOrders, asc)
+	return q
+}
+
+func (q *JwtKeyQuery) OrderBySignEndTime(asc bool) *JwtKeyQuery {
+	q.orderByFields = append(q.orderByFields, "sign_end_time")
+	q.orderByOrders = append(q.orderByOrders, asc)
+	return q
+}
+
+func (q *JwtKeyQuery) OrderByExpireTime(asc bool) *JwtKeyQuery {
+	q.orderByFields = append(q.orderByFields, "expire_time")
+	q.orderByOrders = append(q.orderByOrders, asc)
+	return q
+}
+
+func (q *JwtKeyQuery) OrderByCreateTime(asc bool) *JwtKeyQuery {
 	q.orderByFields = append(q.orderByFields, "create_time")
 	q.orderByOrders = append(q.orderByOrders, asc)
 	return q
 }
 
-func (q *OauthStateQuery) OrderByUpdateTime(asc bool) *OauthStateQuery {
+func (q *JwtKeyQuery) OrderByUpdateTime(asc bool) *JwtKeyQuery {
 	q.orderByFields = append(q.orderByFields, "update_time")
 	q.orderByOrders = append(q.orderByOrders, asc)
 	return q
 }
 
-func (q *OauthStateQuery) OrderByGroupCount(asc bool) *OauthStateQuery {
+func (q *JwtKeyQuery) OrderByGroupCount(asc bool) *JwtKeyQuery {
 	q.orderByFields = append(q.orderByFields, "count(*)")
 	q.orderByOrders = append(q.orderByOrders, asc)
 	return q
 }
 
-func (q *OauthStateQuery) Limit(startIncluded int64, count int64) *OauthStateQuery {
+func (q *JwtKeyQuery) Limit(startIncluded int64, count int64) *JwtKeyQuery {
 	q.hasLimit = true
 	q.limitStartIncluded = startIncluded
 	q.limitCount = count
 	return q
 }
 
-func (q *OauthStateQuery) ForUpdate() *OauthStateQuery {
+func (q *JwtKeyQuery) ForUpdate() *JwtKeyQuery {
 	q.forUpdate = true
 	return q
 }
 
-func (q *OauthStateQuery) ForShare() *OauthStateQuery {
+func (q *JwtKeyQuery) ForShare() *JwtKeyQuery {
 	q.forShare = true
 	return q
 }
 
-func (q *OauthStateQuery) SetOauthState(v string) *OauthStateQuery {
-	q.updateFields = append(q.updateFields, "oauth_state")
+func (q *JwtKeyQuery) SetKid(v string) *JwtKeyQuery {
+	q.updateFields = append(q.updateFields, "kid")
 	q.updateParams = append(q.updateParams, v)
 	return q
 }
 
-func (q *OauthStateQuery) SetIsUsed(v int32) *OauthStateQuery {
-	q.updateFields = append(q.updateFields, "is_used")
+func (q *JwtKeyQuery) SetAlgorithm(v string) *JwtKeyQuery {
+	q.updateFields = append(q.updateFields, "algorithm")
 	q.updateParams = append(q.updateParams, v)
 	return q
 }
 
-func (q *OauthStateQuery) SetUserAgent(v string) *OauthStateQuery {
-	q.updateFields = append(q.updateFields, "user_agent")
+func (q *JwtKeyQuery) SetPrivateKey(v string) *JwtKeyQuery {
+	q.updateFields = append(q.updateFields, "private_key")
 	q.updateParams = append(q.updateParams, v)
 	return q
 }
 
-func (q *OauthStateQuery) DuplicatedUpdateIsUsed() *OauthStateQuery {
-	q.duplicatedUpdateFields = append(q.duplicatedUpdateFields, "is_used=VALUES(is_used)")
+func (q *JwtKeyQuery) SetPublicKey(v string) *JwtKeyQuery {
+	q.updateFields = append(q.updateFields, "public_key")
+	q.updateParams = append(q.updateParams, v)
 	return q
 }
 
-func (q *OauthStateQuery) DuplicatedUpdateUserAgent() *OauthStateQuery {
-	q.duplicatedUpdateFields = append(q.duplicatedUpdateFields, "user_agent=VALUES(user_agent)")
+func (q *JwtKeyQuery) SetSignBeginTime(v time.Time) *JwtKeyQuery {
+	q.updateFields = append(q.updateFields, "sign_begin_time")
+	q.updateParams = append(q.updateParams, v)
 	return q
 }
 
-func (q *OauthStateQuery) GetId() *OauthStateQuery {
+func (q *JwtKeyQuery) SetSignEndTime(v time.Time) *JwtKeyQuery {
+	q.updateFields = append(q.updateFields, "sign_end_time")
+	q.updateParams = append(q.updateParams, v)
+	return q
+}
+
+func (q *JwtKeyQuery) SetExpireTime(v time.Time) *JwtKeyQuery {
+	q.updateFields = append(q.updateFields, "expire_time")
+	q.updateParams = append(q.updateParams, v)
+	return q
+}
+
+func (q *JwtKeyQuery) DuplicatedUpdateAlgorithm() *JwtKeyQuery {
+	q.duplicatedUpdateFields = append(q.duplicatedUpdateFields, "algorithm=VALUES(algorithm)")
+	return q
+}
+
+func (q *JwtKeyQuery) DuplicatedUpdatePrivateKey() *JwtKeyQuery {
+	q.duplicatedUpdateFields = append(q.duplicatedUpdateFields, "private_key=VALUES(private_key)")
+	return q
+}
+
+func (q *JwtKeyQuery) DuplicatedUpdatePublicKey() *JwtKeyQuery {
+	q.duplicatedUpdateFields = append(q.duplicatedUpdateFields, "public_key=VALUES(public_key)")
+	return q
+}
+
+func (q *JwtKeyQuery) DuplicatedUpdateSignBeginTime() *JwtKeyQuery {
+	q.duplicatedUpdateFields = append(q.duplicatedUpdateFields, "sign_begin_time=VALUES(sign_begin_time)")
+	return q
+}
+
+func (q *JwtKeyQuery) DuplicatedUpdateSignEndTime() *JwtKeyQuery {
+	q.duplicatedUpdateFields = append(q.duplicatedUpdateFields, "sign_end_time=VALUES(sign_end_time)")
+	return q
+}
+
+func (q *JwtKeyQuery) DuplicatedUpdateExpireTime() *JwtKeyQuery {
+	q.duplicatedUpdateFields = append(q.duplicatedUpdateFields, "expire_time=VALUES(expire_time)")
+	return q
+}
+
+func (q *JwtKeyQuery) GetId() *JwtKeyQuery {
 	q.getFields = append(q.getFields, "id")
 	return q
 }
 
-func (q *OauthStateQuery) GetOauthState() *OauthStateQuery {
-	q.getFields = append(q.getFields, "oauth_state")
+func (q *JwtKeyQuery) GetKid() *JwtKeyQuery {
+	q.getFields = append(q.getFields, "kid")
 	return q
 }
 
-func (q *OauthStateQuery) GetIsUsed() *OauthStateQuery {
-	q.getFields = append(q.getFields, "is_used")
+func (q *JwtKeyQuery) GetAlgorithm() *JwtKeyQuery {
+	q.getFields = append(q.getFields, "algorithm")
 	return q
 }
 
-func (q *OauthStateQuery) GetUserAgent() *OauthStateQuery {
-	q.getFields = append(q.getFields, "user_agent")
+func (q *JwtKeyQuery) GetPrivateKey() *JwtKeyQuery {
+	q.getFields = append(q.getFields, "private_key")
 	return q
 }
 
-func (q *OauthStateQuery) GetCreateTime() *OauthStateQuery {
+func (q *JwtKeyQuery) GetPublicKey() *JwtKeyQuery {
+	q.getFields = append(q.getFields, "public_key")
+	return q
+}
+
+func (q *JwtKeyQuery) GetSignBeginTime() *JwtKeyQuery {
+	q.getFields = append(q.getFields, "sign_begin_time")
+	return q
+}
+
+func (q *JwtKeyQuery) GetSignEndTime() *JwtKeyQuery {
+	q.getFields = append(q.getFields, "sign_end_time")
+	return q
+}
+
+func (q *JwtKeyQuery) GetExpireTime() *JwtKeyQuery {
+	q.getFields = append(q.getFields, "expire_time")
+	return q
+}
+
+func (q *JwtKeyQuery) GetCreateTime() *JwtKeyQuery {
 	q.getFields = append(q.getFields, "create_time")
 	return q
 }
 
-func (q *OauthStateQuery) GetUpdateTime() *OauthStateQuery {
+func (q *JwtKeyQuery) GetUpdateTime() *JwtKeyQuery {
 	q.getFields = append(q.getFields, "update_time")
 	return q
 }
 
-func (q *OauthStateQuery) Select(ctx context.Context, tx *wrap.Tx) (e *OauthState, err error) {
+func (q *JwtKeyQuery) Select(ctx context.Context, tx *wrap.Tx) (e *JwtKey, err error) {
 	if !q.hasLimit {
 		q.limitCount = 1
 		q.hasLimit = true
@@ -2324,16 +2693,16 @@ func (q *OauthStateQuery) Select(ctx context.Context, tx *wrap.Tx) (e *OauthStat
 	queryString, params := q.buildSelectQuery()
 	query := bytes.NewBufferString("")
 	if len(q.getFields) == 0 {
-		query.WriteString("SELECT id,oauth_state,is_used,user_agent,create_time,update_time FROM oauth_state ")
+		query.WriteString("SELECT id,kid,algorithm,private_key,public_key,sign_begin_time,sign_end_time,expire_time,create_time,update_time FROM jwt_key ")
 	} else {
 		query.WriteString("SELECT ")
 		query.WriteString(strings.Join(q.getFields, ","))
-		query.WriteString(" FROM oauth_state ")
+		query.WriteString(" FROM jwt_key ")
 	}
 	query.WriteString(queryString)
-	e = &OauthState{}
+	e = &JwtKey{}
 	row := q.dao.db.QueryRow(ctx, tx, query.String(), params...)
-	err = row.Scan(&e.Id, &e.OauthState, &e.IsUsed, &e.UserAgent, &e.CreateTime, &e.UpdateTime)
+	err = row.Scan(&e.Id, &e.Kid, &e.Algorithm, &e.PrivateKey, &e.PublicKey, &e.SignBeginTime, &e.SignEndTime, &e.ExpireTime, &e.CreateTime, &e.UpdateTime)
 	if err == wrap.ErrNoRows {
 		return nil, nil
 	}
@@ -2341,15 +2710,15 @@ func (q *OauthStateQuery) Select(ctx context.Context, tx *wrap.Tx) (e *OauthStat
 	return e, err
 }
 
-func (q *OauthStateQuery) SelectList(ctx context.Context, tx *wrap.Tx) (list []*OauthState, err error) {
+func (q *JwtKeyQuery) SelectList(ctx context.Context, tx *wrap.Tx) (list []*JwtKey, err error) {
 	queryString, params := q.buildSelectQuery()
 	query := bytes.NewBufferString("")
 	if len(q.getFields) == 0 {
-		query.WriteString("SELECT id,oauth_state,is_used,user_agent,create_time,update_time FROM oauth_state ")
+		query.WriteString("SELECT id,kid,algorithm,private_key,public_key,sign_begin_time,sign_end_time,expire_time,create_time,update_time FROM jwt_key ")
 	} else {
 		query.WriteString("SELECT ")
 		query.WriteString(strings.Join(q.getFields, ","))
-		query.WriteString(" FROM oauth_state ")
+		query.WriteString(" FROM jwt_key ")
 	}
 	query.WriteString(queryString)
 	rows, err := q.dao.db.Query(ctx, tx, query.String(), params...)
@@ -2357,8 +2726,8 @@ func (q *OauthStateQuery) SelectList(ctx context.Context, tx *wrap.Tx) (list []*
 		return nil, err
 	}
 	for rows.Next() {
-		e := OauthState{}
-		err = rows.Scan(&e.Id, &e.OauthState, &e.IsUsed, &e.UserAgent, &e.CreateTime, &e.UpdateTime)
+		e := JwtKey{}
+		err = rows.Scan(&e.Id, &e.Kid, &e.Algorithm, &e.PrivateKey, &e.PublicKey, &e.SignBeginTime, &e.SignEndTime, &e.ExpireTime, &e.CreateTime, &e.UpdateTime)
 		if err != nil {
 			return nil, err
 		}
@@ -2372,10 +2741,10 @@ func (q *OauthStateQuery) SelectList(ctx context.Context, tx *wrap.Tx) (list []*
 	return list, nil
 }
 
-func (q *OauthStateQuery) SelectCount(ctx context.Context, tx *wrap.Tx) (count int64, err error) {
+func (q *JwtKeyQuery) SelectCount(ctx context.Context, tx *wrap.Tx) (count int64, err error) {
 	queryString, params := q.buildSelectQuery()
 	query := bytes.NewBufferString("")
-	query.WriteString("SELECT COUNT(*) FROM oauth_state ")
+	query.WriteString("SELECT COUNT(*) FROM jwt_key ")
 	query.WriteString(queryString)
 	row := q.dao.db.QueryRow(ctx, tx, query.String(), params...)
 	err = row.Scan(&count)
@@ -2383,7 +2752,7 @@ func (q *OauthStateQuery) SelectCount(ctx context.Context, tx *wrap.Tx) (count i
 	return count, err
 }
 
-func (q *OauthStateQuery) SelectGroupBy(ctx context.Context, tx *wrap.Tx, withCount bool) (rows *wrap.Rows, err error) {
+func (q *JwtKeyQuery) SelectGroupBy(ctx context.Context, tx *wrap.Tx, withCount bool) (rows *wrap.Rows, err error) {
 	queryString, params := q.buildSelectQuery()
 	query := bytes.NewBufferString("")
 	query.WriteString("SELECT ")
@@ -2391,13 +2760,13 @@ func (q *OauthStateQuery) SelectGroupBy(ctx context.Context, tx *wrap.Tx, withCo
 	if withCount {
 		query.WriteString(",MachineListCount(*) ")
 	}
-	query.WriteString(" FROM oauth_state ")
+	query.WriteString(" FROM jwt_key ")
 	query.WriteString(queryString)
 
 	return q.dao.db.Query(ctx, tx, query.String(), params...)
 }
 
-func (q *OauthStateQuery) SelectRow(ctx context.Context, tx *wrap.Tx) (row *wrap.Row) {
+func (q *JwtKeyQuery) SelectRow(ctx context.Context, tx *wrap.Tx) (row *wrap.Row) {
 	if !q.hasLimit {
 		q.limitCount = 1
 		q.hasLimit = true
@@ -2407,84 +2776,92 @@ func (q *OauthStateQuery) SelectRow(ctx context.Context, tx *wrap.Tx) (row *wrap
 	query := bytes.NewBufferString("")
 	query.WriteString("SELECT ")
 	query.WriteString(strings.Join(q.getFields, ","))
-	query.WriteString(" FROM oauth_state ")
+	query.WriteString(" FROM jwt_key ")
 	query.WriteString(queryString)
 	return q.dao.db.QueryRow(ctx, tx, query.String(), params...)
 }
 
-func (q *OauthStateQuery) SelectRows(ctx context.Context, tx *wrap.Tx) (rows *wrap.Rows, err error) {
+func (q *JwtKeyQuery) SelectRows(ctx context.Context, tx *wrap.Tx) (rows *wrap.Rows, err error) {
 	queryString, params := q.buildSelectQuery()
 	query := bytes.NewBufferString("")
 	query.WriteString("SELECT ")
 	query.WriteString(strings.Join(q.getFields, ","))
-	query.WriteString(" FROM oauth_state ")
+	query.WriteString(" FROM jwt_key ")
 	query.WriteString(queryString)
 	return q.dao.db.Query(ctx, tx, query.String(), params...)
 }
 
-func (q *OauthStateQuery) Insert(ctx context.Context, tx *wrap.Tx, e *OauthState) (result *wrap.Result, err error) {
+func (q *JwtKeyQuery) Insert(ctx context.Context, tx *wrap.Tx, e *JwtKey) (result *wrap.Result, err error) {
 	query := bytes.NewBufferString("")
-	query.WriteString("INSERT INTO oauth_state (oauth_state,is_used,user_agent) VALUES (?,?,?)")
-	params := []interface{}{e.OauthState, e.IsUsed, e.UserAgent}
+	query.WriteString("INSERT INTO jwt_key (kid,algorithm,private_key,public_key,sign_begin_time,sign_end_time,expire_time) VALUES (?,?,?,?,?,?,?)")
+	params := []interface{}{e.Kid, e.Algorithm, e.PrivateKey, e.PublicKey, e.SignBeginTime, e.SignEndTime, e.ExpireTime}
 	return q.dao.db.Exec(ctx, tx, query.String(), params...)
 }
 
-func (q *OauthStateQuery) BatchInsert(ctx context.Context, tx *wrap.Tx, list []*OauthState) (result *wrap.Result, err error) {
+func (q *JwtKeyQuery) BatchInsert(ctx context.Context, tx *wrap.Tx, list []*JwtKey) (result *wrap.Result, err error) {
 	query := bytes.NewBufferString("")
-	query.WriteString("INSERT INTO oauth_state (oauth_state,is_used,user_agent) VALUES ")
-	query.WriteString(wrap.RepeatWithSeparator("(?,?,?)", len(list), ","))
-	params := make([]interface{}, len(list)*3)
+	query.WriteString("INSERT INTO jwt_key (kid,algorithm,private_key,public_key,sign_begin_time,sign_end_time,expire_time) VALUES ")
+	query.WriteString(wrap.RepeatWithSeparator("(?,?,?,?,?,?,?)", len(list), ","))
+	params := make([]interface{}, len(list)*7)
 	offset := 0
 	for _, e := range list {
-		params[offset+0] = e.OauthState
-		params[offset+1] = e.IsUsed
-		params[offset+2] = e.UserAgent
-		offset += 3
+		params[offset+0] = e.Kid
+		params[offset+1] = e.Algorithm
+		params[offset+2] = e.PrivateKey
+		params[offset+3] = e.PublicKey
+		params[offset+4] = e.SignBeginTime
+		params[offset+5] = e.SignEndTime
+		params[offset+6] = e.ExpireTime
+		offset += 7
 	}
 
 	return q.dao.db.Exec(ctx, tx, query.String(), params...)
 }
 
-func (q *OauthStateQuery) InsertOnDuplicatedKeyUpdate(ctx context.Context, tx *wrap.Tx, e *OauthState) (result *wrap.Result, err error) {
+func (q *JwtKeyQuery) InsertOnDuplicatedKeyUpdate(ctx context.Context, tx *wrap.Tx, e *JwtKey) (result *wrap.Result, err error) {
 	query := bytes.NewBufferString("")
-	query.WriteString("INSERT INTO oauth_state (oauth_state,is_used,user_agent) VALUES (?,?,?)")
+	query.WriteString("INSERT INTO jwt_key (kid,algorithm,private_key,public_key,sign_begin_time,sign_end_time,expire_time) VALUES (?,?,?,?,?,?,?)")
 	query.WriteString(" ON DUPLICATED KEY UPDATE ")
 	if len(q.duplicatedUpdateFields) > 0 {
 		query.WriteString(strings.Join(q.duplicatedUpdateFields, ","))
 		query.WriteString(",")
 	}
 	query.WriteString("update_time=now()")
-	params := []interface{}{e.OauthState, e.IsUsed, e.UserAgent}
+	params := []interface{}{e.Kid, e.Algorithm, e.PrivateKey, e.PublicKey, e.SignBeginTime, e.SignEndTime, e.ExpireTime}
 	return q.dao.db.Exec(ctx, tx, query.String(), params...)
 }
 
-func (q *OauthStateQuery) BatchInsertOnDuplicatedKeyUpdate(ctx context.Context, tx *wrap.Tx, list []*OauthState) (result *wrap.Result, err error) {
+func (q *JwtKeyQuery) BatchInsertOnDuplicatedKeyUpdate(ctx context.Context, tx *wrap.Tx, list []*JwtKey) (result *wrap.Result, err error) {
 	query := bytes.NewBufferString("")
-	query.WriteString("INSERT INTO oauth_state (oauth_state,is_used,user_agent) VALUES ")
-	query.WriteString(wrap.RepeatWithSeparator("(?,?,?)", len(list), ","))
+	query.WriteString("INSERT INTO jwt_key (kid,algorithm,private_key,public_key,sign_begin_time,sign_end_time,expire_time) VALUES ")
+	query.WriteString(wrap.RepeatWithSeparator("(?,?,?,?,?,?,?)", len(list), ","))
 	query.WriteString(" ON DUPLICATED KEY UPDATE")
 	if len(q.duplicatedUpdateFields) > 0 {
 		query.WriteString(strings.Join(q.duplicatedUpdateFields, ","))
 		query.WriteString(",")
 	}
 	query.WriteString("update_time=now()")
-	params := make([]interface{}, len(list)*3)
+	params := make([]interface{}, len(list)*7)
 	offset := 0
 	for _, e := range list {
-		params[offset+0] = e.OauthState
-		params[offset+1] = e.IsUsed
-		params[offset+2] = e.UserAgent
-		offset += 3
+		params[offset+0] = e.Kid
+		params[offset+1] = e.Algorithm
+		params[offset+2] = e.PrivateKey
+		params[offset+3] = e.PublicKey
+		params[offset+4] = e.SignBeginTime
+		params[offset+5] = e.SignEndTime
+		params[offset+6] = e.ExpireTime
+		offset += 7
 	}
 
 	return q.dao.db.Exec(ctx, tx, query.String(), params...)
 }
 
-func (q *OauthStateQuery) Update(ctx context.Context, tx *wrap.Tx) (result *wrap.Result, err error) {
+func (q *JwtKeyQuery) Update(ctx context.Context, tx *wrap.Tx) (result *wrap.Result, err error) {
 	query := bytes.NewBufferString("")
 	var params []interface{}
 	params = append(params, q.updateParams...)
-	query.WriteString("UPDATE oauth_state SET ")
+	query.WriteString("UPDATE jwt_key SET ")
 	updateItems := make([]string, len(q.updateFields))
 	for i, v := range q.updateFields {
 		updateItems[i] = v + "=?"
@@ -2501,107 +2878,111 @@ func (q *OauthStateQuery) Update(ctx context.Context, tx *wrap.Tx) (result *wrap
 	return q.dao.db.Exec(ctx, tx, query.String(), params...)
 }
 
-func (q *OauthStateQuery) Delete(ctx context.Context, tx *wrap.Tx) (result *wrap.Result, err error) {
-	query := "DELETE FROM oauth_state WHERE " + q.where.String()
+func (q *JwtKeyQuery) Delete(ctx context.Context, tx *wrap.Tx) (result *wrap.Result, err error) {
+	query := "DELETE FROM jwt_key WHERE " + q.where.String()
 	return q.dao.db.Exec(ctx, tx, query, q.whereParams...)
 }
 
-type OauthStateDao struct {
+type JwtKeyDao struct {
 	logger *zap.Logger
 	db     *DB
 }
 
-func NewOauthStateDao(db *DB) (t *OauthStateDao, err error) {
-	t = &OauthStateDao{}
+func NewJwtKeyDao(db *DB) (t *JwtKeyDao, err error) {
+	t = &JwtKeyDao{}
 	t.logger = log.TypedLogger(t)
 	t.db = db
 
 	return t, nil
 }
 
-func (dao *OauthStateDao) Query() *OauthStateQuery {
-	q := &OauthStateQuery{}
+func (dao *JwtKeyDao) Query() *JwtKeyQuery {
+	q := &JwtKeyQuery{}
 	q.dao = dao
-	q.tableName = "oauth_state"
+	q.tableName = "jwt_key"
 	q.where = bytes.NewBufferString("")
 	return q
 }
 
-type PhoneAccount struct {
-	Id             uint64 //size=20
-	UserId         string //size=32
-	PhoneEncrypted string //size=32
-	CreateTime     time.Time
-	UpdateTime     time.Time
+type OauthAccount struct {
+	Id           uint64 //size=20
+	UserId       string //size=32
+	ProviderId   string //size=32
+	ProviderName string //size=32
+	OpenId       string //size=128
+	UserName     string //size=32
+	UserIcon     string //size=256
+	CreateTime   time.Time
+	UpdateTime   time.Time
 }
 
-type PhoneAccountQuery struct {
+type OauthAccountQuery struct {
 	QueryBase
-	dao *PhoneAccountDao
+	dao *OauthAccountDao
 }
 
-func (q *PhoneAccountQuery) Left() *PhoneAccountQuery {
+func (q *OauthAccountQuery) Left() *OauthAccountQuery {
 	q.where.WriteString(" (")
 	return q
 }
 
-func (q *PhoneAccountQuery) Right() *PhoneAccountQuery {
+func (q *OauthAccountQuery) Right() *OauthAccountQuery {
 	q.where.WriteString(" )")
 	return q
 }
 
-func (q *PhoneAccountQuery) And() *PhoneAccountQuery {
+func (q *OauthAccountQuery) And() *OauthAccountQuery {
 	q.where.WriteString(" AND")
 	return q
 }
 
-func (q *PhoneAccountQuery) Or() *PhoneAccountQuery {
+func (q *OauthAccountQuery) Or() *OauthAccountQuery {
 	q.where.WriteString(" OR")
 	return q
 }
 
-func (q *PhoneAccountQuery) Not() *PhoneAccountQuery {
+func (q *OauthAccountQuery) Not() *OauthAccountQuery {
 	q.where.WriteString(" NOT")
 	return q
 }
 
-func (q *PhoneAccountQuery) IdEqual(v uint64) *PhoneAccountQuery {
+func (q *OauthAccountQuery) IdEqual(v uint64) *OauthAccountQuery {
 	q.where.WriteString(" id=?")
 	q.whereParams = append(q.whereParams, v)
 	return q
 }
 
-func (q *PhoneAccountQuery) IdNotEqual(v uint64) *PhoneAccountQuery {
+func (q *OauthAccountQuery) IdNotEqual(v uint64) *OauthAccountQuery {
 	q.where.WriteString(" id<>?")
 	q.whereParams = append(q.whereParams, v)
 	return q
 }
 
-func (q *PhoneAccountQuery) IdLess(v uint64) *PhoneAccountQuery {
+func (q *OauthAccountQuery) IdLess(v uint64) *OauthAccountQuery {
 	q.where.WriteString(" id<?")
 	q.whereParams = append(q.whereParams, v)
 	return q
 }
 
-func (q *PhoneAccountQuery) IdLessEqual(v uint64) *PhoneAccountQuery {
+func (q *OauthAccountQuery) IdLessEqual(v uint64) *OauthAccountQuery {
 	q.where.WriteString(" id<=?")
 	q.whereParams = append(q.whereParams, v)
 	return q
 }
 
-func (q *PhoneAccountQuery) IdGreater(v uint64) *PhoneAccountQuery {
+func (q *OauthAccountQuery) IdGreater(v uint64) *OauthAccountQuery {
 	q.where.WriteString(" id>?")
 	q.whereParams = append(q.whereParams, v)
 	return q
 }
 
-func (q *PhoneAccountQuery) IdGreaterEqual(v uint64) *PhoneAccountQuery {
+func (q *OauthAccountQuery) IdGreaterEqual(v uint64) *OauthAccountQuery {
 	q.where.WriteString(" id>=?")
 	q.whereParams = append(q.whereParams, v)
 	return q
 }
 
-func (q *PhoneAccountQuery) IdIn(items []uint64) *PhoneAccountQuery {
+func (q *OauthAccountQuery) IdIn(items []uint64) *OauthAccountQuery {
 	q.where.WriteString(" id IN(")
 	q.where.WriteString(wrap.RepeatWithSeparator("?", len(items), ","))
 	q.where.WriteString(")")
@@ -2609,19 +2990,19 @@ func (q *PhoneAccountQuery) IdIn(items []uint64) *PhoneAccountQuery {
 	return q
 }
 
-func (q *PhoneAccountQuery) UserIdEqual(v string) *PhoneAccountQuery {
+func (q *OauthAccountQuery) UserIdEqual(v string) *OauthAccountQuery {
 	q.where.WriteString(" user_id=?")
 	q.whereParams = append(q.whereParams, v)
 	return q
 }
 
-func (q *PhoneAccountQuery) UserIdNotEqual(v string) *PhoneAccountQuery {
+func (q *OauthAccountQuery) UserIdNotEqual(v string) *OauthAccountQuery {
 	q.where.WriteString(" user_id<>?")
 	q.whereParams = append(q.whereParams, v)
 	return q
 }
 
-func (q *PhoneAccountQuery) UserIdIn(items []string) *PhoneAccountQuery {
+func (q *OauthAccountQuery) UserIdIn(items []string) *OauthAccountQuery {
 	q.where.WriteString(" user_id IN(")
 	q.where.WriteString(wrap.RepeatWithSeparator("?", len(items), ","))
 	q.where.WriteString(")")
@@ -2629,200 +3010,5940 @@ func (q *PhoneAccountQuery) UserIdIn(items []string) *PhoneAccountQuery {
 	return q
 }
 
-func (q *PhoneAccountQuery) PhoneEncryptedEqual(v string) *PhoneAccountQuery {
-	q.where.WriteString(" phone_encrypted=?")
+func (q *OauthAccountQuery) ProviderIdEqual(v string) *OauthAccountQuery {
+	q.where.WriteString(" providerId=?")
 	q.whereParams = append(q.whereParams, v)
 	return q
 }
 
-func (q *PhoneAccountQuery) PhoneEncryptedNotEqual(v string) *PhoneAccountQuery {
-	q.where.WriteString(" phone_encrypted<>?")
+func (q *OauthAccountQuery) ProviderIdNotEqual(v string) *OauthAccountQuery {
+	q.where.WriteString(" providerId<>?")
 	q.whereParams = append(q.whereParams, v)
 	return q
 }
 
-func (q *PhoneAccountQuery) PhoneEncryptedIn(items []string) *PhoneAccountQuery {
-	q.where.WriteString(" phone_encrypted IN(")
+func (q *OauthAccountQuery) ProviderIdIn(items []string) *OauthAccountQuery {
+	q.where.WriteString(" providerId IN(")
 	q.where.WriteString(wrap.RepeatWithSeparator("?", len(items), ","))
 	q.where.WriteString(")")
 	q.whereParams = append(q.whereParams, items)
 	return q
 }
 
-func (q *PhoneAccountQuery) CreateTimeEqual(v time.Time) *PhoneAccountQuery {
+func (q *OauthAccountQuery) ProviderNameEqual(v string) *OauthAccountQuery {
+	q.where.WriteString(" provider_name=?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *OauthAccountQuery) ProviderNameNotEqual(v string) *OauthAccountQuery {
+	q.where.WriteString(" provider_name<>?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *OauthAccountQuery) ProviderNameIn(items []string) *OauthAccountQuery {
+	q.where.WriteString(" provider_name IN(")
+	q.where.WriteString(wrap.RepeatWithSeparator("?", len(items), ","))
+	q.where.WriteString(")")
+	q.whereParams = append(q.whereParams, items)
+	return q
+}
+
+func (q *OauthAccountQuery) OpenIdEqual(v string) *OauthAccountQuery {
+	q.where.WriteString(" open_id=?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *OauthAccountQuery) OpenIdNotEqual(v string) *OauthAccountQuery {
+	q.where.WriteString(" open_id<>?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *OauthAccountQuery) OpenIdIn(items []string) *OauthAccountQuery {
+	q.where.WriteString(" open_id IN(")
+	q.where.WriteString(wrap.RepeatWithSeparator("?", len(items), ","))
+	q.where.WriteString(")")
+	q.whereParams = append(q.whereParams, items)
+	return q
+}
+
+func (q *OauthAccountQuery) UserNameEqual(v string) *OauthAccountQuery {
+	q.where.WriteString(" user_name=?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *OauthAccountQuery) UserNameNotEqual(v string) *OauthAccountQuery {
+	q.where.WriteString(" user_name<>?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *OauthAccountQuery) UserNameIn(items []string) *OauthAccountQuery {
+	q.where.WriteString(" user_name IN(")
+	q.where.WriteString(wrap.RepeatWithSeparator("?", len(items), ","))
+	q.where.WriteString(")")
+	q.whereParams = append(q.whereParams, items)
+	return q
+}
+
+func (q *OauthAccountQuery) UserIconEqual(v string) *OauthAccountQuery {
+	q.where.WriteString(" user_icon=?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *OauthAccountQuery) UserIconNotEqual(v string) *OauthAccountQuery {
+	q.where.WriteString(" user_icon<>?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *OauthAccountQuery) UserIconIn(items []string) *OauthAccountQuery {
+	q.where.WriteString(" user_icon IN(")
+	q.where.WriteString(wrap.RepeatWithSeparator("?", len(items), ","))
+	q.where.WriteString(")")
+	q.whereParams = append(q.whereParams, items)
+	return q
+}
+
+func (q *OauthAccountQuery) CreateTimeEqual(v time.Time) *OauthAccountQuery {
 	q.where.WriteString(" create_time=?")
 	q.whereParams = append(q.whereParams, v)
 	return q
 }
 
-func (q *PhoneAccountQuery) CreateTimeNotEqual(v time.Time) *PhoneAccountQuery {
+func (q *OauthAccountQuery) CreateTimeNotEqual(v time.Time) *OauthAccountQuery {
 	q.where.WriteString(" create_time<>?")
 	q.whereParams = append(q.whereParams, v)
 	return q
 }
 
-func (q *PhoneAccountQuery) CreateTimeLess(v time.Time) *PhoneAccountQuery {
+func (q *OauthAccountQuery) CreateTimeLess(v time.Time) *OauthAccountQuery {
 	q.where.WriteString(" create_time<?")
 	q.whereParams = append(q.whereParams, v)
 	return q
 }
 
-func (q *PhoneAccountQuery) CreateTimeLessEqual(v time.Time) *PhoneAccountQuery {
+func (q *OauthAccountQuery) CreateTimeLessEqual(v time.Time) *OauthAccountQuery {
 	q.where.WriteString(" create_time<=?")
 	q.whereParams = append(q.whereParams, v)
 	return q
 }
 
-func (q *PhoneAccountQuery) CreateTimeGreater(v time.Time) *PhoneAccountQuery {
+func (q *OauthAccountQuery) CreateTimeGreater(v time.Time) *OauthAccountQuery {
 	q.where.WriteString(" create_time>?")
 	q.whereParams = append(q.whereParams, v)
 	return q
 }
 
-func (q *PhoneAccountQuery) CreateTimeGreaterEqual(v time.Time) *PhoneAccountQuery {
+func (q *OauthAccountQuery) CreateTimeGreaterEqual(v time.Time) *OauthAccountQuery {
 	q.where.WriteString(" create_time>=?")
 	q.whereParams = append(q.whereParams, v)
 	return q
 }
 
-func (q *PhoneAccountQuery) UpdateTimeEqual(v time.Time) *PhoneAccountQuery {
+func (q *OauthAccountQuery) UpdateTimeEqual(v time.Time) *OauthAccountQuery {
 	q.where.WriteString(" update_time=?")
 	q.whereParams = append(q.whereParams, v)
 	return q
 }
 
-func (q *PhoneAccountQuery) UpdateTimeNotEqual(v time.Time) *PhoneAccountQuery {
+func (q *OauthAccountQuery) UpdateTimeNotEqual(v time.Time) *OauthAccountQuery {
 	q.where.WriteString(" update_time<>?")
 	q.whereParams = append(q.whereParams, v)
 	return q
 }
 
-func (q *PhoneAccountQuery) UpdateTimeLess(v time.Time) *PhoneAccountQuery {
+func (q *OauthAccountQuery) UpdateTimeLess(v time.Time) *OauthAccountQuery {
 	q.where.WriteString(" update_time<?")
 	q.whereParams = append(q.whereParams, v)
 	return q
 }
 
-func (q *PhoneAccountQuery) UpdateTimeLessEqual(v time.Time) *PhoneAccountQuery {
+func (q *OauthAccountQuery) UpdateTimeLessEqual(v time.Time) *OauthAccountQuery {
 	q.where.WriteString(" update_time<=?")
 	q.whereParams = append(q.whereParams, v)
 	return q
 }
 
-func (q *PhoneAccountQuery) UpdateTimeGreater(v time.Time) *PhoneAccountQuery {
+func (q *OauthAccountQuery) UpdateTimeGreater(v time.Time) *OauthAccountQuery {
 	q.where.WriteString(" update_time>?")
 	q.whereParams = append(q.whereParams, v)
 	return q
 }
 
-func (q *PhoneAccountQuery) UpdateTimeGreaterEqual(v time.Time) *PhoneAccountQuery {
+func (q *OauthAccountQuery) UpdateTimeGreaterEqual(v time.Time) *OauthAccountQuery {
 	q.where.WriteString(" update_time>=?")
 	q.whereParams = append(q.whereParams, v)
 	return q
 }
 
-func (q *PhoneAccountQuery) GroupByUserId(asc bool) *PhoneAccountQuery {
+func (q *OauthAccountQuery) GroupByUserId(asc bool) *OauthAccountQuery {
 	q.groupByFields = append(q.groupByFields, "user_id")
 	q.groupByOrders = append(q.groupByOrders, asc)
 	return q
 }
 
-func (q *PhoneAccountQuery) OrderById(asc bool) *PhoneAccountQuery {
+func (q *OauthAccountQuery) GroupByProviderId(asc bool) *OauthAccountQuery {
+	q.groupByFields = append(q.groupByFields, "providerId")
+	q.groupByOrders = append(q.groupByOrders, asc)
+	return q
+}
+
+func (q *OauthAccountQuery) GroupByProviderName(asc bool) *OauthAccountQuery {
+	q.groupByFields = append(q.groupByFields, "provider_name")
+	q.groupByOrders = append(q.groupByOrders, asc)
+	return q
+}
+
+func (q *OauthAccountQuery) GroupByOpenId(asc bool) *OauthAccountQuery {
+	q.groupByFields = append(q.groupByFields, "open_id")
+	q.groupByOrders = append(q.groupByOrders, asc)
+	return q
+}
+
+func (q *OauthAccountQuery) GroupByUserName(asc bool) *OauthAccountQuery {
+	q.groupByFields = append(q.groupByFields, "user_name")
+	q.groupByOrders = append(q.groupByOrders, asc)
+	return q
+}
+
+func (q *OauthAccountQuery) GroupByUserIcon(asc bool) *OauthAccountQuery {
+	q.groupByFields = append(q.groupByFields, "user_icon")
+	q.groupByOrders = append(q.groupByOrders, asc)
+	return q
+}
+
+func (q *OauthAccountQuery) OrderById(asc bool) *OauthAccountQuery {
 	q.orderByFields = append(q.orderByFields, "id")
 	q.orderByOrders = append(q.orderByOrders, asc)
 	return q
 }
 
-func (q *PhoneAccountQuery) OrderByUserId(asc bool) *PhoneAccountQuery {
+func (q *OauthAccountQuery) OrderByUserId(asc bool) *OauthAccountQuery {
 	q.orderByFields = append(q.orderByFields, "user_id")
 	q.orderByOrders = append(q.orderByOrders, asc)
 	return q
 }
 
-func (q *PhoneAccountQuery) OrderByPhoneEncrypted(asc bool) *PhoneAccountQuery {
-	q.orderByFields = append(q.orderByFields, "phone_encrypted")
+func (q *OauthAccountQuery) OrderByProviderId(asc bool) *OauthAccountQuery {
+	q.orderByFields = append(q.orderByFields, "providerId")
 	q.orderByOrders = append(q.orderByOrders, asc)
 	return q
 }
 
-func (q *PhoneAccountQuery) OrderByCreateTime(asc bool) *PhoneAccountQuery {
+func (q *OauthAccountQuery) OrderByProviderName(asc bool) *OauthAccountQuery {
+	q.orderByFields = append(q.orderByFields, "provider_name")
+	q.orderByOrders = append(q.orderByOrders, asc)
+	return q
+}
+
+func (q *OauthAccountQuery) OrderByOpenId(asc bool) *OauthAccountQuery {
+	q.orderByFields = append(q.orderByFields, "open_id")
+	q.orderByOrders = append(q.orderByOrders, asc)
+	return q
+}
+
+func (q *OauthAccountQuery) OrderByUserName(asc bool) *OauthAccountQuery {
+	q.orderByFields = append(q.orderByFields, "user_name")
+	q.orderByOrders = append(q.orderByOrders, asc)
+	return q
+}
+
+func (q *OauthAccountQuery) OrderByUserIcon(asc bool) *OauthAccountQuery {
+	q.orderByFields = append(q.orderByFields, "user_icon")
+	q.orderByOrders = append(q.orderByOrders, asc)
+	return q
+}
+
+func (q *OauthAccountQuery) OrderByCreateTime(asc bool) *OauthAccountQuery {
 	q.orderByFields = append(q.orderByFields, "create_time")
 	q.orderByOrders = append(q.orderByOrders, asc)
 	return q
 }
 
-func (q *PhoneAccountQuery) OrderByUpdateTime(asc bool) *PhoneAccountQuery {
+func (q *OauthAccountQuery) OrderByUpdateTime(asc bool) *OauthAccountQuery {
 	q.orderByFields = append(q.orderByFields, "update_time")
 	q.orderByOrders = append(q.orderByOrders, asc)
 	return q
 }
 
-func (q *PhoneAccountQuery) OrderByGroupCount(asc bool) *PhoneAccountQuery {
+func (q *OauthAccountQuery) OrderByGroupCount(asc bool) *OauthAccountQuery {
 	q.orderByFields = append(q.orderByFields, "count(*)")
 	q.orderByOrders = append(q.orderByOrders, asc)
 	return q
 }
 
-func (q *PhoneAccountQuery) Limit(startIncluded int64, count int64) *PhoneAccountQuery {
+func (q *OauthAccountQuery) Limit(startIncluded int64, count int64) *OauthAccountQuery {
 	q.hasLimit = true
 	q.limitStartIncluded = startIncluded
 	q.limitCount = count
 	return q
 }
 
-func (q *PhoneAccountQuery) ForUpdate() *PhoneAccountQuery {
+func (q *OauthAccountQuery) ForUpdate() *OauthAccountQuery {
 	q.forUpdate = true
 	return q
 }
 
-func (q *PhoneAccountQuery) ForShare() *PhoneAccountQuery {
+func (q *OauthAccountQuery) ForShare() *OauthAccountQuery {
 	q.forShare = true
 	return q
 }
 
-func (q *PhoneAccountQuery) SetUserId(v string) *PhoneAccountQuery {
+func (q *OauthAccountQuery) SetUserId(v string) *OauthAccountQuery {
 	q.updateFields = append(q.updateFields, "user_id")
 	q.updateParams = append(q.updateParams, v)
 	return q
 }
 
-func (q *PhoneAccountQuery) SetPhoneEncrypted(v string) *PhoneAccountQuery {
-	q.updateFields = append(q.updateFields, "phone_encrypted")
+func (q *OauthAccountQuery) SetProviderId(v string) *OauthAccountQuery {
+	q.updateFields = append(q.updateFields, "providerId")
+	q.updateParams = append(q.updateParams, v)
+	return q
+}
+
+func (q *OauthAccountQuery) SetProviderName(v string) *OauthAccountQuery {
+	q.updateFields = append(q.updateFields, "provider_name")
+	q.updateParams = append(q.updateParams, v)
+	return q
+}
+
+func (q *OauthAccountQuery) SetOpenId(v string) *OauthAccountQuery {
+	q.updateFields = append(q.updateFields, "open_id")
 	q.updateParams = append(q.updateParams, v)
 	return q
 }
 
-func (q *PhoneAccountQuery) DuplicatedUpdateUserId() *PhoneAccountQuery {
-	q.duplicatedUpdateFields = append(q.duplicatedUpdateFields, "user_id=VALUES(user_id)")
+func (q *OauthAccountQuery) SetUserName(v string) *OauthAccountQuery {
+	q.updateFields = append(q.updateFields, "user_name")
+	q.updateParams = append(q.updateParams, v)
+	return q
+}
+
+func (q *OauthAccountQuery) SetUserIcon(v string) *OauthAccountQuery {
+	q.updateFields = append(q.updateFields, "user_icon")
+	q.updateParams = append(q.updateParams, v)
+	return q
+}
+
+func (q *OauthAccountQuery) DuplicatedUpdateUserId() *OauthAccountQuery {
+	q.duplicatedUpdateFields = append(q.duplicatedUpdateFields, "user_id=VALUES(user_id)")
+	return q
+}
+
+func (q *OauthAccountQuery) DuplicatedUpdateProviderName() *OauthAccountQuery {
+	q.duplicatedUpdateFields = append(q.duplicatedUpdateFields, "provider_name=VALUES(provider_name)")
+	return q
+}
+
+func (q *OauthAccountQuery) DuplicatedUpdateUserName() *OauthAccountQuery {
+	q.duplicatedUpdateFields = append(q.duplicatedUpdateFields, "user_name=VALUES(user_name)")
+	return q
+}
+
+func (q *OauthAccountQuery) DuplicatedUpdateUserIcon() *OauthAccountQuery {
+	q.duplicatedUpdateFields = append(q.duplicatedUpdateFields, "user_icon=VALUES(user_icon)")
+	return q
+}
+
+func (q *OauthAccountQuery) GetId() *OauthAccountQuery {
+	q.getFields = append(q.getFields, "id")
+	return q
+}
+
+func (q *OauthAccountQuery) GetUserId() *OauthAccountQuery {
+	q.getFields = append(q.getFields, "user_id")
+	return q
+}
+
+func (q *OauthAccountQuery) GetProviderId() *OauthAccountQuery {
+	q.getFields = append(q.getFields, "providerId")
+	return q
+}
+
+func (q *OauthAccountQuery) GetProviderName() *OauthAccountQuery {
+	q.getFields = append(q.getFields, "provider_name")
+	return q
+}
+
+func (q *OauthAccountQuery) GetOpenId() *OauthAccountQuery {
+	q.getFields = append(q.getFields, "open_id")
+	return q
+}
+
+func (q *OauthAccountQuery) GetUserName() *OauthAccountQuery {
+	q.getFields = append(q.getFields, "user_name")
+	return q
+}
+
+func (q *OauthAccountQuery) GetUserIcon() *OauthAccountQuery {
+	q.getFields = append(q.getFields, "user_icon")
+	return q
+}
+
+func (q *OauthAccountQuery) GetCreateTime() *OauthAccountQuery {
+	q.getFields = append(q.getFields, "create_time")
+	return q
+}
+
+func (q *OauthAccountQuery) GetUpdateTime() *OauthAccountQuery {
+	q.getFields = append(q.getFields, "update_time")
+	return q
+}
+
+func (q *OauthAccountQuery) Select(ctx context.Context, tx *wrap.Tx) (e *OauthAccount, err error) {
+	if !q.hasLimit {
+		q.limitCount = 1
+		q.hasLimit = true
+	}
+
+	queryString, params := q.buildSelectQuery()
+	query := bytes.NewBufferString("")
+	if len(q.getFields) == 0 {
+		query.WriteString("SELECT id,user_id,providerId,provider_name,open_id,user_name,user_icon,create_time,update_time FROM oauth_account ")
+	} else {
+		query.WriteString("SELECT ")
+		query.WriteString(strings.Join(q.getFields, ","))
+		query.WriteString(" FROM oauth_account ")
+	}
+	query.WriteString(queryString)
+	e = &OauthAccount{}
+	row := q.dao.db.QueryRow(ctx, tx, query.String(), params...)
+	err = row.Scan(&e.Id, &e.UserId, &e.ProviderId, &e.ProviderName, &e.OpenId, &e.UserName, &e.UserIcon, &e.CreateTime, &e.UpdateTime)
+	if err == wrap.ErrNoRows {
+		return nil, nil
+	}
+
+	return e, err
+}
+
+func (q *OauthAccountQuery) SelectList(ctx context.Context, tx *wrap.Tx) (list []*OauthAccount, err error) {
+	queryString, params := q.buildSelectQuery()
+	query := bytes.NewBufferString("")
+	if len(q.getFields) == 0 {
+		query.WriteString("SELECT id,user_id,providerId,provider_name,open_id,user_name,user_icon,create_time,update_time FROM oauth_account ")
+	} else {
+		query.WriteString("SELECT ")
+		query.WriteString(strings.Join(q.getFields, ","))
+		query.WriteString(" FROM oauth_account ")
+	}
+	query.WriteString(queryString)
+	rows, err := q.dao.db.Query(ctx, tx, query.String(), params...)
+	if err != nil {
+		return nil, err
+	}
+	for rows.Next() {
+		e := OauthAccount{}
+		err = rows.Scan(&e.Id, &e.UserId, &e.ProviderId, &e.ProviderName, &e.OpenId, &e.UserName, &e.UserIcon, &e.CreateTime, &e.UpdateTime)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, &e)
+	}
+	if rows.Err() != nil {
+		err = rows.Err()
+		return nil, err
+	}
+
+	return list, nil
+}
+
+func (q *OauthAccountQuery) SelectCount(ctx context.Context, tx *wrap.Tx) (count int64, err error) {
+	queryString, params := q.buildSelectQuery()
+	query := bytes.NewBufferString("")
+	query.WriteString("SELECT COUNT(*) FROM oauth_account ")
+	query.WriteString(queryString)
+	row := q.dao.db.QueryRow(ctx, tx, query.String(), params...)
+	err = row.Scan(&count)
+
+	return count, err
+}
+
+func (q *OauthAccountQuery) SelectGroupBy(ctx context.Context, tx *wrap.Tx, withCount bool) (rows *wrap.Rows, err error) {
+	queryString, params := q.buildSelectQuery()
+	query := bytes.NewBufferString("")
+	query.WriteString("SELECT ")
+	query.WriteString(strings.Join(q.groupByFields, ","))
+	if withCount {
+		query.WriteString(",MachineListCount(*) ")
+	}
+	query.WriteString(" FROM oauth_account ")
+	query.WriteString(queryString)
+
+	return q.dao.db.Query(ctx, tx, query.String(), params...)
+}
+
+func (q *OauthAccountQuery) SelectRow(ctx context.Context, tx *wrap.Tx) (row *wrap.Row) {
+	if !q.hasLimit {
+		q.limitCount = 1
+		q.hasLimit = true
+	}
+
+	queryString, params := q.buildSelectQuery()
+	query := bytes.NewBufferString("")
+	query.WriteString("SELECT ")
+	query.WriteString(strings.Join(q.getFields, ","))
+	query.WriteString(" FROM oauth_account ")
+	query.WriteString(queryString)
+	return q.dao.db.QueryRow(ctx, tx, query.String(), params...)
+}
+
+func (q *OauthAccountQuery) SelectRows(ctx context.Context, tx *wrap.Tx) (rows *wrap.Rows, err error) {
+	queryString, params := q.buildSelectQuery()
+	query := bytes.NewBufferString("")
+	query.WriteString("SELECT ")
+	query.WriteString(strings.Join(q.getFields, ","))
+	query.WriteString(" FROM oauth_account ")
+	query.WriteString(queryString)
+	return q.dao.db.Query(ctx, tx, query.String(), params...)
+}
+
+func (q *OauthAccountQuery) Insert(ctx context.Context, tx *wrap.Tx, e *OauthAccount) (result *wrap.Result, err error) {
+	query := bytes.NewBufferString("")
+	query.WriteString("INSERT INTO oauth_account (user_id,providerId,provider_name,open_id,user_name,user_icon) VALUES (?,?,?,?,?,?)")
+	params := []interface{}{e.UserId, e.ProviderId, e.ProviderName, e.OpenId, e.UserName, e.UserIcon}
+	return q.dao.db.Exec(ctx, tx, query.String(), params...)
+}
+
+func (q *OauthAccountQuery) BatchInsert(ctx context.Context, tx *wrap.Tx, list []*OauthAccount) (result *wrap.Result, err error) {
+	query := bytes.NewBufferString("")
+	query.WriteString("INSERT INTO oauth_account (user_id,providerId,provider_name,open_id,user_name,user_icon) VALUES ")
+	query.WriteString(wrap.RepeatWithSeparator("(?,?,?,?,?,?)", len(list), ","))
+	params := make([]interface{}, len(list)*6)
+	offset := 0
+	for _, e := range list {
+		params[offset+0] = e.UserId
+		params[offset+1] = e.ProviderId
+		params[offset+2] = e.ProviderName
+		params[offset+3] = e.OpenId
+		params[offset+4] = e.UserName
+		params[offset+5] = e.UserIcon
+		offset += 6
+	}
+
+	return q.dao.db.Exec(ctx, tx, query.String(), params...)
+}
+
+func (q *OauthAccountQuery) InsertOnDuplicatedKeyUpdate(ctx context.Context, tx *wrap.Tx, e *OauthAccount) (result *wrap.Result, err error) {
+	query := bytes.NewBufferString("")
+	query.WriteString("INSERT INTO oauth_account (user_id,providerId,provider_name,open_id,user_name,user_icon) VALUES (?,?,?,?,?,?)")
+	query.WriteString(" ON DUPLICATED KEY UPDATE ")
+	if len(q.duplicatedUpdateFields) > 0 {
+		query.WriteString(strings.Join(q.duplicatedUpdateFields, ","))
+		query.WriteString(",")
+	}
+	query.WriteString("update_time=now()")
+	params := []interface{}{e.UserId, e.ProviderId, e.ProviderName, e.OpenId, e.UserName, e.UserIcon}
+	return q.dao.db.Exec(ctx, tx, query.String(), params...)
+}
+
+func (q *OauthAccountQuery) BatchInsertOnDuplicatedKeyUpdate(ctx context.Context, tx *wrap.Tx, list []*OauthAccount) (result *wrap.Result, err error) {
+	query := bytes.NewBufferString("")
+	query.WriteString("INSERT INTO oauth_account (user_id,providerId,provider_name,open_id,user_name,user_icon) VALUES ")
+	query.WriteString(wrap.RepeatWithSeparator("(?,?,?,?,?,?)", len(list), ","))
+	query.WriteString(" ON DUPLICATED KEY UPDATE")
+	if len(q.duplicatedUpdateFields) > 0 {
+		query.WriteString(strings.Join(q.duplicatedUpdateFields, ","))
+		query.WriteString(",")
+	}
+	query.WriteString("update_time=now()")
+	params := make([]interface{}, len(list)*6)
+	offset := 0
+	for _, e := range list {
+		params[offset+0] = e.UserId
+		params[offset+1] = e.ProviderId
+		params[offset+2] = e.ProviderName
+		params[offset+3] = e.OpenId
+		params[offset+4] = e.UserName
+		params[offset+5] = e.UserIcon
+		offset += 6
+	}
+
+	return q.dao.db.Exec(ctx, tx, query.String(), params...)
+}
+
+func (q *OauthAccountQuery) Update(ctx context.Context, tx *wrap.Tx) (result *wrap.Result, err error) {
+	query := bytes.NewBufferString("")
+	var params []interface{}
+	params = append(params, q.updateParams...)
+	query.WriteString("UPDATE oauth_account SET ")
+	updateItems := make([]string, len(q.updateFields))
+	for i, v := range q.updateFields {
+		updateItems[i] = v + "=?"
+	}
+	query.WriteString(strings.Join(updateItems, ","))
+	query.WriteString(",update_time=now()")
+	where := q.where.String()
+	if where != "" {
+		query.WriteString(" WHERE ")
+		query.WriteString(where)
+		params = append(params, q.whereParams...)
+	}
+
+	return q.dao.db.Exec(ctx, tx, query.String(), params...)
+}
+
+func (q *OauthAccountQuery) Delete(ctx context.Context, tx *wrap.Tx) (result *wrap.Result, err error) {
+	query := "DELETE FROM oauth_account WHERE " + q.where.String()
+	return q.dao.db.Exec(ctx, tx, query, q.whereParams...)
+}
+
+type OauthAccountDao struct {
+	logger *zap.Logger
+	db     *DB
+}
+
+func NewOauthAccountDao(db *DB) (t *OauthAccountDao, err error) {
+	t = &OauthAccountDao{}
+	t.logger = log.TypedLogger(t)
+	t.db = db
+
+	return t, nil
+}
+
+func (dao *OauthAccountDao) Query() *OauthAccountQuery {
+	q := &OauthAccountQuery{}
+	q.dao = dao
+	q.tableName = "oauth_account"
+	q.where = bytes.NewBufferString("")
+	return q
+}
+
+type OauthAuthorizationCode struct {
+	Id                  uint64 //size=20
+	Code                string //size=128
+	ClientId            string //size=32
+	UserId              string //size=32
+	RedirectUri         string //size=1024
+	Scope               string //size=1024
+	CodeChallenge       string //size=128
+	CodeChallengeMethod string //size=16
+	Nonce               string //size=256
+	AuthTime            time.Time
+	Amr                 string //size=64
+	IsUsed              int32  //size=1
+	SessionId           string //size=32
+	ExpireTime          time.Time
+	CreateTime          time.Time
+	UpdateTime          time.Time
+}
+
+type OauthAuthorizationCodeQuery struct {
+	QueryBase
+	dao *OauthAuthorizationCodeDao
+}
+
+func (q *OauthAuthorizationCodeQuery) Left() *OauthAuthorizationCodeQuery {
+	q.where.WriteString(" (")
+	return q
+}
+
+func (q *OauthAuthorizationCodeQuery) Right() *OauthAuthorizationCodeQuery {
+	q.where.WriteString(" )")
+	return q
+}
+
+func (q *OauthAuthorizationCodeQuery) And() *OauthAuthorizationCodeQuery {
+	q.where.WriteString(" AND")
+	return q
+}
+
+func (q *OauthAuthorizationCodeQuery) Or() *OauthAuthorizationCodeQuery {
+	q.where.WriteString(" OR")
+	return q
+}
+
+func (q *OauthAuthorizationCodeQuery) Not() *OauthAuthorizationCodeQuery {
+	q.where.WriteString(" NOT")
+	return q
+}
+
+func (q *OauthAuthorizationCodeQuery) IdEqual(v uint64) *OauthAuthorizationCodeQuery {
+	q.where.WriteString(" id=?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *OauthAuthorizationCodeQuery) IdNotEqual(v uint64) *OauthAuthorizationCodeQuery {
+	q.where.WriteString(" id<>?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *OauthAuthorizationCodeQuery) IdLess(v uint64) *OauthAuthorizationCodeQuery {
+	q.where.WriteString(" id<?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *OauthAuthorizationCodeQuery) IdLessEqual(v uint64) *OauthAuthorizationCodeQuery {
+	q.where.WriteString(" id<=?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *OauthAuthorizationCodeQuery) IdGreater(v uint64) *OauthAuthorizationCodeQuery {
+	q.where.WriteString(" id>?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *OauthAuthorizationCodeQuery) IdGreaterEqual(v uint64) *OauthAuthorizationCodeQuery {
+	q.where.WriteString(" id>=?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *OauthAuthorizationCodeQuery) IdIn(items []uint64) *OauthAuthorizationCodeQuery {
+	q.where.WriteString(" id IN(")
+	q.where.WriteString(wrap.RepeatWithSeparator("?", len(items), ","))
+	q.where.WriteString(")")
+	q.whereParams = append(q.whereParams, items)
+	return q
+}
+
+func (q *OauthAuthorizationCodeQuery) CodeEqual(v string) *OauthAuthorizationCodeQuery {
+	q.where.WriteString(" code=?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *OauthAuthorizationCodeQuery) CodeNotEqual(v string) *OauthAuthorizationCodeQuery {
+	q.where.WriteString(" code<>?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *OauthAuthorizationCodeQuery) CodeIn(items []string) *OauthAuthorizationCodeQuery {
+	q.where.WriteString(" code IN(")
+	q.where.WriteString(wrap.RepeatWithSeparator("?", len(items), ","))
+	q.where.WriteString(")")
+	q.whereParams = append(q.whereParams, items)
+	return q
+}
+
+func (q *OauthAuthorizationCodeQuery) ClientIdEqual(v string) *OauthAuthorizationCodeQuery {
+	q.where.WriteString(" client_id=?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *OauthAuthorizationCodeQuery) ClientIdNotEqual(v string) *OauthAuthorizationCodeQuery {
+	q.where.WriteString(" client_id<>?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *OauthAuthorizationCodeQuery) ClientIdIn(items []string) *OauthAuthorizationCodeQuery {
+	q.where.WriteString(" client_id IN(")
+	q.where.WriteString(wrap.RepeatWithSeparator("?", len(items), ","))
+	q.where.WriteString(")")
+	q.whereParams = append(q.whereParams, items)
+	return q
+}
+
+func (q *OauthAuthorizationCodeQuery) UserIdEqual(v string) *OauthAuthorizationCodeQuery {
+	q.where.WriteString(" user_id=?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *OauthAuthorizationCodeQuery) UserIdNotEqual(v string) *OauthAuthorizationCodeQuery {
+	q.where.WriteString(" user_id<>?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *OauthAuthorizationCodeQuery) UserIdIn(items []string) *OauthAuthorizationCodeQuery {
+	q.where.WriteString(" user_id IN(")
+	q.where.WriteString(wrap.RepeatWithSeparator("?", len(items), ","))
+	q.where.WriteString(")")
+	q.whereParams = append(q.whereParams, items)
+	return q
+}
+
+func (q *OauthAuthorizationCodeQuery) RedirectUriEqual(v string) *OauthAuthorizationCodeQuery {
+	q.where.WriteString(" redirect_uri=?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *OauthAuthorizationCodeQuery) RedirectUriNotEqual(v string) *OauthAuthorizationCodeQuery {
+	q.where.WriteString(" redirect_uri<>?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *OauthAuthorizationCodeQuery) RedirectUriIn(items []string) *OauthAuthorizationCodeQuery {
+	q.where.WriteString(" redirect_uri IN(")
+	q.where.WriteString(wrap.RepeatWithSeparator("?", len(items), ","))
+	q.where.WriteString(")")
+	q.whereParams = append(q.whereParams, items)
+	return q
+}
+
+func (q *OauthAuthorizationCodeQuery) ScopeEqual(v string) *OauthAuthorizationCodeQuery {
+	q.where.WriteString(" scope=?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *OauthAuthorizationCodeQuery) ScopeNotEqual(v string) *OauthAuthorizationCodeQuery {
+	q.where.WriteString(" scope<>?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *OauthAuthorizationCodeQuery) ScopeIn(items []string) *OauthAuthorizationCodeQuery {
+	q.where.WriteString(" scope IN(")
+	q.where.WriteString(wrap.RepeatWithSeparator("?", len(items), ","))
+	q.where.WriteString(")")
+	q.whereParams = append(q.whereParams, items)
+	return q
+}
+
+func (q *OauthAuthorizationCodeQuery) CodeChallengeEqual(v string) *OauthAuthorizationCodeQuery {
+	q.where.WriteString(" code_challenge=?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *OauthAuthorizationCodeQuery) CodeChallengeNotEqual(v string) *OauthAuthorizationCodeQuery {
+	q.where.WriteString(" code_challenge<>?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *OauthAuthorizationCodeQuery) CodeChallengeIn(items []string) *OauthAuthorizationCodeQuery {
+	q.where.WriteString(" code_challenge IN(")
+	q.where.WriteString(wrap.RepeatWithSeparator("?", len(items), ","))
+	q.where.WriteString(")")
+	q.whereParams = append(q.whereParams, items)
+	return q
+}
+
+func (q *OauthAuthorizationCodeQuery) CodeChallengeMethodEqual(v string) *OauthAuthorizationCodeQuery {
+	q.where.WriteString(" code_challenge_method=?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *OauthAuthorizationCodeQuery) CodeChallengeMethodNotEqual(v string) *OauthAuthorizationCodeQuery {
+	q.where.WriteString(" code_challenge_method<>?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *OauthAuthorizationCodeQuery) CodeChallengeMethodIn(items []string) *OauthAuthorizationCodeQuery {
+	q.where.WriteString(" code_challenge_method IN(")
+	q.where.WriteString(wrap.RepeatWithSeparator("?", len(items), ","))
+	q.where.WriteString(")")
+	q.whereParams = append(q.whereParams, items)
+	return q
+}
+
+func (q *OauthAuthorizationCodeQuery) NonceEqual(v string) *OauthAuthorizationCodeQuery {
+	q.where.WriteString(" nonce=?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *OauthAuthorizationCodeQuery) NonceNotEqual(v string) *OauthAuthorizationCodeQuery {
+	q.where.WriteString(" nonce<>?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *OauthAuthorizationCodeQuery) NonceIn(items []string) *OauthAuthorizationCodeQuery {
+	q.where.WriteString(" nonce IN(")
+	q.where.WriteString(wrap.RepeatWithSeparator("?", len(items), ","))
+	q.where.WriteString(")")
+	q.whereParams = append(q.whereParams, items)
+	return q
+}
+
+func (q *OauthAuthorizationCodeQuery) AuthTimeEqual(v time.Time) *OauthAuthorizationCodeQuery {
+	q.where.WriteString(" auth_time=?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *OauthAuthorizationCodeQuery) AuthTimeNotEqual(v time.Time) *OauthAuthorizationCodeQuery {
+	q.where.WriteString(" auth_time<>?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *OauthAuthorizationCodeQuery) AuthTimeLess(v time.Time) *OauthAuthorizationCodeQuery {
+	q.where.WriteString(" auth_time<?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *OauthAuthorizationCodeQuery) AuthTimeLessEqual(v time.Time) *OauthAuthorizationCodeQuery {
+	q.where.WriteString(" auth_time<=?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *OauthAuthorizationCodeQuery) AuthTimeGreater(v time.Time) *OauthAuthorizationCodeQuery {
+	q.where.WriteString(" auth_time>?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *OauthAuthorizationCodeQuery) AuthTimeGreaterEqual(v time.Time) *OauthAuthorizationCodeQuery {
+	q.where.WriteString(" auth_time>=?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *OauthAuthorizationCodeQuery) AmrEqual(v string) *OauthAuthorizationCodeQuery {
+	q.where.WriteString(" amr=?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *OauthAuthorizationCodeQuery) AmrNotEqual(v string) *OauthAuthorizationCodeQuery {
+	q.where.WriteString(" amr<>?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *OauthAuthorizationCodeQuery) AmrIn(items []string) *OauthAuthorizationCodeQuery {
+	q.where.WriteString(" amr IN(")
+	q.where.WriteString(wrap.RepeatWithSeparator("?", len(items), ","))
+	q.where.WriteString(")")
+	q.whereParams = append(q.whereParams, items)
+	return q
+}
+
+func (q *OauthAuthorizationCodeQuery) IsUsedEqual(v int32) *OauthAuthorizationCodeQuery {
+	q.where.WriteString(" is_used=?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *OauthAuthorizationCodeQuery) IsUsedNotEqual(v int32) *OauthAuthorizationCodeQuery {
+	q.where.WriteString(" is_used<>?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *OauthAuthorizationCodeQuery) IsUsedLess(v int32) *OauthAuthorizationCodeQuery {
+	q.where.WriteString(" is_used<?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *OauthAuthorizationCodeQuery) IsUsedLessEqual(v int32) *OauthAuthorizationCodeQuery {
+	q.where.WriteString(" is_used<=?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *OauthAuthorizationCodeQuery) IsUsedGreater(v int32) *OauthAuthorizationCodeQuery {
+	q.where.WriteString(" is_used>?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *OauthAuthorizationCodeQuery) IsUsedGreaterEqual(v int32) *OauthAuthorizationCodeQuery {
+	q.where.WriteString(" is_used>=?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *OauthAuthorizationCodeQuery) IsUsedIn(items []int32) *OauthAuthorizationCodeQuery {
+	q.where.WriteString(" is_used IN(")
+	q.where.WriteString(wrap.RepeatWithSeparator("?", len(items), ","))
+	q.where.WriteString(")")
+	q.whereParams = append(q.whereParams, items)
+	return q
+}
+
+func (q *OauthAuthorizationCodeQuery) SessionIdEqual(v string) *OauthAuthorizationCodeQuery {
+	q.where.WriteString(" session_id=?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *OauthAuthorizationCodeQuery) SessionIdNotEqual(v string) *OauthAuthorizationCodeQuery {
+	q.where.WriteString(" session_id<>?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *OauthAuthorizationCodeQuery) SessionIdIn(items []string) *OauthAuthorizationCodeQuery {
+	q.where.WriteString(" session_id IN(")
+	q.where.WriteString(wrap.RepeatWithSeparator("?", len(items), ","))
+	q.where.WriteString(")")
+	q.whereParams = append(q.whereParams, items)
+	return q
+}
+
+func (q *OauthAuthorizationCodeQuery) ExpireTimeEqual(v time.Time) *OauthAuthorizationCodeQuery {
+	q.where.WriteString(" expire_time=?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *OauthAuthorizationCodeQuery) ExpireTimeNotEqual(v time.Time) *OauthAuthorizationCodeQuery {
+	q.where.WriteString(" expire_time<>?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *OauthAuthorizationCodeQuery) ExpireTimeLess(v time.Time) *OauthAuthorizationCodeQuery {
+	q.where.WriteString(" expire_time<?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *OauthAuthorizationCodeQuery) ExpireTimeLessEqual(v time.Time) *OauthAuthorizationCodeQuery {
+	q.where.WriteString(" expire_time<=?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *OauthAuthorizationCodeQuery) ExpireTimeGreater(v time.Time) *OauthAuthorizationCodeQuery {
+	q.where.WriteString(" expire_time>?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *OauthAuthorizationCodeQuery) ExpireTimeGreaterEqual(v time.Time) *OauthAuthorizationCodeQuery {
+	q.where.WriteString(" expire_time>=?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *OauthAuthorizationCodeQuery) CreateTimeEqual(v time.Time) *OauthAuthorizationCodeQuery {
+	q.where.WriteString(" create_time=?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *OauthAuthorizationCodeQuery) CreateTimeNotEqual(v time.Time) *OauthAuthorizationCodeQuery {
+	q.where.WriteString(" create_time<>?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *OauthAuthorizationCodeQuery) CreateTimeLess(v time.Time) *OauthAuthorizationCodeQuery {
+	q.where.WriteString(" create_time<?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *OauthAuthorizationCodeQuery) CreateTimeLessEqual(v time.Time) *OauthAuthorizationCodeQuery {
+	q.where.WriteString(" create_time<=?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *OauthAuthorizationCodeQuery) CreateTimeGreater(v time.Time) *OauthAuthorizationCodeQuery {
+	q.where.WriteString(" create_time>?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *OauthAuthorizationCodeQuery) CreateTimeGreaterEqual(v time.Time) *OauthAuthorizationCodeQuery {
+	q.where.WriteString(" create_time>=?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *OauthAuthorizationCodeQuery) UpdateTimeEqual(v time.Time) *OauthAuthorizationCodeQuery {
+	q.where.WriteString(" update_time=?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *OauthAuthorizationCodeQuery) UpdateTimeNotEqual(v time.Time) *OauthAuthorizationCodeQuery {
+	q.where.WriteString(" update_time<>?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *OauthAuthorizationCodeQuery) UpdateTimeLess(v time.Time) *OauthAuthorizationCodeQuery {
+	q.where.WriteString(" update_time<?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *OauthAuthorizationCodeQuery) UpdateTimeLessEqual(v time.Time) *OauthAuthorizationCodeQuery {
+	q.where.WriteString(" update_time<=?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *OauthAuthorizationCodeQuery) UpdateTimeGreater(v time.Time) *OauthAuthorizationCodeQuery {
+	q.where.WriteString(" update_time>?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *OauthAuthorizationCodeQuery) UpdateTimeGreaterEqual(v time.Time) *OauthAuthorizationCodeQuery {
+	q.where.WriteString(" update_time>=?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *OauthAuthorizationCodeQuery) GroupByClientId(asc bool) *OauthAuthorizationCodeQuery {
+	q.groupByFields = append(q.groupByFields, "client_id")
+	q.groupByOrders = append(q.groupByOrders, asc)
+	return q
+}
+
+func (q *OauthAuthorizationCodeQuery) GroupByUserId(asc bool) *OauthAuthorizationCodeQuery {
+	q.groupByFields = append(q.groupByFields, "user_id")
+	q.groupByOrders = append(q.groupByOrders, asc)
+	return q
+}
+
+func (q *OauthAuthorizationCodeQuery) GroupByRedirectUri(asc bool) *OauthAuthorizationCodeQuery {
+	q.groupByFields = append(q.groupByFields, "redirect_uri")
+	q.groupByOrders = append(q.groupByOrders, asc)
+	return q
+}
+
+func (q *OauthAuthorizationCodeQuery) GroupByScope(asc bool) *OauthAuthorizationCodeQuery {
+	q.groupByFields = append(q.groupByFields, "scope")
+	q.groupByOrders = append(q.groupByOrders, asc)
+	return q
+}
+
+func (q *OauthAuthorizationCodeQuery) GroupByCodeChallenge(asc bool) *OauthAuthorizationCodeQuery {
+	q.groupByFields = append(q.groupByFields, "code_challenge")
+	q.groupByOrders = append(q.groupByOrders, asc)
+	return q
+}
+
+func (q *OauthAuthorizationCodeQuery) GroupByCodeChallengeMethod(asc bool) *OauthAuthorizationCodeQuery {
+	q.groupByFields = append(q.groupByFields, "code_challenge_method")
+	q.groupByOrders = append(q.groupByOrders, asc)
+	return q
+}
+
+func (q *OauthAuthorizationCodeQuery) GroupByNonce(asc bool) *OauthAuthorizationCodeQuery {
+	q.groupByFields = append(q.groupByFields, "nonce")
+	q.groupByOrders = append(q.groupByOrders, asc)
+	return q
+}
+
+func (q *OauthAuthorizationCodeQuery) GroupByAmr(asc bool) *OauthAuthorizationCodeQuery {
+	q.groupByFields = append(q.groupByFields, "amr")
+	q.groupByOrders = append(q.groupByOrders, asc)
+	return q
+}
+
+func (q *OauthAuthorizationCodeQuery) GroupByIsUsed(asc bool) *OauthAuthorizationCodeQuery {
+	q.groupByFields = append(q.groupByFields, "is_used")
+	q.groupByOrders = append(q.groupByOrders, asc)
+	return q
+}
+
+func (q *OauthAuthorizationCodeQuery) GroupBySessionId(asc bool) *OauthAuthorizationCodeQuery {
+	q.groupByFields = append(q.groupByFields, "session_id")
+	q.groupByOrders = append(q.groupByOrders, asc)
+	return q
+}
+
+func (q *OauthAuthorizationCodeQuery) OrderById(asc bool) *OauthAuthorizationCodeQuery {
+	q.orderByFields = append(q.orderByFields, "id")
+	q.orderByOrders = append(q.orderByOrders, asc)
+	return q
+}
+
+func (q *OauthAuthorizationCodeQuery) OrderByCode(asc bool) *OauthAuthorizationCodeQuery {
+	q.orderByFields = append(q.orderByFields, "code")
+	q.orderByOrders = append(q.orderByOrders, asc)
+	return q
+}
+
+func (q *OauthAuthorizationCodeQuery) OrderByClientId(asc bool) *OauthAuthorizationCodeQuery {
+	q.orderByFields = append(q.orderByFields, "client_id")
+	q.orderByOrders = append(q.orderByOrders, asc)
+	return q
+}
+
+func (q *OauthAuthorizationCodeQuery) OrderByUserId(asc bool) *OauthAuthorizationCodeQuery {
+	q.orderByFields = append(q.orderByFields, "user_id")
+	q.orderByOrders = append(q.orderByOrders, asc)
+	return q
+}
+
+func (q *OauthAuthorizationCodeQuery) OrderByRedirectUri(asc bool) *OauthAuthorizationCodeQuery {
+	q.orderByFields = append(q.orderByFields, "redirect_uri")
+	q.orderByOrders = append(q.orderByOrders, asc)
+	return q
+}
+
+func (q *OauthAuthorizationCodeQuery) OrderByScope(asc bool) *OauthAuthorizationCodeQuery {
+	q.orderByFields = append(q.orderByFields, "scope")
+	q.orderByOrders = append(q.orderByOrders, asc)
+	return q
+}
+
+func (q *OauthAuthorizationCodeQuery) OrderByCodeChallenge(asc bool) *OauthAuthorizationCodeQuery {
+	q.orderByFields = append(q.orderByFields, "code_challenge")
+	q.orderByOrders = append(q.orderByOrders, asc)
+	return q
+}
+
+func (q *OauthAuthorizationCodeQuery) OrderByCodeChallengeMethod(asc bool) *OauthAuthorizationCodeQuery {
+	q.orderByFields = append(q.orderByFields, "code_challenge_method")
+	q.orderByOrders = append(q.orderByOrders, asc)
+	return q
+}
+
+func (q *OauthAuthorizationCodeQuery) OrderByNonce(asc bool) *OauthAuthorizationCodeQuery {
+	q.orderByFields = append(q.orderByFields, "nonce")
+	q.orderByOrders = append(q.orderByOrders, asc)
+	return q
+}
+
+func (q *OauthAuthorizationCodeQuery) OrderByAuthTime(asc bool) *OauthAuthorizationCodeQuery {
+	q.orderByFields = append(q.orderByFields, "auth_time")
+	q.orderByOrders = append(q.orderByOrders, asc)
+	return q
+}
+
+func (q *OauthAuthorizationCodeQuery) OrderByAmr(asc bool) *OauthAuthorizationCodeQuery {
+	q.orderByFields = append(q.orderByFields, "amr")
+	q.orderByOrders = append(q.orderByOrders, asc)
+	return q
+}
+
+func (q *OauthAuthorizationCodeQuery) OrderByIsUsed(asc bool) *OauthAuthorizationCodeQuery {
+	q.orderByFields = append(q.orderByFields, "is_used")
+	q.orderByOrders = append(q.orderByOrders, asc)
+	return q
+}
+
+func (q *OauthAuthorizationCodeQuery) OrderBySessionId(asc bool) *OauthAuthorizationCodeQuery {
+	q.orderByFields = append(q.orderByFields, "session_id")
+	q.orderByOrders = append(q.orderByOrders, asc)
+	return q
+}
+
+func (q *OauthAuthorizationCodeQuery) OrderByExpireTime(asc bool) *OauthAuthorizationCodeQuery {
+	q.orderByFields = append(q.orderByFields, "expire_time")
+	q.orderByOrders = append(q.orderByOrders, asc)
+	return q
+}
+
+func (q *OauthAuthorizationCodeQuery) OrderByCreateTime(asc bool) *OauthAuthorizationCodeQuery {
+	q.orderByFields = append(q.orderByFields, "create_time")
+	q.orderByOrders = append(q.orderByOrders, asc)
+	return q
+}
+
+func (q *OauthAuthorizationCodeQuery) OrderByUpdateTime(asc bool) *OauthAuthorizationCodeQuery {
+	q.orderByFields = append(q.orderByFields, "update_time")
+	q.orderByOrders = append(q.orderByOrders, asc)
+	return q
+}
+
+func (q *OauthAuthorizationCodeQuery) OrderByGroupCount(asc bool) *OauthAuthorizationCodeQuery {
+	q.orderByFields = append(q.orderByFields, "count(*)")
+	q.orderByOrders = append(q.orderByOrders, asc)
+	return q
+}
+
+func (q *OauthAuthorizationCodeQuery) Limit(startIncluded int64, count int64) *OauthAuthorizationCodeQuery {
+	q.hasLimit = true
+	q.limitStartIncluded = startIncluded
+	q.limitCount = count
+	return q
+}
+
+func (q *OauthAuthorizationCodeQuery) ForUpdate() *OauthAuthorizationCodeQuery {
+	q.forUpdate = true
+	return q
+}
+
+func (q *OauthAuthorizationCodeQuery) ForShare() *OauthAuthorizationCodeQuery {
+	q.forShare = true
+	return q
+}
+
+func (q *OauthAuthorizationCodeQuery) SetCode(v string) *OauthAuthorizationCodeQuery {
+	q.updateFields = append(q.updateFields, "code")
+	q.updateParams = append(q.updateParams, v)
+	return q
+}
+
+func (q *OauthAuthorizationCodeQuery) SetClientId(v string) *OauthAuthorizationCodeQuery {
+	q.updateFields = append(q.updateFields, "client_id")
+	q.updateParams = append(q.updateParams, v)
+	return q
+}
+
+func (q *OauthAuthorizationCodeQuery) SetUserId(v string) *OauthAuthorizationCodeQuery {
+	q.updateFields = append(q.updateFields, "user_id")
+	q.updateParams = append(q.updateParams, v)
+	return q
+}
+
+func (q *OauthAuthorizationCodeQuery) SetRedirectUri(v string) *OauthAuthorizationCodeQuery {
+	q.updateFields = append(q.updateFields, "redirect_uri")
+	q.updateParams = append(q.updateParams, v)
+	return q
+}
+
+func (q *OauthAuthorizationCodeQuery) SetScope(v string) *OauthAuthorizationCodeQuery {
+	q.updateFields = append(q.updateFields, "scope")
+	q.updateParams = append(q.updateParams, v)
+	return q
+}
+
+func (q *OauthAuthorizationCodeQuery) SetCodeChallenge(v string) *OauthAuthorizationCodeQuery {
+	q.updateFields = append(q.updateFields, "code_challenge")
+	q.updateParams = append(q.updateParams, v)
+	return q
+}
+
+func (q *OauthAuthorizationCodeQuery) SetCodeChallengeMethod(v string) *OauthAuthorizationCodeQuery {
+	q.updateFields = append(q.updateFields, "code_challenge_method")
+	q.updateParams = append(q.updateParams, v)
+	return q
+}
+
+func (q *OauthAuthorizationCodeQuery) SetNonce(v string) *OauthAuthorizationCodeQuery {
+	q.updateFields = append(q.updateFields, "nonce")
+	q.updateParams = append(q.updateParams, v)
+	return q
+}
+
+func (q *OauthAuthorizationCodeQuery) SetAuthTime(v time.Time) *OauthAuthorizationCodeQuery {
+	q.updateFields = append(q.updateFields, "auth_time")
+	q.updateParams = append(q.updateParams, v)
+	return q
+}
+
+func (q *OauthAuthorizationCodeQuery) SetAmr(v string) *OauthAuthorizationCodeQuery {
+	q.updateFields = append(q.updateFields, "amr")
+	q.updateParams = append(q.updateParams, v)
+	return q
+}
+
+func (q *OauthAuthorizationCodeQuery) SetIsUsed(v int32) *OauthAuthorizationCodeQuery {
+	q.updateFields = append(q.updateFields, "is_used")
+	q.updateParams = append(q.updateParams, v)
+	return q
+}
+
+func (q *OauthAuthorizationCodeQuery) SetSessionId(v string) *OauthAuthorizationCodeQuery {
+	q.updateFields = append(q.updateFields, "session_id")
+	q.updateParams = append(q.updateParams, v)
+	return q
+}
+
+func (q *OauthAuthorizationCodeQuery) SetExpireTime(v time.Time) *OauthAuthorizationCodeQuery {
+	q.updateFields = append(q.updateFields, "expire_time")
+	q.updateParams = append(q.updateParams, v)
+	return q
+}
+
+func (q *OauthAuthorizationCodeQuery) DuplicatedUpdateClientId() *OauthAuthorizationCodeQuery {
+	q.duplicatedUpdateFields = append(q.duplicatedUpdateFields, "client_id=VALUES(client_id)")
+	return q
+}
+
+func (q *OauthAuthorizationCodeQuery) DuplicatedUpdateUserId() *OauthAuthorizationCodeQuery {
+	q.duplicatedUpdateFields = append(q.duplicatedUpdateFields, "user_id=VALUES(user_id)")
+	return q
+}
+
+func (q *OauthAuthorizationCodeQuery) DuplicatedUpdateRedirectUri() *OauthAuthorizationCodeQuery {
+	q.duplicatedUpdateFields = append(q.duplicatedUpdateFields, "redirect_uri=VALUES(redirect_uri)")
+	return q
+}
+
+func (q *OauthAuthorizationCodeQuery) DuplicatedUpdateScope() *OauthAuthorizationCodeQuery {
+	q.duplicatedUpdateFields = append(q.duplicatedUpdateFields, "scope=VALUES(scope)")
+	return q
+}
+
+func (q *OauthAuthorizationCodeQuery) DuplicatedUpdateCodeChallenge() *OauthAuthorizationCodeQuery {
+	q.duplicatedUpdateFields = append(q.duplicatedUpdateFields, "code_challenge=VALUES(code_challenge)")
+	return q
+}
+
+func (q *OauthAuthorizationCodeQuery) DuplicatedUpdateCodeChallengeMethod() *OauthAuthorizationCodeQuery {
+	q.duplicatedUpdateFields = append(q.duplicatedUpdateFields, "code_challenge_method=VALUES(code_challenge_method)")
+	return q
+}
+
+func (q *OauthAuthorizationCodeQuery) DuplicatedUpdateNonce() *OauthAuthorizationCodeQuery {
+	q.duplicatedUpdateFields = append(q.duplicatedUpdateFields, "nonce=VALUES(nonce)")
+	return q
+}
+
+func (q *OauthAuthorizationCodeQuery) DuplicatedUpdateAuthTime() *OauthAuthorizationCodeQuery {
+	q.duplicatedUpdateFields = append(q.duplicatedUpdateFields, "auth_time=VALUES(auth_time)")
+	return q
+}
+
+func (q *OauthAuthorizationCodeQuery) DuplicatedUpdateAmr() *OauthAuthorizationCodeQuery {
+	q.duplicatedUpdateFields = append(q.duplicatedUpdateFields, "amr=VALUES(amr)")
+	return q
+}
+
+func (q *OauthAuthorizationCodeQuery) DuplicatedUpdateIsUsed() *OauthAuthorizationCodeQuery {
+	q.duplicatedUpdateFields = append(q.duplicatedUpdateFields, "is_used=VALUES(is_used)")
+	return q
+}
+
+func (q *OauthAuthorizationCodeQuery) DuplicatedUpdateSessionId() *OauthAuthorizationCodeQuery {
+	q.duplicatedUpdateFields = append(q.duplicatedUpdateFields, "session_id=VALUES(session_id)")
+	return q
+}
+
+func (q *OauthAuthorizationCodeQuery) DuplicatedUpdateExpireTime() *OauthAuthorizationCodeQuery {
+	q.duplicatedUpdateFields = append(q.duplicatedUpdateFields, "expire_time=VALUES(expire_time)")
+	return q
+}
+
+func (q *OauthAuthorizationCodeQuery) GetId() *OauthAuthorizationCodeQuery {
+	q.getFields = append(q.getFields, "id")
+	return q
+}
+
+func (q *OauthAuthorizationCodeQuery) GetCode() *OauthAuthorizationCodeQuery {
+	q.getFields = append(q.getFields, "code")
+	return q
+}
+
+func (q *OauthAuthorizationCodeQuery) GetClientId() *OauthAuthorizationCodeQuery {
+	q.getFields = append(q.getFields, "client_id")
+	return q
+}
+
+func (q *OauthAuthorizationCodeQuery) GetUserId() *OauthAuthorizationCodeQuery {
+	q.getFields = append(q.getFields, "user_id")
+	return q
+}
+
+func (q *OauthAuthorizationCodeQuery) GetRedirectUri() *OauthAuthorizationCodeQuery {
+	q.getFields = append(q.getFields, "redirect_uri")
+	return q
+}
+
+func (q *OauthAuthorizationCodeQuery) GetScope() *OauthAuthorizationCodeQuery {
+	q.getFields = append(q.getFields, "scope")
+	return q
+}
+
+func (q *OauthAuthorizationCodeQuery) GetCodeChallenge() *OauthAuthorizationCodeQuery {
+	q.getFields = append(q.getFields, "code_challenge")
+	return q
+}
+
+func (q *OauthAuthorizationCodeQuery) GetCodeChallengeMethod() *OauthAuthorizationCodeQuery {
+	q.getFields = append(q.getFields, "code_challenge_method")
+	return q
+}
+
+func (q *OauthAuthorizationCodeQuery) GetNonce() *OauthAuthorizationCodeQuery {
+	q.getFields = append(q.getFields, "nonce")
+	return q
+}
+
+func (q *OauthAuthorizationCodeQuery) GetAuthTime() *OauthAuthorizationCodeQuery {
+	q.getFields = append(q.getFields, "auth_time")
+	return q
+}
+
+func (q *OauthAuthorizationCodeQuery) GetAmr() *OauthAuthorizationCodeQuery {
+	q.getFields = append(q.getFields, "amr")
+	return q
+}
+
+func (q *OauthAuthorizationCodeQuery) GetIsUsed() *OauthAuthorizationCodeQuery {
+	q.getFields = append(q.getFields, "is_used")
+	return q
+}
+
+func (q *OauthAuthorizationCodeQuery) GetSessionId() *OauthAuthorizationCodeQuery {
+	q.getFields = append(q.getFields, "session_id")
+	return q
+}
+
+func (q *OauthAuthorizationCodeQuery) GetExpireTime() *OauthAuthorizationCodeQuery {
+	q.getFields = append(q.getFields, "expire_time")
+	return q
+}
+
+func (q *OauthAuthorizationCodeQuery) GetCreateTime() *OauthAuthorizationCodeQuery {
+	q.getFields = append(q.getFields, "create_time")
+	return q
+}
+
+func (q *OauthAuthorizationCodeQuery) GetUpdateTime() *OauthAuthorizationCodeQuery {
+	q.getFields = append(q.getFields, "update_time")
+	return q
+}
+
+func (q *OauthAuthorizationCodeQuery) Select(ctx context.Context, tx *wrap.Tx) (e *OauthAuthorizationCode, err error) {
+	if !q.hasLimit {
+		q.limitCount = 1
+		q.hasLimit = true
+	}
+
+	queryString, params := q.buildSelectQuery()
+	query := bytes.NewBufferString("")
+	if len(q.getFields) == 0 {
+		query.WriteString("SELECT id,code,client_id,user_id,redirect_uri,scope,code_challenge,code_challenge_method,nonce,auth_time,amr,is_used,session_id,expire_time,create_time,update_time FROM oauth_authorization_code ")
+	} else {
+		query.WriteString("SELECT ")
+		query.WriteString(strings.Join(q.getFields, ","))
+		query.WriteString(" FROM oauth_authorization_code ")
+	}
+	query.WriteString(queryString)
+	e = &OauthAuthorizationCode{}
+	row := q.dao.db.QueryRow(ctx, tx, query.String(), params...)
+	err = row.Scan(&e.Id, &e.Code, &e.ClientId, &e.UserId, &e.RedirectUri, &e.Scope, &e.CodeChallenge, &e.CodeChallengeMethod, &e.Nonce, &e.AuthTime, &e.Amr, &e.IsUsed, &e.SessionId, &e.ExpireTime, &e.CreateTime, &e.UpdateTime)
+	if err == wrap.ErrNoRows {
+		return nil, nil
+	}
+
+	return e, err
+}
+
+func (q *OauthAuthorizationCodeQuery) SelectList(ctx context.Context, tx *wrap.Tx) (list []*OauthAuthorizationCode, err error) {
+	queryString, params := q.buildSelectQuery()
+	query := bytes.NewBufferString("")
+	if len(q.getFields) == 0 {
+		query.WriteString("SELECT id,code,client_id,user_id,redirect_uri,scope,code_challenge,code_challenge_method,nonce,auth_time,amr,is_used,session_id,expire_time,create_time,update_time FROM oauth_authorization_code ")
+	} else {
+		query.WriteString("SELECT ")
+		query.WriteString(strings.Join(q.getFields, ","))
+		query.WriteString(" FROM oauth_authorization_code ")
+	}
+	query.WriteString(queryString)
+	rows, err := q.dao.db.Query(ctx, tx, query.String(), params...)
+	if err != nil {
+		return nil, err
+	}
+	for rows.Next() {
+		e := OauthAuthorizationCode{}
+		err = rows.Scan(&e.Id, &e.Code, &e.ClientId, &e.UserId, &e.RedirectUri, &e.Scope, &e.CodeChallenge, &e.CodeChallengeMethod, &e.Nonce, &e.AuthTime, &e.Amr, &e.IsUsed, &e.SessionId, &e.ExpireTime, &e.CreateTime, &e.UpdateTime)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, &e)
+	}
+	if rows.Err() != nil {
+		err = rows.Err()
+		return nil, err
+	}
+
+	return list, nil
+}
+
+func (q *OauthAuthorizationCodeQuery) SelectCount(ctx context.Context, tx *wrap.Tx) (count int64, err error) {
+	queryString, params := q.buildSelectQuery()
+	query := bytes.NewBufferString("")
+	query.WriteString("SELECT COUNT(*) FROM oauth_authorization_code ")
+	query.WriteString(queryString)
+	row := q.dao.db.QueryRow(ctx, tx, query.String(), params...)
+	err = row.Scan(&count)
+
+	return count, err
+}
+
+func (q *OauthAuthorizationCodeQuery) SelectGroupBy(ctx context.Context, tx *wrap.Tx, withCount bool) (rows *wrap.Rows, err error) {
+	queryString, params := q.buildSelectQuery()
+	query := bytes.NewBufferString("")
+	query.WriteString("SELECT ")
+	query.WriteString(strings.Join(q.groupByFields, ","))
+	if withCount {
+		query.WriteString(",MachineListCount(*) ")
+	}
+	query.WriteString(" FROM oauth_authorization_code ")
+	query.WriteString(queryString)
+
+	return q.dao.db.Query(ctx, tx, query.String(), params...)
+}
+
+func (q *OauthAuthorizationCodeQuery) SelectRow(ctx context.Context, tx *wrap.Tx) (row *wrap.Row) {
+	if !q.hasLimit {
+		q.limitCount = 1
+		q.hasLimit = true
+	}
+
+	queryString, params := q.buildSelectQuery()
+	query := bytes.NewBufferString("")
+	query.WriteString("SELECT ")
+	query.WriteString(strings.Join(q.getFields, ","))
+	query.WriteString(" FROM oauth_authorization_code ")
+	query.WriteString(queryString)
+	return q.dao.db.QueryRow(ctx, tx, query.String(), params...)
+}
+
+func (q *OauthAuthorizationCodeQuery) SelectRows(ctx context.Context, tx *wrap.Tx) (rows *wrap.Rows, err error) {
+	queryString, params := q.buildSelectQuery()
+	query := bytes.NewBufferString("")
+	query.WriteString("SELECT ")
+	query.WriteString(strings.Join(q.getFields, ","))
+	query.WriteString(" FROM oauth_authorization_code ")
+	query.WriteString(queryString)
+	return q.dao.db.Query(ctx, tx, query.String(), params...)
+}
+
+func (q *OauthAuthorizationCodeQuery) Insert(ctx context.Context, tx *wrap.Tx, e *OauthAuthorizationCode) (result *wrap.Result, err error) {
+	query := bytes.NewBufferString("")
+	query.WriteString("INSERT INTO oauth_authorization_code (code,client_id,user_id,redirect_uri,scope,code_challenge,code_challenge_method,nonce,auth_time,amr,is_used,session_id,expire_time) VALUES (?,?,?,?,?,?,?,?,?,?,?,?,?)")
+	params := []interface{}{e.Code, e.ClientId, e.UserId, e.RedirectUri, e.Scope, e.CodeChallenge, e.CodeChallengeMethod, e.Nonce, e.AuthTime, e.Amr, e.IsUsed, e.SessionId, e.ExpireTime}
+	return q.dao.db.Exec(ctx, tx, query.String(), params...)
+}
+
+func (q *OauthAuthorizationCodeQuery) BatchInsert(ctx context.Context, tx *wrap.Tx, list []*OauthAuthorizationCode) (result *wrap.Result, err error) {
+	query := bytes.NewBufferString("")
+	query.WriteString("INSERT INTO oauth_authorization_code (code,client_id,user_id,redirect_uri,scope,code_challenge,code_challenge_method,nonce,auth_time,amr,is_used,session_id,expire_time) VALUES ")
+	query.WriteString(wrap.RepeatWithSeparator("(?,?,?,?,?,?,?,?,?,?,?,?,?)", len(list), ","))
+	params := make([]interface{}, len(list)*13)
+	offset := 0
+	for _, e := range list {
+		params[offset+0] = e.Code
+		params[offset+1] = e.ClientId
+		params[offset+2] = e.UserId
+		params[offset+3] = e.RedirectUri
+		params[offset+4] = e.Scope
+		params[offset+5] = e.CodeChallenge
+		params[offset+6] = e.CodeChallengeMethod
+		params[offset+7] = e.Nonce
+		params[offset+8] = e.AuthTime
+		params[offset+9] = e.Amr
+		params[offset+10] = e.IsUsed
+		params[offset+11] = e.SessionId
+		params[offset+12] = e.ExpireTime
+		offset += 13
+	}
+
+	return q.dao.db.Exec(ctx, tx, query.String(), params...)
+}
+
+func (q *OauthAuthorizationCodeQuery) InsertOnDuplicatedKeyUpdate(ctx context.Context, tx *wrap.Tx, e *OauthAuthorizationCode) (result *wrap.Result, err error) {
+	query := bytes.NewBufferString("")
+	query.WriteString("INSERT INTO oauth_authorization_code (code,client_id,user_id,redirect_uri,scope,code_challenge,code_challenge_method,nonce,auth_time,amr,is_used,session_id,expire_time) VALUES (?,?,?,?,?,?,?,?,?,?,?,?,?)")
+	query.WriteString(" ON DUPLICATED KEY UPDATE ")
+	if len(q.duplicatedUpdateFields) > 0 {
+		query.WriteString(strings.Join(q.duplicatedUpdateFields, ","))
+		query.WriteString(",")
+	}
+	query.WriteString("update_time=now()")
+	params := []interface{}{e.Code, e.ClientId, e.UserId, e.RedirectUri, e.Scope, e.CodeChallenge, e.CodeChallengeMethod, e.Nonce, e.AuthTime, e.Amr, e.IsUsed, e.SessionId, e.ExpireTime}
+	return q.dao.db.Exec(ctx, tx, query.String(), params...)
+}
+
+func (q *OauthAuthorizationCodeQuery) BatchInsertOnDuplicatedKeyUpdate(ctx context.Context, tx *wrap.Tx, list []*OauthAuthorizationCode) (result *wrap.Result, err error) {
+	query := bytes.NewBufferString("")
+	query.WriteString("INSERT INTO oauth_authorization_code (code,client_id,user_id,redirect_uri,scope,code_challenge,code_challenge_method,nonce,auth_time,amr,is_used,session_id,expire_time) VALUES ")
+	query.WriteString(wrap.RepeatWithSeparator("(?,?,?,?,?,?,?,?,?,?,?,?,?)", len(list), ","))
+	query.WriteString(" ON DUPLICATED KEY UPDATE")
+	if len(q.duplicatedUpdateFields) > 0 {
+		query.WriteString(strings.Join(q.duplicatedUpdateFields, ","))
+		query.WriteString(",")
+	}
+	query.WriteString("update_time=now()")
+	params := make([]interface{}, len(list)*13)
+	offset := 0
+	for _, e := range list {
+		params[offset+0] = e.Code
+		params[offset+1] = e.ClientId
+		params[offset+2] = e.UserId
+		params[offset+3] = e.RedirectUri
+		params[offset+4] = e.Scope
+		params[offset+5] = e.CodeChallenge
+		params[offset+6] = e.CodeChallengeMethod
+		params[offset+7] = e.Nonce
+		params[offset+8] = e.AuthTime
+		params[offset+9] = e.Amr
+		params[offset+10] = e.IsUsed
+		params[offset+11] = e.SessionId
+		params[offset+12] = e.ExpireTime
+		offset += 13
+	}
+
+	return q.dao.db.Exec(ctx, tx, query.String(), params...)
+}
+
+func (q *OauthAuthorizationCodeQuery) Update(ctx context.Context, tx *wrap.Tx) (result *wrap.Result, err error) {
+	query := bytes.NewBufferString("")
+	var params []interface{}
+	params = append(params, q.updateParams...)
+	query.WriteString("UPDATE oauth_authorization_code SET ")
+	updateItems := make([]string, len(q.updateFields))
+	for i, v := range q.updateFields {
+		updateItems[i] = v + "=?"
+	}
+	query.WriteString(strings.Join(updateItems, ","))
+	query.WriteString(",update_time=now()")
+	where := q.where.String()
+	if where != "" {
+		query.WriteString(" WHERE ")
+		query.WriteString(where)
+		params = append(params, q.whereParams...)
+	}
+
+	return q.dao.db.Exec(ctx, tx, query.String(), params...)
+}
+
+func (q *OauthAuthorizationCodeQuery) Delete(ctx context.Context, tx *wrap.Tx) (result *wrap.Result, err error) {
+	query := "DELETE FROM oauth_authorization_code WHERE " + q.where.String()
+	return q.dao.db.Exec(ctx, tx, query, q.whereParams...)
+}
+
+type OauthAuthorizationCodeDao struct {
+	logger *zap.Logger
+	db     *DB
+}
+
+func NewOauthAuthorizationCodeDao(db *DB) (t *OauthAuthorizationCodeDao, err error) {
+	t = &OauthAuthorizationCodeDao{}
+	t.logger = log.TypedLogger(t)
+	t.db = db
+
+	return t, nil
+}
+
+func (dao *OauthAuthorizationCodeDao) Query() *OauthAuthorizationCodeQuery {
+	q := &OauthAuthorizationCodeQuery{}
+	q.dao = dao
+	q.tableName = "oauth_authorization_code"
+	q.where = bytes.NewBufferString("")
+	return q
+}
+
+type OauthClient struct {
+	Id               uint64 //size=20
+	ClientId         string //size=32
+	ClientSecretHash string //size=128
+	ClientName       string //size=64
+	OwnerUserId      string //size=32
+	IsPublic         int32  //size=1
+	RedirectUris     string //size=4096
+	Audience         string //size=256
+	GrantTypes       string //size=256
+	Scope            string //size=1024
+	CreateTime       time.Time
+	UpdateTime       time.Time
+}
+
+type OauthClientQuery struct {
+	QueryBase
+	dao *OauthClientDao
+}
+
+func (q *OauthClientQuery) Left() *OauthClientQuery {
+	q.where.WriteString(" (")
+	return q
+}
+
+func (q *OauthClientQuery) Right() *OauthClientQuery {
+	q.where.WriteString(" )")
+	return q
+}
+
+func (q *OauthClientQuery) And() *OauthClientQuery {
+	q.where.WriteString(" AND")
+	return q
+}
+
+func (q *OauthClientQuery) Or() *OauthClientQuery {
+	q.where.WriteString(" OR")
+	return q
+}
+
+func (q *OauthClientQuery) Not() *OauthClientQuery {
+	q.where.WriteString(" NOT")
+	return q
+}
+
+func (q *OauthClientQuery) IdEqual(v uint64) *OauthClientQuery {
+	q.where.WriteString(" id=?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *OauthClientQuery) IdNotEqual(v uint64) *OauthClientQuery {
+	q.where.WriteString(" id<>?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *OauthClientQuery) IdLess(v uint64) *OauthClientQuery {
+	q.where.WriteString(" id<?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *OauthClientQuery) IdLessEqual(v uint64) *OauthClientQuery {
+	q.where.WriteString(" id<=?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *OauthClientQuery) IdGreater(v uint64) *OauthClientQuery {
+	q.where.WriteString(" id>?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *OauthClientQuery) IdGreaterEqual(v uint64) *OauthClientQuery {
+	q.where.WriteString(" id>=?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *OauthClientQuery) IdIn(items []uint64) *OauthClientQuery {
+	q.where.WriteString(" id IN(")
+	q.where.WriteString(wrap.RepeatWithSeparator("?", len(items), ","))
+	q.where.WriteString(")")
+	q.whereParams = append(q.whereParams, items)
+	return q
+}
+
+func (q *OauthClientQuery) ClientIdEqual(v string) *OauthClientQuery {
+	q.where.WriteString(" client_id=?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *OauthClientQuery) ClientIdNotEqual(v string) *OauthClientQuery {
+	q.where.WriteString(" client_id<>?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *OauthClientQuery) ClientIdIn(items []string) *OauthClientQuery {
+	q.where.WriteString(" client_id IN(")
+	q.where.WriteString(wrap.RepeatWithSeparator("?", len(items), ","))
+	q.where.WriteString(")")
+	q.whereParams = append(q.whereParams, items)
+	return q
+}
+
+func (q *OauthClientQuery) ClientSecretHashEqual(v string) *OauthClientQuery {
+	q.where.WriteString(" client_secret_hash=?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *OauthClientQuery) ClientSecretHashNotEqual(v string) *OauthClientQuery {
+	q.where.WriteString(" client_secret_hash<>?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *OauthClientQuery) ClientSecretHashIn(items []string) *OauthClientQuery {
+	q.where.WriteString(" client_secret_hash IN(")
+	q.where.WriteString(wrap.RepeatWithSeparator("?", len(items), ","))
+	q.where.WriteString(")")
+	q.whereParams = append(q.whereParams, items)
+	return q
+}
+
+func (q *OauthClientQuery) ClientNameEqual(v string) *OauthClientQuery {
+	q.where.WriteString(" client_name=?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *OauthClientQuery) ClientNameNotEqual(v string) *OauthClientQuery {
+	q.where.WriteString(" client_name<>?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *OauthClientQuery) ClientNameIn(items []string) *OauthClientQuery {
+	q.where.WriteString(" client_name IN(")
+	q.where.WriteString(wrap.RepeatWithSeparator("?", len(items), ","))
+	q.where.WriteString(")")
+	q.whereParams = append(q.whereParams, items)
+	return q
+}
+
+func (q *OauthClientQuery) OwnerUserIdEqual(v string) *OauthClientQuery {
+	q.where.WriteString(" owner_user_id=?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *OauthClientQuery) OwnerUserIdNotEqual(v string) *OauthClientQuery {
+	q.where.WriteString(" owner_user_id<>?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *OauthClientQuery) OwnerUserIdIn(items []string) *OauthClientQuery {
+	q.where.WriteString(" owner_user_id IN(")
+	q.where.WriteString(wrap.RepeatWithSeparator("?", len(items), ","))
+	q.where.WriteString(")")
+	q.whereParams = append(q.whereParams, items)
+	return q
+}
+
+func (q *OauthClientQuery) IsPublicEqual(v int32) *OauthClientQuery {
+	q.where.WriteString(" is_public=?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *OauthClientQuery) IsPublicNotEqual(v int32) *OauthClientQuery {
+	q.where.WriteString(" is_public<>?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *OauthClientQuery) IsPublicLess(v int32) *OauthClientQuery {
+	q.where.WriteString(" is_public<?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *OauthClientQuery) IsPublicLessEqual(v int32) *OauthClientQuery {
+	q.where.WriteString(" is_public<=?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *OauthClientQuery) IsPublicGreater(v int32) *OauthClientQuery {
+	q.where.WriteString(" is_public>?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *OauthClientQuery) IsPublicGreaterEqual(v int32) *OauthClientQuery {
+	q.where.WriteString(" is_public>=?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *OauthClientQuery) IsPublicIn(items []int32) *OauthClientQuery {
+	q.where.WriteString(" is_public IN(")
+	q.where.WriteString(wrap.RepeatWithSeparator("?", len(items), ","))
+	q.where.WriteString(")")
+	q.whereParams = append(q.whereParams, items)
+	return q
+}
+
+func (q *OauthClientQuery) RedirectUrisEqual(v string) *OauthClientQuery {
+	q.where.WriteString(" redirect_uris=?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *OauthClientQuery) RedirectUrisNotEqual(v string) *OauthClientQuery {
+	q.where.WriteString(" redirect_uris<>?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *OauthClientQuery) RedirectUrisIn(items []string) *OauthClientQuery {
+	q.where.WriteString(" redirect_uris IN(")
+	q.where.WriteString(wrap.RepeatWithSeparator("?", len(items), ","))
+	q.where.WriteString(")")
+	q.whereParams = append(q.whereParams, items)
+	return q
+}
+
+func (q *OauthClientQuery) AudienceEqual(v string) *OauthClientQuery {
+	q.where.WriteString(" audience=?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *OauthClientQuery) AudienceNotEqual(v string) *OauthClientQuery {
+	q.where.WriteString(" audience<>?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *OauthClientQuery) AudienceIn(items []string) *OauthClientQuery {
+	q.where.WriteString(" audience IN(")
+	q.where.WriteString(wrap.RepeatWithSeparator("?", len(items), ","))
+	q.where.WriteString(")")
+	q.whereParams = append(q.whereParams, items)
+	return q
+}
+
+func (q *OauthClientQuery) GrantTypesEqual(v string) *OauthClientQuery {
+	q.where.WriteString(" grant_types=?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *OauthClientQuery) GrantTypesNotEqual(v string) *OauthClientQuery {
+	q.where.WriteString(" grant_types<>?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *OauthClientQuery) GrantTypesIn(items []string) *OauthClientQuery {
+	q.where.WriteString(" grant_types IN(")
+	q.where.WriteString(wrap.RepeatWithSeparator("?", len(items), ","))
+	q.where.WriteString(")")
+	q.whereParams = append(q.whereParams, items)
+	return q
+}
+
+func (q *OauthClientQuery) ScopeEqual(v string) *OauthClientQuery {
+	q.where.WriteString(" scope=?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *OauthClientQuery) ScopeNotEqual(v string) *OauthClientQuery {
+	q.where.WriteString(" scope<>?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *OauthClientQuery) ScopeIn(items []string) *OauthClientQuery {
+	q.where.WriteString(" scope IN(")
+	q.where.WriteString(wrap.RepeatWithSeparator("?", len(items), ","))
+	q.where.WriteString(")")
+	q.whereParams = append(q.whereParams, items)
+	return q
+}
+
+func (q *OauthClientQuery) CreateTimeEqual(v time.Time) *OauthClientQuery {
+	q.where.WriteString(" create_time=?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *OauthClientQuery) CreateTimeNotEqual(v time.Time) *OauthClientQuery {
+	q.where.WriteString(" create_time<>?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *OauthClientQuery) CreateTimeLess(v time.Time) *OauthClientQuery {
+	q.where.WriteString(" create_time<?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *OauthClientQuery) CreateTimeLessEqual(v time.Time) *OauthClientQuery {
+	q.where.WriteString(" create_time<=?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *OauthClientQuery) CreateTimeGreater(v time.Time) *OauthClientQuery {
+	q.where.WriteString(" create_time>?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *OauthClientQuery) CreateTimeGreaterEqual(v time.Time) *OauthClientQuery {
+	q.where.WriteString(" create_time>=?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *OauthClientQuery) UpdateTimeEqual(v time.Time) *OauthClientQuery {
+	q.where.WriteString(" update_time=?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *OauthClientQuery) UpdateTimeNotEqual(v time.Time) *OauthClientQuery {
+	q.where.WriteString(" update_time<>?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *OauthClientQuery) UpdateTimeLess(v time.Time) *OauthClientQuery {
+	q.where.WriteString(" update_time<?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *OauthClientQuery) UpdateTimeLessEqual(v time.Time) *OauthClientQuery {
+	q.where.WriteString(" update_time<=?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *OauthClientQuery) UpdateTimeGreater(v time.Time) *OauthClientQuery {
+	q.where.WriteString(" update_time>?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *OauthClientQuery) UpdateTimeGreaterEqual(v time.Time) *OauthClientQuery {
+	q.where.WriteString(" update_time>=?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *OauthClientQuery) GroupByClientSecretHash(asc bool) *OauthClientQuery {
+	q.groupByFields = append(q.groupByFields, "client_secret_hash")
+	q.groupByOrders = append(q.groupByOrders, asc)
+	return q
+}
+
+func (q *OauthClientQuery) GroupByClientName(asc bool) *OauthClientQuery {
+	q.groupByFields = append(q.groupByFields, "client_name")
+	q.groupByOrders = append(q.groupByOrders, asc)
+	return q
+}
+
+func (q *OauthClientQuery) GroupByOwnerUserId(asc bool) *OauthClientQuery {
+	q.groupByFields = append(q.groupByFields, "owner_user_id")
+	q.groupByOrders = append(q.groupByOrders, asc)
+	return q
+}
+
+func (q *OauthClientQuery) GroupByIsPublic(asc bool) *OauthClientQuery {
+	q.groupByFields = append(q.groupByFields, "is_public")
+	q.groupByOrders = append(q.groupByOrders, asc)
+	return q
+}
+
+func (q *OauthClientQuery) GroupByRedirectUris(asc bool) *OauthClientQuery {
+	q.groupByFields = append(q.groupByFields, "redirect_uris")
+	q.groupByOrders = append(q.groupByOrders, asc)
+	return q
+}
+
+func (q *OauthClientQuery) GroupByAudience(asc bool) *OauthClientQuery {
+	q.groupByFields = append(q.groupByFields, "audience")
+	q.groupByOrders = append(q.groupByOrders, asc)
+	return q
+}
+
+func (q *OauthClientQuery) GroupByGrantTypes(asc bool) *OauthClientQuery {
+	q.groupByFields = append(q.groupByFields, "grant_types")
+	q.groupByOrders = append(q.groupByOrders, asc)
+	return q
+}
+
+func (q *OauthClientQuery) GroupByScope(asc bool) *OauthClientQuery {
+	q.groupByFields = append(q.groupByFields, "scope")
+	q.groupByOrders = append(q.groupByOrders, asc)
+	return q
+}
+
+func (q *OauthClientQuery) OrderById(asc bool) *OauthClientQuery {
+	q.orderByFields = append(q.orderByFields, "id")
+	q.orderByOrders = append(q.orderByOrders, asc)
+	return q
+}
+
+func (q *OauthClientQuery) OrderByClientId(asc bool) *OauthClientQuery {
+	q.orderByFields = append(q.orderByFields, "client_id")
+	q.orderByOrders = append(q.orderByOrders, asc)
+	return q
+}
+
+func (q *OauthClientQuery) OrderByClientSecretHash(asc bool) *OauthClientQuery {
+	q.orderByFields = append(q.orderByFields, "client_secret_hash")
+	q.orderByOrders = append(q.orderByOrders, asc)
+	return q
+}
+
+func (q *OauthClientQuery) OrderByClientName(asc bool) *OauthClientQuery {
+	q.orderByFields = append(q.orderByFields, "client_name")
+	q.orderByOrders = append(q.orderByOrders, asc)
+	return q
+}
+
+func (q *OauthClientQuery) OrderByOwnerUserId(asc bool) *OauthClientQuery {
+	q.orderByFields = append(q.orderByFields, "owner_user_id")
+	q.orderByOrders = append(q.orderByOrders, asc)
+	return q
+}
+
+func (q *OauthClientQuery) OrderByIsPublic(asc bool) *OauthClientQuery {
+	q.orderByFields = append(q.orderByFields, "is_public")
+	q.orderByOrders = append(q.orderByOrders, asc)
+	return q
+}
+
+func (q *OauthClientQuery) OrderByRedirectUris(asc bool) *OauthClientQuery {
+	q.orderByFields = append(q.orderByFields, "redirect_uris")
+	q.orderByOrders = append(q.orderByOrders, asc)
+	return q
+}
+
+func (q *OauthClientQuery) OrderByAudience(asc bool) *OauthClientQuery {
+	q.orderByFields = append(q.orderByFields, "audience")
+	q.orderByOrders = append(q.orderByOrders, asc)
+	return q
+}
+
+func (q *OauthClientQuery) OrderByGrantTypes(asc bool) *OauthClientQuery {
+	q.orderByFields = append(q.orderByFields, "grant_types")
+	q.orderByOrders = append(q.orderByOrders, asc)
+	return q
+}
+
+func (q *OauthClientQuery) OrderByScope(asc bool) *OauthClientQuery {
+	q.orderByFields = append(q.orderByFields, "scope")
+	q.orderByOrders = append(q.orderByOrders, asc)
+	return q
+}
+
+func (q *OauthClientQuery) OrderByCreateTime(asc bool) *OauthClientQuery {
+	q.orderByFields = append(q.orderByFields, "create_time")
+	q.orderByOrders = append(q.orderByOrders, asc)
+	return q
+}
+
+func (q *OauthClientQuery) OrderByUpdateTime(asc bool) *OauthClientQuery {
+	q.orderByFields = append(q.orderByFields, "update_time")
+	q.orderByOrders = append(q.orderByOrders, asc)
+	return q
+}
+
+func (q *OauthClientQuery) OrderByGroupCount(asc bool) *OauthClientQuery {
+	q.orderByFields = append(q.orderByFields, "count(*)")
+	q.orderByOrders = append(q.orderByOrders, asc)
+	return q
+}
+
+func (q *OauthClientQuery) Limit(startIncluded int64, count int64) *OauthClientQuery {
+	q.hasLimit = true
+	q.limitStartIncluded = startIncluded
+	q.limitCount = count
+	return q
+}
+
+func (q *OauthClientQuery) ForUpdate() *OauthClientQuery {
+	q.forUpdate = true
+	return q
+}
+
+func (q *OauthClientQuery) ForShare() *OauthClientQuery {
+	q.forShare = true
+	return q
+}
+
+func (q *OauthClientQuery) SetClientId(v string) *OauthClientQuery {
+	q.updateFields = append(q.updateFields, "client_id")
+	q.updateParams = append(q.updateParams, v)
+	return q
+}
+
+func (q *OauthClientQuery) SetClientSecretHash(v string) *OauthClientQuery {
+	q.updateFields = append(q.updateFields, "client_secret_hash")
+	q.updateParams = append(q.updateParams, v)
+	return q
+}
+
+func (q *OauthClientQuery) SetClientName(v string) *OauthClientQuery {
+	q.updateFields = append(q.updateFields, "client_name")
+	q.updateParams = append(q.updateParams, v)
+	return q
+}
+
+func (q *OauthClientQuery) SetOwnerUserId(v string) *OauthClientQuery {
+	q.updateFields = append(q.updateFields, "owner_user_id")
+	q.updateParams = append(q.updateParams, v)
+	return q
+}
+
+func (q *OauthClientQuery) SetIsPublic(v int32) *OauthClientQuery {
+	q.updateFields = append(q.updateFields, "is_public")
+	q.updateParams = append(q.updateParams, v)
+	return q
+}
+
+func (q *OauthClientQuery) SetRedirectUris(v string) *OauthClientQuery {
+	q.updateFields = append(q.updateFields, "redirect_uris")
+	q.updateParams = append(q.updateParams, v)
+	return q
+}
+
+func (q *OauthClientQuery) SetAudience(v string) *OauthClientQuery {
+	q.updateFields = append(q.updateFields, "audience")
+	q.updateParams = append(q.updateParams, v)
+	return q
+}
+
+func (q *OauthClientQuery) SetGrantTypes(v string) *OauthClientQuery {
+	q.updateFields = append(q.updateFields, "grant_types")
+	q.updateParams = append(q.updateParams, v)
+	return q
+}
+
+func (q *OauthClientQuery) SetScope(v string) *OauthClientQuery {
+	q.updateFields = append(q.updateFields, "scope")
+	q.updateParams = append(q.updateParams, v)
+	return q
+}
+
+func (q *OauthClientQuery) DuplicatedUpdateClientSecretHash() *OauthClientQuery {
+	q.duplicatedUpdateFields = append(q.duplicatedUpdateFields, "client_secret_hash=VALUES(client_secret_hash)")
+	return q
+}
+
+func (q *OauthClientQuery) DuplicatedUpdateClientName() *OauthClientQuery {
+	q.duplicatedUpdateFields = append(q.duplicatedUpdateFields, "client_name=VALUES(client_name)")
+	return q
+}
+
+func (q *OauthClientQuery) DuplicatedUpdateOwnerUserId() *OauthClientQuery {
+	q.duplicatedUpdateFields = append(q.duplicatedUpdateFields, "owner_user_id=VALUES(owner_user_id)")
+	return q
+}
+
+func (q *OauthClientQuery) DuplicatedUpdateIsPublic() *OauthClientQuery {
+	q.duplicatedUpdateFields = append(q.duplicatedUpdateFields, "is_public=VALUES(is_public)")
+	return q
+}
+
+func (q *OauthClientQuery) DuplicatedUpdateRedirectUris() *OauthClientQuery {
+	q.duplicatedUpdateFields = append(q.duplicatedUpdateFields, "redirect_uris=VALUES(redirect_uris)")
+	return q
+}
+
+func (q *OauthClientQuery) DuplicatedUpdateAudience() *OauthClientQuery {
+	q.duplicatedUpdateFields = append(q.duplicatedUpdateFields, "audience=VALUES(audience)")
+	return q
+}
+
+func (q *OauthClientQuery) DuplicatedUpdateGrantTypes() *OauthClientQuery {
+	q.duplicatedUpdateFields = append(q.duplicatedUpdateFields, "grant_types=VALUES(grant_types)")
+	return q
+}
+
+func (q *OauthClientQuery) DuplicatedUpdateScope() *OauthClientQuery {
+	q.duplicatedUpdateFields = append(q.duplicatedUpdateFields, "scope=VALUES(scope)")
+	return q
+}
+
+func (q *OauthClientQuery) GetId() *OauthClientQuery {
+	q.getFields = append(q.getFields, "id")
+	return q
+}
+
+func (q *OauthClientQuery) GetClientId() *OauthClientQuery {
+	q.getFields = append(q.getFields, "client_id")
+	return q
+}
+
+func (q *OauthClientQuery) GetClientSecretHash() *OauthClientQuery {
+	q.getFields = append(q.getFields, "client_secret_hash")
+	return q
+}
+
+func (q *OauthClientQuery) GetClientName() *OauthClientQuery {
+	q.getFields = append(q.getFields, "client_name")
+	return q
+}
+
+func (q *OauthClientQuery) GetOwnerUserId() *OauthClientQuery {
+	q.getFields = append(q.getFields, "owner_user_id")
+	return q
+}
+
+func (q *OauthClientQuery) GetIsPublic() *OauthClientQuery {
+	q.getFields = append(q.getFields, "is_public")
+	return q
+}
+
+func (q *OauthClientQuery) GetRedirectUris() *OauthClientQuery {
+	q.getFields = append(q.getFields, "redirect_uris")
+	return q
+}
+
+func (q *OauthClientQuery) GetAudience() *OauthClientQuery {
+	q.getFields = append(q.getFields, "audience")
+	return q
+}
+
+func (q *OauthClientQuery) GetGrantTypes() *OauthClientQuery {
+	q.getFields = append(q.getFields, "grant_types")
+	return q
+}
+
+func (q *OauthClientQuery) GetScope() *OauthClientQuery {
+	q.getFields = append(q.getFields, "scope")
+	return q
+}
+
+func (q *OauthClientQuery) GetCreateTime() *OauthClientQuery {
+	q.getFields = append(q.getFields, "create_time")
+	return q
+}
+
+func (q *OauthClientQuery) GetUpdateTime() *OauthClientQuery {
+	q.getFields = append(q.getFields, "update_time")
+	return q
+}
+
+func (q *OauthClientQuery) Select(ctx context.Context, tx *wrap.Tx) (e *OauthClient, err error) {
+	if !q.hasLimit {
+		q.limitCount = 1
+		q.hasLimit = true
+	}
+
+	queryString, params := q.buildSelectQuery()
+	query := bytes.NewBufferString("")
+	if len(q.getFields) == 0 {
+		query.WriteString("SELECT id,client_id,client_secret_hash,client_name,owner_user_id,is_public,redirect_uris,audience,grant_types,scope,create_time,update_time FROM oauth_client ")
+	} else {
+		query.WriteString("SELECT ")
+		query.WriteString(strings.Join(q.getFields, ","))
+		query.WriteString(" FROM oauth_client ")
+	}
+	query.WriteString(queryString)
+	e = &OauthClient{}
+	row := q.dao.db.QueryRow(ctx, tx, query.String(), params...)
+	err = row.Scan(&e.Id, &e.ClientId, &e.ClientSecretHash, &e.ClientName, &e.OwnerUserId, &e.IsPublic, &e.RedirectUris, &e.Audience, &e.GrantTypes, &e.Scope, &e.CreateTime, &e.UpdateTime)
+	if err == wrap.ErrNoRows {
+		return nil, nil
+	}
+
+	return e, err
+}
+
+func (q *OauthClientQuery) SelectList(ctx context.Context, tx *wrap.Tx) (list []*OauthClient, err error) {
+	queryString, params := q.buildSelectQuery()
+	query := bytes.NewBufferString("")
+	if len(q.getFields) == 0 {
+		query.WriteString("SELECT id,client_id,client_secret_hash,client_name,owner_user_id,is_public,redirect_uris,audience,grant_types,scope,create_time,update_time FROM oauth_client ")
+	} else {
+		query.WriteString("SELECT ")
+		query.WriteString(strings.Join(q.getFields, ","))
+		query.WriteString(" FROM oauth_client ")
+	}
+	query.WriteString(queryString)
+	rows, err := q.dao.db.Query(ctx, tx, query.String(), params...)
+	if err != nil {
+		return nil, err
+	}
+	for rows.Next() {
+		e := OauthClient{}
+		err = rows.Scan(&e.Id, &e.ClientId, &e.ClientSecretHash, &e.ClientName, &e.OwnerUserId, &e.IsPublic, &e.RedirectUris, &e.Audience, &e.GrantTypes, &e.Scope, &e.CreateTime, &e.UpdateTime)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, &e)
+	}
+	if rows.Err() != nil {
+		err = rows.Err()
+		return nil, err
+	}
+
+	return list, nil
+}
+
+func (q *OauthClientQuery) SelectCount(ctx context.Context, tx *wrap.Tx) (count int64, err error) {
+	queryString, params := q.buildSelectQuery()
+	query := bytes.NewBufferString("")
+	query.WriteString("SELECT COUNT(*) FROM oauth_client ")
+	query.WriteString(queryString)
+	row := q.dao.db.QueryRow(ctx, tx, query.String(), params...)
+	err = row.Scan(&count)
+
+	return count, err
+}
+
+func (q *OauthClientQuery) SelectGroupBy(ctx context.Context, tx *wrap.Tx, withCount bool) (rows *wrap.Rows, err error) {
+	queryString, params := q.buildSelectQuery()
+	query := bytes.NewBufferString("")
+	query.WriteString("SELECT ")
+	query.WriteString(strings.Join(q.groupByFields, ","))
+	if withCount {
+		query.WriteString(",MachineListCount(*) ")
+	}
+	query.WriteString(" FROM oauth_client ")
+	query.WriteString(queryString)
+
+	return q.dao.db.Query(ctx, tx, query.String(), params...)
+}
+
+func (q *OauthClientQuery) SelectRow(ctx context.Context, tx *wrap.Tx) (row *wrap.Row) {
+	if !q.hasLimit {
+		q.limitCount = 1
+		q.hasLimit = true
+	}
+
+	queryString, params := q.buildSelectQuery()
+	query := bytes.NewBufferString("")
+	query.WriteString("SELECT ")
+	query.WriteString(strings.Join(q.getFields, ","))
+	query.WriteString(" FROM oauth_client ")
+	query.WriteString(queryString)
+	return q.dao.db.QueryRow(ctx, tx, query.String(), params...)
+}
+
+func (q *OauthClientQuery) SelectRows(ctx context.Context, tx *wrap.Tx) (rows *wrap.Rows, err error) {
+	queryString, params := q.buildSelectQuery()
+	query := bytes.NewBufferString("")
+	query.WriteString("SELECT ")
+	query.WriteString(strings.Join(q.getFields, ","))
+	query.WriteString(" FROM oauth_client ")
+	query.WriteString(queryString)
+	return q.dao.db.Query(ctx, tx, query.String(), params...)
+}
+
+func (q *OauthClientQuery) Insert(ctx context.Context, tx *wrap.Tx, e *OauthClient) (result *wrap.Result, err error) {
+	query := bytes.NewBufferString("")
+	query.WriteString("INSERT INTO oauth_client (client_id,client_secret_hash,client_name,owner_user_id,is_public,redirect_uris,audience,grant_types,scope) VALUES (?,?,?,?,?,?,?,?,?)")
+	params := []interface{}{e.ClientId, e.ClientSecretHash, e.ClientName, e.OwnerUserId, e.IsPublic, e.RedirectUris, e.Audience, e.GrantTypes, e.Scope}
+	return q.dao.db.Exec(ctx, tx, query.String(), params...)
+}
+
+func (q *OauthClientQuery) BatchInsert(ctx context.Context, tx *wrap.Tx, list []*OauthClient) (result *wrap.Result, err error) {
+	query := bytes.NewBufferString("")
+	query.WriteString("INSERT INTO oauth_client (client_id,client_secret_hash,client_name,owner_user_id,is_public,redirect_uris,audience,grant_types,scope) VALUES ")
+	query.WriteString(wrap.RepeatWithSeparator("(?,?,?,?,?,?,?,?,?)", len(list), ","))
+	params := make([]interface{}, len(list)*9)
+	offset := 0
+	for _, e := range list {
+		params[offset+0] = e.ClientId
+		params[offset+1] = e.ClientSecretHash
+		params[offset+2] = e.ClientName
+		params[offset+3] = e.OwnerUserId
+		params[offset+4] = e.IsPublic
+		params[offset+5] = e.RedirectUris
+		params[offset+6] = e.Audience
+		params[offset+7] = e.GrantTypes
+		params[offset+8] = e.Scope
+		offset += 9
+	}
+
+	return q.dao.db.Exec(ctx, tx, query.String(), params...)
+}
+
+func (q *OauthClientQuery) InsertOnDuplicatedKeyUpdate(ctx context.Context, tx *wrap.Tx, e *OauthClient) (result *wrap.Result, err error) {
+	query := bytes.NewBufferString("")
+	query.WriteString("INSERT INTO oauth_client (client_id,client_secret_hash,client_name,owner_user_id,is_public,redirect_uris,audience,grant_types,scope) VALUES (?,?,?,?,?,?,?,?,?)")
+	query.WriteString(" ON DUPLICATED KEY UPDATE ")
+	if len(q.duplicatedUpdateFields) > 0 {
+		query.WriteString(strings.Join(q.duplicatedUpdateFields, ","))
+		query.WriteString(",")
+	}
+	query.WriteString("update_time=now()")
+	params := []interface{}{e.ClientId, e.ClientSecretHash, e.ClientName, e.OwnerUserId, e.IsPublic, e.RedirectUris, e.Audience, e.GrantTypes, e.Scope}
+	return q.dao.db.Exec(ctx, tx, query.String(), params...)
+}
+
+func (q *OauthClientQuery) BatchInsertOnDuplicatedKeyUpdate(ctx context.Context, tx *wrap.Tx, list []*OauthClient) (result *wrap.Result, err error) {
+	query := bytes.NewBufferString("")
+	query.WriteString("INSERT INTO oauth_client (client_id,client_secret_hash,client_name,owner_user_id,is_public,redirect_uris,audience,grant_types,scope) VALUES ")
+	query.WriteString(wrap.RepeatWithSeparator("(?,?,?,?,?,?,?,?,?)", len(list), ","))
+	query.WriteString(" ON DUPLICATED KEY UPDATE")
+	if len(q.duplicatedUpdateFields) > 0 {
+		query.WriteString(strings.Join(q.duplicatedUpdateFields, ","))
+		query.WriteString(",")
+	}
+	query.WriteString("update_time=now()")
+	params := make([]interface{}, len(list)*9)
+	offset := 0
+	for _, e := range list {
+		params[offset+0] = e.ClientId
+		params[offset+1] = e.ClientSecretHash
+		params[offset+2] = e.ClientName
+		params[offset+3] = e.OwnerUserId
+		params[offset+4] = e.IsPublic
+		params[offset+5] = e.RedirectUris
+		params[offset+6] = e.Audience
+		params[offset+7] = e.GrantTypes
+		params[offset+8] = e.Scope
+		offset += 9
+	}
+
+	return q.dao.db.Exec(ctx, tx, query.String(), params...)
+}
+
+func (q *OauthClientQuery) Update(ctx context.Context, tx *wrap.Tx) (result *wrap.Result, err error) {
+	query := bytes.NewBufferString("")
+	var params []interface{}
+	params = append(params, q.updateParams...)
+	query.WriteString("UPDATE oauth_client SET ")
+	updateItems := make([]string, len(q.updateFields))
+	for i, v := range q.updateFields {
+		updateItems[i] = v + "=?"
+	}
+	query.WriteString(strings.Join(updateItems, ","))
+	query.WriteString(",update_time=now()")
+	where := q.where.String()
+	if where != "" {
+		query.WriteString(" WHERE ")
+		query.WriteString(where)
+		params = append(params, q.whereParams...)
+	}
+
+	return q.dao.db.Exec(ctx, tx, query.String(), params...)
+}
+
+func (q *OauthClientQuery) Delete(ctx context.Context, tx *wrap.Tx) (result *wrap.Result, err error) {
+	query := "DELETE FROM oauth_client WHERE " + q.where.String()
+	return q.dao.db.Exec(ctx, tx, query, q.whereParams...)
+}
+
+type OauthClientDao struct {
+	logger *zap.Logger
+	db     *DB
+}
+
+func NewOauthClientDao(db *DB) (t *OauthClientDao, err error) {
+	t = &OauthClientDao{}
+	t.logger = log.TypedLogger(t)
+	t.db = db
+
+	return t, nil
+}
+
+func (dao *OauthClientDao) Query() *OauthClientQuery {
+	q := &OauthClientQuery{}
+	q.dao = dao
+	q.tableName = "oauth_client"
+	q.where = bytes.NewBufferString("")
+	return q
+}
+
+type OauthConsent struct {
+	Id         uint64 //size=20
+	UserId     string //size=32
+	ClientId   string //size=32
+	Scope      string //size=1024
+	CreateTime time.Time
+	UpdateTime time.Time
+}
+
+type OauthConsentQuery struct {
+	QueryBase
+	dao *OauthConsentDao
+}
+
+func (q *OauthConsentQuery) Left() *OauthConsentQuery {
+	q.where.WriteString(" (")
+	return q
+}
+
+func (q *OauthConsentQuery) Right() *OauthConsentQuery {
+	q.where.WriteString(" )")
+	return q
+}
+
+func (q *OauthConsentQuery) And() *OauthConsentQuery {
+	q.where.WriteString(" AND")
+	return q
+}
+
+func (q *OauthConsentQuery) Or() *OauthConsentQuery {
+	q.where.WriteString(" OR")
+	return q
+}
+
+func (q *OauthConsentQuery) Not() *OauthConsentQuery {
+	q.where.WriteString(" NOT")
+	return q
+}
+
+func (q *OauthConsentQuery) IdEqual(v uint64) *OauthConsentQuery {
+	q.where.WriteString(" id=?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *OauthConsentQuery) IdNotEqual(v uint64) *OauthConsentQuery {
+	q.where.WriteString(" id<>?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *OauthConsentQuery) IdLess(v uint64) *OauthConsentQuery {
+	q.where.WriteString(" id<?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *OauthConsentQuery) IdLessEqual(v uint64) *OauthConsentQuery {
+	q.where.WriteString(" id<=?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *OauthConsentQuery) IdGreater(v uint64) *OauthConsentQuery {
+	q.where.WriteString(" id>?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *OauthConsentQuery) IdGreaterEqual(v uint64) *OauthConsentQuery {
+	q.where.WriteString(" id>=?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *OauthConsentQuery) IdIn(items []uint64) *OauthConsentQuery {
+	q.where.WriteString(" id IN(")
+	q.where.WriteString(wrap.RepeatWithSeparator("?", len(items), ","))
+	q.where.WriteString(")")
+	q.whereParams = append(q.whereParams, items)
+	return q
+}
+
+func (q *OauthConsentQuery) UserIdEqual(v string) *OauthConsentQuery {
+	q.where.WriteString(" user_id=?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *OauthConsentQuery) UserIdNotEqual(v string) *OauthConsentQuery {
+	q.where.WriteString(" user_id<>?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *OauthConsentQuery) UserIdIn(items []string) *OauthConsentQuery {
+	q.where.WriteString(" user_id IN(")
+	q.where.WriteString(wrap.RepeatWithSeparator("?", len(items), ","))
+	q.where.WriteString(")")
+	q.whereParams = append(q.whereParams, items)
+	return q
+}
+
+func (q *OauthConsentQuery) ClientIdEqual(v string) *OauthConsentQuery {
+	q.where.WriteString(" client_id=?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *OauthConsentQuery) ClientIdNotEqual(v string) *OauthConsentQuery {
+	q.where.WriteString(" client_id<>?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *OauthConsentQuery) ClientIdIn(items []string) *OauthConsentQuery {
+	q.where.WriteString(" client_id IN(")
+	q.where.WriteString(wrap.RepeatWithSeparator("?", len(items), ","))
+	q.where.WriteString(")")
+	q.whereParams = append(q.whereParams, items)
+	return q
+}
+
+func (q *OauthConsentQuery) ScopeEqual(v string) *OauthConsentQuery {
+	q.where.WriteString(" scope=?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *OauthConsentQuery) ScopeNotEqual(v string) *OauthConsentQuery {
+	q.where.WriteString(" scope<>?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *OauthConsentQuery) ScopeIn(items []string) *OauthConsentQuery {
+	q.where.WriteString(" scope IN(")
+	q.where.WriteString(wrap.RepeatWithSeparator("?", len(items), ","))
+	q.where.WriteString(")")
+	q.whereParams = append(q.whereParams, items)
+	return q
+}
+
+func (q *OauthConsentQuery) CreateTimeEqual(v time.Time) *OauthConsentQuery {
+	q.where.WriteString(" create_time=?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *OauthConsentQuery) CreateTimeNotEqual(v time.Time) *OauthConsentQuery {
+	q.where.WriteString(" create_time<>?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *OauthConsentQuery) CreateTimeLess(v time.Time) *OauthConsentQuery {
+	q.where.WriteString(" create_time<?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *OauthConsentQuery) CreateTimeLessEqual(v time.Time) *OauthConsentQuery {
+	q.where.WriteString(" create_time<=?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *OauthConsentQuery) CreateTimeGreater(v time.Time) *OauthConsentQuery {
+	q.where.WriteString(" create_time>?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *OauthConsentQuery) CreateTimeGreaterEqual(v time.Time) *OauthConsentQuery {
+	q.where.WriteString(" create_time>=?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *OauthConsentQuery) UpdateTimeEqual(v time.Time) *OauthConsentQuery {
+	q.where.WriteString(" update_time=?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *OauthConsentQuery) UpdateTimeNotEqual(v time.Time) *OauthConsentQuery {
+	q.where.WriteString(" update_time<>?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *OauthConsentQuery) UpdateTimeLess(v time.Time) *OauthConsentQuery {
+	q.where.WriteString(" update_time<?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *OauthConsentQuery) UpdateTimeLessEqual(v time.Time) *OauthConsentQuery {
+	q.where.WriteString(" update_time<=?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *OauthConsentQuery) UpdateTimeGreater(v time.Time) *OauthConsentQuery {
+	q.where.WriteString(" update_time>?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *OauthConsentQuery) UpdateTimeGreaterEqual(v time.Time) *OauthConsentQuery {
+	q.where.WriteString(" update_time>=?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *OauthConsentQuery) GroupByUserId(asc bool) *OauthConsentQuery {
+	q.groupByFields = append(q.groupByFields, "user_id")
+	q.groupByOrders = append(q.groupByOrders, asc)
+	return q
+}
+
+func (q *OauthConsentQuery) GroupByClientId(asc bool) *OauthConsentQuery {
+	q.groupByFields = append(q.groupByFields, "client_id")
+	q.groupByOrders = append(q.groupByOrders, asc)
+	return q
+}
+
+func (q *OauthConsentQuery) GroupByScope(asc bool) *OauthConsentQuery {
+	q.groupByFields = append(q.groupByFields, "scope")
+	q.groupByOrders = append(q.groupByOrders, asc)
+	return q
+}
+
+func (q *OauthConsentQuery) OrderById(asc bool) *OauthConsentQuery {
+	q.orderByFields = append(q.orderByFields, "id")
+	q.orderByOrders = append(q.orderByOrders, asc)
+	return q
+}
+
+func (q *OauthConsentQuery) OrderByUserId(asc bool) *OauthConsentQuery {
+	q.orderByFields = append(q.orderByFields, "user_id")
+	q.orderByOrders = append(q.orderByOrders, asc)
+	return q
+}
+
+func (q *OauthConsentQuery) OrderByClientId(asc bool) *OauthConsentQuery {
+	q.orderByFields = append(q.orderByFields, "client_id")
+	q.orderByOrders = append(q.orderByOrders, asc)
+	return q
+}
+
+func (q *OauthConsentQuery) OrderByScope(asc bool) *OauthConsentQuery {
+	q.orderByFields = append(q.orderByFields, "scope")
+	q.orderByOrders = append(q.orderByOrders, asc)
+	return q
+}
+
+func (q *OauthConsentQuery) OrderByCreateTime(asc bool) *OauthConsentQuery {
+	q.orderByFields = append(q.orderByFields, "create_time")
+	q.orderByOrders = append(q.orderByOrders, asc)
+	return q
+}
+
+func (q *OauthConsentQuery) OrderByUpdateTime(asc bool) *OauthConsentQuery {
+	q.orderByFields = append(q.orderByFields, "update_time")
+	q.orderByOrders = append(q.orderByOrders, asc)
+	return q
+}
+
+func (q *OauthConsentQuery) OrderByGroupCount(asc bool) *OauthConsentQuery {
+	q.orderByFields = append(q.orderByFields, "count(*)")
+	q.orderByOrders = append(q.orderByOrders, asc)
+	return q
+}
+
+func (q *OauthConsentQuery) Limit(startIncluded int64, count int64) *OauthConsentQuery {
+	q.hasLimit = true
+	q.limitStartIncluded = startIncluded
+	q.limitCount = count
+	return q
+}
+
+func (q *OauthConsentQuery) ForUpdate() *OauthConsentQuery {
+	q.forUpdate = true
+	return q
+}
+
+func (q *OauthConsentQuery) ForShare() *OauthConsentQuery {
+	q.forShare = true
+	return q
+}
+
+func (q *OauthConsentQuery) SetUserId(v string) *OauthConsentQuery {
+	q.updateFields = append(q.updateFields, "user_id")
+	q.updateParams = append(q.updateParams, v)
+	return q
+}
+
+func (q *OauthConsentQuery) SetClientId(v string) *OauthConsentQuery {
+	q.updateFields = append(q.updateFields, "client_id")
+	q.updateParams = append(q.updateParams, v)
+	return q
+}
+
+func (q *OauthConsentQuery) SetScope(v string) *OauthConsentQuery {
+	q.updateFields = append(q.updateFields, "scope")
+	q.updateParams = append(q.updateParams, v)
+	return q
+}
+
+func (q *OauthConsentQuery) DuplicatedUpdateScope() *OauthConsentQuery {
+	q.duplicatedUpdateFields = append(q.duplicatedUpdateFields, "scope=VALUES(scope)")
+	return q
+}
+
+func (q *OauthConsentQuery) GetId() *OauthConsentQuery {
+	q.getFields = append(q.getFields, "id")
+	return q
+}
+
+func (q *OauthConsentQuery) GetUserId() *OauthConsentQuery {
+	q.getFields = append(q.getFields, "user_id")
+	return q
+}
+
+func (q *OauthConsentQuery) GetClientId() *OauthConsentQuery {
+	q.getFields = append(q.getFields, "client_id")
+	return q
+}
+
+func (q *OauthConsentQuery) GetScope() *OauthConsentQuery {
+	q.getFields = append(q.getFields, "scope")
+	return q
+}
+
+func (q *OauthConsentQuery) GetCreateTime() *OauthConsentQuery {
+	q.getFields = append(q.getFields, "create_time")
+	return q
+}
+
+func (q *OauthConsentQuery) GetUpdateTime() *OauthConsentQuery {
+	q.getFields = append(q.getFields, "update_time")
+	return q
+}
+
+func (q *OauthConsentQuery) Select(ctx context.Context, tx *wrap.Tx) (e *OauthConsent, err error) {
+	if !q.hasLimit {
+		q.limitCount = 1
+		q.hasLimit = true
+	}
+
+	queryString, params := q.buildSelectQuery()
+	query := bytes.NewBufferString("")
+	if len(q.getFields) == 0 {
+		query.WriteString("SELECT id,user_id,client_id,scope,create_time,update_time FROM oauth_consent ")
+	} else {
+		query.WriteString("SELECT ")
+		query.WriteString(strings.Join(q.getFields, ","))
+		query.WriteString(" FROM oauth_consent ")
+	}
+	query.WriteString(queryString)
+	e = &OauthConsent{}
+	row := q.dao.db.QueryRow(ctx, tx, query.String(), params...)
+	err = row.Scan(&e.Id, &e.UserId, &e.ClientId, &e.Scope, &e.CreateTime, &e.UpdateTime)
+	if err == wrap.ErrNoRows {
+		return nil, nil
+	}
+
+	return e, err
+}
+
+func (q *OauthConsentQuery) SelectList(ctx context.Context, tx *wrap.Tx) (list []*OauthConsent, err error) {
+	queryString, params := q.buildSelectQuery()
+	query := bytes.NewBufferString("")
+	if len(q.getFields) == 0 {
+		query.WriteString("SELECT id,user_id,client_id,scope,create_time,update_time FROM oauth_consent ")
+	} else {
+		query.WriteString("SELECT ")
+		query.WriteString(strings.Join(q.getFields, ","))
+		query.WriteString(" FROM oauth_consent ")
+	}
+	query.WriteString(queryString)
+	rows, err := q.dao.db.Query(ctx, tx, query.String(), params...)
+	if err != nil {
+		return nil, err
+	}
+	for rows.Next() {
+		e := OauthConsent{}
+		err = rows.Scan(&e.Id, &e.UserId, &e.ClientId, &e.Scope, &e.CreateTime, &e.UpdateTime)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, &e)
+	}
+	if rows.Err() != nil {
+		err = rows.Err()
+		return nil, err
+	}
+
+	return list, nil
+}
+
+func (q *OauthConsentQuery) SelectCount(ctx context.Context, tx *wrap.Tx) (count int64, err error) {
+	queryString, params := q.buildSelectQuery()
+	query := bytes.NewBufferString("")
+	query.WriteString("SELECT COUNT(*) FROM oauth_consent ")
+	query.WriteString(queryString)
+	row := q.dao.db.QueryRow(ctx, tx, query.String(), params...)
+	err = row.Scan(&count)
+
+	return count, err
+}
+
+func (q *OauthConsentQuery) SelectGroupBy(ctx context.Context, tx *wrap.Tx, withCount bool) (rows *wrap.Rows, err error) {
+	queryString, params := q.buildSelectQuery()
+	query := bytes.NewBufferString("")
+	query.WriteString("SELECT ")
+	query.WriteString(strings.Join(q.groupByFields, ","))
+	if withCount {
+		query.WriteString(",MachineListCount(*) ")
+	}
+	query.WriteString(" FROM oauth_consent ")
+	query.WriteString(queryString)
+
+	return q.dao.db.Query(ctx, tx, query.String(), params...)
+}
+
+func (q *OauthConsentQuery) SelectRow(ctx context.Context, tx *wrap.Tx) (row *wrap.Row) {
+	if !q.hasLimit {
+		q.limitCount = 1
+		q.hasLimit = true
+	}
+
+	queryString, params := q.buildSelectQuery()
+	query := bytes.NewBufferString("")
+	query.WriteString("SELECT ")
+	query.WriteString(strings.Join(q.getFields, ","))
+	query.WriteString(" FROM oauth_consent ")
+	query.WriteString(queryString)
+	return q.dao.db.QueryRow(ctx, tx, query.String(), params...)
+}
+
+func (q *OauthConsentQuery) SelectRows(ctx context.Context, tx *wrap.Tx) (rows *wrap.Rows, err error) {
+	queryString, params := q.buildSelectQuery()
+	query := bytes.NewBufferString("")
+	query.WriteString("SELECT ")
+	query.WriteString(strings.Join(q.getFields, ","))
+	query.WriteString(" FROM oauth_consent ")
+	query.WriteString(queryString)
+	return q.dao.db.Query(ctx, tx, query.String(), params...)
+}
+
+func (q *OauthConsentQuery) Insert(ctx context.Context, tx *wrap.Tx, e *OauthConsent) (result *wrap.Result, err error) {
+	query := bytes.NewBufferString("")
+	query.WriteString("INSERT INTO oauth_consent (user_id,client_id,scope) VALUES (?,?,?)")
+	params := []interface{}{e.UserId, e.ClientId, e.Scope}
+	return q.dao.db.Exec(ctx, tx, query.String(), params...)
+}
+
+func (q *OauthConsentQuery) BatchInsert(ctx context.Context, tx *wrap.Tx, list []*OauthConsent) (result *wrap.Result, err error) {
+	query := bytes.NewBufferString("")
+	query.WriteString("INSERT INTO oauth_consent (user_id,client_id,scope) VALUES ")
+	query.WriteString(wrap.RepeatWithSeparator("(?,?,?)", len(list), ","))
+	params := make([]interface{}, len(list)*3)
+	offset := 0
+	for _, e := range list {
+		params[offset+0] = e.UserId
+		params[offset+1] = e.ClientId
+		params[offset+2] = e.Scope
+		offset += 3
+	}
+
+	return q.dao.db.Exec(ctx, tx, query.String(), params...)
+}
+
+func (q *OauthConsentQuery) InsertOnDuplicatedKeyUpdate(ctx context.Context, tx *wrap.Tx, e *OauthConsent) (result *wrap.Result, err error) {
+	query := bytes.NewBufferString("")
+	query.WriteString("INSERT INTO oauth_consent (user_id,client_id,scope) VALUES (?,?,?)")
+	query.WriteString(" ON DUPLICATED KEY UPDATE ")
+	if len(q.duplicatedUpdateFields) > 0 {
+		query.WriteString(strings.Join(q.duplicatedUpdateFields, ","))
+		query.WriteString(",")
+	}
+	query.WriteString("update_time=now()")
+	params := []interface{}{e.UserId, e.ClientId, e.Scope}
+	return q.dao.db.Exec(ctx, tx, query.String(), params...)
+}
+
+func (q *OauthConsentQuery) BatchInsertOnDuplicatedKeyUpdate(ctx context.Context, tx *wrap.Tx, list []*OauthConsent) (result *wrap.Result, err error) {
+	query := bytes.NewBufferString("")
+	query.WriteString("INSERT INTO oauth_consent (user_id,client_id,scope) VALUES ")
+	query.WriteString(wrap.RepeatWithSeparator("(?,?,?)", len(list), ","))
+	query.WriteString(" ON DUPLICATED KEY UPDATE")
+	if len(q.duplicatedUpdateFields) > 0 {
+		query.WriteString(strings.Join(q.duplicatedUpdateFields, ","))
+		query.WriteString(",")
+	}
+	query.WriteString("update_time=now()")
+	params := make([]interface{}, len(list)*3)
+	offset := 0
+	for _, e := range list {
+		params[offset+0] = e.UserId
+		params[offset+1] = e.ClientId
+		params[offset+2] = e.Scope
+		offset += 3
+	}
+
+	return q.dao.db.Exec(ctx, tx, query.String(), params...)
+}
+
+func (q *OauthConsentQuery) Update(ctx context.Context, tx *wrap.Tx) (result *wrap.Result, err error) {
+	query := bytes.NewBufferString("")
+	var params []interface{}
+	params = append(params, q.updateParams...)
+	query.WriteString("UPDATE oauth_consent SET ")
+	updateItems := make([]string, len(q.updateFields))
+	for i, v := range q.updateFields {
+		updateItems[i] = v + "=?"
+	}
+	query.WriteString(strings.Join(updateItems, ","))
+	query.WriteString(",update_time=now()")
+	where := q.where.String()
+	if where != "" {
+		query.WriteString(" WHERE ")
+		query.WriteString(where)
+		params = append(params, q.whereParams...)
+	}
+
+	return q.dao.db.Exec(ctx, tx, query.String(), params...)
+}
+
+func (q *OauthConsentQuery) Delete(ctx context.Context, tx *wrap.Tx) (result *wrap.Result, err error) {
+	query := "DELETE FROM oauth_consent WHERE " + q.where.String()
+	return q.dao.db.Exec(ctx, tx, query, q.whereParams...)
+}
+
+type OauthConsentDao struct {
+	logger *zap.Logger
+	db     *DB
+}
+
+func NewOauthConsentDao(db *DB) (t *OauthConsentDao, err error) {
+	t = &OauthConsentDao{}
+	t.logger = log.TypedLogger(t)
+	t.db = db
+
+	return t, nil
+}
+
+func (dao *OauthConsentDao) Query() *OauthConsentQuery {
+	q := &OauthConsentQuery{}
+	q.dao = dao
+	q.tableName = "oauth_consent"
+	q.where = bytes.NewBufferString("")
+	return q
+}
+
+type OauthState struct {
+	Id         uint64 //size=20
+	OauthState string //size=128
+	IsUsed     int32  //size=1
+	UserAgent  string //size=256
+	CreateTime time.Time
+	UpdateTime time.Time
+}
+
+type OauthStateQuery struct {
+	QueryBase
+	dao *OauthStateDao
+}
+
+func (q *OauthStateQuery) Left() *OauthStateQuery {
+	q.where.WriteString(" (")
+	return q
+}
+
+func (q *OauthStateQuery) Right() *OauthStateQuery {
+	q.where.WriteString(" )")
+	return q
+}
+
+func (q *OauthStateQuery) And() *OauthStateQuery {
+	q.where.WriteString(" AND")
+	return q
+}
+
+func (q *OauthStateQuery) Or() *OauthStateQuery {
+	q.where.WriteString(" OR")
+	return q
+}
+
+func (q *OauthStateQuery) Not() *OauthStateQuery {
+	q.where.WriteString(" NOT")
+	return q
+}
+
+func (q *OauthStateQuery) IdEqual(v uint64) *OauthStateQuery {
+	q.where.WriteString(" id=?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *OauthStateQuery) IdNotEqual(v uint64) *OauthStateQuery {
+	q.where.WriteString(" id<>?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *OauthStateQuery) IdLess(v uint64) *OauthStateQuery {
+	q.where.WriteString(" id<?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *OauthStateQuery) IdLessEqual(v uint64) *OauthStateQuery {
+	q.where.WriteString(" id<=?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *OauthStateQuery) IdGreater(v uint64) *OauthStateQuery {
+	q.where.WriteString(" id>?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *OauthStateQuery) IdGreaterEqual(v uint64) *OauthStateQuery {
+	q.where.WriteString(" id>=?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *OauthStateQuery) IdIn(items []uint64) *OauthStateQuery {
+	q.where.WriteString(" id IN(")
+	q.where.WriteString(wrap.RepeatWithSeparator("?", len(items), ","))
+	q.where.WriteString(")")
+	q.whereParams = append(q.whereParams, items)
+	return q
+}
+
+func (q *OauthStateQuery) OauthStateEqual(v string) *OauthStateQuery {
+	q.where.WriteString(" oauth_state=?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *OauthStateQuery) OauthStateNotEqual(v string) *OauthStateQuery {
+	q.where.WriteString(" oauth_state<>?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *OauthStateQuery) OauthStateIn(items []string) *OauthStateQuery {
+	q.where.WriteString(" oauth_state IN(")
+	q.where.WriteString(wrap.RepeatWithSeparator("?", len(items), ","))
+	q.where.WriteString(")")
+	q.whereParams = append(q.whereParams, items)
+	return q
+}
+
+func (q *OauthStateQuery) IsUsedEqual(v int32) *OauthStateQuery {
+	q.where.WriteString(" is_used=?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *OauthStateQuery) IsUsedNotEqual(v int32) *OauthStateQuery {
+	q.where.WriteString(" is_used<>?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *OauthStateQuery) IsUsedLess(v int32) *OauthStateQuery {
+	q.where.WriteString(" is_used<?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *OauthStateQuery) IsUsedLessEqual(v int32) *OauthStateQuery {
+	q.where.WriteString(" is_used<=?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *OauthStateQuery) IsUsedGreater(v int32) *OauthStateQuery {
+	q.where.WriteString(" is_used>?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *OauthStateQuery) IsUsedGreaterEqual(v int32) *OauthStateQuery {
+	q.where.WriteString(" is_used>=?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *OauthStateQuery) IsUsedIn(items []int32) *OauthStateQuery {
+	q.where.WriteString(" is_used IN(")
+	q.where.WriteString(wrap.RepeatWithSeparator("?", len(items), ","))
+	q.where.WriteString(")")
+	q.whereParams = append(q.whereParams, items)
+	return q
+}
+
+func (q *OauthStateQuery) UserAgentEqual(v string) *OauthStateQuery {
+	q.where.WriteString(" user_agent=?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *OauthStateQuery) UserAgentNotEqual(v string) *OauthStateQuery {
+	q.where.WriteString(" user_agent<>?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *OauthStateQuery) UserAgentIn(items []string) *OauthStateQuery {
+	q.where.WriteString(" user_agent IN(")
+	q.where.WriteString(wrap.RepeatWithSeparator("?", len(items), ","))
+	q.where.WriteString(")")
+	q.whereParams = append(q.whereParams, items)
+	return q
+}
+
+func (q *OauthStateQuery) CreateTimeEqual(v time.Time) *OauthStateQuery {
+	q.where.WriteString(" create_time=?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *OauthStateQuery) CreateTimeNotEqual(v time.Time) *OauthStateQuery {
+	q.where.WriteString(" create_time<>?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *OauthStateQuery) CreateTimeLess(v time.Time) *OauthStateQuery {
+	q.where.WriteString(" create_time<?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *OauthStateQuery) CreateTimeLessEqual(v time.Time) *OauthStateQuery {
+	q.where.WriteString(" create_time<=?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *OauthStateQuery) CreateTimeGreater(v time.Time) *OauthStateQuery {
+	q.where.WriteString(" create_time>?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *OauthStateQuery) CreateTimeGreaterEqual(v time.Time) *OauthStateQuery {
+	q.where.WriteString(" create_time>=?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *OauthStateQuery) UpdateTimeEqual(v time.Time) *OauthStateQuery {
+	q.where.WriteString(" update_time=?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *OauthStateQuery) UpdateTimeNotEqual(v time.Time) *OauthStateQuery {
+	q.where.WriteString(" update_time<>?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *OauthStateQuery) UpdateTimeLess(v time.Time) *OauthStateQuery {
+	q.where.WriteString(" update_time<?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *OauthStateQuery) UpdateTimeLessEqual(v time.Time) *OauthStateQuery {
+	q.where.WriteString(" update_time<=?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *OauthStateQuery) UpdateTimeGreater(v time.Time) *OauthStateQuery {
+	q.where.WriteString(" update_time>?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *OauthStateQuery) UpdateTimeGreaterEqual(v time.Time) *OauthStateQuery {
+	q.where.WriteString(" update_time>=?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *OauthStateQuery) GroupByIsUsed(asc bool) *OauthStateQuery {
+	q.groupByFields = append(q.groupByFields, "is_used")
+	q.groupByOrders = append(q.groupByOrders, asc)
+	return q
+}
+
+func (q *OauthStateQuery) GroupByUserAgent(asc bool) *OauthStateQuery {
+	q.groupByFields = append(q.groupByFields, "user_agent")
+	q.groupByOrders = append(q.groupByOrders, asc)
+	return q
+}
+
+func (q *OauthStateQuery) OrderById(asc bool) *OauthStateQuery {
+	q.orderByFields = append(q.orderByFields, "id")
+	q.orderByOrders = append(q.orderByOrders, asc)
+	return q
+}
+
+func (q *OauthStateQuery) OrderByOauthState(asc bool) *OauthStateQuery {
+	q.orderByFields = append(q.orderByFields, "oauth_state")
+	q.orderByOrders = append(q.orderByOrders, asc)
+	return q
+}
+
+func (q *OauthStateQuery) OrderByIsUsed(asc bool) *OauthStateQuery {
+	q.orderByFields = append(q.orderByFields, "is_used")
+	q.orderByOrders = append(q.orderByOrders, asc)
+	return q
+}
+
+func (q *OauthStateQuery) OrderByUserAgent(asc bool) *OauthStateQuery {
+	q.orderByFields = append(q.orderByFields, "user_agent")
+	q.orderByOrders = append(q.orderByOrders, asc)
+	return q
+}
+
+func (q *OauthStateQuery) OrderByCreateTime(asc bool) *OauthStateQuery {
+	q.orderByFields = append(q.orderByFields, "create_time")
+	q.orderByOrders = append(q.orderByOrders, asc)
+	return q
+}
+
+func (q *OauthStateQuery) OrderByUpdateTime(asc bool) *OauthStateQuery {
+	q.orderByFields = append(q.orderByFields, "update_time")
+	q.orderByOrders = append(q.orderByOrders, asc)
+	return q
+}
+
+func (q *OauthStateQuery) OrderByGroupCount(asc bool) *OauthStateQuery {
+	q.orderByFields = append(q.orderByFields, "count(*)")
+	q.orderByOrders = append(q.orderByOrders, asc)
+	return q
+}
+
+func (q *OauthStateQuery) Limit(startIncluded int64, count int64) *OauthStateQuery {
+	q.hasLimit = true
+	q.limitStartIncluded = startIncluded
+	q.limitCount = count
+	return q
+}
+
+func (q *OauthStateQuery) ForUpdate() *OauthStateQuery {
+	q.forUpdate = true
+	return q
+}
+
+func (q *OauthStateQuery) ForShare() *OauthStateQuery {
+	q.forShare = true
+	return q
+}
+
+func (q *OauthStateQuery) SetOauthState(v string) *OauthStateQuery {
+	q.updateFields = append(q.updateFields, "oauth_state")
+	q.updateParams = append(q.updateParams, v)
+	return q
+}
+
+func (q *OauthStateQuery) SetIsUsed(v int32) *OauthStateQuery {
+	q.updateFields = append(q.updateFields, "is_used")
+	q.updateParams = append(q.updateParams, v)
+	return q
+}
+
+func (q *OauthStateQuery) SetUserAgent(v string) *OauthStateQuery {
+	q.updateFields = append(q.updateFields, "user_agent")
+	q.updateParams = append(q.updateParams, v)
+	return q
+}
+
+func (q *OauthStateQuery) DuplicatedUpdateIsUsed() *OauthStateQuery {
+	q.duplicatedUpdateFields = append(q.duplicatedUpdateFields, "is_used=VALUES(is_used)")
+	return q
+}
+
+func (q *OauthStateQuery) DuplicatedUpdateUserAgent() *OauthStateQuery {
+	q.duplicatedUpdateFields = append(q.duplicatedUpdateFields, "user_agent=VALUES(user_agent)")
+	return q
+}
+
+func (q *OauthStateQuery) GetId() *OauthStateQuery {
+	q.getFields = append(q.getFields, "id")
+	return q
+}
+
+func (q *OauthStateQuery) GetOauthState() *OauthStateQuery {
+	q.getFields = append(q.getFields, "oauth_state")
+	return q
+}
+
+func (q *OauthStateQuery) GetIsUsed() *OauthStateQuery {
+	q.getFields = append(q.getFields, "is_used")
+	return q
+}
+
+func (q *OauthStateQuery) GetUserAgent() *OauthStateQuery {
+	q.getFields = append(q.getFields, "user_agent")
+	return q
+}
+
+func (q *OauthStateQuery) GetCreateTime() *OauthStateQuery {
+	q.getFields = append(q.getFields, "create_time")
+	return q
+}
+
+func (q *OauthStateQuery) GetUpdateTime() *OauthStateQuery {
+	q.getFields = append(q.getFields, "update_time")
+	return q
+}
+
+func (q *OauthStateQuery) Select(ctx context.Context, tx *wrap.Tx) (e *OauthState, err error) {
+	if !q.hasLimit {
+		q.limitCount = 1
+		q.hasLimit = true
+	}
+
+	queryString, params := q.buildSelectQuery()
+	query := bytes.NewBufferString("")
+	if len(q.getFields) == 0 {
+		query.WriteString("SELECT id,oauth_state,is_used,user_agent,create_time,update_time FROM oauth_state ")
+	} else {
+		query.WriteString("SELECT ")
+		query.WriteString(strings.Join(q.getFields, ","))
+		query.WriteString(" FROM oauth_state ")
+	}
+	query.WriteString(queryString)
+	e = &OauthState{}
+	row := q.dao.db.QueryRow(ctx, tx, query.String(), params...)
+	err = row.Scan(&e.Id, &e.OauthState, &e.IsUsed, &e.UserAgent, &e.CreateTime, &e.UpdateTime)
+	if err == wrap.ErrNoRows {
+		return nil, nil
+	}
+
+	return e, err
+}
+
+func (q *OauthStateQuery) SelectList(ctx context.Context, tx *wrap.Tx) (list []*OauthState, err error) {
+	queryString, params := q.buildSelectQuery()
+	query := bytes.NewBufferString("")
+	if len(q.getFields) == 0 {
+		query.WriteString("SELECT id,oauth_state,is_used,user_agent,create_time,update_time FROM oauth_state ")
+	} else {
+		query.WriteString("SELECT ")
+		query.WriteString(strings.Join(q.getFields, ","))
+		query.WriteString(" FROM oauth_state ")
+	}
+	query.WriteString(queryString)
+	rows, err := q.dao.db.Query(ctx, tx, query.String(), params...)
+	if err != nil {
+		return nil, err
+	}
+	for rows.Next() {
+		e := OauthState{}
+		err = rows.Scan(&e.Id, &e.OauthState, &e.IsUsed, &e.UserAgent, &e.CreateTime, &e.UpdateTime)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, &e)
+	}
+	if rows.Err() != nil {
+		err = rows.Err()
+		return nil, err
+	}
+
+	return list, nil
+}
+
+func (q *OauthStateQuery) SelectCount(ctx context.Context, tx *wrap.Tx) (count int64, err error) {
+	queryString, params := q.buildSelectQuery()
+	query := bytes.NewBufferString("")
+	query.WriteString("SELECT COUNT(*) FROM oauth_state ")
+	query.WriteString(queryString)
+	row := q.dao.db.QueryRow(ctx, tx, query.String(), params...)
+	err = row.Scan(&count)
+
+	return count, err
+}
+
+func (q *OauthStateQuery) SelectGroupBy(ctx context.Context, tx *wrap.Tx, withCount bool) (rows *wrap.Rows, err error) {
+	queryString, params := q.buildSelectQuery()
+	query := bytes.NewBufferString("")
+	query.WriteString("SELECT ")
+	query.WriteString(strings.Join(q.groupByFields, ","))
+	if withCount {
+		query.WriteString(",MachineListCount(*) ")
+	}
+	query.WriteString(" FROM oauth_state ")
+	query.WriteString(queryString)
+
+	return q.dao.db.Query(ctx, tx, query.String(), params...)
+}
+
+func (q *OauthStateQuery) SelectRow(ctx context.Context, tx *wrap.Tx) (row *wrap.Row) {
+	if !q.hasLimit {
+		q.limitCount = 1
+		q.hasLimit = true
+	}
+
+	queryString, params := q.buildSelectQuery()
+	query := bytes.NewBufferString("")
+	query.WriteString("SELECT ")
+	query.WriteString(strings.Join(q.getFields, ","))
+	query.WriteString(" FROM oauth_state ")
+	query.WriteString(queryString)
+	return q.dao.db.QueryRow(ctx, tx, query.String(), params...)
+}
+
+func (q *OauthStateQuery) SelectRows(ctx context.Context, tx *wrap.Tx) (rows *wrap.Rows, err error) {
+	queryString, params := q.buildSelectQuery()
+	query := bytes.NewBufferString("")
+	query.WriteString("SELECT ")
+	query.WriteString(strings.Join(q.getFields, ","))
+	query.WriteString(" FROM oauth_state ")
+	query.WriteString(queryString)
+	return q.dao.db.Query(ctx, tx, query.String(), params...)
+}
+
+func (q *OauthStateQuery) Insert(ctx context.Context, tx *wrap.Tx, e *OauthState) (result *wrap.Result, err error) {
+	query := bytes.NewBufferString("")
+	query.WriteString("INSERT INTO oauth_state (oauth_state,is_used,user_agent) VALUES (?,?,?)")
+	params := []interface{}{e.OauthState, e.IsUsed, e.UserAgent}
+	return q.dao.db.Exec(ctx, tx, query.String(), params...)
+}
+
+func (q *OauthStateQuery) BatchInsert(ctx context.Context, tx *wrap.Tx, list []*OauthState) (result *wrap.Result, err error) {
+	query := bytes.NewBufferString("")
+	query.WriteString("INSERT INTO oauth_state (oauth_state,is_used,user_agent) VALUES ")
+	query.WriteString(wrap.RepeatWithSeparator("(?,?,?)", len(list), ","))
+	params := make([]interface{}, len(list)*3)
+	offset := 0
+	for _, e := range list {
+		params[offset+0] = e.OauthState
+		params[offset+1] = e.IsUsed
+		params[offset+2] = e.UserAgent
+		offset += 3
+	}
+
+	return q.dao.db.Exec(ctx, tx, query.String(), params...)
+}
+
+func (q *OauthStateQuery) InsertOnDuplicatedKeyUpdate(ctx context.Context, tx *wrap.Tx, e *OauthState) (result *wrap.Result, err error) {
+	query := bytes.NewBufferString("")
+	query.WriteString("INSERT INTO oauth_state (oauth_state,is_used,user_agent) VALUES (?,?,?)")
+	query.WriteString(" ON DUPLICATED KEY UPDATE ")
+	if len(q.duplicatedUpdateFields) > 0 {
+		query.WriteString(strings.Join(q.duplicatedUpdateFields, ","))
+		query.WriteString(",")
+	}
+	query.WriteString("update_time=now()")
+	params := []interface{}{e.OauthState, e.IsUsed, e.UserAgent}
+	return q.dao.db.Exec(ctx, tx, query.String(), params...)
+}
+
+func (q *OauthStateQuery) BatchInsertOnDuplicatedKeyUpdate(ctx context.Context, tx *wrap.Tx, list []*OauthState) (result *wrap.Result, err error) {
+	query := bytes.NewBufferString("")
+	query.WriteString("INSERT INTO oauth_state (oauth_state,is_used,user_agent) VALUES ")
+	query.WriteString(wrap.RepeatWithSeparator("(?,?,?)", len(list), ","))
+	query.WriteString(" ON DUPLICATED KEY UPDATE")
+	if len(q.duplicatedUpdateFields) > 0 {
+		query.WriteString(strings.Join(q.duplicatedUpdateFields, ","))
+		query.WriteString(",")
+	}
+	query.WriteString("update_time=now()")
+	params := make([]interface{}, len(list)*3)
+	offset := 0
+	for _, e := range list {
+		params[offset+0] = e.OauthState
+		params[offset+1] = e.IsUsed
+		params[offset+2] = e.UserAgent
+		offset += 3
+	}
+
+	return q.dao.db.Exec(ctx, tx, query.String(), params...)
+}
+
+func (q *OauthStateQuery) Update(ctx context.Context, tx *wrap.Tx) (result *wrap.Result, err error) {
+	query := bytes.NewBufferString("")
+	var params []interface{}
+	params = append(params, q.updateParams...)
+	query.WriteString("UPDATE oauth_state SET ")
+	updateItems := make([]string, len(q.updateFields))
+	for i, v := range q.updateFields {
+		updateItems[i] = v + "=?"
+	}
+	query.WriteString(strings.Join(updateItems, ","))
+	query.WriteString(",update_time=now()")
+	where := q.where.String()
+	if where != "" {
+		query.WriteString(" WHERE ")
+		query.WriteString(where)
+		params = append(params, q.whereParams...)
+	}
+
+	return q.dao.db.Exec(ctx, tx, query.String(), params...)
+}
+
+func (q *OauthStateQuery) Delete(ctx context.Context, tx *wrap.Tx) (result *wrap.Result, err error) {
+	query := "DELETE FROM oauth_state WHERE " + q.where.String()
+	return q.dao.db.Exec(ctx, tx, query, q.whereParams...)
+}
+
+type OauthStateDao struct {
+	logger *zap.Logger
+	db     *DB
+}
+
+func NewOauthStateDao(db *DB) (t *OauthStateDao, err error) {
+	t = &OauthStateDao{}
+	t.logger = log.TypedLogger(t)
+	t.db = db
+
+	return t, nil
+}
+
+func (dao *OauthStateDao) Query() *OauthStateQuery {
+	q := &OauthStateQuery{}
+	q.dao = dao
+	q.tableName = "oauth_state"
+	q.where = bytes.NewBufferString("")
+	return q
+}
+
+type PasswordHistory struct {
+	Id           uint64 //size=20
+	UserId       string //size=32
+	PasswordHash string //size=1024
+	CreateTime   time.Time
+	UpdateTime   time.Time
+}
+
+type PasswordHistoryQuery struct {
+	QueryBase
+	dao *PasswordHistoryDao
+}
+
+func (q *PasswordHistoryQuery) Left() *PasswordHistoryQuery {
+	q.where.WriteString(" (")
+	return q
+}
+
+func (q *PasswordHistoryQuery) Right() *PasswordHistoryQuery {
+	q.where.WriteString(" )")
+	return q
+}
+
+func (q *PasswordHistoryQuery) And() *PasswordHistoryQuery {
+	q.where.WriteString(" AND")
+	return q
+}
+
+func (q *PasswordHistoryQuery) Or() *PasswordHistoryQuery {
+	q.where.WriteString(" OR")
+	return q
+}
+
+func (q *PasswordHistoryQuery) Not() *PasswordHistoryQuery {
+	q.where.WriteString(" NOT")
+	return q
+}
+
+func (q *PasswordHistoryQuery) IdEqual(v uint64) *PasswordHistoryQuery {
+	q.where.WriteString(" id=?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *PasswordHistoryQuery) IdNotEqual(v uint64) *PasswordHistoryQuery {
+	q.where.WriteString(" id<>?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *PasswordHistoryQuery) IdLess(v uint64) *PasswordHistoryQuery {
+	q.where.WriteString(" id<?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *PasswordHistoryQuery) IdLessEqual(v uint64) *PasswordHistoryQuery {
+	q.where.WriteString(" id<=?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *PasswordHistoryQuery) IdGreater(v uint64) *PasswordHistoryQuery {
+	q.where.WriteString(" id>?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *PasswordHistoryQuery) IdGreaterEqual(v uint64) *PasswordHistoryQuery {
+	q.where.WriteString(" id>=?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *PasswordHistoryQuery) IdIn(items []uint64) *PasswordHistoryQuery {
+	q.where.WriteString(" id IN(")
+	q.where.WriteString(wrap.RepeatWithSeparator("?", len(items), ","))
+	q.where.WriteString(")")
+	q.whereParams = append(q.whereParams, items)
+	return q
+}
+
+func (q *PasswordHistoryQuery) UserIdEqual(v string) *PasswordHistoryQuery {
+	q.where.WriteString(" user_id=?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *PasswordHistoryQuery) UserIdNotEqual(v string) *PasswordHistoryQuery {
+	q.where.WriteString(" user_id<>?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *PasswordHistoryQuery) UserIdIn(items []string) *PasswordHistoryQuery {
+	q.where.WriteString(" user_id IN(")
+	q.where.WriteString(wrap.RepeatWithSeparator("?", len(items), ","))
+	q.where.WriteString(")")
+	q.whereParams = append(q.whereParams, items)
+	return q
+}
+
+func (q *PasswordHistoryQuery) PasswordHashEqual(v string) *PasswordHistoryQuery {
+	q.where.WriteString(" password_hash=?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *PasswordHistoryQuery) PasswordHashNotEqual(v string) *PasswordHistoryQuery {
+	q.where.WriteString(" password_hash<>?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *PasswordHistoryQuery) PasswordHashIn(items []string) *PasswordHistoryQuery {
+	q.where.WriteString(" password_hash IN(")
+	q.where.WriteString(wrap.RepeatWithSeparator("?", len(items), ","))
+	q.where.WriteString(")")
+	q.whereParams = append(q.whereParams, items)
+	return q
+}
+
+func (q *PasswordHistoryQuery) CreateTimeEqual(v time.Time) *PasswordHistoryQuery {
+	q.where.WriteString(" create_time=?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *PasswordHistoryQuery) CreateTimeNotEqual(v time.Time) *PasswordHistoryQuery {
+	q.where.WriteString(" create_time<>?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *PasswordHistoryQuery) CreateTimeLess(v time.Time) *PasswordHistoryQuery {
+	q.where.WriteString(" create_time<?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *PasswordHistoryQuery) CreateTimeLessEqual(v time.Time) *PasswordHistoryQuery {
+	q.where.WriteString(" create_time<=?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *PasswordHistoryQuery) CreateTimeGreater(v time.Time) *PasswordHistoryQuery {
+	q.where.WriteString(" create_time>?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *PasswordHistoryQuery) CreateTimeGreaterEqual(v time.Time) *PasswordHistoryQuery {
+	q.where.WriteString(" create_time>=?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *PasswordHistoryQuery) UpdateTimeEqual(v time.Time) *PasswordHistoryQuery {
+	q.where.WriteString(" update_time=?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *PasswordHistoryQuery) UpdateTimeNotEqual(v time.Time) *PasswordHistoryQuery {
+	q.where.WriteString(" update_time<>?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *PasswordHistoryQuery) UpdateTimeLess(v time.Time) *PasswordHistoryQuery {
+	q.where.WriteString(" update_time<?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *PasswordHistoryQuery) UpdateTimeLessEqual(v time.Time) *PasswordHistoryQuery {
+	q.where.WriteString(" update_time<=?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *PasswordHistoryQuery) UpdateTimeGreater(v time.Time) *PasswordHistoryQuery {
+	q.where.WriteString(" update_time>?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *PasswordHistoryQuery) UpdateTimeGreaterEqual(v time.Time) *PasswordHistoryQuery {
+	q.where.WriteString(" update_time>=?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *PasswordHistoryQuery) GroupByUserId(asc bool) *PasswordHistoryQuery {
+	q.groupByFields = append(q.groupByFields, "user_id")
+	q.groupByOrders = append(q.groupByOrders, asc)
+	return q
+}
+
+func (q *PasswordHistoryQuery) GroupByPasswordHash(asc bool) *PasswordHistoryQuery {
+	q.groupByFields = append(q.groupByFields, "password_hash")
+	q.groupByOrders = append(q.groupByOrders, asc)
+	return q
+}
+
+func (q *PasswordHistoryQuery) OrderById(asc bool) *PasswordHistoryQuery {
+	q.orderByFields = append(q.orderByFields, "id")
+	q.orderByOrders = append(q.orderByOrders, asc)
+	return q
+}
+
+func (q *PasswordHistoryQuery) OrderByUserId(asc bool) *PasswordHistoryQuery {
+	q.orderByFields = append(q.orderByFields, "user_id")
+	q.orderByOrders = append(q.orderByOrders, asc)
+	return q
+}
+
+func (q *PasswordHistoryQuery) OrderByPasswordHash(asc bool) *PasswordHistoryQuery {
+	q.orderByFields = append(q.orderByFields, "password_hash")
+	q.orderByOrders = append(q.orderByOrders, asc)
+	return q
+}
+
+func (q *PasswordHistoryQuery) OrderByCreateTime(asc bool) *PasswordHistoryQuery {
+	q.orderByFields = append(q.orderByFields, "create_time")
+	q.orderByOrders = append(q.orderByOrders, asc)
+	return q
+}
+
+func (q *PasswordHistoryQuery) OrderByUpdateTime(asc bool) *PasswordHistoryQuery {
+	q.orderByFields = append(q.orderByFields, "update_time")
+	q.orderByOrders = append(q.orderByOrders, asc)
+	return q
+}
+
+func (q *PasswordHistoryQuery) OrderByGroupCount(asc bool) *PasswordHistoryQuery {
+	q.orderByFields = append(q.orderByFields, "count(*)")
+	q.orderByOrders = append(q.orderByOrders, asc)
+	return q
+}
+
+func (q *PasswordHistoryQuery) Limit(startIncluded int64, count int64) *PasswordHistoryQuery {
+	q.hasLimit = true
+	q.limitStartIncluded = startIncluded
+	q.limitCount = count
+	return q
+}
+
+func (q *PasswordHistoryQuery) ForUpdate() *PasswordHistoryQuery {
+	q.forUpdate = true
+	return q
+}
+
+func (q *PasswordHistoryQuery) ForShare() *PasswordHistoryQuery {
+	q.forShare = true
+	return q
+}
+
+func (q *PasswordHistoryQuery) SetUserId(v string) *PasswordHistoryQuery {
+	q.updateFields = append(q.updateFields, "user_id")
+	q.updateParams = append(q.updateParams, v)
+	return q
+}
+
+func (q *PasswordHistoryQuery) SetPasswordHash(v string) *PasswordHistoryQuery {
+	q.updateFields = append(q.updateFields, "password_hash")
+	q.updateParams = append(q.updateParams, v)
+	return q
+}
+
+func (q *PasswordHistoryQuery) DuplicatedUpdateUserId() *PasswordHistoryQuery {
+	q.duplicatedUpdateFields = append(q.duplicatedUpdateFields, "user_id=VALUES(user_id)")
+	return q
+}
+
+func (q *PasswordHistoryQuery) DuplicatedUpdatePasswordHash() *PasswordHistoryQuery {
+	q.duplicatedUpdateFields = append(q.duplicatedUpdateFields, "password_hash=VALUES(password_hash)")
+	return q
+}
+
+func (q *PasswordHistoryQuery) GetId() *PasswordHistoryQuery {
+	q.getFields = append(q.getFields, "id")
+	return q
+}
+
+func (q *PasswordHistoryQuery) GetUserId() *PasswordHistoryQuery {
+	q.getFields = append(q.getFields, "user_id")
+	return q
+}
+
+func (q *PasswordHistoryQuery) GetPasswordHash() *PasswordHistoryQuery {
+	q.getFields = append(q.getFields, "password_hash")
+	return q
+}
+
+func (q *PasswordHistoryQuery) GetCreateTime() *PasswordHistoryQuery {
+	q.getFields = append(q.getFields, "create_time")
+	return q
+}
+
+func (q *PasswordHistoryQuery) GetUpdateTime() *PasswordHistoryQuery {
+	q.getFields = append(q.getFields, "update_time")
+	return q
+}
+
+func (q *PasswordHistoryQuery) Select(ctx context.Context, tx *wrap.Tx) (e *PasswordHistory, err error) {
+	if !q.hasLimit {
+		q.limitCount = 1
+		q.hasLimit = true
+	}
+
+	queryString, params := q.buildSelectQuery()
+	query := bytes.NewBufferString("")
+	if len(q.getFields) == 0 {
+		query.WriteString("SELECT id,user_id,password_hash,create_time,update_time FROM password_history ")
+	} else {
+		query.WriteString("SELECT ")
+		query.WriteString(strings.Join(q.getFields, ","))
+		query.WriteString(" FROM password_history ")
+	}
+	query.WriteString(queryString)
+	e = &PasswordHistory{}
+	row := q.dao.db.QueryRow(ctx, tx, query.String(), params...)
+	err = row.Scan(&e.Id, &e.UserId, &e.PasswordHash, &e.CreateTime, &e.UpdateTime)
+	if err == wrap.ErrNoRows {
+		return nil, nil
+	}
+
+	return e, err
+}
+
+func (q *PasswordHistoryQuery) SelectList(ctx context.Context, tx *wrap.Tx) (list []*PasswordHistory, err error) {
+	queryString, params := q.buildSelectQuery()
+	query := bytes.NewBufferString("")
+	if len(q.getFields) == 0 {
+		query.WriteString("SELECT id,user_id,password_hash,create_time,update_time FROM password_history ")
+	} else {
+		query.WriteString("SELECT ")
+		query.WriteString(strings.Join(q.getFields, ","))
+		query.WriteString(" FROM password_history ")
+	}
+	query.WriteString(queryString)
+	rows, err := q.dao.db.Query(ctx, tx, query.String(), params...)
+	if err != nil {
+		return nil, err
+	}
+	for rows.Next() {
+		e := PasswordHistory{}
+		err = rows.Scan(&e.Id, &e.UserId, &e.PasswordHash, &e.CreateTime, &e.UpdateTime)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, &e)
+	}
+	if rows.Err() != nil {
+		err = rows.Err()
+		return nil, err
+	}
+
+	return list, nil
+}
+
+func (q *PasswordHistoryQuery) SelectCount(ctx context.Context, tx *wrap.Tx) (count int64, err error) {
+	queryString, params := q.buildSelectQuery()
+	query := bytes.NewBufferString("")
+	query.WriteString("SELECT COUNT(*) FROM password_history ")
+	query.WriteString(queryString)
+	row := q.dao.db.QueryRow(ctx, tx, query.String(), params...)
+	err = row.Scan(&count)
+
+	return count, err
+}
+
+func (q *PasswordHistoryQuery) SelectGroupBy(ctx context.Context, tx *wrap.Tx, withCount bool) (rows *wrap.Rows, err error) {
+	queryString, params := q.buildSelectQuery()
+	query := bytes.NewBufferString("")
+	query.WriteString("SELECT ")
+	query.WriteString(strings.Join(q.groupByFields, ","))
+	if withCount {
+		query.WriteString(",MachineListCount(*) ")
+	}
+	query.WriteString(" FROM password_history ")
+	query.WriteString(queryString)
+
+	return q.dao.db.Query(ctx, tx, query.String(), params...)
+}
+
+func (q *PasswordHistoryQuery) SelectRow(ctx context.Context, tx *wrap.Tx) (row *wrap.Row) {
+	if !q.hasLimit {
+		q.limitCount = 1
+		q.hasLimit = true
+	}
+
+	queryString, params := q.buildSelectQuery()
+	query := bytes.NewBufferString("")
+	query.WriteString("SELECT ")
+	query.WriteString(strings.Join(q.getFields, ","))
+	query.WriteString(" FROM password_history ")
+	query.WriteString(queryString)
+	return q.dao.db.QueryRow(ctx, tx, query.String(), params...)
+}
+
+func (q *PasswordHistoryQuery) SelectRows(ctx context.Context, tx *wrap.Tx) (rows *wrap.Rows, err error) {
+	queryString, params := q.buildSelectQuery()
+	query := bytes.NewBufferString("")
+	query.WriteString("SELECT ")
+	query.WriteString(strings.Join(q.getFields, ","))
+	query.WriteString(" FROM password_history ")
+	query.WriteString(queryString)
+	return q.dao.db.Query(ctx, tx, query.String(), params...)
+}
+
+func (q *PasswordHistoryQuery) Insert(ctx context.Context, tx *wrap.Tx, e *PasswordHistory) (result *wrap.Result, err error) {
+	query := bytes.NewBufferString("")
+	query.WriteString("INSERT INTO password_history (user_id,password_hash) VALUES (?,?)")
+	params := []interface{}{e.UserId, e.PasswordHash}
+	return q.dao.db.Exec(ctx, tx, query.String(), params...)
+}
+
+func (q *PasswordHistoryQuery) BatchInsert(ctx context.Context, tx *wrap.Tx, list []*PasswordHistory) (result *wrap.Result, err error) {
+	query := bytes.NewBufferString("")
+	query.WriteString("INSERT INTO password_history (user_id,password_hash) VALUES ")
+	query.WriteString(wrap.RepeatWithSeparator("(?,?)", len(list), ","))
+	params := make([]interface{}, len(list)*2)
+	offset := 0
+	for _, e := range list {
+		params[offset+0] = e.UserId
+		params[offset+1] = e.PasswordHash
+		offset += 2
+	}
+
+	return q.dao.db.Exec(ctx, tx, query.String(), params...)
+}
+
+func (q *PasswordHistoryQuery) Update(ctx context.Context, tx *wrap.Tx) (result *wrap.Result, err error) {
+	query := bytes.NewBufferString("")
+	var params []interface{}
+	params = append(params, q.updateParams...)
+	query.WriteString("UPDATE password_history SET ")
+	updateItems := make([]string, len(q.updateFields))
+	for i, v := range q.updateFields {
+		updateItems[i] = v + "=?"
+	}
+	query.WriteString(strings.Join(updateItems, ","))
+	query.WriteString(",update_time=now()")
+	where := q.where.String()
+	if where != "" {
+		query.WriteString(" WHERE ")
+		query.WriteString(where)
+		params = append(params, q.whereParams...)
+	}
+
+	return q.dao.db.Exec(ctx, tx, query.String(), params...)
+}
+
+func (q *PasswordHistoryQuery) Delete(ctx context.Context, tx *wrap.Tx) (result *wrap.Result, err error) {
+	query := "DELETE FROM password_history WHERE " + q.where.String()
+	return q.dao.db.Exec(ctx, tx, query, q.whereParams...)
+}
+
+type PasswordHistoryDao struct {
+	logger *zap.Logger
+	db     *DB
+}
+
+func NewPasswordHistoryDao(db *DB) (t *PasswordHistoryDao, err error) {
+	t = &PasswordHistoryDao{}
+	t.logger = log.TypedLogger(t)
+	t.db = db
+
+	return t, nil
+}
+
+func (dao *PasswordHistoryDao) Query() *PasswordHistoryQuery {
+	q := &PasswordHistoryQuery{}
+	q.dao = dao
+	q.tableName = "password_history"
+	q.where = bytes.NewBufferString("")
+	return q
+}
+
+type PhoneAccount struct {
+	Id             uint64 //size=20
+	UserId         string //size=32
+	PhoneEncrypted string //size=32
+	CreateTime     time.Time
+	UpdateTime     time.Time
+}
+
+type PhoneAccountQuery struct {
+	QueryBase
+	dao *PhoneAccountDao
+}
+
+func (q *PhoneAccountQuery) Left() *PhoneAccountQuery {
+	q.where.WriteString(" (")
+	return q
+}
+
+func (q *PhoneAccountQuery) Right() *PhoneAccountQuery {
+	q.where.WriteString(" )")
+	return q
+}
+
+func (q *PhoneAccountQuery) And() *PhoneAccountQuery {
+	q.where.WriteString(" AND")
+	return q
+}
+
+func (q *PhoneAccountQuery) Or() *PhoneAccountQuery {
+	q.where.WriteString(" OR")
+	return q
+}
+
+func (q *PhoneAccountQuery) Not() *PhoneAccountQuery {
+	q.where.WriteString(" NOT")
+	return q
+}
+
+func (q *PhoneAccountQuery) IdEqual(v uint64) *PhoneAccountQuery {
+	q.where.WriteString(" id=?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *PhoneAccountQuery) IdNotEqual(v uint64) *PhoneAccountQuery {
+	q.where.WriteString(" id<>?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *PhoneAccountQuery) IdLess(v uint64) *PhoneAccountQuery {
+	q.where.WriteString(" id<?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *PhoneAccountQuery) IdLessEqual(v uint64) *PhoneAccountQuery {
+	q.where.WriteString(" id<=?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *PhoneAccountQuery) IdGreater(v uint64) *PhoneAccountQuery {
+	q.where.WriteString(" id>?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *PhoneAccountQuery) IdGreaterEqual(v uint64) *PhoneAccountQuery {
+	q.where.WriteString(" id>=?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *PhoneAccountQuery) IdIn(items []uint64) *PhoneAccountQuery {
+	q.where.WriteString(" id IN(")
+	q.where.WriteString(wrap.RepeatWithSeparator("?", len(items), ","))
+	q.where.WriteString(")")
+	q.whereParams = append(q.whereParams, items)
+	return q
+}
+
+func (q *PhoneAccountQuery) UserIdEqual(v string) *PhoneAccountQuery {
+	q.where.WriteString(" user_id=?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *PhoneAccountQuery) UserIdNotEqual(v string) *PhoneAccountQuery {
+	q.where.WriteString(" user_id<>?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *PhoneAccountQuery) UserIdIn(items []string) *PhoneAccountQuery {
+	q.where.WriteString(" user_id IN(")
+	q.where.WriteString(wrap.RepeatWithSeparator("?", len(items), ","))
+	q.where.WriteString(")")
+	q.whereParams = append(q.whereParams, items)
+	return q
+}
+
+func (q *PhoneAccountQuery) PhoneEncryptedEqual(v string) *PhoneAccountQuery {
+	q.where.WriteString(" phone_encrypted=?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *PhoneAccountQuery) PhoneEncryptedNotEqual(v string) *PhoneAccountQuery {
+	q.where.WriteString(" phone_encrypted<>?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *PhoneAccountQuery) PhoneEncryptedIn(items []string) *PhoneAccountQuery {
+	q.where.WriteString(" phone_encrypted IN(")
+	q.where.WriteString(wrap.RepeatWithSeparator("?", len(items), ","))
+	q.where.WriteString(")")
+	q.whereParams = append(q.whereParams, items)
+	return q
+}
+
+func (q *PhoneAccountQuery) CreateTimeEqual(v time.Time) *PhoneAccountQuery {
+	q.where.WriteString(" create_time=?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *PhoneAccountQuery) CreateTimeNotEqual(v time.Time) *PhoneAccountQuery {
+	q.where.WriteString(" create_time<>?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *PhoneAccountQuery) CreateTimeLess(v time.Time) *PhoneAccountQuery {
+	q.where.WriteString(" create_time<?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *PhoneAccountQuery) CreateTimeLessEqual(v time.Time) *PhoneAccountQuery {
+	q.where.WriteString(" create_time<=?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *PhoneAccountQuery) CreateTimeGreater(v time.Time) *PhoneAccountQuery {
+	q.where.WriteString(" create_time>?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *PhoneAccountQuery) CreateTimeGreaterEqual(v time.Time) *PhoneAccountQuery {
+	q.where.WriteString(" create_time>=?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *PhoneAccountQuery) UpdateTimeEqual(v time.Time) *PhoneAccountQuery {
+	q.where.WriteString(" update_time=?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *PhoneAccountQuery) UpdateTimeNotEqual(v time.Time) *PhoneAccountQuery {
+	q.where.WriteString(" update_time<>?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *PhoneAccountQuery) UpdateTimeLess(v time.Time) *PhoneAccountQuery {
+	q.where.WriteString(" update_time<?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *PhoneAccountQuery) UpdateTimeLessEqual(v time.Time) *PhoneAccountQuery {
+	q.where.WriteString(" update_time<=?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *PhoneAccountQuery) UpdateTimeGreater(v time.Time) *PhoneAccountQuery {
+	q.where.WriteString(" update_time>?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *PhoneAccountQuery) UpdateTimeGreaterEqual(v time.Time) *PhoneAccountQuery {
+	q.where.WriteString(" update_time>=?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *PhoneAccountQuery) GroupByUserId(asc bool) *PhoneAccountQuery {
+	q.groupByFields = append(q.groupByFields, "user_id")
+	q.groupByOrders = append(q.groupByOrders, asc)
+	return q
+}
+
+func (q *PhoneAccountQuery) OrderById(asc bool) *PhoneAccountQuery {
+	q.orderByFields = append(q.orderByFields, "id")
+	q.orderByOrders = append(q.orderByOrders, asc)
+	return q
+}
+
+func (q *PhoneAccountQuery) OrderByUserId(asc bool) *PhoneAccountQuery {
+	q.orderByFields = append(q.orderByFields, "user_id")
+	q.orderByOrders = append(q.orderByOrders, asc)
+	return q
+}
+
+func (q *PhoneAccountQuery) OrderByPhoneEncrypted(asc bool) *PhoneAccountQuery {
+	q.orderByFields = append(q.orderByFields, "phone_encrypted")
+	q.orderByOrders = append(q.orderByOrders, asc)
+	return q
+}
+
+func (q *PhoneAccountQuery) OrderByCreateTime(asc bool) *PhoneAccountQuery {
+	q.orderByFields = append(q.orderByFields, "create_time")
+	q.orderByOrders = append(q.orderByOrders, asc)
+	return q
+}
+
+func (q *PhoneAccountQuery) OrderByUpdateTime(asc bool) *PhoneAccountQuery {
+	q.orderByFields = append(q.orderByFields, "update_time")
+	q.orderByOrders = append(q.orderByOrders, asc)
+	return q
+}
+
+func (q *PhoneAccountQuery) OrderByGroupCount(asc bool) *PhoneAccountQuery {
+	q.orderByFields = append(q.orderByFields, "count(*)")
+	q.orderByOrders = append(q.orderByOrders, asc)
+	return q
+}
+
+func (q *PhoneAccountQuery) Limit(startIncluded int64, count int64) *PhoneAccountQuery {
+	q.hasLimit = true
+	q.limitStartIncluded = startIncluded
+	q.limitCount = count
+	return q
+}
+
+func (q *PhoneAccountQuery) ForUpdate() *PhoneAccountQuery {
+	q.forUpdate = true
+	return q
+}
+
+func (q *PhoneAccountQuery) ForShare() *PhoneAccountQuery {
+	q.forShare = true
+	return q
+}
+
+func (q *PhoneAccountQuery) SetUserId(v string) *PhoneAccountQuery {
+	q.updateFields = append(q.updateFields, "user_id")
+	q.updateParams = append(q.updateParams, v)
+	return q
+}
+
+func (q *PhoneAccountQuery) SetPhoneEncrypted(v string) *PhoneAccountQuery {
+	q.updateFields = append(q.updateFields, "phone_encrypted")
+	q.updateParams = append(q.updateParams, v)
+	return q
+}
+
+func (q *PhoneAccountQuery) DuplicatedUpdateUserId() *PhoneAccountQuery {
+	q.duplicatedUpdateFields = append(q.duplicatedUpdateFields, "user_id=VALUES(user_id)")
+	return q
+}
+
+func (q *PhoneAccountQuery) GetId() *PhoneAccountQuery {
+	q.getFields = append(q.getFields, "id")
+	return q
+}
+
+func (q *PhoneAccountQuery) GetUserId() *PhoneAccountQuery {
+	q.getFields = append(q.getFields, "user_id")
+	return q
+}
+
+func (q *PhoneAccountQuery) GetPhoneEncrypted() *PhoneAccountQuery {
+	q.getFields = append(q.getFields, "phone_encrypted")
+	return q
+}
+
+func (q *PhoneAccountQuery) GetCreateTime() *PhoneAccountQuery {
+	q.getFields = append(q.getFields, "create_time")
+	return q
+}
+
+func (q *PhoneAccountQuery) GetUpdateTime() *PhoneAccountQuery {
+	q.getFields = append(q.getFields, "update_time")
+	return q
+}
+
+func (q *PhoneAccountQuery) Select(ctx context.Context, tx *wrap.Tx) (e *PhoneAccount, err error) {
+	if !q.hasLimit {
+		q.limitCount = 1
+		q.hasLimit = true
+	}
+
+	queryString, params := q.buildSelectQuery()
+	query := bytes.NewBufferString("")
+	if len(q.getFields) == 0 {
+		query.WriteString("SELECT id,user_id,phone_encrypted,create_time,update_time FROM phone_account ")
+	} else {
+		query.WriteString("SELECT ")
+		query.WriteString(strings.Join(q.getFields, ","))
+		query.WriteString(" FROM phone_account ")
+	}
+	query.WriteString(queryString)
+	e = &PhoneAccount{}
+	row := q.dao.db.QueryRow(ctx, tx, query.String(), params...)
+	err = row.Scan(&e.Id, &e.UserId, &e.PhoneEncrypted, &e.CreateTime, &e.UpdateTime)
+	if err == wrap.ErrNoRows {
+		return nil, nil
+	}
+
+	return e, err
+}
+
+func (q *PhoneAccountQuery) SelectList(ctx context.Context, tx *wrap.Tx) (list []*PhoneAccount, err error) {
+	queryString, params := q.buildSelectQuery()
+	query := bytes.NewBufferString("")
+	if len(q.getFields) == 0 {
+		query.WriteString("SELECT id,user_id,phone_encrypted,create_time,update_time FROM phone_account ")
+	} else {
+		query.WriteString("SELECT ")
+		query.WriteString(strings.Join(q.getFields, ","))
+		query.WriteString(" FROM phone_account ")
+	}
+	query.WriteString(queryString)
+	rows, err := q.dao.db.Query(ctx, tx, query.String(), params...)
+	if err != nil {
+		return nil, err
+	}
+	for rows.Next() {
+		e := PhoneAccount{}
+		err = rows.Scan(&e.Id, &e.UserId, &e.PhoneEncrypted, &e.CreateTime, &e.UpdateTime)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, &e)
+	}
+	if rows.Err() != nil {
+		err = rows.Err()
+		return nil, err
+	}
+
+	return list, nil
+}
+
+func (q *PhoneAccountQuery) SelectCount(ctx context.Context, tx *wrap.Tx) (count int64, err error) {
+	queryString, params := q.buildSelectQuery()
+	query := bytes.NewBufferString("")
+	query.WriteString("SELECT COUNT(*) FROM phone_account ")
+	query.WriteString(queryString)
+	row := q.dao.db.QueryRow(ctx, tx, query.String(), params...)
+	err = row.Scan(&count)
+
+	return count, err
+}
+
+func (q *PhoneAccountQuery) SelectGroupBy(ctx context.Context, tx *wrap.Tx, withCount bool) (rows *wrap.Rows, err error) {
+	queryString, params := q.buildSelectQuery()
+	query := bytes.NewBufferString("")
+	query.WriteString("SELECT ")
+	query.WriteString(strings.Join(q.groupByFields, ","))
+	if withCount {
+		query.WriteString(",MachineListCount(*) ")
+	}
+	query.WriteString(" FROM phone_account ")
+	query.WriteString(queryString)
+
+	return q.dao.db.Query(ctx, tx, query.String(), params...)
+}
+
+func (q *PhoneAccountQuery) SelectRow(ctx context.Context, tx *wrap.Tx) (row *wrap.Row) {
+	if !q.hasLimit {
+		q.limitCount = 1
+		q.hasLimit = true
+	}
+
+	queryString, params := q.buildSelectQuery()
+	query := bytes.NewBufferString("")
+	query.WriteString("SELECT ")
+	query.WriteString(strings.Join(q.getFields, ","))
+	query.WriteString(" FROM phone_account ")
+	query.WriteString(queryString)
+	return q.dao.db.QueryRow(ctx, tx, query.String(), params...)
+}
+
+func (q *PhoneAccountQuery) SelectRows(ctx context.Context, tx *wrap.Tx) (rows *wrap.Rows, err error) {
+	queryString, params := q.buildSelectQuery()
+	query := bytes.NewBufferString("")
+	query.WriteString("SELECT ")
+	query.WriteString(strings.Join(q.getFields, ","))
+	query.WriteString(" FROM phone_account ")
+	query.WriteString(queryString)
+	return q.dao.db.Query(ctx, tx, query.String(), params...)
+}
+
+func (q *PhoneAccountQuery) Insert(ctx context.Context, tx *wrap.Tx, e *PhoneAccount) (result *wrap.Result, err error) {
+	query := bytes.NewBufferString("")
+	query.WriteString("INSERT INTO phone_account (user_id,phone_encrypted) VALUES (?,?)")
+	params := []interface{}{e.UserId, e.PhoneEncrypted}
+	return q.dao.db.Exec(ctx, tx, query.String(), params...)
+}
+
+func (q *PhoneAccountQuery) BatchInsert(ctx context.Context, tx *wrap.Tx, list []*PhoneAccount) (result *wrap.Result, err error) {
+	query := bytes.NewBufferString("")
+	query.WriteString("INSERT INTO phone_account (user_id,phone_encrypted) VALUES ")
+	query.WriteString(wrap.RepeatWithSeparator("(?,?)", len(list), ","))
+	params := make([]interface{}, len(list)*2)
+	offset := 0
+	for _, e := range list {
+		params[offset+0] = e.UserId
+		params[offset+1] = e.PhoneEncrypted
+		offset += 2
+	}
+
+	return q.dao.db.Exec(ctx, tx, query.String(), params...)
+}
+
+func (q *PhoneAccountQuery) InsertOnDuplicatedKeyUpdate(ctx context.Context, tx *wrap.Tx, e *PhoneAccount) (result *wrap.Result, err error) {
+	query := bytes.NewBufferString("")
+	query.WriteString("INSERT INTO phone_account (user_id,phone_encrypted) VALUES (?,?)")
+	query.WriteString(" ON DUPLICATED KEY UPDATE ")
+	if len(q.duplicatedUpdateFields) > 0 {
+		query.WriteString(strings.Join(q.duplicatedUpdateFields, ","))
+		query.WriteString(",")
+	}
+	query.WriteString("update_time=now()")
+	params := []interface{}{e.UserId, e.PhoneEncrypted}
+	return q.dao.db.Exec(ctx, tx, query.String(), params...)
+}
+
+func (q *PhoneAccountQuery) BatchInsertOnDuplicatedKeyUpdate(ctx context.Context, tx *wrap.Tx, list []*PhoneAccount) (result *wrap.Result, err error) {
+	query := bytes.NewBufferString("")
+	query.WriteString("INSERT INTO phone_account (user_id,phone_encrypted) VALUES ")
+	query.WriteString(wrap.RepeatWithSeparator("(?,?)", len(list), ","))
+	query.WriteString(" ON DUPLICATED KEY UPDATE")
+	if len(q.duplicatedUpdateFields) > 0 {
+		query.WriteString(strings.Join(q.duplicatedUpdateFields, ","))
+		query.WriteString(",")
+	}
+	query.WriteString("update_time=now()")
+	params := make([]interface{}, len(list)*2)
+	offset := 0
+	for _, e := range list {
+		params[offset+0] = e.UserId
+		params[offset+1] = e.PhoneEncrypted
+		offset += 2
+	}
+
+	return q.dao.db.Exec(ctx, tx, query.String(), params...)
+}
+
+func (q *PhoneAccountQuery) Update(ctx context.Context, tx *wrap.Tx) (result *wrap.Result, err error) {
+	query := bytes.NewBufferString("")
+	var params []interface{}
+	params = append(params, q.updateParams...)
+	query.WriteString("UPDATE phone_account SET ")
+	updateItems := make([]string, len(q.updateFields))
+	for i, v := range q.updateFields {
+		updateItems[i] = v + "=?"
+	}
+	query.WriteString(strings.Join(updateItems, ","))
+	query.WriteString(",update_time=now()")
+	where := q.where.String()
+	if where != "" {
+		query.WriteString(" WHERE ")
+		query.WriteString(where)
+		params = append(params, q.whereParams...)
+	}
+
+	return q.dao.db.Exec(ctx, tx, query.String(), params...)
+}
+
+func (q *PhoneAccountQuery) Delete(ctx context.Context, tx *wrap.Tx) (result *wrap.Result, err error) {
+	query := "DELETE FROM phone_account WHERE " + q.where.String()
+	return q.dao.db.Exec(ctx, tx, query, q.whereParams...)
+}
+
+type PhoneAccountDao struct {
+	logger *zap.Logger
+	db     *DB
+}
+
+func NewPhoneAccountDao(db *DB) (t *PhoneAccountDao, err error) {
+	t = &PhoneAccountDao{}
+	t.logger = log.TypedLogger(t)
+	t.db = db
+
+	return t, nil
+}
+
+func (dao *PhoneAccountDao) Query() *PhoneAccountQuery {
+	q := &PhoneAccountQuery{}
+	q.dao = dao
+	q.tableName = "phone_account"
+	q.where = bytes.NewBufferString("")
+	return q
+}
+
+type RefreshToken struct {
+	Id                uint64 //size=20
+	UserId            string //size=32
+	RefreshToken      string //size=128
+	SessionId         string //size=32
+	ClientId          string //size=32
+	Scope             string //size=1024
+	IsRotated         int32  //size=1
+	ExpireTime        time.Time
+	SessionExpireTime time.Time
+	CreateTime        time.Time
+	UpdateTime        time.Time
+}
+
+type RefreshTokenQuery struct {
+	QueryBase
+	dao *RefreshTokenDao
+}
+
+func (q *RefreshTokenQuery) Left() *RefreshTokenQuery {
+	q.where.WriteString(" (")
+	return q
+}
+
+func (q *RefreshTokenQuery) Right() *RefreshTokenQuery {
+	q.where.WriteString(" )")
+	return q
+}
+
+func (q *RefreshTokenQuery) And() *RefreshTokenQuery {
+	q.where.WriteString(" AND")
+	return q
+}
+
+func (q *RefreshTokenQuery) Or() *RefreshTokenQuery {
+	q.where.WriteString(" OR")
+	return q
+}
+
+func (q *RefreshTokenQuery) Not() *RefreshTokenQuery {
+	q.where.WriteString(" NOT")
+	return q
+}
+
+func (q *RefreshTokenQuery) IdEqual(v uint64) *RefreshTokenQuery {
+	q.where.WriteString(" id=?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *RefreshTokenQuery) IdNotEqual(v uint64) *RefreshTokenQuery {
+	q.where.WriteString(" id<>?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *RefreshTokenQuery) IdLess(v uint64) *RefreshTokenQuery {
+	q.where.WriteString(" id<?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *RefreshTokenQuery) IdLessEqual(v uint64) *RefreshTokenQuery {
+	q.where.WriteString(" id<=?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *RefreshTokenQuery) IdGreater(v uint64) *RefreshTokenQuery {
+	q.where.WriteString(" id>?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *RefreshTokenQuery) IdGreaterEqual(v uint64) *RefreshTokenQuery {
+	q.where.WriteString(" id>=?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *RefreshTokenQuery) IdIn(items []uint64) *RefreshTokenQuery {
+	q.where.WriteString(" id IN(")
+	q.where.WriteString(wrap.RepeatWithSeparator("?", len(items), ","))
+	q.where.WriteString(")")
+	q.whereParams = append(q.whereParams, items)
+	return q
+}
+
+func (q *RefreshTokenQuery) UserIdEqual(v string) *RefreshTokenQuery {
+	q.where.WriteString(" user_id=?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *RefreshTokenQuery) UserIdNotEqual(v string) *RefreshTokenQuery {
+	q.where.WriteString(" user_id<>?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *RefreshTokenQuery) UserIdIn(items []string) *RefreshTokenQuery {
+	q.where.WriteString(" user_id IN(")
+	q.where.WriteString(wrap.RepeatWithSeparator("?", len(items), ","))
+	q.where.WriteString(")")
+	q.whereParams = append(q.whereParams, items)
+	return q
+}
+
+func (q *RefreshTokenQuery) RefreshTokenEqual(v string) *RefreshTokenQuery {
+	q.where.WriteString(" refresh_token=?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *RefreshTokenQuery) RefreshTokenNotEqual(v string) *RefreshTokenQuery {
+	q.where.WriteString(" refresh_token<>?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *RefreshTokenQuery) RefreshTokenIn(items []string) *RefreshTokenQuery {
+	q.where.WriteString(" refresh_token IN(")
+	q.where.WriteString(wrap.RepeatWithSeparator("?", len(items), ","))
+	q.where.WriteString(")")
+	q.whereParams = append(q.whereParams, items)
+	return q
+}
+
+func (q *RefreshTokenQuery) SessionIdEqual(v string) *RefreshTokenQuery {
+	q.where.WriteString(" session_id=?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *RefreshTokenQuery) SessionIdNotEqual(v string) *RefreshTokenQuery {
+	q.where.WriteString(" session_id<>?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *RefreshTokenQuery) SessionIdIn(items []string) *RefreshTokenQuery {
+	q.where.WriteString(" session_id IN(")
+	q.where.WriteString(wrap.RepeatWithSeparator("?", len(items), ","))
+	q.where.WriteString(")")
+	q.whereParams = append(q.whereParams, items)
+	return q
+}
+
+func (q *RefreshTokenQuery) ClientIdEqual(v string) *RefreshTokenQuery {
+	q.where.WriteString(" client_id=?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *RefreshTokenQuery) ClientIdNotEqual(v string) *RefreshTokenQuery {
+	q.where.WriteString(" client_id<>?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *RefreshTokenQuery) ClientIdIn(items []string) *RefreshTokenQuery {
+	q.where.WriteString(" client_id IN(")
+	q.where.WriteString(wrap.RepeatWithSeparator("?", len(items), ","))
+	q.where.WriteString(")")
+	q.whereParams = append(q.whereParams, items)
+	return q
+}
+
+func (q *RefreshTokenQuery) ScopeEqual(v string) *RefreshTokenQuery {
+	q.where.WriteString(" scope=?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *RefreshTokenQuery) ScopeNotEqual(v string) *RefreshTokenQuery {
+	q.where.WriteString(" scope<>?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *RefreshTokenQuery) ScopeIn(items []string) *RefreshTokenQuery {
+	q.where.WriteString(" scope IN(")
+	q.where.WriteString(wrap.RepeatWithSeparator("?", len(items), ","))
+	q.where.WriteString(")")
+	q.whereParams = append(q.whereParams, items)
+	return q
+}
+
+func (q *RefreshTokenQuery) IsRotatedEqual(v int32) *RefreshTokenQuery {
+	q.where.WriteString(" is_rotated=?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *RefreshTokenQuery) IsRotatedNotEqual(v int32) *RefreshTokenQuery {
+	q.where.WriteString(" is_rotated<>?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *RefreshTokenQuery) IsRotatedLess(v int32) *RefreshTokenQuery {
+	q.where.WriteString(" is_rotated<?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *RefreshTokenQuery) IsRotatedLessEqual(v int32) *RefreshTokenQuery {
+	q.where.WriteString(" is_rotated<=?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *RefreshTokenQuery) IsRotatedGreater(v int32) *RefreshTokenQuery {
+	q.where.WriteString(" is_rotated>?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *RefreshTokenQuery) IsRotatedGreaterEqual(v int32) *RefreshTokenQuery {
+	q.where.WriteString(" is_rotated>=?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *RefreshTokenQuery) IsRotatedIn(items []int32) *RefreshTokenQuery {
+	q.where.WriteString(" is_rotated IN(")
+	q.where.WriteString(wrap.RepeatWithSeparator("?", len(items), ","))
+	q.where.WriteString(")")
+	q.whereParams = append(q.whereParams, items)
+	return q
+}
+
+func (q *RefreshTokenQuery) ExpireTimeEqual(v time.Time) *RefreshTokenQuery {
+	q.where.WriteString(" expire_time=?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *RefreshTokenQuery) ExpireTimeNotEqual(v time.Time) *RefreshTokenQuery {
+	q.where.WriteString(" expire_time<>?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *RefreshTokenQuery) ExpireTimeLess(v time.Time) *RefreshTokenQuery {
+	q.where.WriteString(" expire_time<?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *RefreshTokenQuery) ExpireTimeLessEqual(v time.Time) *RefreshTokenQuery {
+	q.where.WriteString(" expire_time<=?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *RefreshTokenQuery) ExpireTimeGreater(v time.Time) *RefreshTokenQuery {
+	q.where.WriteString(" expire_time>?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *RefreshTokenQuery) ExpireTimeGreaterEqual(v time.Time) *RefreshTokenQuery {
+	q.where.WriteString(" expire_time>=?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *RefreshTokenQuery) SessionExpireTimeEqual(v time.Time) *RefreshTokenQuery {
+	q.where.WriteString(" session_expire_time=?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *RefreshTokenQuery) SessionExpireTimeNotEqual(v time.Time) *RefreshTokenQuery {
+	q.where.WriteString(" session_expire_time<>?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *RefreshTokenQuery) SessionExpireTimeLess(v time.Time) *RefreshTokenQuery {
+	q.where.WriteString(" session_expire_time<?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *RefreshTokenQuery) SessionExpireTimeLessEqual(v time.Time) *RefreshTokenQuery {
+	q.where.WriteString(" session_expire_time<=?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *RefreshTokenQuery) SessionExpireTimeGreater(v time.Time) *RefreshTokenQuery {
+	q.where.WriteString(" session_expire_time>?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *RefreshTokenQuery) SessionExpireTimeGreaterEqual(v time.Time) *RefreshTokenQuery {
+	q.where.WriteString(" session_expire_time>=?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *RefreshTokenQuery) CreateTimeEqual(v time.Time) *RefreshTokenQuery {
+	q.where.WriteString(" create_time=?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *RefreshTokenQuery) CreateTimeNotEqual(v time.Time) *RefreshTokenQuery {
+	q.where.WriteString(" create_time<>?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *RefreshTokenQuery) CreateTimeLess(v time.Time) *RefreshTokenQuery {
+	q.where.WriteString(" create_time<?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *RefreshTokenQuery) CreateTimeLessEqual(v time.Time) *RefreshTokenQuery {
+	q.where.WriteString(" create_time<=?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *RefreshTokenQuery) CreateTimeGreater(v time.Time) *RefreshTokenQuery {
+	q.where.WriteString(" create_time>?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *RefreshTokenQuery) CreateTimeGreaterEqual(v time.Time) *RefreshTokenQuery {
+	q.where.WriteString(" create_time>=?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *RefreshTokenQuery) UpdateTimeEqual(v time.Time) *RefreshTokenQuery {
+	q.where.WriteString(" update_time=?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *RefreshTokenQuery) UpdateTimeNotEqual(v time.Time) *RefreshTokenQuery {
+	q.where.WriteString(" update_time<>?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *RefreshTokenQuery) UpdateTimeLess(v time.Time) *RefreshTokenQuery {
+	q.where.WriteString(" update_time<?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *RefreshTokenQuery) UpdateTimeLessEqual(v time.Time) *RefreshTokenQuery {
+	q.where.WriteString(" update_time<=?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *RefreshTokenQuery) UpdateTimeGreater(v time.Time) *RefreshTokenQuery {
+	q.where.WriteString(" update_time>?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *RefreshTokenQuery) UpdateTimeGreaterEqual(v time.Time) *RefreshTokenQuery {
+	q.where.WriteString(" update_time>=?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *RefreshTokenQuery) GroupByUserId(asc bool) *RefreshTokenQuery {
+	q.groupByFields = append(q.groupByFields, "user_id")
+	q.groupByOrders = append(q.groupByOrders, asc)
+	return q
+}
+
+func (q *RefreshTokenQuery) GroupBySessionId(asc bool) *RefreshTokenQuery {
+	q.groupByFields = append(q.groupByFields, "session_id")
+	q.groupByOrders = append(q.groupByOrders, asc)
+	return q
+}
+
+func (q *RefreshTokenQuery) GroupByClientId(asc bool) *RefreshTokenQuery {
+	q.groupByFields = append(q.groupByFields, "client_id")
+	q.groupByOrders = append(q.groupByOrders, asc)
+	return q
+}
+
+func (q *RefreshTokenQuery) GroupByScope(asc bool) *RefreshTokenQuery {
+	q.groupByFields = append(q.groupByFields, "scope")
+	q.groupByOrders = append(q.groupByOrders, asc)
+	return q
+}
+
+func (q *RefreshTokenQuery) GroupByIsRotated(asc bool) *RefreshTokenQuery {
+	q.groupByFields = append(q.groupByFields, "is_rotated")
+	q.groupByOrders = append(q.groupByOrders, asc)
+	return q
+}
+
+func (q *RefreshTokenQuery) OrderById(asc bool) *RefreshTokenQuery {
+	q.orderByFields = append(q.orderByFields, "id")
+	q.orderByOrders = append(q.orderByOrders, asc)
+	return q
+}
+
+func (q *RefreshTokenQuery) OrderByUserId(asc bool) *RefreshTokenQuery {
+	q.orderByFields = append(q.orderByFields, "user_id")
+	q.orderByOrders = append(q.orderByOrders, asc)
+	return q
+}
+
+func (q *RefreshTokenQuery) OrderByRefreshToken(asc bool) *RefreshTokenQuery {
+	q.orderByFields = append(q.orderByFields, "refresh_token")
+	q.orderByOrders = append(q.orderByOrders, asc)
+	return q
+}
+
+func (q *RefreshTokenQuery) OrderBySessionId(asc bool) *RefreshTokenQuery {
+	q.orderByFields = append(q.orderByFields, "session_id")
+	q.orderByOrders = append(q.orderByOrders, asc)
+	return q
+}
+
+func (q *RefreshTokenQuery) OrderByClientId(asc bool) *RefreshTokenQuery {
+	q.orderByFields = append(q.orderByFields, "client_id")
+	q.orderByOrders = append(q.orderByOrders, asc)
+	return q
+}
+
+func (q *RefreshTokenQuery) OrderByScope(asc bool) *RefreshTokenQuery {
+	q.orderByFields = append(q.orderByFields, "scope")
+	q.orderByOrders = append(q.orderByOrders, asc)
+	return q
+}
+
+func (q *RefreshTokenQuery) OrderByIsRotated(asc bool) *RefreshTokenQuery {
+	q.orderByFields = append(q.orderByFields, "is_rotated")
+	q.orderByOrders = append(q.orderByOrders, asc)
+	return q
+}
+
+func (q *RefreshTokenQuery) OrderByExpireTime(asc bool) *RefreshTokenQuery {
+	q.orderByFields = append(q.orderByFields, "expire_time")
+	q.orderByOrders = append(q.orderByOrders, asc)
+	return q
+}
+
+func (q *RefreshTokenQuery) OrderBySessionExpireTime(asc bool) *RefreshTokenQuery {
+	q.orderByFields = append(q.orderByFields, "session_expire_time")
+	q.orderByOrders = append(q.orderByOrders, asc)
+	return q
+}
+
+func (q *RefreshTokenQuery) OrderByCreateTime(asc bool) *RefreshTokenQuery {
+	q.orderByFields = append(q.orderByFields, "create_time")
+	q.orderByOrders = append(q.orderByOrders, asc)
+	return q
+}
+
+func (q *RefreshTokenQuery) OrderByUpdateTime(asc bool) *RefreshTokenQuery {
+	q.orderByFields = append(q.orderByFields, "update_time")
+	q.orderByOrders = append(q.orderByOrders, asc)
+	return q
+}
+
+func (q *RefreshTokenQuery) OrderByGroupCount(asc bool) *RefreshTokenQuery {
+	q.orderByFields = append(q.orderByFields, "count(*)")
+	q.orderByOrders = append(q.orderByOrders, asc)
+	return q
+}
+
+func (q *RefreshTokenQuery) Limit(startIncluded int64, count int64) *RefreshTokenQuery {
+	q.hasLimit = true
+	q.limitStartIncluded = startIncluded
+	q.limitCount = count
+	return q
+}
+
+func (q *RefreshTokenQuery) ForUpdate() *RefreshTokenQuery {
+	q.forUpdate = true
+	return q
+}
+
+func (q *RefreshTokenQuery) ForShare() *RefreshTokenQuery {
+	q.forShare = true
+	return q
+}
+
+func (q *RefreshTokenQuery) SetUserId(v string) *RefreshTokenQuery {
+	q.updateFields = append(q.updateFields, "user_id")
+	q.updateParams = append(q.updateParams, v)
+	return q
+}
+
+func (q *RefreshTokenQuery) SetRefreshToken(v string) *RefreshTokenQuery {
+	q.updateFields = append(q.updateFields, "refresh_token")
+	q.updateParams = append(q.updateParams, v)
+	return q
+}
+
+func (q *RefreshTokenQuery) SetSessionId(v string) *RefreshTokenQuery {
+	q.updateFields = append(q.updateFields, "session_id")
+	q.updateParams = append(q.updateParams, v)
+	return q
+}
+
+func (q *RefreshTokenQuery) SetClientId(v string) *RefreshTokenQuery {
+	q.updateFields = append(q.updateFields, "client_id")
+	q.updateParams = append(q.updateParams, v)
+	return q
+}
+
+func (q *RefreshTokenQuery) SetScope(v string) *RefreshTokenQuery {
+	q.updateFields = append(q.updateFields, "scope")
+	q.updateParams = append(q.updateParams, v)
+	return q
+}
+
+func (q *RefreshTokenQuery) SetIsRotated(v int32) *RefreshTokenQuery {
+	q.updateFields = append(q.updateFields, "is_rotated")
+	q.updateParams = append(q.updateParams, v)
+	return q
+}
+
+func (q *RefreshTokenQuery) SetExpireTime(v time.Time) *RefreshTokenQuery {
+	q.updateFields = append(q.updateFields, "expire_time")
+	q.updateParams = append(q.updateParams, v)
+	return q
+}
+
+func (q *RefreshTokenQuery) SetSessionExpireTime(v time.Time) *RefreshTokenQuery {
+	q.updateFields = append(q.updateFields, "session_expire_time")
+	q.updateParams = append(q.updateParams, v)
+	return q
+}
+
+func (q *RefreshTokenQuery) DuplicatedUpdateUserId() *RefreshTokenQuery {
+	q.duplicatedUpdateFields = append(q.duplicatedUpdateFields, "user_id=VALUES(user_id)")
+	return q
+}
+
+func (q *RefreshTokenQuery) DuplicatedUpdateSessionId() *RefreshTokenQuery {
+	q.duplicatedUpdateFields = append(q.duplicatedUpdateFields, "session_id=VALUES(session_id)")
+	return q
+}
+
+func (q *RefreshTokenQuery) DuplicatedUpdateClientId() *RefreshTokenQuery {
+	q.duplicatedUpdateFields = append(q.duplicatedUpdateFields, "client_id=VALUES(client_id)")
+	return q
+}
+
+func (q *RefreshTokenQuery) DuplicatedUpdateScope() *RefreshTokenQuery {
+	q.duplicatedUpdateFields = append(q.duplicatedUpdateFields, "scope=VALUES(scope)")
+	return q
+}
+
+func (q *RefreshTokenQuery) DuplicatedUpdateIsRotated() *RefreshTokenQuery {
+	q.duplicatedUpdateFields = append(q.duplicatedUpdateFields, "is_rotated=VALUES(is_rotated)")
+	return q
+}
+
+func (q *RefreshTokenQuery) DuplicatedUpdateExpireTime() *RefreshTokenQuery {
+	q.duplicatedUpdateFields = append(q.duplicatedUpdateFields, "expire_time=VALUES(expire_time)")
+	return q
+}
+
+func (q *RefreshTokenQuery) DuplicatedUpdateSessionExpireTime() *RefreshTokenQuery {
+	q.duplicatedUpdateFields = append(q.duplicatedUpdateFields, "session_expire_time=VALUES(session_expire_time)")
+	return q
+}
+
+func (q *RefreshTokenQuery) GetId() *RefreshTokenQuery {
+	q.getFields = append(q.getFields, "id")
+	return q
+}
+
+func (q *RefreshTokenQuery) GetUserId() *RefreshTokenQuery {
+	q.getFields = append(q.getFields, "user_id")
+	return q
+}
+
+func (q *RefreshTokenQuery) GetRefreshToken() *RefreshTokenQuery {
+	q.getFields = append(q.getFields, "refresh_token")
+	return q
+}
+
+func (q *RefreshTokenQuery) GetSessionId() *RefreshTokenQuery {
+	q.getFields = append(q.getFields, "session_id")
+	return q
+}
+
+func (q *RefreshTokenQuery) GetClientId() *RefreshTokenQuery {
+	q.getFields = append(q.getFields, "client_id")
+	return q
+}
+
+func (q *RefreshTokenQuery) GetScope() *RefreshTokenQuery {
+	q.getFields = append(q.getFields, "scope")
+	return q
+}
+
+func (q *RefreshTokenQuery) GetIsRotated() *RefreshTokenQuery {
+	q.getFields = append(q.getFields, "is_rotated")
+	return q
+}
+
+func (q *RefreshTokenQuery) GetExpireTime() *RefreshTokenQuery {
+	q.getFields = append(q.getFields, "expire_time")
+	return q
+}
+
+func (q *RefreshTokenQuery) GetSessionExpireTime() *RefreshTokenQuery {
+	q.getFields = append(q.getFields, "session_expire_time")
+	return q
+}
+
+func (q *RefreshTokenQuery) GetCreateTime() *RefreshTokenQuery {
+	q.getFields = append(q.getFields, "create_time")
+	return q
+}
+
+func (q *RefreshTokenQuery) GetUpdateTime() *RefreshTokenQuery {
+	q.getFields = append(q.getFields, "update_time")
+	return q
+}
+
+func (q *RefreshTokenQuery) Select(ctx context.Context, tx *wrap.Tx) (e *RefreshToken, err error) {
+	if !q.hasLimit {
+		q.limitCount = 1
+		q.hasLimit = true
+	}
+
+	queryString, params := q.buildSelectQuery()
+	query := bytes.NewBufferString("")
+	if len(q.getFields) == 0 {
+		query.WriteString("SELECT id,user_id,refresh_token,session_id,client_id,scope,is_rotated,expire_time,session_expire_time,create_time,update_time FROM refresh_token ")
+	} else {
+		query.WriteString("SELECT ")
+		query.WriteString(strings.Join(q.getFields, ","))
+		query.WriteString(" FROM refresh_token ")
+	}
+	query.WriteString(queryString)
+	e = &RefreshToken{}
+	row := q.dao.db.QueryRow(ctx, tx, query.String(), params...)
+	err = row.Scan(&e.Id, &e.UserId, &e.RefreshToken, &e.SessionId, &e.ClientId, &e.Scope, &e.IsRotated, &e.ExpireTime, &e.SessionExpireTime, &e.CreateTime, &e.UpdateTime)
+	if err == wrap.ErrNoRows {
+		return nil, nil
+	}
+
+	return e, err
+}
+
+func (q *RefreshTokenQuery) SelectList(ctx context.Context, tx *wrap.Tx) (list []*RefreshToken, err error) {
+	queryString, params := q.buildSelectQuery()
+	query := bytes.NewBufferString("")
+	if len(q.getFields) == 0 {
+		query.WriteString("SELECT id,user_id,refresh_token,session_id,client_id,scope,is_rotated,expire_time,session_expire_time,create_time,update_time FROM refresh_token ")
+	} else {
+		query.WriteString("SELECT ")
+		query.WriteString(strings.Join(q.getFields, ","))
+		query.WriteString(" FROM refresh_token ")
+	}
+	query.WriteString(queryString)
+	rows, err := q.dao.db.Query(ctx, tx, query.String(), params...)
+	if err != nil {
+		return nil, err
+	}
+	for rows.Next() {
+		e := RefreshToken{}
+		err = rows.Scan(&e.Id, &e.UserId, &e.RefreshToken, &e.SessionId, &e.ClientId, &e.Scope, &e.IsRotated, &e.ExpireTime, &e.SessionExpireTime, &e.CreateTime, &e.UpdateTime)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, &e)
+	}
+	if rows.Err() != nil {
+		err = rows.Err()
+		return nil, err
+	}
+
+	return list, nil
+}
+
+func (q *RefreshTokenQuery) SelectCount(ctx context.Context, tx *wrap.Tx) (count int64, err error) {
+	queryString, params := q.buildSelectQuery()
+	query := bytes.NewBufferString("")
+	query.WriteString("SELECT COUNT(*) FROM refresh_token ")
+	query.WriteString(queryString)
+	row := q.dao.db.QueryRow(ctx, tx, query.String(), params...)
+	err = row.Scan(&count)
+
+	return count, err
+}
+
+func (q *RefreshTokenQuery) SelectGroupBy(ctx context.Context, tx *wrap.Tx, withCount bool) (rows *wrap.Rows, err error) {
+	queryString, params := q.buildSelectQuery()
+	query := bytes.NewBufferString("")
+	query.WriteString("SELECT ")
+	query.WriteString(strings.Join(q.groupByFields, ","))
+	if withCount {
+		query.WriteString(",MachineListCount(*) ")
+	}
+	query.WriteString(" FROM refresh_token ")
+	query.WriteString(queryString)
+
+	return q.dao.db.Query(ctx, tx, query.String(), params...)
+}
+
+func (q *RefreshTokenQuery) SelectRow(ctx context.Context, tx *wrap.Tx) (row *wrap.Row) {
+	if !q.hasLimit {
+		q.limitCount = 1
+		q.hasLimit = true
+	}
+
+	queryString, params := q.buildSelectQuery()
+	query := bytes.NewBufferString("")
+	query.WriteString("SELECT ")
+	query.WriteString(strings.Join(q.getFields, ","))
+	query.WriteString(" FROM refresh_token ")
+	query.WriteString(queryString)
+	return q.dao.db.QueryRow(ctx, tx, query.String(), params...)
+}
+
+func (q *RefreshTokenQuery) SelectRows(ctx context.Context, tx *wrap.Tx) (rows *wrap.Rows, err error) {
+	queryString, params := q.buildSelectQuery()
+	query := bytes.NewBufferString("")
+	query.WriteString("SELECT ")
+	query.WriteString(strings.Join(q.getFields, ","))
+	query.WriteString(" FROM refresh_token ")
+	query.WriteString(queryString)
+	return q.dao.db.Query(ctx, tx, query.String(), params...)
+}
+
+func (q *RefreshTokenQuery) Insert(ctx context.Context, tx *wrap.Tx, e *RefreshToken) (result *wrap.Result, err error) {
+	query := bytes.NewBufferString("")
+	query.WriteString("INSERT INTO refresh_token (user_id,refresh_token,session_id,client_id,scope,is_rotated,expire_time,session_expire_time) VALUES (?,?,?,?,?,?,?,?)")
+	params := []interface{}{e.UserId, e.RefreshToken, e.SessionId, e.ClientId, e.Scope, e.IsRotated, e.ExpireTime, e.SessionExpireTime}
+	return q.dao.db.Exec(ctx, tx, query.String(), params...)
+}
+
+func (q *RefreshTokenQuery) BatchInsert(ctx context.Context, tx *wrap.Tx, list []*RefreshToken) (result *wrap.Result, err error) {
+	query := bytes.NewBufferString("")
+	query.WriteString("INSERT INTO refresh_token (user_id,refresh_token,session_id,client_id,scope,is_rotated,expire_time,session_expire_time) VALUES ")
+	query.WriteString(wrap.RepeatWithSeparator("(?,?,?,?,?,?,?,?)", len(list), ","))
+	params := make([]interface{}, len(list)*8)
+	offset := 0
+	for _, e := range list {
+		params[offset+0] = e.UserId
+		params[offset+1] = e.RefreshToken
+		params[offset+2] = e.SessionId
+		params[offset+3] = e.ClientId
+		params[offset+4] = e.Scope
+		params[offset+5] = e.IsRotated
+		params[offset+6] = e.ExpireTime
+		params[offset+7] = e.SessionExpireTime
+		offset += 8
+	}
+
+	return q.dao.db.Exec(ctx, tx, query.String(), params...)
+}
+
+func (q *RefreshTokenQuery) InsertOnDuplicatedKeyUpdate(ctx context.Context, tx *wrap.Tx, e *RefreshToken) (result *wrap.Result, err error) {
+	query := bytes.NewBufferString("")
+	query.WriteString("INSERT INTO refresh_token (user_id,refresh_token,session_id,client_id,scope,is_rotated,expire_time,session_expire_time) VALUES (?,?,?,?,?,?,?,?)")
+	query.WriteString(" ON DUPLICATED KEY UPDATE ")
+	if len(q.duplicatedUpdateFields) > 0 {
+		query.WriteString(strings.Join(q.duplicatedUpdateFields, ","))
+		query.WriteString(",")
+	}
+	query.WriteString("update_time=now()")
+	params := []interface{}{e.UserId, e.RefreshToken, e.SessionId, e.ClientId, e.Scope, e.IsRotated, e.ExpireTime, e.SessionExpireTime}
+	return q.dao.db.Exec(ctx, tx, query.String(), params...)
+}
+
+func (q *RefreshTokenQuery) BatchInsertOnDuplicatedKeyUpdate(ctx context.Context, tx *wrap.Tx, list []*RefreshToken) (result *wrap.Result, err error) {
+	query := bytes.NewBufferString("")
+	query.WriteString("INSERT INTO refresh_token (user_id,refresh_token,session_id,client_id,scope,is_rotated,expire_time,session_expire_time) VALUES ")
+	query.WriteString(wrap.RepeatWithSeparator("(?,?,?,?,?,?,?,?)", len(list), ","))
+	query.WriteString(" ON DUPLICATED KEY UPDATE")
+	if len(q.duplicatedUpdateFields) > 0 {
+		query.WriteString(strings.Join(q.duplicatedUpdateFields, ","))
+		query.WriteString(",")
+	}
+	query.WriteString("update_time=now()")
+	params := make([]interface{}, len(list)*8)
+	offset := 0
+	for _, e := range list {
+		params[offset+0] = e.UserId
+		params[offset+1] = e.RefreshToken
+		params[offset+2] = e.SessionId
+		params[offset+3] = e.ClientId
+		params[offset+4] = e.Scope
+		params[offset+5] = e.IsRotated
+		params[offset+6] = e.ExpireTime
+		params[offset+7] = e.SessionExpireTime
+		offset += 8
+	}
+
+	return q.dao.db.Exec(ctx, tx, query.String(), params...)
+}
+
+func (q *RefreshTokenQuery) Update(ctx context.Context, tx *wrap.Tx) (result *wrap.Result, err error) {
+	query := bytes.NewBufferString("")
+	var params []interface{}
+	params = append(params, q.updateParams...)
+	query.WriteString("UPDATE refresh_token SET ")
+	updateItems := make([]string, len(q.updateFields))
+	for i, v := range q.updateFields {
+		updateItems[i] = v + "=?"
+	}
+	query.WriteString(strings.Join(updateItems, ","))
+	query.WriteString(",update_time=now()")
+	where := q.where.String()
+	if where != "" {
+		query.WriteString(" WHERE ")
+		query.WriteString(where)
+		params = append(params, q.whereParams...)
+	}
+
+	return q.dao.db.Exec(ctx, tx, query.String(), params...)
+}
+
+func (q *RefreshTokenQuery) Delete(ctx context.Context, tx *wrap.Tx) (result *wrap.Result, err error) {
+	query := "DELETE FROM refresh_token WHERE " + q.where.String()
+	return q.dao.db.Exec(ctx, tx, query, q.whereParams...)
+}
+
+type RefreshTokenDao struct {
+	logger *zap.Logger
+	db     *DB
+}
+
+func NewRefreshTokenDao(db *DB) (t *RefreshTokenDao, err error) {
+	t = &RefreshTokenDao{}
+	t.logger = log.TypedLogger(t)
+	t.db = db
+
+	return t, nil
+}
+
+func (dao *RefreshTokenDao) Query() *RefreshTokenQuery {
+	q := &RefreshTokenQuery{}
+	q.dao = dao
+	q.tableName = "refresh_token"
+	q.where = bytes.NewBufferString("")
+	return q
+}
+
+type ServiceLock struct {
+	Id         uint64 //size=20
+	LockName   string //size=64
+	Owner      string //size=128
+	ExpireTime time.Time
+	CreateTime time.Time
+	UpdateTime time.Time
+}
+
+type ServiceLockQuery struct {
+	QueryBase
+	dao *ServiceLockDao
+}
+
+func (q *ServiceLockQuery) Left() *ServiceLockQuery {
+	q.where.WriteString(" (")
+	return q
+}
+
+func (q *ServiceLockQuery) Right() *ServiceLockQuery {
+	q.where.WriteString(" )")
+	return q
+}
+
+func (q *ServiceLockQuery) And() *ServiceLockQuery {
+	q.where.WriteString(" AND")
+	return q
+}
+
+func (q *ServiceLockQuery) Or() *ServiceLockQuery {
+	q.where.WriteString(" OR")
+	return q
+}
+
+func (q *ServiceLockQuery) Not() *ServiceLockQuery {
+	q.where.WriteString(" NOT")
+	return q
+}
+
+func (q *ServiceLockQuery) IdEqual(v uint64) *ServiceLockQuery {
+	q.where.WriteString(" id=?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *ServiceLockQuery) IdNotEqual(v uint64) *ServiceLockQuery {
+	q.where.WriteString(" id<>?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *ServiceLockQuery) IdLess(v uint64) *ServiceLockQuery {
+	q.where.WriteString(" id<?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *ServiceLockQuery) IdLessEqual(v uint64) *ServiceLockQuery {
+	q.where.WriteString(" id<=?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *ServiceLockQuery) IdGreater(v uint64) *ServiceLockQuery {
+	q.where.WriteString(" id>?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *ServiceLockQuery) IdGreaterEqual(v uint64) *ServiceLockQuery {
+	q.where.WriteString(" id>=?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *ServiceLockQuery) IdIn(items []uint64) *ServiceLockQuery {
+	q.where.WriteString(" id IN(")
+	q.where.WriteString(wrap.RepeatWithSeparator("?", len(items), ","))
+	q.where.WriteString(")")
+	q.whereParams = append(q.whereParams, items)
+	return q
+}
+
+func (q *ServiceLockQuery) LockNameEqual(v string) *ServiceLockQuery {
+	q.where.WriteString(" lock_name=?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *ServiceLockQuery) LockNameNotEqual(v string) *ServiceLockQuery {
+	q.where.WriteString(" lock_name<>?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *ServiceLockQuery) LockNameIn(items []string) *ServiceLockQuery {
+	q.where.WriteString(" lock_name IN(")
+	q.where.WriteString(wrap.RepeatWithSeparator("?", len(items), ","))
+	q.where.WriteString(")")
+	q.whereParams = append(q.whereParams, items)
+	return q
+}
+
+func (q *ServiceLockQuery) OwnerEqual(v string) *ServiceLockQuery {
+	q.where.WriteString(" owner=?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *ServiceLockQuery) OwnerNotEqual(v string) *ServiceLockQuery {
+	q.where.WriteString(" owner<>?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *ServiceLockQuery) OwnerIn(items []string) *ServiceLockQuery {
+	q.where.WriteString(" owner IN(")
+	q.where.WriteString(wrap.RepeatWithSeparator("?", len(items), ","))
+	q.where.WriteString(")")
+	q.whereParams = append(q.whereParams, items)
+	return q
+}
+
+func (q *ServiceLockQuery) ExpireTimeEqual(v time.Time) *ServiceLockQuery {
+	q.where.WriteString(" expire_time=?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *ServiceLockQuery) ExpireTimeNotEqual(v time.Time) *ServiceLockQuery {
+	q.where.WriteString(" expire_time<>?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *ServiceLockQuery) ExpireTimeLess(v time.Time) *ServiceLockQuery {
+	q.where.WriteString(" expire_time<?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *ServiceLockQuery) ExpireTimeLessEqual(v time.Time) *ServiceLockQuery {
+	q.where.WriteString(" expire_time<=?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *ServiceLockQuery) ExpireTimeGreater(v time.Time) *ServiceLockQuery {
+	q.where.WriteString(" expire_time>?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *ServiceLockQuery) ExpireTimeGreaterEqual(v time.Time) *ServiceLockQuery {
+	q.where.WriteString(" expire_time>=?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *ServiceLockQuery) CreateTimeEqual(v time.Time) *ServiceLockQuery {
+	q.where.WriteString(" create_time=?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *ServiceLockQuery) CreateTimeNotEqual(v time.Time) *ServiceLockQuery {
+	q.where.WriteString(" create_time<>?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *ServiceLockQuery) CreateTimeLess(v time.Time) *ServiceLockQuery {
+	q.where.WriteString(" create_time<?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *ServiceLockQuery) CreateTimeLessEqual(v time.Time) *ServiceLockQuery {
+	q.where.WriteString(" create_time<=?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *ServiceLockQuery) CreateTimeGreater(v time.Time) *ServiceLockQuery {
+	q.where.WriteString(" create_time>?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *ServiceLockQuery) CreateTimeGreaterEqual(v time.Time) *ServiceLockQuery {
+	q.where.WriteString(" create_time>=?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *ServiceLockQuery) UpdateTimeEqual(v time.Time) *ServiceLockQuery {
+	q.where.WriteString(" update_time=?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *ServiceLockQuery) UpdateTimeNotEqual(v time.Time) *ServiceLockQuery {
+	q.where.WriteString(" update_time<>?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *ServiceLockQuery) UpdateTimeLess(v time.Time) *ServiceLockQuery {
+	q.where.WriteString(" update_time<?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *ServiceLockQuery) UpdateTimeLessEqual(v time.Time) *ServiceLockQuery {
+	q.where.WriteString(" update_time<=?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *ServiceLockQuery) UpdateTimeGreater(v time.Time) *ServiceLockQuery {
+	q.where.WriteString(" update_time>?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *ServiceLockQuery) UpdateTimeGreaterEqual(v time.Time) *ServiceLockQuery {
+	q.where.WriteString(" update_time>=?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *ServiceLockQuery) GroupByOwner(asc bool) *ServiceLockQuery {
+	q.groupByFields = append(q.groupByFields, "owner")
+	q.groupByOrders = append(q.groupByOrders, asc)
+	return q
+}
+
+func (q *ServiceLockQuery) OrderById(asc bool) *ServiceLockQuery {
+	q.orderByFields = append(q.orderByFields, "id")
+	q.orderByOrders = append(q.orderByOrders, asc)
+	return q
+}
+
+func (q *ServiceLockQuery) OrderByLockName(asc bool) *ServiceLockQuery {
+	q.orderByFields = append(q.orderByFields, "lock_name")
+	q.orderByOrders = append(q.orderByOrders, asc)
+	return q
+}
+
+func (q *ServiceLockQuery) OrderByOwner(asc bool) *ServiceLockQuery {
+	q.orderByFields = append(q.orderByFields, "owner")
+	q.orderByOrders = append(q.orderByOrders, asc)
+	return q
+}
+
+func (q *ServiceLockQuery) OrderByExpireTime(asc bool) *ServiceLockQuery {
+	q.orderByFields = append(q.orderByFields, "expire_time")
+	q.orderByOrders = append(q.orderByOrders, asc)
+	return q
+}
+
+func (q *ServiceLockQuery) OrderByCreateTime(asc bool) *ServiceLockQuery {
+	q.orderByFields = append(q.orderByFields, "create_time")
+	q.orderByOrders = append(q.orderByOrders, asc)
+	return q
+}
+
+func (q *ServiceLockQuery) OrderByUpdateTime(asc bool) *ServiceLockQuery {
+	q.orderByFields = append(q.orderByFields, "update_time")
+	q.orderByOrders = append(q.orderByOrders, asc)
+	return q
+}
+
+func (q *ServiceLockQuery) OrderByGroupCount(asc bool) *ServiceLockQuery {
+	q.orderByFields = append(q.orderByFields, "count(*)")
+	q.orderByOrders = append(q.orderByOrders, asc)
+	return q
+}
+
+func (q *ServiceLockQuery) Limit(startIncluded int64, count int64) *ServiceLockQuery {
+	q.hasLimit = true
+	q.limitStartIncluded = startIncluded
+	q.limitCount = count
+	return q
+}
+
+func (q *ServiceLockQuery) ForUpdate() *ServiceLockQuery {
+	q.forUpdate = true
+	return q
+}
+
+func (q *ServiceLockQuery) ForShare() *ServiceLockQuery {
+	q.forShare = true
+	return q
+}
+
+func (q *ServiceLockQuery) SetLockName(v string) *ServiceLockQuery {
+	q.updateFields = append(q.updateFields, "lock_name")
+	q.updateParams = append(q.updateParams, v)
+	return q
+}
+
+func (q *ServiceLockQuery) SetOwner(v string) *ServiceLockQuery {
+	q.updateFields = append(q.updateFields, "owner")
+	q.updateParams = append(q.updateParams, v)
+	return q
+}
+
+func (q *ServiceLockQuery) SetExpireTime(v time.Time) *ServiceLockQuery {
+	q.updateFields = append(q.updateFields, "expire_time")
+	q.updateParams = append(q.updateParams, v)
+	return q
+}
+
+func (q *ServiceLockQuery) DuplicatedUpdateOwner() *ServiceLockQuery {
+	q.duplicatedUpdateFields = append(q.duplicatedUpdateFields, "owner=VALUES(owner)")
+	return q
+}
+
+func (q *ServiceLockQuery) DuplicatedUpdateExpireTime() *ServiceLockQuery {
+	q.duplicatedUpdateFields = append(q.duplicatedUpdateFields, "expire_time=VALUES(expire_time)")
+	return q
+}
+
+func (q *ServiceLockQuery) GetId() *ServiceLockQuery {
+	q.getFields = append(q.getFields, "id")
 	return q
 }
 
-func (q *PhoneAccountQuery) GetId() *PhoneAccountQuery {
-	q.getFields = append(q.getFields, "id")
+func (q *ServiceLockQuery) GetLockName() *ServiceLockQuery {
+	q.getFields = append(q.getFields, "lock_name")
 	return q
 }
 
-func (q *PhoneAccountQuery) GetUserId() *PhoneAccountQuery {
-	q.getFields = append(q.getFields, "user_id")
+func (q *ServiceLockQuery) GetOwner() *ServiceLockQuery {
+	q.getFields = append(q.getFields, "owner")
 	return q
 }
 
-func (q *PhoneAccountQuery) GetPhoneEncrypted() *PhoneAccountQuery {
-	q.getFields = append(q.getFields, "phone_encrypted")
+func (q *ServiceLockQuery) GetExpireTime() *ServiceLockQuery {
+	q.getFields = append(q.getFields, "expire_time")
 	return q
 }
 
-func (q *PhoneAccountQuery) GetCreateTime() *PhoneAccountQuery {
+func (q *ServiceLockQuery) GetCreateTime() *ServiceLockQuery {
 	q.getFields = append(q.getFields, "create_time")
 	return q
 }
 
-func (q *PhoneAccountQuery) GetUpdateTime() *PhoneAccountQuery {
+func (q *ServiceLockQuery) GetUpdateTime() *ServiceLockQuery {
 	q.getFields = append(q.getFields, "update_time")
 	return q
 }
 
-func (q *PhoneAccountQuery) Select(ctx context.Context, tx *wrap.Tx) (e *PhoneAccount, err error) {
+func (q *ServiceLockQuery) Select(ctx context.Context, tx *wrap.Tx) (e *ServiceLock, err error) {
 	if !q.hasLimit {
 		q.limitCount = 1
 		q.hasLimit = true
@@ -2831,16 +8952,16 @@ func (q *PhoneAccountQuery) Select(ctx context.Context, tx *wrap.Tx) (e *PhoneAc
 	queryString, params := q.buildSelectQuery()
 	query := bytes.NewBufferString("")
 	if len(q.getFields) == 0 {
-		query.WriteString("SELECT id,user_id,phone_encrypted,create_time,update_time FROM phone_account ")
+		query.WriteString("SELECT id,lock_name,owner,expire_time,create_time,update_time FROM service_lock ")
 	} else {
 		query.WriteString("SELECT ")
 		query.WriteString(strings.Join(q.getFields, ","))
-		query.WriteString(" FROM phone_account ")
+		query.WriteString(" FROM service_lock ")
 	}
 	query.WriteString(queryString)
-	e = &PhoneAccount{}
+	e = &ServiceLock{}
 	row := q.dao.db.QueryRow(ctx, tx, query.String(), params...)
-	err = row.Scan(&e.Id, &e.UserId, &e.PhoneEncrypted, &e.CreateTime, &e.UpdateTime)
+	err = row.Scan(&e.Id, &e.LockName, &e.Owner, &e.ExpireTime, &e.CreateTime, &e.UpdateTime)
 	if err == wrap.ErrNoRows {
 		return nil, nil
 	}
@@ -2848,15 +8969,15 @@ func (q *PhoneAccountQuery) Select(ctx context.Context, tx *wrap.Tx) (e *PhoneAc
 	return e, err
 }
 
-func (q *PhoneAccountQuery) SelectList(ctx context.Context, tx *wrap.Tx) (list []*PhoneAccount, err error) {
+func (q *ServiceLockQuery) SelectList(ctx context.Context, tx *wrap.Tx) (list []*ServiceLock, err error) {
 	queryString, params := q.buildSelectQuery()
 	query := bytes.NewBufferString("")
 	if len(q.getFields) == 0 {
-		query.WriteString("SELECT id,user_id,phone_encrypted,create_time,update_time FROM phone_account ")
+		query.WriteString("SELECT id,lock_name,owner,expire_time,create_time,update_time FROM service_lock ")
 	} else {
 		query.WriteString("SELECT ")
 		query.WriteString(strings.Join(q.getFields, ","))
-		query.WriteString(" FROM phone_account ")
+		query.WriteString(" FROM service_lock ")
 	}
 	query.WriteString(queryString)
 	rows, err := q.dao.db.Query(ctx, tx, query.String(), params...)
@@ -2864,8 +8985,8 @@ func (q *PhoneAccountQuery) SelectList(ctx context.Context, tx *wrap.Tx) (list [
 		return nil, err
 	}
 	for rows.Next() {
-		e := PhoneAccount{}
-		err = rows.Scan(&e.Id, &e.UserId, &e.PhoneEncrypted, &e.CreateTime, &e.UpdateTime)
+		e := ServiceLock{}
+		err = rows.Scan(&e.Id, &e.LockName, &e.Owner, &e.ExpireTime, &e.CreateTime, &e.UpdateTime)
 		if err != nil {
 			return nil, err
 		}
@@ -2879,10 +9000,10 @@ func (q *PhoneAccountQuery) SelectList(ctx context.Context, tx *wrap.Tx) (list [
 	return list, nil
 }
 
-func (q *PhoneAccountQuery) SelectCount(ctx context.Context, tx *wrap.Tx) (count int64, err error) {
+func (q *ServiceLockQuery) SelectCount(ctx context.Context, tx *wrap.Tx) (count int64, err error) {
 	queryString, params := q.buildSelectQuery()
 	query := bytes.NewBufferString("")
-	query.WriteString("SELECT COUNT(*) FROM phone_account ")
+	query.WriteString("SELECT COUNT(*) FROM service_lock ")
 	query.WriteString(queryString)
 	row := q.dao.db.QueryRow(ctx, tx, query.String(), params...)
 	err = row.Scan(&count)
@@ -2890,7 +9011,7 @@ func (q *PhoneAccountQuery) SelectCount(ctx context.Context, tx *wrap.Tx) (count
 	return count, err
 }
 
-func (q *PhoneAccountQuery) SelectGroupBy(ctx context.Context, tx *wrap.Tx, withCount bool) (rows *wrap.Rows, err error) {
+func (q *ServiceLockQuery) SelectGroupBy(ctx context.Context, tx *wrap.Tx, withCount bool) (rows *wrap.Rows, err error) {
 	queryString, params := q.buildSelectQuery()
 	query := bytes.NewBufferString("")
 	query.WriteString("SELECT ")
@@ -2898,13 +9019,13 @@ func (q *PhoneAccountQuery) SelectGroupBy(ctx context.Context, tx *wrap.Tx, with
 	if withCount {
 		query.WriteString(",MachineListCount(*) ")
 	}
-	query.WriteString(" FROM phone_account ")
+	query.WriteString(" FROM service_lock ")
 	query.WriteString(queryString)
 
 	return q.dao.db.Query(ctx, tx, query.String(), params...)
 }
 
-func (q *PhoneAccountQuery) SelectRow(ctx context.Context, tx *wrap.Tx) (row *wrap.Row) {
+func (q *ServiceLockQuery) SelectRow(ctx context.Context, tx *wrap.Tx) (row *wrap.Row) {
 	if !q.hasLimit {
 		q.limitCount = 1
 		q.hasLimit = true
@@ -2914,82 +9035,84 @@ func (q *PhoneAccountQuery) SelectRow(ctx context.Context, tx *wrap.Tx) (row *wr
 	query := bytes.NewBufferString("")
 	query.WriteString("SELECT ")
 	query.WriteString(strings.Join(q.getFields, ","))
-	query.WriteString(" FROM phone_account ")
+	query.WriteString(" FROM service_lock ")
 	query.WriteString(queryString)
 	return q.dao.db.QueryRow(ctx, tx, query.String(), params...)
 }
 
-func (q *PhoneAccountQuery) SelectRows(ctx context.Context, tx *wrap.Tx) (rows *wrap.Rows, err error) {
+func (q *ServiceLockQuery) SelectRows(ctx context.Context, tx *wrap.Tx) (rows *wrap.Rows, err error) {
 	queryString, params := q.buildSelectQuery()
 	query := bytes.NewBufferString("")
 	query.WriteString("SELECT ")
 	query.WriteString(strings.Join(q.getFields, ","))
-	query.WriteString(" FROM phone_account ")
+	query.WriteString(" FROM service_lock ")
 	query.WriteString(queryString)
 	return q.dao.db.Query(ctx, tx, query.String(), params...)
 }
 
-func (q *PhoneAccountQuery) Insert(ctx context.Context, tx *wrap.Tx, e *PhoneAccount) (result *wrap.Result, err error) {
+func (q *ServiceLockQuery) Insert(ctx context.Context, tx *wrap.Tx, e *ServiceLock) (result *wrap.Result, err error) {
 	query := bytes.NewBufferString("")
-	query.WriteString("INSERT INTO phone_account (user_id,phone_encrypted) VALUES (?,?)")
-	params := []interface{}{e.UserId, e.PhoneEncrypted}
+	query.WriteString("INSERT INTO service_lock (lock_name,owner,expire_time) VALUES (?,?,?)")
+	params := []interface{}{e.LockName, e.Owner, e.ExpireTime}
 	return q.dao.db.Exec(ctx, tx, query.String(), params...)
 }
 
-func (q *PhoneAccountQuery) BatchInsert(ctx context.Context, tx *wrap.Tx, list []*PhoneAccount) (result *wrap.Result, err error) {
+func (q *ServiceLockQuery) BatchInsert(ctx context.Context, tx *wrap.Tx, list []*ServiceLock) (result *wrap.Result, err error) {
 	query := bytes.NewBufferString("")
-	query.WriteString("INSERT INTO phone_account (user_id,phone_encrypted) VALUES ")
-	query.WriteString(wrap.RepeatWithSeparator("(?,?)", len(list), ","))
-	params := make([]interface{}, len(list)*2)
+	query.WriteString("INSERT INTO service_lock (lock_name,owner,expire_time) VALUES ")
+	query.WriteString(wrap.RepeatWithSeparator("(?,?,?)", len(list), ","))
+	params := make([]interface{}, len(list)*3)
 	offset := 0
 	for _, e := range list {
-		params[offset+0] = e.UserId
-		params[offset+1] = e.PhoneEncrypted
-		offset += 2
+		params[offset+0] = e.LockName
+		params[offset+1] = e.Owner
+		params[offset+2] = e.ExpireTime
+		offset += 3
 	}
 
 	return q.dao.db.Exec(ctx, tx, query.String(), params...)
 }
 
-func (q *PhoneAccountQuery) InsertOnDuplicatedKeyUpdate(ctx context.Context, tx *wrap.Tx, e *PhoneAccount) (result *wrap.Result, err error) {
+func (q *ServiceLockQuery) InsertOnDuplicatedKeyUpdate(ctx context.Context, tx *wrap.Tx, e *ServiceLock) (result *wrap.Result, err error) {
 	query := bytes.NewBufferString("")
-	query.WriteString("INSERT INTO phone_account (user_id,phone_encrypted) VALUES (?,?)")
+	query.WriteString("INSERT INTO service_lock (lock_name,owner,expire_time) VALUES (?,?,?)")
 	query.WriteString(" ON DUPLICATED KEY UPDATE ")
 	if len(q.duplicatedUpdateFields) > 0 {
 		query.WriteString(strings.Join(q.duplicatedUpdateFields, ","))
 		query.WriteString(",")
 	}
 	query.WriteString("update_time=now()")
-	params := []interface{}{e.UserId, e.PhoneEncrypted}
+	params := []interface{}{e.LockName, e.Owner, e.ExpireTime}
 	return q.dao.db.Exec(ctx, tx, query.String(), params...)
 }
 
-func (q *PhoneAccountQuery) BatchInsertOnDuplicatedKeyUpdate(ctx context.Context, tx *wrap.Tx, list []*PhoneAccount) (result *wrap.Result, err error) {
+func (q *ServiceLockQuery) BatchInsertOnDuplicatedKeyUpdate(ctx context.Context, tx *wrap.Tx, list []*ServiceLock) (result *wrap.Result, err error) {
 	query := bytes.NewBufferString("")
-	query.WriteString("INSERT INTO phone_account (user_id,phone_encrypted) VALUES ")
-	query.WriteString(wrap.RepeatWithSeparator("(?,?)", len(list), ","))
+	query.WriteString("INSERT INTO service_lock (lock_name,owner,expire_time) VALUES ")
+	query.WriteString(wrap.RepeatWithSeparator("(?,?,?)", len(list), ","))
 	query.WriteString(" ON DUPLICATED KEY UPDATE")
 	if len(q.duplicatedUpdateFields) > 0 {
 		query.WriteString(strings.Join(q.duplicatedUpdateFields, ","))
 		query.WriteString(",")
 	}
 	query.WriteString("update_time=now()")
-	params := make([]interface{}, len(list)*2)
+	params := make([]interface{}, len(list)*3)
 	offset := 0
 	for _, e := range list {
-		params[offset+0] = e.UserId
-		params[offset+1] = e.PhoneEncrypted
-		offset += 2
+		params[offset+0] = e.LockName
+		params[offset+1] = e.Owner
+		params[offset+2] = e.ExpireTime
+		offset += 3
 	}
 
 	return q.dao.db.Exec(ctx, tx, query.String(), params...)
 }
 
-func (q *PhoneAccountQuery) Update(ctx context.Context, tx *wrap.Tx) (result *wrap.Result, err error) {
+func (q *ServiceLockQuery) Update(ctx context.Context, tx *wrap.Tx) (result *wrap.Result, err error) {
 	query := bytes.NewBufferString("")
 	var params []interface{}
 	params = append(params, q.updateParams...)
-	query.WriteString("UPDATE phone_account SET ")
+	query.WriteString("UPDATE service_lock SET ")
 	updateItems := make([]string, len(q.updateFields))
 	for i, v := range q.updateFields {
 		updateItems[i] = v + "=?"
@@ -3006,107 +9129,112 @@ func (q *PhoneAccountQuery) Update(ctx context.Context, tx *wrap.Tx) (result *wr
 	return q.dao.db.Exec(ctx, tx, query.String(), params...)
 }
 
-func (q *PhoneAccountQuery) Delete(ctx context.Context, tx *wrap.Tx) (result *wrap.Result, err error) {
-	query := "DELETE FROM phone_account WHERE " + q.where.String()
+func (q *ServiceLockQuery) Delete(ctx context.Context, tx *wrap.Tx) (result *wrap.Result, err error) {
+	query := "DELETE FROM service_lock WHERE " + q.where.String()
 	return q.dao.db.Exec(ctx, tx, query, q.whereParams...)
 }
 
-type PhoneAccountDao struct {
+type ServiceLockDao struct {
 	logger *zap.Logger
 	db     *DB
 }
 
-func NewPhoneAccountDao(db *DB) (t *PhoneAccountDao, err error) {
-	t = &PhoneAccountDao{}
+func NewServiceLockDao(db *DB) (t *ServiceLockDao, err error) {
+	t = &ServiceLockDao{}
 	t.logger = log.TypedLogger(t)
 	t.db = db
 
 	return t, nil
 }
 
-func (dao *PhoneAccountDao) Query() *PhoneAccountQuery {
-	q := &PhoneAccountQuery{}
+func (dao *ServiceLockDao) Query() *ServiceLockQuery {
+	q := &ServiceLockQuery{}
 	q.dao = dao
-	q.tableName = "phone_account"
+	q.tableName = "service_lock"
 	q.where = bytes.NewBufferString("")
 	return q
 }
 
-type RefreshToken struct {
-	Id           uint64 //size=20
-	UserId       string //size=32
-	RefreshToken string //size=128
-	CreateTime   time.Time
-	UpdateTime   time.Time
+type SmsCode struct {
+	Id             uint64 //size=20
+	SmsScene       string //size=32
+	PhoneEncrypted string //size=32
+	SmsCode        string //size=128
+	UserId         string //size=32
+	IsUsed         int32  //size=1
+	Attempts       int32  //size=11
+	IsHashed       int32  //size=1
+	CreateTime     time.Time
+	UpdateTime     time.Time
 }
 
-type RefreshTokenQuery struct {
+type SmsCodeQuery struct {
 	QueryBase
-	dao *RefreshTokenDao
+	dao *SmsCodeDao
 }
 
-func (q *RefreshTokenQuery) Left() *RefreshTokenQuery {
+func (q *SmsCodeQuery) Left() *SmsCodeQuery {
 	q.where.WriteString(" (")
 	return q
 }
 
-func (q *RefreshTokenQuery) Right() *RefreshTokenQuery {
+func (q *SmsCodeQuery) Right() *SmsCodeQuery {
 	q.where.WriteString(" )")
 	return q
 }
 
-func (q *RefreshTokenQuery) And() *RefreshTokenQuery {
+func (q *SmsCodeQuery) And() *SmsCodeQuery {
 	q.where.WriteString(" AND")
 	return q
 }
 
-func (q *RefreshTokenQuery) Or() *RefreshTokenQuery {
+func (q *SmsCodeQuery) Or() *SmsCodeQuery {
 	q.where.WriteString(" OR")
 	return q
 }
 
-func (q *RefreshTokenQuery) Not() *RefreshTokenQuery {
+func (q *SmsCodeQuery) Not() *SmsCodeQuery {
 	q.where.WriteString(" NOT")
 	return q
 }
 
-func (q *RefreshTokenQuery) IdEqual(v uint64) *RefreshTokenQuery {
+func (q *SmsCodeQuery) IdEqual(v uint64) *SmsCodeQuery {
 	q.where.WriteString(" id=?")
 	q.whereParams = append(q.whereParams, v)
 	return q
 }
 
-func (q *RefreshTokenQuery) IdNotEqual(v uint64) *RefreshTokenQuery {
+func (q *SmsCodeQuery) IdNotEqual(v uint64) *SmsCodeQuery {
 	q.where.WriteString(" id<>?")
 	q.whereParams = append(q.whereParams, v)
 	return q
 }
 
-func (q *RefreshTokenQuery) IdLess(v uint64) *RefreshTokenQuery {
+func (q *SmsCodeQuery) IdLess(v uint64) *SmsCodeQuery {
 	q.where.WriteString(" id<?")
 	q.whereParams = append(q.whereParams, v)
 	return q
 }
 
-func (q *RefreshTokenQuery) IdLessEqual(v uint64) *RefreshTokenQuery {
+func (q *SmsCodeQuery) IdLessEqual(v uint64) *SmsCodeQuery {
 	q.where.WriteString(" id<=?")
 	q.whereParams = append(q.whereParams, v)
 	return q
 }
 
-func (q *RefreshTokenQuery) IdGreater(v uint64) *RefreshTokenQuery {
+func (q *SmsCodeQuery) IdGreater(v uint64) *SmsCodeQuery {
 	q.where.WriteString(" id>?")
 	q.whereParams = append(q.whereParams, v)
 	return q
 }
 
-func (q *RefreshTokenQuery) IdGreaterEqual(v uint64) *RefreshTokenQuery {
+func (q *SmsCodeQuery) IdGreaterEqual(v uint64) *SmsCodeQuery {
 	q.where.WriteString(" id>=?")
 	q.whereParams = append(q.whereParams, v)
 	return q
 }
 
-func (q *RefreshTokenQuery) IdIn(items []uint64) *RefreshTokenQuery {
+func (q *SmsCodeQuery) IdIn(items []uint64) *SmsCodeQuery {
 	q.where.WriteString(" id IN(")
 	q.where.WriteString(wrap.RepeatWithSeparator("?", len(items), ","))
 	q.where.WriteString(")")
@@ -3114,19 +9242,79 @@ func (q *RefreshTokenQuery) IdIn(items []uint64) *RefreshTokenQuery {
 	return q
 }
 
-func (q *RefreshTokenQuery) UserIdEqual(v string) *RefreshTokenQuery {
+func (q *SmsCodeQuery) SmsSceneEqual(v string) *SmsCodeQuery {
+	q.where.WriteString(" sms_scene=?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *SmsCodeQuery) SmsSceneNotEqual(v string) *SmsCodeQuery {
+	q.where.WriteString(" sms_scene<>?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *SmsCodeQuery) SmsSceneIn(items []string) *SmsCodeQuery {
+	q.where.WriteString(" sms_scene IN(")
+	q.where.WriteString(wrap.RepeatWithSeparator("?", len(items), ","))
+	q.where.WriteString(")")
+	q.whereParams = append(q.whereParams, items)
+	return q
+}
+
+func (q *SmsCodeQuery) PhoneEncryptedEqual(v string) *SmsCodeQuery {
+	q.where.WriteString(" phone_encrypted=?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *SmsCodeQuery) PhoneEncryptedNotEqual(v string) *SmsCodeQuery {
+	q.where.WriteString(" phone_encrypted<>?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *SmsCodeQuery) PhoneEncryptedIn(items []string) *SmsCodeQuery {
+	q.where.WriteString(" phone_encrypted IN(")
+	q.where.WriteString(wrap.RepeatWithSeparator("?", len(items), ","))
+	q.where.WriteString(")")
+	q.whereParams = append(q.whereParams, items)
+	return q
+}
+
+func (q *SmsCodeQuery) SmsCodeEqual(v string) *SmsCodeQuery {
+	q.where.WriteString(" sms_code=?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *SmsCodeQuery) SmsCodeNotEqual(v string) *SmsCodeQuery {
+	q.where.WriteString(" sms_code<>?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *SmsCodeQuery) SmsCodeIn(items []string) *SmsCodeQuery {
+	q.where.WriteString(" sms_code IN(")
+	q.where.WriteString(wrap.RepeatWithSeparator("?", len(items), ","))
+	q.where.WriteString(")")
+	q.whereParams = append(q.whereParams, items)
+	return q
+}
+
+func (q *SmsCodeQuery) UserIdEqual(v string) *SmsCodeQuery {
 	q.where.WriteString(" user_id=?")
 	q.whereParams = append(q.whereParams, v)
 	return q
 }
 
-func (q *RefreshTokenQuery) UserIdNotEqual(v string) *RefreshTokenQuery {
+func (q *SmsCodeQuery) UserIdNotEqual(v string) *SmsCodeQuery {
 	q.where.WriteString(" user_id<>?")
 	q.whereParams = append(q.whereParams, v)
 	return q
 }
 
-func (q *RefreshTokenQuery) UserIdIn(items []string) *RefreshTokenQuery {
+func (q *SmsCodeQuery) UserIdIn(items []string) *SmsCodeQuery {
 	q.where.WriteString(" user_id IN(")
 	q.where.WriteString(wrap.RepeatWithSeparator("?", len(items), ","))
 	q.where.WriteString(")")
@@ -3134,189 +9322,463 @@ func (q *RefreshTokenQuery) UserIdIn(items []string) *RefreshTokenQuery {
 	return q
 }
 
-func (q *RefreshTokenQuery) RefreshTokenEqual(v string) *RefreshTokenQuery {
-	q.where.WriteString(" refresh_token=?")
+func (q *SmsCodeQuery) IsUsedEqual(v int32) *SmsCodeQuery {
+	q.where.WriteString(" is_used=?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *SmsCodeQuery) IsUsedNotEqual(v int32) *SmsCodeQuery {
+	q.where.WriteString(" is_used<>?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *SmsCodeQuery) IsUsedLess(v int32) *SmsCodeQuery {
+	q.where.WriteString(" is_used<?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *SmsCodeQuery) IsUsedLessEqual(v int32) *SmsCodeQuery {
+	q.where.WriteString(" is_used<=?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *SmsCodeQuery) IsUsedGreater(v int32) *SmsCodeQuery {
+	q.where.WriteString(" is_used>?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *SmsCodeQuery) IsUsedGreaterEqual(v int32) *SmsCodeQuery {
+	q.where.WriteString(" is_used>=?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *SmsCodeQuery) IsUsedIn(items []int32) *SmsCodeQuery {
+	q.where.WriteString(" is_used IN(")
+	q.where.WriteString(wrap.RepeatWithSeparator("?", len(items), ","))
+	q.where.WriteString(")")
+	q.whereParams = append(q.whereParams, items)
+	return q
+}
+
+func (q *SmsCodeQuery) AttemptsEqual(v int32) *SmsCodeQuery {
+	q.where.WriteString(" attempts=?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *SmsCodeQuery) AttemptsNotEqual(v int32) *SmsCodeQuery {
+	q.where.WriteString(" attempts<>?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *SmsCodeQuery) AttemptsLess(v int32) *SmsCodeQuery {
+	q.where.WriteString(" attempts<?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *SmsCodeQuery) AttemptsLessEqual(v int32) *SmsCodeQuery {
+	q.where.WriteString(" attempts<=?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *SmsCodeQuery) AttemptsGreater(v int32) *SmsCodeQuery {
+	q.where.WriteString(" attempts>?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *SmsCodeQuery) AttemptsGreaterEqual(v int32) *SmsCodeQuery {
+	q.where.WriteString(" attempts>=?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *SmsCodeQuery) AttemptsIn(items []int32) *SmsCodeQuery {
+	q.where.WriteString(" attempts IN(")
+	q.where.WriteString(wrap.RepeatWithSeparator("?", len(items), ","))
+	q.where.WriteString(")")
+	q.whereParams = append(q.whereParams, items)
+	return q
+}
+
+func (q *SmsCodeQuery) IsHashedEqual(v int32) *SmsCodeQuery {
+	q.where.WriteString(" is_hashed=?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *SmsCodeQuery) IsHashedNotEqual(v int32) *SmsCodeQuery {
+	q.where.WriteString(" is_hashed<>?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *SmsCodeQuery) IsHashedLess(v int32) *SmsCodeQuery {
+	q.where.WriteString(" is_hashed<?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *SmsCodeQuery) IsHashedLessEqual(v int32) *SmsCodeQuery {
+	q.where.WriteString(" is_hashed<=?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *SmsCodeQuery) IsHashedGreater(v int32) *SmsCodeQuery {
+	q.where.WriteString(" is_hashed>?")
 	q.whereParams = append(q.whereParams, v)
 	return q
 }
 
-func (q *RefreshTokenQuery) RefreshTokenNotEqual(v string) *RefreshTokenQuery {
-	q.where.WriteString(" refresh_token<>?")
+func (q *SmsCodeQuery) IsHashedGreaterEqual(v int32) *SmsCodeQuery {
+	q.where.WriteString(" is_hashed>=?")
 	q.whereParams = append(q.whereParams, v)
 	return q
 }
 
-func (q *RefreshTokenQuery) RefreshTokenIn(items []string) *RefreshTokenQuery {
-	q.where.WriteString(" refresh_token IN(")
+func (q *SmsCodeQuery) IsHashedIn(items []int32) *SmsCodeQuery {
+	q.where.WriteString(" is_hashed IN(")
 	q.where.WriteString(wrap.RepeatWithSeparator("?", len(items), ","))
 	q.where.WriteString(")")
 	q.whereParams = append(q.whereParams, items)
 	return q
 }
 
-func (q *RefreshTokenQuery) CreateTimeEqual(v time.Time) *RefreshTokenQuery {
+func (q *SmsCodeQuery) CreateTimeEqual(v time.Time) *SmsCodeQuery {
 	q.where.WriteString(" create_time=?")
 	q.whereParams = append(q.whereParams, v)
 	return q
 }
 
-func (q *RefreshTokenQuery) CreateTimeNotEqual(v time.Time) *RefreshTokenQuery {
+func (q *SmsCodeQuery) CreateTimeNotEqual(v time.Time) *SmsCodeQuery {
 	q.where.WriteString(" create_time<>?")
 	q.whereParams = append(q.whereParams, v)
 	return q
 }
 
-func (q *RefreshTokenQuery) CreateTimeLess(v time.Time) *RefreshTokenQuery {
+func (q *SmsCodeQuery) CreateTimeLess(v time.Time) *SmsCodeQuery {
 	q.where.WriteString(" create_time<?")
 	q.whereParams = append(q.whereParams, v)
 	return q
 }
 
-func (q *RefreshTokenQuery) CreateTimeLessEqual(v time.Time) *RefreshTokenQuery {
+func (q *SmsCodeQuery) CreateTimeLessEqual(v time.Time) *SmsCodeQuery {
 	q.where.WriteString(" create_time<=?")
 	q.whereParams = append(q.whereParams, v)
 	return q
 }
 
-func (q *RefreshTokenQuery) CreateTimeGreater(v time.Time) *RefreshTokenQuery {
+func (q *SmsCodeQuery) CreateTimeGreater(v time.Time) *SmsCodeQuery {
 	q.where.WriteString(" create_time>?")
 	q.whereParams = append(q.whereParams, v)
 	return q
 }
 
-func (q *RefreshTokenQuery) CreateTimeGreaterEqual(v time.Time) *RefreshTokenQuery {
+func (q *SmsCodeQuery) CreateTimeGreaterEqual(v time.Time) *SmsCodeQuery {
 	q.where.WriteString(" create_time>=?")
 	q.whereParams = append(q.whereParams, v)
 	return q
 }
 
-func (q *RefreshTokenQuery) UpdateTimeEqual(v time.Time) *RefreshTokenQuery {
+func (q *SmsCodeQuery) UpdateTimeEqual(v time.Time) *SmsCodeQuery {
 	q.where.WriteString(" update_time=?")
 	q.whereParams = append(q.whereParams, v)
 	return q
 }
 
-func (q *RefreshTokenQuery) UpdateTimeNotEqual(v time.Time) *RefreshTokenQuery {
+func (q *SmsCodeQuery) UpdateTimeNotEqual(v time.Time) *SmsCodeQuery {
 	q.where.WriteString(" update_time<>?")
 	q.whereParams = append(q.whereParams, v)
 	return q
 }
 
-func (q *RefreshTokenQuery) UpdateTimeLess(v time.Time) *RefreshTokenQuery {
+func (q *SmsCodeQuery) UpdateTimeLess(v time.Time) *SmsCodeQuery {
 	q.where.WriteString(" update_time<?")
 	q.whereParams = append(q.whereParams, v)
 	return q
 }
 
-func (q *RefreshTokenQuery) UpdateTimeLessEqual(v time.Time) *RefreshTokenQuery {
+func (q *SmsCodeQuery) UpdateTimeLessEqual(v time.Time) *SmsCodeQuery {
 	q.where.WriteString(" update_time<=?")
 	q.whereParams = append(q.whereParams, v)
 	return q
 }
 
-func (q *RefreshTokenQuery) UpdateTimeGreater(v time.Time) *RefreshTokenQuery {
+func (q *SmsCodeQuery) UpdateTimeGreater(v time.Time) *SmsCodeQuery {
 	q.where.WriteString(" update_time>?")
 	q.whereParams = append(q.whereParams, v)
 	return q
 }
 
-func (q *RefreshTokenQuery) UpdateTimeGreaterEqual(v time.Time) *RefreshTokenQuery {
+func (q *SmsCodeQuery) UpdateTimeGreaterEqual(v time.Time) *SmsCodeQuery {
 	q.where.WriteString(" update_time>=?")
 	q.whereParams = append(q.whereParams, v)
 	return q
 }
 
-func (q *RefreshTokenQuery) OrderById(asc bool) *RefreshTokenQuery {
+func (q *SmsCodeQuery) GroupBySmsScene(asc bool) *SmsCodeQuery {
+	q.groupByFields = append(q.groupByFields, "sms_scene")
+	q.groupByOrders = append(q.groupByOrders, asc)
+	return q
+}
+
+func (q *SmsCodeQuery) GroupByPhoneEncrypted(asc bool) *SmsCodeQuery {
+	q.groupByFields = append(q.groupByFields, "phone_encrypted")
+	q.groupByOrders = append(q.groupByOrders, asc)
+	return q
+}
+
+func (q *SmsCodeQuery) GroupBySmsCode(asc bool) *SmsCodeQuery {
+	q.groupByFields = append(q.groupByFields, "sms_code")
+	q.groupByOrders = append(q.groupByOrders, asc)
+	return q
+}
+
+func (q *SmsCodeQuery) GroupByUserId(asc bool) *SmsCodeQuery {
+	q.groupByFields = append(q.groupByFields, "user_id")
+	q.groupByOrders = append(q.groupByOrders, asc)
+	return q
+}
+
+func (q *SmsCodeQuery) GroupByIsUsed(asc bool) *SmsCodeQuery {
+	q.groupByFields = append(q.groupByFields, "is_used")
+	q.groupByOrders = append(q.groupByOrders, asc)
+	return q
+}
+
+func (q *SmsCodeQuery) GroupByAttempts(asc bool) *SmsCodeQuery {
+	q.groupByFields = append(q.groupByFields, "attempts")
+	q.groupByOrders = append(q.groupByOrders, asc)
+	return q
+}
+
+func (q *SmsCodeQuery) GroupByIsHashed(asc bool) *SmsCodeQuery {
+	q.groupByFields = append(q.groupByFields, "is_hashed")
+	q.groupByOrders = append(q.groupByOrders, asc)
+	return q
+}
+
+func (q *SmsCodeQuery) OrderById(asc bool) *SmsCodeQuery {
 	q.orderByFields = append(q.orderByFields, "id")
 	q.orderByOrders = append(q.orderByOrders, asc)
 	return q
 }
 
-func (q *RefreshTokenQuery) OrderByUserId(asc bool) *RefreshTokenQuery {
+func (q *SmsCodeQuery) OrderBySmsScene(asc bool) *SmsCodeQuery {
+	q.orderByFields = append(q.orderByFields, "sms_scene")
+	q.orderByOrders = append(q.orderByOrders, asc)
+	return q
+}
+
+func (q *SmsCodeQuery) OrderByPhoneEncrypted(asc bool) *SmsCodeQuery {
+	q.orderByFields = append(q.orderByFields, "phone_encrypted")
+	q.orderByOrders = append(q.orderByOrders, asc)
+	return q
+}
+
+func (q *SmsCodeQuery) OrderBySmsCode(asc bool) *SmsCodeQuery {
+	q.orderByFields = append(q.orderByFields, "sms_code")
+	q.orderByOrders = append(q.orderByOrders, asc)
+	return q
+}
+
+func (q *SmsCodeQuery) OrderByUserId(asc bool) *SmsCodeQuery {
 	q.orderByFields = append(q.orderByFields, "user_id")
 	q.orderByOrders = append(q.orderByOrders, asc)
 	return q
 }
 
-func (q *RefreshTokenQuery) OrderByRefreshToken(asc bool) *RefreshTokenQuery {
-	q.orderByFields = append(q.orderByFields, "refresh_token")
+func (q *SmsCodeQuery) OrderByIsUsed(asc bool) *SmsCodeQuery {
+	q.orderByFields = append(q.orderByFields, "is_used")
 	q.orderByOrders = append(q.orderByOrders, asc)
 	return q
 }
 
-func (q *RefreshTokenQuery) OrderByCreateTime(asc bool) *RefreshTokenQuery {
+func (q *SmsCodeQuery) OrderByAttempts(asc bool) *SmsCodeQuery {
+	q.orderByFields = append(q.orderByFields, "attempts")
+	q.orderByOrders = append(q.orderByOrders, asc)
+	return q
+}
+
+func (q *SmsCodeQuery) OrderByIsHashed(asc bool) *SmsCodeQuery {
+	q.orderByFields = append(q.orderByFields, "is_hashed")
+	q.orderByOrders = append(q.orderByOrders, asc)
+	return q
+}
+
+func (q *SmsCodeQuery) OrderByCreateTime(asc bool) *SmsCodeQuery {
 	q.orderByFields = append(q.orderByFields, "create_time")
 	q.orderByOrders = append(q.orderByOrders, asc)
 	return q
 }
 
-func (q *RefreshTokenQuery) OrderByUpdateTime(asc bool) *RefreshTokenQuery {
+func (q *SmsCodeQuery) OrderByUpdateTime(asc bool) *SmsCodeQuery {
 	q.orderByFields = append(q.orderByFields, "update_time")
 	q.orderByOrders = append(q.orderByOrders, asc)
 	return q
 }
 
-func (q *RefreshTokenQuery) OrderByGroupCount(asc bool) *RefreshTokenQuery {
+func (q *SmsCodeQuery) OrderByGroupCount(asc bool) *SmsCodeQuery {
 	q.orderByFields = append(q.orderByFields, "count(*)")
 	q.orderByOrders = append(q.orderByOrders, asc)
 	return q
 }
 
-func (q *RefreshTokenQuery) Limit(startIncluded int64, count int64) *RefreshTokenQuery {
+func (q *SmsCodeQuery) Limit(startIncluded int64, count int64) *SmsCodeQuery {
 	q.hasLimit = true
 	q.limitStartIncluded = startIncluded
 	q.limitCount = count
 	return q
 }
 
-func (q *RefreshTokenQuery) ForUpdate() *RefreshTokenQuery {
+func (q *SmsCodeQuery) ForUpdate() *SmsCodeQuery {
 	q.forUpdate = true
 	return q
 }
 
-func (q *RefreshTokenQuery) ForShare() *RefreshTokenQuery {
+func (q *SmsCodeQuery) ForShare() *SmsCodeQuery {
 	q.forShare = true
 	return q
 }
 
-func (q *RefreshTokenQuery) SetUserId(v string) *RefreshTokenQuery {
+func (q *SmsCodeQuery) SetSmsScene(v string) *SmsCodeQuery {
+	q.updateFields = append(q.updateFields, "sms_scene")
+	q.updateParams = append(q.updateParams, v)
+	return q
+}
+
+func (q *SmsCodeQuery) SetPhoneEncrypted(v string) *SmsCodeQuery {
+	q.updateFields = append(q.updateFields, "phone_encrypted")
+	q.updateParams = append(q.updateParams, v)
+	return q
+}
+
+func (q *SmsCodeQuery) SetSmsCode(v string) *SmsCodeQuery {
+	q.updateFields = append(q.updateFields, "sms_code")
+	q.updateParams = append(q.updateParams, v)
+	return q
+}
+
+func (q *SmsCodeQuery) SetUserId(v string) *SmsCodeQuery {
 	q.updateFields = append(q.updateFields, "user_id")
 	q.updateParams = append(q.updateParams, v)
 	return q
 }
 
-func (q *RefreshTokenQuery) SetRefreshToken(v string) *RefreshTokenQuery {
-	q.updateFields = append(q.updateFields, "refresh_token")
+func (q *SmsCodeQuery) SetIsUsed(v int32) *SmsCodeQuery {
+	q.updateFields = append(q.updateFields, "is_used")
 	q.updateParams = append(q.updateParams, v)
 	return q
 }
 
-func (q *RefreshTokenQuery) GetId() *RefreshTokenQuery {
+func (q *SmsCodeQuery) SetAttempts(v int32) *SmsCodeQuery {
+	q.updateFields = append(q.updateFields, "attempts")
+	q.updateParams = append(q.updateParams, v)
+	return q
+}
+
+func (q *SmsCodeQuery) SetIsHashed(v int32) *SmsCodeQuery {
+	q.updateFields = append(q.updateFields, "is_hashed")
+	q.updateParams = append(q.updateParams, v)
+	return q
+}
+
+func (q *SmsCodeQuery) DuplicatedUpdateSmsScene() *SmsCodeQuery {
+	q.duplicatedUpdateFields = append(q.duplicatedUpdateFields, "sms_scene=VALUES(sms_scene)")
+	return q
+}
+
+func (q *SmsCodeQuery) DuplicatedUpdatePhoneEncrypted() *SmsCodeQuery {
+	q.duplicatedUpdateFields = append(q.duplicatedUpdateFields, "phone_encrypted=VALUES(phone_encrypted)")
+	return q
+}
+
+func (q *SmsCodeQuery) DuplicatedUpdateSmsCode() *SmsCodeQuery {
+	q.duplicatedUpdateFields = append(q.duplicatedUpdateFields, "sms_code=VALUES(sms_code)")
+	return q
+}
+
+func (q *SmsCodeQuery) DuplicatedUpdateUserId() *SmsCodeQuery {
+	q.duplicatedUpdateFields = append(q.duplicatedUpdateFields, "user_id=VALUES(user_id)")
+	return q
+}
+
+func (q *SmsCodeQuery) DuplicatedUpdateIsUsed() *SmsCodeQuery {
+	q.duplicatedUpdateFields = append(q.duplicatedUpdateFields, "is_used=VALUES(is_used)")
+	return q
+}
+
+func (q *SmsCodeQuery) DuplicatedUpdateAttempts() *SmsCodeQuery {
+	q.duplicatedUpdateFields = append(q.duplicatedUpdateFields, "attempts=VALUES(attempts)")
+	return q
+}
+
+func (q *SmsCodeQuery) DuplicatedUpdateIsHashed() *SmsCodeQuery {
+	q.duplicatedUpdateFields = append(q.duplicatedUpdateFields, "is_hashed=VALUES(is_hashed)")
+	return q
+}
+
+func (q *SmsCodeQuery) GetId() *SmsCodeQuery {
 	q.getFields = append(q.getFields, "id")
 	return q
 }
 
-func (q *RefreshTokenQuery) GetUserId() *RefreshTokenQuery {
+func (q *SmsCodeQuery) GetSmsScene() *SmsCodeQuery {
+	q.getFields = append(q.getFields, "sms_scene")
+	return q
+}
+
+func (q *SmsCodeQuery) GetPhoneEncrypted() *SmsCodeQuery {
+	q.getFields = append(q.getFields, "phone_encrypted")
+	return q
+}
+
+func (q *SmsCodeQuery) GetSmsCode() *SmsCodeQuery {
+	q.getFields = append(q.getFields, "sms_code")
+	return q
+}
+
+func (q *SmsCodeQuery) GetUserId() *SmsCodeQuery {
 	q.getFields = append(q.getFields, "user_id")
 	return q
 }
 
-func (q *RefreshTokenQuery) GetRefreshToken() *RefreshTokenQuery {
-	q.getFields = append(q.getFields, "refresh_token")
+func (q *SmsCodeQuery) GetIsUsed() *SmsCodeQuery {
+	q.getFields = append(q.getFields, "is_used")
 	return q
 }
 
-func (q *RefreshTokenQuery) GetCreateTime() *RefreshTokenQuery {
+func (q *SmsCodeQuery) GetAttempts() *SmsCodeQuery {
+	q.getFields = append(q.getFields, "attempts")
+	return q
+}
+
+func (q *SmsCodeQuery) GetIsHashed() *SmsCodeQuery {
+	q.getFields = append(q.getFields, "is_hashed")
+	return q
+}
+
+func (q *SmsCodeQuery) GetCreateTime() *SmsCodeQuery {
 	q.getFields = append(q.getFields, "create_time")
 	return q
 }
 
-func (q *RefreshTokenQuery) GetUpdateTime() *RefreshTokenQuery {
+func (q *SmsCodeQuery) GetUpdateTime() *SmsCodeQuery {
 	q.getFields = append(q.getFields, "update_time")
 	return q
 }
 
-func (q *RefreshTokenQuery) Select(ctx context.Context, tx *wrap.Tx) (e *RefreshToken, err error) {
+func (q *SmsCodeQuery) Select(ctx context.Context, tx *wrap.Tx) (e *SmsCode, err error) {
 	if !q.hasLimit {
 		q.limitCount = 1
 		q.hasLimit = true
@@ -3325,16 +9787,16 @@ func (q *RefreshTokenQuery) Select(ctx context.Context, tx *wrap.Tx) (e *Refresh
 	queryString, params := q.buildSelectQuery()
 	query := bytes.NewBufferString("")
 	if len(q.getFields) == 0 {
-		query.WriteString("SELECT id,user_id,refresh_token,create_time,update_time FROM refresh_token ")
+		query.WriteString("SELECT id,sms_scene,phone_encrypted,sms_code,user_id,is_used,attempts,is_hashed,create_time,update_time FROM sms_code ")
 	} else {
 		query.WriteString("SELECT ")
 		query.WriteString(strings.Join(q.getFields, ","))
-		query.WriteString(" FROM refresh_token ")
+		query.WriteString(" FROM sms_code ")
 	}
 	query.WriteString(queryString)
-	e = &RefreshToken{}
+	e = &SmsCode{}
 	row := q.dao.db.QueryRow(ctx, tx, query.String(), params...)
-	err = row.Scan(&e.Id, &e.UserId, &e.RefreshToken, &e.CreateTime, &e.UpdateTime)
+	err = row.Scan(&e.Id, &e.SmsScene, &e.PhoneEncrypted, &e.SmsCode, &e.UserId, &e.IsUsed, &e.Attempts, &e.IsHashed, &e.CreateTime, &e.UpdateTime)
 	if err == wrap.ErrNoRows {
 		return nil, nil
 	}
@@ -3342,15 +9804,15 @@ func (q *RefreshTokenQuery) Select(ctx context.Context, tx *wrap.Tx) (e *Refresh
 	return e, err
 }
 
-func (q *RefreshTokenQuery) SelectList(ctx context.Context, tx *wrap.Tx) (list []*RefreshToken, err error) {
+func (q *SmsCodeQuery) SelectList(ctx context.Context, tx *wrap.Tx) (list []*SmsCode, err error) {
 	queryString, params := q.buildSelectQuery()
 	query := bytes.NewBufferString("")
 	if len(q.getFields) == 0 {
-		query.WriteString("SELECT id,user_id,refresh_token,create_time,update_time FROM refresh_token ")
+		query.WriteString("SELECT id,sms_scene,phone_encrypted,sms_code,user_id,is_used,attempts,is_hashed,create_time,update_time FROM sms_code ")
 	} else {
 		query.WriteString("SELECT ")
 		query.WriteString(strings.Join(q.getFields, ","))
-		query.WriteString(" FROM refresh_token ")
+		query.WriteString(" FROM sms_code ")
 	}
 	query.WriteString(queryString)
 	rows, err := q.dao.db.Query(ctx, tx, query.String(), params...)
@@ -3358,8 +9820,8 @@ func (q *RefreshTokenQuery) SelectList(ctx context.Context, tx *wrap.Tx) (list [
 		return nil, err
 	}
 	for rows.Next() {
-		e := RefreshToken{}
-		err = rows.Scan(&e.Id, &e.UserId, &e.RefreshToken, &e.CreateTime, &e.UpdateTime)
+		e := SmsCode{}
+		err = rows.Scan(&e.Id, &e.SmsScene, &e.PhoneEncrypted, &e.SmsCode, &e.UserId, &e.IsUsed, &e.Attempts, &e.IsHashed, &e.CreateTime, &e.UpdateTime)
 		if err != nil {
 			return nil, err
 		}
@@ -3373,10 +9835,10 @@ func (q *RefreshTokenQuery) SelectList(ctx context.Context, tx *wrap.Tx) (list [
 	return list, nil
 }
 
-func (q *RefreshTokenQuery) SelectCount(ctx context.Context, tx *wrap.Tx) (count int64, err error) {
+func (q *SmsCodeQuery) SelectCount(ctx context.Context, tx *wrap.Tx) (count int64, err error) {
 	queryString, params := q.buildSelectQuery()
 	query := bytes.NewBufferString("")
-	query.WriteString("SELECT COUNT(*) FROM refresh_token ")
+	query.WriteString("SELECT COUNT(*) FROM sms_code ")
 	query.WriteString(queryString)
 	row := q.dao.db.QueryRow(ctx, tx, query.String(), params...)
 	err = row.Scan(&count)
@@ -3384,7 +9846,7 @@ func (q *RefreshTokenQuery) SelectCount(ctx context.Context, tx *wrap.Tx) (count
 	return count, err
 }
 
-func (q *RefreshTokenQuery) SelectGroupBy(ctx context.Context, tx *wrap.Tx, withCount bool) (rows *wrap.Rows, err error) {
+func (q *SmsCodeQuery) SelectGroupBy(ctx context.Context, tx *wrap.Tx, withCount bool) (rows *wrap.Rows, err error) {
 	queryString, params := q.buildSelectQuery()
 	query := bytes.NewBufferString("")
 	query.WriteString("SELECT ")
@@ -3392,13 +9854,13 @@ func (q *RefreshTokenQuery) SelectGroupBy(ctx context.Context, tx *wrap.Tx, with
 	if withCount {
 		query.WriteString(",MachineListCount(*) ")
 	}
-	query.WriteString(" FROM refresh_token ")
+	query.WriteString(" FROM sms_code ")
 	query.WriteString(queryString)
 
 	return q.dao.db.Query(ctx, tx, query.String(), params...)
 }
 
-func (q *RefreshTokenQuery) SelectRow(ctx context.Context, tx *wrap.Tx) (row *wrap.Row) {
+func (q *SmsCodeQuery) SelectRow(ctx context.Context, tx *wrap.Tx) (row *wrap.Row) {
 	if !q.hasLimit {
 		q.limitCount = 1
 		q.hasLimit = true
@@ -3408,82 +9870,53 @@ func (q *RefreshTokenQuery) SelectRow(ctx context.Context, tx *wrap.Tx) (row *wr
 	query := bytes.NewBufferString("")
 	query.WriteString("SELECT ")
 	query.WriteString(strings.Join(q.getFields, ","))
-	query.WriteString(" FROM refresh_token ")
-	query.WriteString(queryString)
-	return q.dao.db.QueryRow(ctx, tx, query.String(), params...)
-}
-
-func (q *RefreshTokenQuery) SelectRows(ctx context.Context, tx *wrap.Tx) (rows *wrap.Rows, err error) {
-	queryString, params := q.buildSelectQuery()
-	query := bytes.NewBufferString("")
-	query.WriteString("SELECT ")
-	query.WriteString(strings.Join(q.getFields, ","))
-	query.WriteString(" FROM refresh_token ")
-	query.WriteString(queryString)
-	return q.dao.db.Query(ctx, tx, query.String(), params...)
-}
-
-func (q *RefreshTokenQuery) Insert(ctx context.Context, tx *wrap.Tx, e *RefreshToken) (result *wrap.Result, err error) {
-	query := bytes.NewBufferString("")
-	query.WriteString("INSERT INTO refresh_token (user_id,refresh_token) VALUES (?,?)")
-	params := []interface{}{e.UserId, e.RefreshToken}
-	return q.dao.db.Exec(ctx, tx, query.String(), params...)
-}
-
-func (q *RefreshTokenQuery) BatchInsert(ctx context.Context, tx *wrap.Tx, list []*RefreshToken) (result *wrap.Result, err error) {
-	query := bytes.NewBufferString("")
-	query.WriteString("INSERT INTO refresh_token (user_id,refresh_token) VALUES ")
-	query.WriteString(wrap.RepeatWithSeparator("(?,?)", len(list), ","))
-	params := make([]interface{}, len(list)*2)
-	offset := 0
-	for _, e := range list {
-		params[offset+0] = e.UserId
-		params[offset+1] = e.RefreshToken
-		offset += 2
-	}
+	query.WriteString(" FROM sms_code ")
+	query.WriteString(queryString)
+	return q.dao.db.QueryRow(ctx, tx, query.String(), params...)
+}
 
-	return q.dao.db.Exec(ctx, tx, query.String(), params...)
+func (q *SmsCodeQuery) SelectRows(ctx context.Context, tx *wrap.Tx) (rows *wrap.Rows, err error) {
+	queryString, params := q.buildSelectQuery()
+	query := bytes.NewBufferString("")
+	query.WriteString("SELECT ")
+	query.WriteString(strings.Join(q.getFields, ","))
+	query.WriteString(" FROM sms_code ")
+	query.WriteString(queryString)
+	return q.dao.db.Query(ctx, tx, query.String(), params...)
 }
 
-func (q *RefreshTokenQuery) InsertOnDuplicatedKeyUpdate(ctx context.Context, tx *wrap.Tx, e *RefreshToken) (result *wrap.Result, err error) {
+func (q *SmsCodeQuery) Insert(ctx context.Context, tx *wrap.Tx, e *SmsCode) (result *wrap.Result, err error) {
 	query := bytes.NewBufferString("")
-	query.WriteString("INSERT INTO refresh_token (user_id,refresh_token) VALUES (?,?)")
-	query.WriteString(" ON DUPLICATED KEY UPDATE ")
-	if len(q.duplicatedUpdateFields) > 0 {
-		query.WriteString(strings.Join(q.duplicatedUpdateFields, ","))
-		query.WriteString(",")
-	}
-	query.WriteString("update_time=now()")
-	params := []interface{}{e.UserId, e.RefreshToken}
+	query.WriteString("INSERT INTO sms_code (sms_scene,phone_encrypted,sms_code,user_id,is_used,attempts,is_hashed) VALUES (?,?,?,?,?,?,?)")
+	params := []interface{}{e.SmsScene, e.PhoneEncrypted, e.SmsCode, e.UserId, e.IsUsed, e.Attempts, e.IsHashed}
 	return q.dao.db.Exec(ctx, tx, query.String(), params...)
 }
 
-func (q *RefreshTokenQuery) BatchInsertOnDuplicatedKeyUpdate(ctx context.Context, tx *wrap.Tx, list []*RefreshToken) (result *wrap.Result, err error) {
+func (q *SmsCodeQuery) BatchInsert(ctx context.Context, tx *wrap.Tx, list []*SmsCode) (result *wrap.Result, err error) {
 	query := bytes.NewBufferString("")
-	query.WriteString("INSERT INTO refresh_token (user_id,refresh_token) VALUES ")
-	query.WriteString(wrap.RepeatWithSeparator("(?,?)", len(list), ","))
-	query.WriteString(" ON DUPLICATED KEY UPDATE")
-	if len(q.duplicatedUpdateFields) > 0 {
-		query.WriteString(strings.Join(q.duplicatedUpdateFields, ","))
-		query.WriteString(",")
-	}
-	query.WriteString("update_time=now()")
-	params := make([]interface{}, len(list)*2)
+	query.WriteString("INSERT INTO sms_code (sms_scene,phone_encrypted,sms_code,user_id,is_used,attempts,is_hashed) VALUES ")
+	query.WriteString(wrap.RepeatWithSeparator("(?,?,?,?,?,?,?)", len(list), ","))
+	params := make([]interface{}, len(list)*7)
 	offset := 0
 	for _, e := range list {
-		params[offset+0] = e.UserId
-		params[offset+1] = e.RefreshToken
-		offset += 2
+		params[offset+0] = e.SmsScene
+		params[offset+1] = e.PhoneEncrypted
+		params[offset+2] = e.SmsCode
+		params[offset+3] = e.UserId
+		params[offset+4] = e.IsUsed
+		params[offset+5] = e.Attempts
+		params[offset+6] = e.IsHashed
+		offset += 7
 	}
 
 	return q.dao.db.Exec(ctx, tx, query.String(), params...)
 }
 
-func (q *RefreshTokenQuery) Update(ctx context.Context, tx *wrap.Tx) (result *wrap.Result, err error) {
+func (q *SmsCodeQuery) Update(ctx context.Context, tx *wrap.Tx) (result *wrap.Result, err error) {
 	query := bytes.NewBufferString("")
 	var params []interface{}
 	params = append(params, q.updateParams...)
-	query.WriteString("UPDATE refresh_token SET ")
+	query.WriteString("UPDATE sms_code SET ")
 	updateItems := make([]string, len(q.updateFields))
 	for i, v := range q.updateFields {
 		updateItems[i] = v + "=?"
@@ -3500,109 +9933,109 @@ func (q *RefreshTokenQuery) Update(ctx context.Context, tx *wrap.Tx) (result *wr
 	return q.dao.db.Exec(ctx, tx, query.String(), params...)
 }
 
-func (q *RefreshTokenQuery) Delete(ctx context.Context, tx *wrap.Tx) (result *wrap.Result, err error) {
-	query := "DELETE FROM refresh_token WHERE " + q.where.String()
+func (q *SmsCodeQuery) Delete(ctx context.Context, tx *wrap.Tx) (result *wrap.Result, err error) {
+	query := "DELETE FROM sms_code WHERE " + q.where.String()
 	return q.dao.db.Exec(ctx, tx, query, q.whereParams...)
 }
 
-type RefreshTokenDao struct {
+type SmsCodeDao struct {
 	logger *zap.Logger
 	db     *DB
 }
 
-func NewRefreshTokenDao(db *DB) (t *RefreshTokenDao, err error) {
-	t = &RefreshTokenDao{}
+func NewSmsCodeDao(db *DB) (t *SmsCodeDao, err error) {
+	t = &SmsCodeDao{}
 	t.logger = log.TypedLogger(t)
 	t.db = db
 
 	return t, nil
 }
 
-func (dao *RefreshTokenDao) Query() *RefreshTokenQuery {
-	q := &RefreshTokenQuery{}
+func (dao *SmsCodeDao) Query() *SmsCodeQuery {
+	q := &SmsCodeQuery{}
 	q.dao = dao
-	q.tableName = "refresh_token"
+	q.tableName = "sms_code"
 	q.where = bytes.NewBufferString("")
 	return q
 }
 
-type SmsCode struct {
-	Id             uint64 //size=20
-	SmsScene       string //size=32
-	PhoneEncrypted string //size=32
-	SmsCode        string //size=8
-	UserId         string //size=32
-	CreateTime     time.Time
-	UpdateTime     time.Time
+type UserInfo struct {
+	Id           uint64 //size=20
+	UserId       string //size=32
+	UserName     string //size=32
+	UserIcon     string //size=256
+	PasswordHash string //size=1024
+	CreateTime   time.Time
+	UpdateTime   time.Time
 }
 
-type SmsCodeQuery struct {
+type UserInfoQuery struct {
 	QueryBase
-	dao *SmsCodeDao
+	dao *UserInfoDao
 }
 
-func (q *SmsCodeQuery) Left() *SmsCodeQuery {
+func (q *UserInfoQuery) Left() *UserInfoQuery {
 	q.where.WriteString(" (")
 	return q
 }
 
-func (q *SmsCodeQuery) Right() *SmsCodeQuery {
+func (q *UserInfoQuery) Right() *UserInfoQuery {
 	q.where.WriteString(" )")
 	return q
 }
 
-func (q *SmsCodeQuery) And() *SmsCodeQuery {
+func (q *UserInfoQuery) And() *UserInfoQuery {
 	q.where.WriteString(" AND")
 	return q
 }
 
-func (q *SmsCodeQuery) Or() *SmsCodeQuery {
+func (q *UserInfoQuery) Or() *UserInfoQuery {
 	q.where.WriteString(" OR")
 	return q
 }
 
-func (q *SmsCodeQuery) Not() *SmsCodeQuery {
+func (q *UserInfoQuery) Not() *UserInfoQuery {
 	q.where.WriteString(" NOT")
 	return q
 }
 
-func (q *SmsCodeQuery) IdEqual(v uint64) *SmsCodeQuery {
+func (q *UserInfoQuery) IdEqual(v uint64) *UserInfoQuery {
 	q.where.WriteString(" id=?")
 	q.whereParams = append(q.whereParams, v)
 	return q
 }
 
-func (q *SmsCodeQuery) IdNotEqual(v uint64) *SmsCodeQuery {
+func (q *UserInfoQuery) IdNotEqual(v uint64) *UserInfoQuery {
 	q.where.WriteString(" id<>?")
 	q.whereParams = append(q.whereParams, v)
 	return q
 }
 
-func (q *SmsCodeQuery) IdLess(v uint64) *SmsCodeQuery {
+func (q *UserInfoQuery) IdLess(v uint64) *UserInfoQuery {
 	q.where.WriteString(" id<?")
 	q.whereParams = append(q.whereParams, v)
 	return q
 }
 
-func (q *SmsCodeQuery) IdLessEqual(v uint64) *SmsCodeQuery {
+func (q *UserInfoQuery) IdLessEqual(v uint64) *UserInfoQuery {
 	q.where.WriteString(" id<=?")
 	q.whereParams = append(q.whereParams, v)
 	return q
 }
 
-func (q *SmsCodeQuery) IdGreater(v uint64) *SmsCodeQuery {
+func (q *UserInfoQuery) IdGreater(v uint64) *UserInfoQuery {
 	q.where.WriteString(" id>?")
 	q.whereParams = append(q.whereParams, v)
 	return q
 }
 
-func (q *SmsCodeQuery) IdGreaterEqual(v uint64) *SmsCodeQuery {
+func (q *UserInfoQuery) IdGreaterEqual(v uint64) *UserInfoQuery {
 	q.where.WriteString(" id>=?")
 	q.whereParams = append(q.whereParams, v)
 	return q
 }
 
-func (q *SmsCodeQuery) IdIn(items []uint64) *SmsCodeQuery {
+func (q *UserInfoQuery) IdIn(items []uint64) *UserInfoQuery {
 	q.where.WriteString(" id IN(")
 	q.where.WriteString(wrap.RepeatWithSeparator("?", len(items), ","))
 	q.where.WriteString(")")
@@ -3610,327 +10043,305 @@ func (q *SmsCodeQuery) IdIn(items []uint64) *SmsCodeQuery {
 	return q
 }
 
-func (q *SmsCodeQuery) SmsSceneEqual(v string) *SmsCodeQuery {
-	q.where.WriteString(" sms_scene=?")
+func (q *UserInfoQuery) UserIdEqual(v string) *UserInfoQuery {
+	q.where.WriteString(" user_id=?")
 	q.whereParams = append(q.whereParams, v)
 	return q
 }
 
-func (q *SmsCodeQuery) SmsSceneNotEqual(v string) *SmsCodeQuery {
-	q.where.WriteString(" sms_scene<>?")
+func (q *UserInfoQuery) UserIdNotEqual(v string) *UserInfoQuery {
+	q.where.WriteString(" user_id<>?")
 	q.whereParams = append(q.whereParams, v)
 	return q
 }
 
-func (q *SmsCodeQuery) SmsSceneIn(items []string) *SmsCodeQuery {
-	q.where.WriteString(" sms_scene IN(")
+func (q *UserInfoQuery) UserIdIn(items []string) *UserInfoQuery {
+	q.where.WriteString(" user_id IN(")
 	q.where.WriteString(wrap.RepeatWithSeparator("?", len(items), ","))
 	q.where.WriteString(")")
 	q.whereParams = append(q.whereParams, items)
 	return q
 }
 
-func (q *SmsCodeQuery) PhoneEncryptedEqual(v string) *SmsCodeQuery {
-	q.where.WriteString(" phone_encrypted=?")
+func (q *UserInfoQuery) UserNameEqual(v string) *UserInfoQuery {
+	q.where.WriteString(" user_name=?")
 	q.whereParams = append(q.whereParams, v)
 	return q
 }
 
-func (q *SmsCodeQuery) PhoneEncryptedNotEqual(v string) *SmsCodeQuery {
-	q.where.WriteString(" phone_encrypted<>?")
+func (q *UserInfoQuery) UserNameNotEqual(v string) *UserInfoQuery {
+	q.where.WriteString(" user_name<>?")
 	q.whereParams = append(q.whereParams, v)
 	return q
 }
 
-func (q *SmsCodeQuery) PhoneEncryptedIn(items []string) *SmsCodeQuery {
-	q.where.WriteString(" phone_encrypted IN(")
+func (q *UserInfoQuery) UserNameIn(items []string) *UserInfoQuery {
+	q.where.WriteString(" user_name IN(")
 	q.where.WriteString(wrap.RepeatWithSeparator("?", len(items), ","))
 	q.where.WriteString(")")
 	q.whereParams = append(q.whereParams, items)
 	return q
 }
 
-func (q *SmsCodeQuery) SmsCodeEqual(v string) *SmsCodeQuery {
-	q.where.WriteString(" sms_code=?")
+func (q *UserInfoQuery) UserIconEqual(v string) *UserInfoQuery {
+	q.where.WriteString(" user_icon=?")
 	q.whereParams = append(q.whereParams, v)
 	return q
 }
 
-func (q *SmsCodeQuery) SmsCodeNotEqual(v string) *SmsCodeQuery {
-	q.where.WriteString(" sms_code<>?")
+func (q *UserInfoQuery) UserIconNotEqual(v string) *UserInfoQuery {
+	q.where.WriteString(" user_icon<>?")
 	q.whereParams = append(q.whereParams, v)
 	return q
 }
 
-func (q *SmsCodeQuery) SmsCodeIn(items []string) *SmsCodeQuery {
-	q.where.WriteString(" sms_code IN(")
+func (q *UserInfoQuery) UserIconIn(items []string) *UserInfoQuery {
+	q.where.WriteString(" user_icon IN(")
 	q.where.WriteString(wrap.RepeatWithSeparator("?", len(items), ","))
 	q.where.WriteString(")")
 	q.whereParams = append(q.whereParams, items)
 	return q
 }
 
-func (q *SmsCodeQuery) UserIdEqual(v string) *SmsCodeQuery {
-	q.where.WriteString(" user_id=?")
+func (q *UserInfoQuery) PasswordHashEqual(v string) *UserInfoQuery {
+	q.where.WriteString(" password_hash=?")
 	q.whereParams = append(q.whereParams, v)
 	return q
 }
 
-func (q *SmsCodeQuery) UserIdNotEqual(v string) *SmsCodeQuery {
-	q.where.WriteString(" user_id<>?")
+func (q *UserInfoQuery) PasswordHashNotEqual(v string) *UserInfoQuery {
+	q.where.WriteString(" password_hash<>?")
 	q.whereParams = append(q.whereParams, v)
 	return q
 }
 
-func (q *SmsCodeQuery) UserIdIn(items []string) *SmsCodeQuery {
-	q.where.WriteString(" user_id IN(")
+func (q *UserInfoQuery) PasswordHashIn(items []string) *UserInfoQuery {
+	q.where.WriteString(" password_hash IN(")
 	q.where.WriteString(wrap.RepeatWithSeparator("?", len(items), ","))
 	q.where.WriteString(")")
 	q.whereParams = append(q.whereParams, items)
 	return q
 }
 
-func (q *SmsCodeQuery) CreateTimeEqual(v time.Time) *SmsCodeQuery {
+func (q *UserInfoQuery) CreateTimeEqual(v time.Time) *UserInfoQuery {
 	q.where.WriteString(" create_time=?")
 	q.whereParams = append(q.whereParams, v)
 	return q
 }
 
-func (q *SmsCodeQuery) CreateTimeNotEqual(v time.Time) *SmsCodeQuery {
+func (q *UserInfoQuery) CreateTimeNotEqual(v time.Time) *UserInfoQuery {
 	q.where.WriteString(" create_time<>?")
 	q.whereParams = append(q.whereParams, v)
 	return q
 }
 
-func (q *SmsCodeQuery) CreateTimeLess(v time.Time) *SmsCodeQuery {
+func (q *UserInfoQuery) CreateTimeLess(v time.Time) *UserInfoQuery {
 	q.where.WriteString(" create_time<?")
 	q.whereParams = append(q.whereParams, v)
 	return q
 }
 
-func (q *SmsCodeQuery) CreateTimeLessEqual(v time.Time) *SmsCodeQuery {
+func (q *UserInfoQuery) CreateTimeLessEqual(v time.Time) *UserInfoQuery {
 	q.where.WriteString(" create_time<=?")
 	q.whereParams = append(q.whereParams, v)
 	return q
 }
 
-func (q *SmsCodeQuery) CreateTimeGreater(v time.Time) *SmsCodeQuery {
+func (q *UserInfoQuery) CreateTimeGreater(v time.Time) *UserInfoQuery {
 	q.where.WriteString(" create_time>?")
 	q.whereParams = append(q.whereParams, v)
 	return q
 }
 
-func (q *SmsCodeQuery) CreateTimeGreaterEqual(v time.Time) *SmsCodeQuery {
+func (q *UserInfoQuery) CreateTimeGreaterEqual(v time.Time) *UserInfoQuery {
 	q.where.WriteString(" create_time>=?")
 	q.whereParams = append(q.whereParams, v)
 	return q
 }
 
-func (q *SmsCodeQuery) UpdateTimeEqual(v time.Time) *SmsCodeQuery {
+func (q *UserInfoQuery) UpdateTimeEqual(v time.Time) *UserInfoQuery {
 	q.where.WriteString(" update_time=?")
 	q.whereParams = append(q.whereParams, v)
 	return q
 }
 
-func (q *SmsCodeQuery) UpdateTimeNotEqual(v time.Time) *SmsCodeQuery {
+func (q *UserInfoQuery) UpdateTimeNotEqual(v time.Time) *UserInfoQuery {
 	q.where.WriteString(" update_time<>?")
 	q.whereParams = append(q.whereParams, v)
 	return q
 }
 
-func (q *SmsCodeQuery) UpdateTimeLess(v time.Time) *SmsCodeQuery {
+func (q *UserInfoQuery) UpdateTimeLess(v time.Time) *UserInfoQuery {
 	q.where.WriteString(" update_time<?")
 	q.whereParams = append(q.whereParams, v)
 	return q
 }
 
-func (q *SmsCodeQuery) UpdateTimeLessEqual(v time.Time) *SmsCodeQuery {
+func (q *UserInfoQuery) UpdateTimeLessEqual(v time.Time) *UserInfoQuery {
 	q.where.WriteString(" update_time<=?")
 	q.whereParams = append(q.whereParams, v)
 	return q
 }
 
-func (q *SmsCodeQuery) UpdateTimeGreater(v time.Time) *SmsCodeQuery {
+func (q *UserInfoQuery) UpdateTimeGreater(v time.Time) *UserInfoQuery {
 	q.where.WriteString(" update_time>?")
 	q.whereParams = append(q.whereParams, v)
 	return q
 }
 
-func (q *SmsCodeQuery) UpdateTimeGreaterEqual(v time.Time) *SmsCodeQuery {
+func (q *UserInfoQuery) UpdateTimeGreaterEqual(v time.Time) *UserInfoQuery {
 	q.where.WriteString(" update_time>=?")
 	q.whereParams = append(q.whereParams, v)
 	return q
 }
 
-func (q *SmsCodeQuery) GroupBySmsScene(asc bool) *SmsCodeQuery {
-	q.groupByFields = append(q.groupByFields, "sms_scene")
-	q.groupByOrders = append(q.groupByOrders, asc)
-	return q
-}
-
-func (q *SmsCodeQuery) GroupByPhoneEncrypted(asc bool) *SmsCodeQuery {
-	q.groupByFields = append(q.groupByFields, "phone_encrypted")
-	q.groupByOrders = append(q.groupByOrders, asc)
-	return q
-}
-
-func (q *SmsCodeQuery) GroupBySmsCode(asc bool) *SmsCodeQuery {
-	q.groupByFields = append(q.groupByFields, "sms_code")
+func (q *UserInfoQuery) GroupByUserIcon(asc bool) *UserInfoQuery {
+	q.groupByFields = append(q.groupByFields, "user_icon")
 	q.groupByOrders = append(q.groupByOrders, asc)
 	return q
 }
 
-func (q *SmsCodeQuery) GroupByUserId(asc bool) *SmsCodeQuery {
-	q.groupByFields = append(q.groupByFields, "user_id")
+func (q *UserInfoQuery) GroupByPasswordHash(asc bool) *UserInfoQuery {
+	q.groupByFields = append(q.groupByFields, "password_hash")
 	q.groupByOrders = append(q.groupByOrders, asc)
 	return q
 }
 
-func (q *SmsCodeQuery) OrderById(asc bool) *SmsCodeQuery {
+func (q *UserInfoQuery) OrderById(asc bool) *UserInfoQuery {
 	q.orderByFields = append(q.orderByFields, "id")
 	q.orderByOrders = append(q.orderByOrders, asc)
 	return q
 }
 
-func (q *SmsCodeQuery) OrderBySmsScene(asc bool) *SmsCodeQuery {
-	q.orderByFields = append(q.orderByFields, "sms_scene")
+func (q *UserInfoQuery) OrderByUserId(asc bool) *UserInfoQuery {
+	q.orderByFields = append(q.orderByFields, "user_id")
 	q.orderByOrders = append(q.orderByOrders, asc)
 	return q
 }
 
-func (q *SmsCodeQuery) OrderByPhoneEncrypted(asc bool) *SmsCodeQuery {
-	q.orderByFields = append(q.orderByFields, "phone_encrypted")
+func (q *UserInfoQuery) OrderByUserName(asc bool) *UserInfoQuery {
+	q.orderByFields = append(q.orderByFields, "user_name")
 	q.orderByOrders = append(q.orderByOrders, asc)
 	return q
 }
 
-func (q *SmsCodeQuery) OrderBySmsCode(asc bool) *SmsCodeQuery {
-	q.orderByFields = append(q.orderByFields, "sms_code")
+func (q *UserInfoQuery) OrderByUserIcon(asc bool) *UserInfoQuery {
+	q.orderByFields = append(q.orderByFields, "user_icon")
 	q.orderByOrders = append(q.orderByOrders, asc)
 	return q
 }
 
-func (q *SmsCodeQuery) OrderByUserId(asc bool) *SmsCodeQuery {
-	q.orderByFields = append(q.orderByFields, "user_id")
+func (q *UserInfoQuery) OrderByPasswordHash(asc bool) *UserInfoQuery {
+	q.orderByFields = append(q.orderByFields, "password_hash")
 	q.orderByOrders = append(q.orderByOrders, asc)
 	return q
 }
 
-func (q *SmsCodeQuery) OrderByCreateTime(asc bool) *SmsCodeQuery {
+func (q *UserInfoQuery) OrderByCreateTime(asc bool) *UserInfoQuery {
 	q.orderByFields = append(q.orderByFields, "create_time")
 	q.orderByOrders = append(q.orderByOrders, asc)
 	return q
 }
 
-func (q *SmsCodeQuery) OrderByUpdateTime(asc bool) *SmsCodeQuery {
+func (q *UserInfoQuery) OrderByUpdateTime(asc bool) *UserInfoQuery {
 	q.orderByFields = append(q.orderByFields, "update_time")
 	q.orderByOrders = append(q.orderByOrders, asc)
 	return q
 }
 
-func (q *SmsCodeQuery) OrderByGroupCount(asc bool) *SmsCodeQuery {
+func (q *UserInfoQuery) OrderByGroupCount(asc bool) *UserInfoQuery {
 	q.orderByFields = append(q.orderByFields, "count(*)")
 	q.orderByOrders = append(q.orderByOrders, asc)
 	return q
 }
 
-func (q *SmsCodeQuery) Limit(startIncluded int64, count int64) *SmsCodeQuery {
+func (q *UserInfoQuery) Limit(startIncluded int64, count int64) *UserInfoQuery {
 	q.hasLimit = true
 	q.limitStartIncluded = startIncluded
 	q.limitCount = count
 	return q
 }
 
-func (q *SmsCodeQuery) ForUpdate() *SmsCodeQuery {
+func (q *UserInfoQuery) ForUpdate() *UserInfoQuery {
 	q.forUpdate = true
 	return q
 }
 
-func (q *SmsCodeQuery) ForShare() *SmsCodeQuery {
+func (q *UserInfoQuery) ForShare() *UserInfoQuery {
 	q.forShare = true
 	return q
 }
 
-func (q *SmsCodeQuery) SetSmsScene(v string) *SmsCodeQuery {
-	q.updateFields = append(q.updateFields, "sms_scene")
+func (q *UserInfoQuery) SetUserId(v string) *UserInfoQuery {
+	q.updateFields = append(q.updateFields, "user_id")
 	q.updateParams = append(q.updateParams, v)
 	return q
 }
 
-func (q *SmsCodeQuery) SetPhoneEncrypted(v string) *SmsCodeQuery {
-	q.updateFields = append(q.updateFields, "phone_encrypted")
+func (q *UserInfoQuery) SetUserName(v string) *UserInfoQuery {
+	q.updateFields = append(q.updateFields, "user_name")
 	q.updateParams = append(q.updateParams, v)
 	return q
 }
 
-func (q *SmsCodeQuery) SetSmsCode(v string) *SmsCodeQuery {
-	q.updateFields = append(q.updateFields, "sms_code")
+func (q *UserInfoQuery) SetUserIcon(v string) *UserInfoQuery {
+	q.updateFields = append(q.updateFields, "user_icon")
 	q.updateParams = append(q.updateParams, v)
 	return q
 }
 
-func (q *SmsCodeQuery) SetUserId(v string) *SmsCodeQuery {
-	q.updateFields = append(q.updateFields, "user_id")
+func (q *UserInfoQuery) SetPasswordHash(v string) *UserInfoQuery {
+	q.updateFields = append(q.updateFields, "password_hash")
 	q.updateParams = append(q.updateParams, v)
 	return q
 }
 
-func (q *SmsCodeQuery) DuplicatedUpdateSmsScene() *SmsCodeQuery {
-	q.duplicatedUpdateFields = append(q.duplicatedUpdateFields, "sms_scene=VALUES(sms_scene)")
-	return q
-}
-
-func (q *SmsCodeQuery) DuplicatedUpdatePhoneEncrypted() *SmsCodeQuery {
-	q.duplicatedUpdateFields = append(q.duplicatedUpdateFields, "phone_encrypted=VALUES(phone_encrypted)")
-	return q
-}
-
-func (q *SmsCodeQuery) DuplicatedUpdateSmsCode() *SmsCodeQuery {
-	q.duplicatedUpdateFields = append(q.duplicatedUpdateFields, "sms_code=VALUES(sms_code)")
+func (q *UserInfoQuery) DuplicatedUpdateUserIcon() *UserInfoQuery {
+	q.duplicatedUpdateFields = append(q.duplicatedUpdateFields, "user_icon=VALUES(user_icon)")
 	return q
 }
 
-func (q *SmsCodeQuery) DuplicatedUpdateUserId() *SmsCodeQuery {
-	q.duplicatedUpdateFields = append(q.duplicatedUpdateFields, "user_id=VALUES(user_id)")
+func (q *UserInfoQuery) DuplicatedUpdatePasswordHash() *UserInfoQuery {
+	q.duplicatedUpdateFields = append(q.duplicatedUpdateFields, "password_hash=VALUES(password_hash)")
 	return q
 }
 
-func (q *SmsCodeQuery) GetId() *SmsCodeQuery {
+func (q *UserInfoQuery) GetId() *UserInfoQuery {
 	q.getFields = append(q.getFields, "id")
 	return q
 }
 
-func (q *SmsCodeQuery) GetSmsScene() *SmsCodeQuery {
-	q.getFields = append(q.getFields, "sms_scene")
+func (q *UserInfoQuery) GetUserId() *UserInfoQuery {
+	q.getFields = append(q.getFields, "user_id")
 	return q
 }
 
-func (q *SmsCodeQuery) GetPhoneEncrypted() *SmsCodeQuery {
-	q.getFields = append(q.getFields, "phone_encrypted")
+func (q *UserInfoQuery) GetUserName() *UserInfoQuery {
+	q.getFields = append(q.getFields, "user_name")
 	return q
 }
 
-func (q *SmsCodeQuery) GetSmsCode() *SmsCodeQuery {
-	q.getFields = append(q.getFields, "sms_code")
+func (q *UserInfoQuery) GetUserIcon() *UserInfoQuery {
+	q.getFields = append(q.getFields, "user_icon")
 	return q
 }
 
-func (q *SmsCodeQuery) GetUserId() *SmsCodeQuery {
-	q.getFields = append(q.getFields, "user_id")
+func (q *UserInfoQuery) GetPasswordHash() *UserInfoQuery {
+	q.getFields = append(q.getFields, "password_hash")
 	return q
 }
 
-func (q *SmsCodeQuery) GetCreateTime() *SmsCodeQuery {
+func (q *UserInfoQuery) GetCreateTime() *UserInfoQuery {
 	q.getFields = append(q.getFields, "create_time")
 	return q
 }
 
-func (q *SmsCodeQuery) GetUpdateTime() *SmsCodeQuery {
+func (q *UserInfoQuery) GetUpdateTime() *UserInfoQuery {
 	q.getFields = append(q.getFields, "update_time")
 	return q
 }
 
-func (q *SmsCodeQuery) Select(ctx context.Context, tx *wrap.Tx) (e *SmsCode, err error) {
+func (q *UserInfoQuery) Select(ctx context.Context, tx *wrap.Tx) (e *UserInfo, err error) {
 	if !q.hasLimit {
 		q.limitCount = 1
 		q.hasLimit = true
@@ -3939,16 +10350,16 @@ func (q *SmsCodeQuery) Select(ctx context.Context, tx *wrap.Tx) (e *SmsCode, err
 	queryString, params := q.buildSelectQuery()
 	query := bytes.NewBufferString("")
 	if len(q.getFields) == 0 {
-		query.WriteString("SELECT id,sms_scene,phone_encrypted,sms_code,user_id,create_time,update_time FROM sms_code ")
+		query.WriteString("SELECT id,user_id,user_name,user_icon,password_hash,create_time,update_time FROM user_info ")
 	} else {
 		query.WriteString("SELECT ")
 		query.WriteString(strings.Join(q.getFields, ","))
-		query.WriteString(" FROM sms_code ")
+		query.WriteString(" FROM user_info ")
 	}
 	query.WriteString(queryString)
-	e = &SmsCode{}
+	e = &UserInfo{}
 	row := q.dao.db.QueryRow(ctx, tx, query.String(), params...)
-	err = row.Scan(&e.Id, &e.SmsScene, &e.PhoneEncrypted, &e.SmsCode, &e.UserId, &e.CreateTime, &e.UpdateTime)
+	err = row.Scan(&e.Id, &e.UserId, &e.UserName, &e.UserIcon, &e.PasswordHash, &e.CreateTime, &e.UpdateTime)
 	if err == wrap.ErrNoRows {
 		return nil, nil
 	}
@@ -3956,15 +10367,15 @@ func (q *SmsCodeQuery) Select(ctx context.Context, tx *wrap.Tx) (e *SmsCode, err
 	return e, err
 }
 
-func (q *SmsCodeQuery) SelectList(ctx context.Context, tx *wrap.Tx) (list []*SmsCode, err error) {
+func (q *UserInfoQuery) SelectList(ctx context.Context, tx *wrap.Tx) (list []*UserInfo, err error) {
 	queryString, params := q.buildSelectQuery()
 	query := bytes.NewBufferString("")
 	if len(q.getFields) == 0 {
-		query.WriteString("SELECT id,sms_scene,phone_encrypted,sms_code,user_id,create_time,update_time FROM sms_code ")
+		query.WriteString("SELECT id,user_id,user_name,user_icon,password_hash,create_time,update_time FROM user_info ")
 	} else {
 		query.WriteString("SELECT ")
 		query.WriteString(strings.Join(q.getFields, ","))
-		query.WriteString(" FROM sms_code ")
+		query.WriteString(" FROM user_info ")
 	}
 	query.WriteString(queryString)
 	rows, err := q.dao.db.Query(ctx, tx, query.String(), params...)
@@ -3972,8 +10383,8 @@ func (q *SmsCodeQuery) SelectList(ctx context.Context, tx *wrap.Tx) (list []*Sms
 		return nil, err
 	}
 	for rows.Next() {
-		e := SmsCode{}
-		err = rows.Scan(&e.Id, &e.SmsScene, &e.PhoneEncrypted, &e.SmsCode, &e.UserId, &e.CreateTime, &e.UpdateTime)
+		e := UserInfo{}
+		err = rows.Scan(&e.Id, &e.UserId, &e.UserName, &e.UserIcon, &e.PasswordHash, &e.CreateTime, &e.UpdateTime)
 		if err != nil {
 			return nil, err
 		}
@@ -3987,10 +10398,10 @@ func (q *SmsCodeQuery) SelectList(ctx context.Context, tx *wrap.Tx) (list []*Sms
 	return list, nil
 }
 
-func (q *SmsCodeQuery) SelectCount(ctx context.Context, tx *wrap.Tx) (count int64, err error) {
+func (q *UserInfoQuery) SelectCount(ctx context.Context, tx *wrap.Tx) (count int64, err error) {
 	queryString, params := q.buildSelectQuery()
 	query := bytes.NewBufferString("")
-	query.WriteString("SELECT COUNT(*) FROM sms_code ")
+	query.WriteString("SELECT COUNT(*) FROM user_info ")
 	query.WriteString(queryString)
 	row := q.dao.db.QueryRow(ctx, tx, query.String(), params...)
 	err = row.Scan(&count)
@@ -3998,7 +10409,7 @@ func (q *SmsCodeQuery) SelectCount(ctx context.Context, tx *wrap.Tx) (count int6
 	return count, err
 }
 
-func (q *SmsCodeQuery) SelectGroupBy(ctx context.Context, tx *wrap.Tx, withCount bool) (rows *wrap.Rows, err error) {
+func (q *UserInfoQuery) SelectGroupBy(ctx context.Context, tx *wrap.Tx, withCount bool) (rows *wrap.Rows, err error) {
 	queryString, params := q.buildSelectQuery()
 	query := bytes.NewBufferString("")
 	query.WriteString("SELECT ")
@@ -4006,13 +10417,13 @@ func (q *SmsCodeQuery) SelectGroupBy(ctx context.Context, tx *wrap.Tx, withCount
 	if withCount {
 		query.WriteString(",MachineListCount(*) ")
 	}
-	query.WriteString(" FROM sms_code ")
+	query.WriteString(" FROM user_info ")
 	query.WriteString(queryString)
 
 	return q.dao.db.Query(ctx, tx, query.String(), params...)
 }
 
-func (q *SmsCodeQuery) SelectRow(ctx context.Context, tx *wrap.Tx) (row *wrap.Row) {
+func (q *UserInfoQuery) SelectRow(ctx context.Context, tx *wrap.Tx) (row *wrap.Row) {
 	if !q.hasLimit {
 		q.limitCount = 1
 		q.hasLimit = true
@@ -4022,50 +10433,86 @@ func (q *SmsCodeQuery) SelectRow(ctx context.Context, tx *wrap.Tx) (row *wrap.Ro
 	query := bytes.NewBufferString("")
 	query.WriteString("SELECT ")
 	query.WriteString(strings.Join(q.getFields, ","))
-	query.WriteString(" FROM sms_code ")
+	query.WriteString(" FROM user_info ")
 	query.WriteString(queryString)
 	return q.dao.db.QueryRow(ctx, tx, query.String(), params...)
 }
 
-func (q *SmsCodeQuery) SelectRows(ctx context.Context, tx *wrap.Tx) (rows *wrap.Rows, err error) {
+func (q *UserInfoQuery) SelectRows(ctx context.Context, tx *wrap.Tx) (rows *wrap.Rows, err error) {
 	queryString, params := q.buildSelectQuery()
 	query := bytes.NewBufferString("")
 	query.WriteString("SELECT ")
 	query.WriteString(strings.Join(q.getFields, ","))
-	query.WriteString(" FROM sms_code ")
+	query.WriteString(" FROM user_info ")
 	query.WriteString(queryString)
 	return q.dao.db.Query(ctx, tx, query.String(), params...)
 }
 
-func (q *SmsCodeQuery) Insert(ctx context.Context, tx *wrap.Tx, e *SmsCode) (result *wrap.Result, err error) {
+func (q *UserInfoQuery) Insert(ctx context.Context, tx *wrap.Tx, e *UserInfo) (result *wrap.Result, err error) {
 	query := bytes.NewBufferString("")
-	query.WriteString("INSERT INTO sms_code (sms_scene,phone_encrypted,sms_code,user_id) VALUES (?,?,?,?)")
-	params := []interface{}{e.SmsScene, e.PhoneEncrypted, e.SmsCode, e.UserId}
+	query.WriteString("INSERT INTO user_info (user_id,user_name,user_icon,password_hash) VALUES (?,?,?,?)")
+	params := []interface{}{e.UserId, e.UserName, e.UserIcon, e.PasswordHash}
 	return q.dao.db.Exec(ctx, tx, query.String(), params...)
 }
 
-func (q *SmsCodeQuery) BatchInsert(ctx context.Context, tx *wrap.Tx, list []*SmsCode) (result *wrap.Result, err error) {
+func (q *UserInfoQuery) BatchInsert(ctx context.Context, tx *wrap.Tx, list []*UserInfo) (result *wrap.Result, err error) {
 	query := bytes.NewBufferString("")
-	query.WriteString("INSERT INTO sms_code (sms_scene,phone_encrypted,sms_code,user_id) VALUES ")
+	query.WriteString("INSERT INTO user_info (user_id,user_name,user_icon,password_hash) VALUES ")
 	query.WriteString(wrap.RepeatWithSeparator("(?,?,?,?)", len(list), ","))
 	params := make([]interface{}, len(list)*4)
 	offset := 0
 	for _, e := range list {
-		params[offset+0] = e.SmsScene
-		params[offset+1] = e.PhoneEncrypted
-		params[offset+2] = e.SmsCode
-		params[offset+3] = e.UserId
+		params[offset+0] = e.UserId
+		params[offset+1] = e.UserName
+		params[offset+2] = e.UserIcon
+		params[offset+3] = e.PasswordHash
 		offset += 4
 	}
 
 	return q.dao.db.Exec(ctx, tx, query.String(), params...)
 }
 
-func (q *SmsCodeQuery) Update(ctx context.Context, tx *wrap.Tx) (result *wrap.Result, err error) {
+func (q *UserInfoQuery) InsertOnDuplicatedKeyUpdate(ctx context.Context, tx *wrap.Tx, e *UserInfo) (result *wrap.Result, err error) {
+	query := bytes.NewBufferString("")
+	query.WriteString("INSERT INTO user_info (user_id,user_name,user_icon,password_hash) VALUES (?,?,?,?)")
+	query.WriteString(" ON DUPLICATED KEY UPDATE ")
+	if len(q.duplicatedUpdateFields) > 0 {
+		query.WriteString(strings.Join(q.duplicatedUpdateFields, ","))
+		query.WriteString(",")
+	}
+	query.WriteString("update_time=now()")
+	params := []interface{}{e.UserId, e.UserName, e.UserIcon, e.PasswordHash}
+	return q.dao.db.Exec(ctx, tx, query.String(), params...)
+}
+
+func (q *UserInfoQuery) BatchInsertOnDuplicatedKeyUpdate(ctx context.Context, tx *wrap.Tx, list []*UserInfo) (result *wrap.Result, err error) {
+	query := bytes.NewBufferString("")
+	query.WriteString("INSERT INTO user_info (user_id,user_name,user_icon,password_hash) VALUES ")
+	query.WriteString(wrap.RepeatWithSeparator("(?,?,?,?)", len(list), ","))
+	query.WriteString(" ON DUPLICATED KEY UPDATE")
+	if len(q.duplicatedUpdateFields) > 0 {
+		query.WriteString(strings.Join(q.duplicatedUpdateFields, ","))
+		query.WriteString(",")
+	}
+	query.WriteString("update_time=now()")
+	params := make([]interface{}, len(list)*4)
+	offset := 0
+	for _, e := range list {
+		params[offset+0] = e.UserId
+		params[offset+1] = e.UserName
+		params[offset+2] = e.UserIcon
+		params[offset+3] = e.PasswordHash
+		offset += 4
+	}
+
+	return q.dao.db.Exec(ctx, tx, query.String(), params...)
+}
+
+func (q *UserInfoQuery) Update(ctx context.Context, tx *wrap.Tx) (result *wrap.Result, err error) {
 	query := bytes.NewBufferString("")
 	var params []interface{}
 	params = append(params, q.updateParams...)
-	query.WriteString("UPDATE sms_code SET ")
+	query.WriteString("UPDATE user_info SET ")
 	updateItems := make([]string, len(q.updateFields))
 	for i, v := range q.updateFields {
 		updateItems[i] = v + "=?"
@@ -4082,109 +10529,114 @@ func (q *SmsCodeQuery) Update(ctx context.Context, tx *wrap.Tx) (result *wrap.Re
 	return q.dao.db.Exec(ctx, tx, query.String(), params...)
 }
 
-func (q *SmsCodeQuery) Delete(ctx context.Context, tx *wrap.Tx) (result *wrap.Result, err error) {
-	query := "DELETE FROM sms_code WHERE " + q.where.String()
+func (q *UserInfoQuery) Delete(ctx context.Context, tx *wrap.Tx) (result *wrap.Result, err error) {
+	query := "DELETE FROM user_info WHERE " + q.where.String()
 	return q.dao.db.Exec(ctx, tx, query, q.whereParams...)
 }
 
-type SmsCodeDao struct {
+type UserInfoDao struct {
 	logger *zap.Logger
 	db     *DB
 }
 
-func NewSmsCodeDao(db *DB) (t *SmsCodeDao, err error) {
-	t = &SmsCodeDao{}
+func NewUserInfoDao(db *DB) (t *UserInfoDao, err error) {
+	t = &UserInfoDao{}
 	t.logger = log.TypedLogger(t)
 	t.db = db
 
 	return t, nil
 }
 
-func (dao *SmsCodeDao) Query() *SmsCodeQuery {
-	q := &SmsCodeQuery{}
+func (dao *UserInfoDao) Query() *UserInfoQuery {
+	q := &UserInfoQuery{}
 	q.dao = dao
-	q.tableName = "sms_code"
+	q.tableName = "user_info"
 	q.where = bytes.NewBufferString("")
 	return q
 }
 
-type UserInfo struct {
+type UserSession struct {
 	Id           uint64 //size=20
+	SessionId    string //size=32
 	UserId       string //size=32
-	UserName     string //size=32
-	UserIcon     string //size=256
-	PasswordHash string //size=1024
+	DeviceId     string //size=128
+	UserAgent    string //size=256
+	ClientIp     string //size=64
+	AuthTime     time.Time
+	Amr          string //size=64
+	LastSeenTime time.Time
+	ExpireTime   time.Time
 	CreateTime   time.Time
 	UpdateTime   time.Time
 }
 
-type UserInfoQuery struct {
+type UserSessionQuery struct {
 	QueryBase
-	dao *UserInfoDao
+	dao *UserSessionDao
 }
 
-func (q *UserInfoQuery) Left() *UserInfoQuery {
+func (q *UserSessionQuery) Left() *UserSessionQuery {
 	q.where.WriteString(" (")
 	return q
 }
 
-func (q *UserInfoQuery) Right() *UserInfoQuery {
+func (q *UserSessionQuery) Right() *UserSessionQuery {
 	q.where.WriteString(" )")
 	return q
 }
 
-func (q *UserInfoQuery) And() *UserInfoQuery {
+func (q *UserSessionQuery) And() *UserSessionQuery {
 	q.where.WriteString(" AND")
 	return q
 }
 
-func (q *UserInfoQuery) Or() *UserInfoQuery {
+func (q *UserSessionQuery) Or() *UserSessionQuery {
 	q.where.WriteString(" OR")
 	return q
 }
 
-func (q *UserInfoQuery) Not() *UserInfoQuery {
+func (q *UserSessionQuery) Not() *UserSessionQuery {
 	q.where.WriteString(" NOT")
 	return q
 }
 
-func (q *UserInfoQuery) IdEqual(v uint64) *UserInfoQuery {
+func (q *UserSessionQuery) IdEqual(v uint64) *UserSessionQuery {
 	q.where.WriteString(" id=?")
 	q.whereParams = append(q.whereParams, v)
 	return q
 }
 
-func (q *UserInfoQuery) IdNotEqual(v uint64) *UserInfoQuery {
+func (q *UserSessionQuery) IdNotEqual(v uint64) *UserSessionQuery {
 	q.where.WriteString(" id<>?")
 	q.whereParams = append(q.whereParams, v)
 	return q
 }
 
-func (q *UserInfoQuery) IdLess(v uint64) *UserInfoQuery {
+func (q *UserSessionQuery) IdLess(v uint64) *UserSessionQuery {
 	q.where.WriteString(" id<?")
 	q.whereParams = append(q.whereParams, v)
 	return q
 }
 
-func (q *UserInfoQuery) IdLessEqual(v uint64) *UserInfoQuery {
+func (q *UserSessionQuery) IdLessEqual(v uint64) *UserSessionQuery {
 	q.where.WriteString(" id<=?")
 	q.whereParams = append(q.whereParams, v)
 	return q
 }
 
-func (q *UserInfoQuery) IdGreater(v uint64) *UserInfoQuery {
+func (q *UserSessionQuery) IdGreater(v uint64) *UserSessionQuery {
 	q.where.WriteString(" id>?")
 	q.whereParams = append(q.whereParams, v)
 	return q
 }
 
-func (q *UserInfoQuery) IdGreaterEqual(v uint64) *UserInfoQuery {
+func (q *UserSessionQuery) IdGreaterEqual(v uint64) *UserSessionQuery {
 	q.where.WriteString(" id>=?")
 	q.whereParams = append(q.whereParams, v)
 	return q
 }
 
-func (q *UserInfoQuery) IdIn(items []uint64) *UserInfoQuery {
+func (q *UserSessionQuery) IdIn(items []uint64) *UserSessionQuery {
 	q.where.WriteString(" id IN(")
 	q.where.WriteString(wrap.RepeatWithSeparator("?", len(items), ","))
 	q.where.WriteString(")")
@@ -4192,19 +10644,39 @@ func (q *UserInfoQuery) IdIn(items []uint64) *UserInfoQuery {
 	return q
 }
 
-func (q *UserInfoQuery) UserIdEqual(v string) *UserInfoQuery {
+func (q *UserSessionQuery) SessionIdEqual(v string) *UserSessionQuery {
+	q.where.WriteString(" session_id=?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *UserSessionQuery) SessionIdNotEqual(v string) *UserSessionQuery {
+	q.where.WriteString(" session_id<>?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *UserSessionQuery) SessionIdIn(items []string) *UserSessionQuery {
+	q.where.WriteString(" session_id IN(")
+	q.where.WriteString(wrap.RepeatWithSeparator("?", len(items), ","))
+	q.where.WriteString(")")
+	q.whereParams = append(q.whereParams, items)
+	return q
+}
+
+func (q *UserSessionQuery) UserIdEqual(v string) *UserSessionQuery {
 	q.where.WriteString(" user_id=?")
 	q.whereParams = append(q.whereParams, v)
 	return q
 }
 
-func (q *UserInfoQuery) UserIdNotEqual(v string) *UserInfoQuery {
+func (q *UserSessionQuery) UserIdNotEqual(v string) *UserSessionQuery {
 	q.where.WriteString(" user_id<>?")
 	q.whereParams = append(q.whereParams, v)
 	return q
 }
 
-func (q *UserInfoQuery) UserIdIn(items []string) *UserInfoQuery {
+func (q *UserSessionQuery) UserIdIn(items []string) *UserSessionQuery {
 	q.where.WriteString(" user_id IN(")
 	q.where.WriteString(wrap.RepeatWithSeparator("?", len(items), ","))
 	q.where.WriteString(")")
@@ -4212,285 +10684,546 @@ func (q *UserInfoQuery) UserIdIn(items []string) *UserInfoQuery {
 	return q
 }
 
-func (q *UserInfoQuery) UserNameEqual(v string) *UserInfoQuery {
-	q.where.WriteString(" user_name=?")
+func (q *UserSessionQuery) DeviceIdEqual(v string) *UserSessionQuery {
+	q.where.WriteString(" device_id=?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *UserSessionQuery) DeviceIdNotEqual(v string) *UserSessionQuery {
+	q.where.WriteString(" device_id<>?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *UserSessionQuery) DeviceIdIn(items []string) *UserSessionQuery {
+	q.where.WriteString(" device_id IN(")
+	q.where.WriteString(wrap.RepeatWithSeparator("?", len(items), ","))
+	q.where.WriteString(")")
+	q.whereParams = append(q.whereParams, items)
+	return q
+}
+
+func (q *UserSessionQuery) UserAgentEqual(v string) *UserSessionQuery {
+	q.where.WriteString(" user_agent=?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *UserSessionQuery) UserAgentNotEqual(v string) *UserSessionQuery {
+	q.where.WriteString(" user_agent<>?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *UserSessionQuery) UserAgentIn(items []string) *UserSessionQuery {
+	q.where.WriteString(" user_agent IN(")
+	q.where.WriteString(wrap.RepeatWithSeparator("?", len(items), ","))
+	q.where.WriteString(")")
+	q.whereParams = append(q.whereParams, items)
+	return q
+}
+
+func (q *UserSessionQuery) ClientIpEqual(v string) *UserSessionQuery {
+	q.where.WriteString(" client_ip=?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *UserSessionQuery) ClientIpNotEqual(v string) *UserSessionQuery {
+	q.where.WriteString(" client_ip<>?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *UserSessionQuery) ClientIpIn(items []string) *UserSessionQuery {
+	q.where.WriteString(" client_ip IN(")
+	q.where.WriteString(wrap.RepeatWithSeparator("?", len(items), ","))
+	q.where.WriteString(")")
+	q.whereParams = append(q.whereParams, items)
+	return q
+}
+
+func (q *UserSessionQuery) AuthTimeEqual(v time.Time) *UserSessionQuery {
+	q.where.WriteString(" auth_time=?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *UserSessionQuery) AuthTimeNotEqual(v time.Time) *UserSessionQuery {
+	q.where.WriteString(" auth_time<>?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *UserSessionQuery) AuthTimeLess(v time.Time) *UserSessionQuery {
+	q.where.WriteString(" auth_time<?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *UserSessionQuery) AuthTimeLessEqual(v time.Time) *UserSessionQuery {
+	q.where.WriteString(" auth_time<=?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *UserSessionQuery) AuthTimeGreater(v time.Time) *UserSessionQuery {
+	q.where.WriteString(" auth_time>?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *UserSessionQuery) AuthTimeGreaterEqual(v time.Time) *UserSessionQuery {
+	q.where.WriteString(" auth_time>=?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *UserSessionQuery) AmrEqual(v string) *UserSessionQuery {
+	q.where.WriteString(" amr=?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *UserSessionQuery) AmrNotEqual(v string) *UserSessionQuery {
+	q.where.WriteString(" amr<>?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *UserSessionQuery) AmrIn(items []string) *UserSessionQuery {
+	q.where.WriteString(" amr IN(")
+	q.where.WriteString(wrap.RepeatWithSeparator("?", len(items), ","))
+	q.where.WriteString(")")
+	q.whereParams = append(q.whereParams, items)
+	return q
+}
+
+func (q *UserSessionQuery) LastSeenTimeEqual(v time.Time) *UserSessionQuery {
+	q.where.WriteString(" last_seen_time=?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *UserSessionQuery) LastSeenTimeNotEqual(v time.Time) *UserSessionQuery {
+	q.where.WriteString(" last_seen_time<>?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *UserSessionQuery) LastSeenTimeLess(v time.Time) *UserSessionQuery {
+	q.where.WriteString(" last_seen_time<?")
+	q.whereParams = append(q.whereParams, v)
+	return q
+}
+
+func (q *UserSessionQuery) LastSeenTimeLessEqual(v time.Time) *UserSessionQuery {
+	q.where.WriteString(" last_seen_time<=?")
 	q.whereParams = append(q.whereParams, v)
 	return q
 }
 
-func (q *UserInfoQuery) UserNameNotEqual(v string) *UserInfoQuery {
-	q.where.WriteString(" user_name<>?")
+func (q *UserSessionQuery) LastSeenTimeGreater(v time.Time) *UserSessionQuery {
+	q.where.WriteString(" last_seen_time>?")
 	q.whereParams = append(q.whereParams, v)
 	return q
 }
 
-func (q *UserInfoQuery) UserNameIn(items []string) *UserInfoQuery {
-	q.where.WriteString(" user_name IN(")
-	q.where.WriteString(wrap.RepeatWithSeparator("?", len(items), ","))
-	q.where.WriteString(")")
-	q.whereParams = append(q.whereParams, items)
+func (q *UserSessionQuery) LastSeenTimeGreaterEqual(v time.Time) *UserSessionQuery {
+	q.where.WriteString(" last_seen_time>=?")
+	q.whereParams = append(q.whereParams, v)
 	return q
 }
 
-func (q *UserInfoQuery) UserIconEqual(v string) *UserInfoQuery {
-	q.where.WriteString(" user_icon=?")
+func (q *UserSessionQuery) ExpireTimeEqual(v time.Time) *UserSessionQuery {
+	q.where.WriteString(" expire_time=?")
 	q.whereParams = append(q.whereParams, v)
 	return q
 }
 
-func (q *UserInfoQuery) UserIconNotEqual(v string) *UserInfoQuery {
-	q.where.WriteString(" user_icon<>?")
+func (q *UserSessionQuery) ExpireTimeNotEqual(v time.Time) *UserSessionQuery {
+	q.where.WriteString(" expire_time<>?")
 	q.whereParams = append(q.whereParams, v)
 	return q
 }
 
-func (q *UserInfoQuery) UserIconIn(items []string) *UserInfoQuery {
-	q.where.WriteString(" user_icon IN(")
-	q.where.WriteString(wrap.RepeatWithSeparator("?", len(items), ","))
-	q.where.WriteString(")")
-	q.whereParams = append(q.whereParams, items)
+func (q *UserSessionQuery) ExpireTimeLess(v time.Time) *UserSessionQuery {
+	q.where.WriteString(" expire_time<?")
+	q.whereParams = append(q.whereParams, v)
 	return q
 }
 
-func (q *UserInfoQuery) PasswordHashEqual(v string) *UserInfoQuery {
-	q.where.WriteString(" password_hash=?")
+func (q *UserSessionQuery) ExpireTimeLessEqual(v time.Time) *UserSessionQuery {
+	q.where.WriteString(" expire_time<=?")
 	q.whereParams = append(q.whereParams, v)
 	return q
 }
 
-func (q *UserInfoQuery) PasswordHashNotEqual(v string) *UserInfoQuery {
-	q.where.WriteString(" password_hash<>?")
+func (q *UserSessionQuery) ExpireTimeGreater(v time.Time) *UserSessionQuery {
+	q.where.WriteString(" expire_time>?")
 	q.whereParams = append(q.whereParams, v)
 	return q
 }
 
-func (q *UserInfoQuery) PasswordHashIn(items []string) *UserInfoQuery {
-	q.where.WriteString(" password_hash IN(")
-	q.where.WriteString(wrap.RepeatWithSeparator("?", len(items), ","))
-	q.where.WriteString(")")
-	q.whereParams = append(q.whereParams, items)
+func (q *UserSessionQuery) ExpireTimeGreaterEqual(v time.Time) *UserSessionQuery {
+	q.where.WriteString(" expire_time>=?")
+	q.whereParams = append(q.whereParams, v)
 	return q
 }
 
-func (q *UserInfoQuery) CreateTimeEqual(v time.Time) *UserInfoQuery {
+func (q *UserSessionQuery) CreateTimeEqual(v time.Time) *UserSessionQuery {
 	q.where.WriteString(" create_time=?")
 	q.whereParams = append(q.whereParams, v)
 	return q
 }
 
-func (q *UserInfoQuery) CreateTimeNotEqual(v time.Time) *UserInfoQuery {
+func (q *UserSessionQuery) CreateTimeNotEqual(v time.Time) *UserSessionQuery {
 	q.where.WriteString(" create_time<>?")
 	q.whereParams = append(q.whereParams, v)
 	return q
 }
 
-func (q *UserInfoQuery) CreateTimeLess(v time.Time) *UserInfoQuery {
+func (q *UserSessionQuery) CreateTimeLess(v time.Time) *UserSessionQuery {
 	q.where.WriteString(" create_time<?")
 	q.whereParams = append(q.whereParams, v)
 	return q
 }
 
-func (q *UserInfoQuery) CreateTimeLessEqual(v time.Time) *UserInfoQuery {
+func (q *UserSessionQuery) CreateTimeLessEqual(v time.Time) *UserSessionQuery {
 	q.where.WriteString(" create_time<=?")
 	q.whereParams = append(q.whereParams, v)
 	return q
 }
 
-func (q *UserInfoQuery) CreateTimeGreater(v time.Time) *UserInfoQuery {
+func (q *UserSessionQuery) CreateTimeGreater(v time.Time) *UserSessionQuery {
 	q.where.WriteString(" create_time>?")
 	q.whereParams = append(q.whereParams, v)
 	return q
 }
 
-func (q *UserInfoQuery) CreateTimeGreaterEqual(v time.Time) *UserInfoQuery {
+func (q *UserSessionQuery) CreateTimeGreaterEqual(v time.Time) *UserSessionQuery {
 	q.where.WriteString(" create_time>=?")
 	q.whereParams = append(q.whereParams, v)
 	return q
 }
 
-func (q *UserInfoQuery) UpdateTimeEqual(v time.Time) *UserInfoQuery {
+func (q *UserSessionQuery) UpdateTimeEqual(v time.Time) *UserSessionQuery {
 	q.where.WriteString(" update_time=?")
 	q.whereParams = append(q.whereParams, v)
 	return q
 }
 
-func (q *UserInfoQuery) UpdateTimeNotEqual(v time.Time) *UserInfoQuery {
+func (q *UserSessionQuery) UpdateTimeNotEqual(v time.Time) *UserSessionQuery {
 	q.where.WriteString(" update_time<>?")
 	q.whereParams = append(q.whereParams, v)
 	return q
 }
 
-func (q *UserInfoQuery) UpdateTimeLess(v time.Time) *UserInfoQuery {
+func (q *UserSessionQuery) UpdateTimeLess(v time.Time) *UserSessionQuery {
 	q.where.WriteString(" update_time<?")
 	q.whereParams = append(q.whereParams, v)
 	return q
 }
 
-func (q *UserInfoQuery) UpdateTimeLessEqual(v time.Time) *UserInfoQuery {
+func (q *UserSessionQuery) UpdateTimeLessEqual(v time.Time) *UserSessionQuery {
 	q.where.WriteString(" update_time<=?")
 	q.whereParams = append(q.whereParams, v)
 	return q
 }
 
-func (q *UserInfoQuery) UpdateTimeGreater(v time.Time) *UserInfoQuery {
+func (q *UserSessionQuery) UpdateTimeGreater(v time.Time) *UserSessionQuery {
 	q.where.WriteString(" update_time>?")
 	q.whereParams = append(q.whereParams, v)
 	return q
 }
 
-func (q *UserInfoQuery) UpdateTimeGreaterEqual(v time.Time) *UserInfoQuery {
+func (q *UserSessionQuery) UpdateTimeGreaterEqual(v time.Time) *UserSessionQuery {
 	q.where.WriteString(" update_time>=?")
 	q.whereParams = append(q.whereParams, v)
 	return q
 }
 
-func (q *UserInfoQuery) GroupByUserIcon(asc bool) *UserInfoQuery {
-	q.groupByFields = append(q.groupByFields, "user_icon")
+func (q *UserSessionQuery) GroupByUserId(asc bool) *UserSessionQuery {
+	q.groupByFields = append(q.groupByFields, "user_id")
 	q.groupByOrders = append(q.groupByOrders, asc)
 	return q
 }
 
-func (q *UserInfoQuery) GroupByPasswordHash(asc bool) *UserInfoQuery {
-	q.groupByFields = append(q.groupByFields, "password_hash")
+func (q *UserSessionQuery) GroupByDeviceId(asc bool) *UserSessionQuery {
+	q.groupByFields = append(q.groupByFields, "device_id")
 	q.groupByOrders = append(q.groupByOrders, asc)
 	return q
 }
 
-func (q *UserInfoQuery) OrderById(asc bool) *UserInfoQuery {
+func (q *UserSessionQuery) GroupByUserAgent(asc bool) *UserSessionQuery {
+	q.groupByFields = append(q.groupByFields, "user_agent")
+	q.groupByOrders = append(q.groupByOrders, asc)
+	return q
+}
+
+func (q *UserSessionQuery) GroupByClientIp(asc bool) *UserSessionQuery {
+	q.groupByFields = append(q.groupByFields, "client_ip")
+	q.groupByOrders = append(q.groupByOrders, asc)
+	return q
+}
+
+func (q *UserSessionQuery) GroupByAmr(asc bool) *UserSessionQuery {
+	q.groupByFields = append(q.groupByFields, "amr")
+	q.groupByOrders = append(q.groupByOrders, asc)
+	return q
+}
+
+func (q *UserSessionQuery) OrderById(asc bool) *UserSessionQuery {
 	q.orderByFields = append(q.orderByFields, "id")
 	q.orderByOrders = append(q.orderByOrders, asc)
 	return q
 }
 
-func (q *UserInfoQuery) OrderByUserId(asc bool) *UserInfoQuery {
+func (q *UserSessionQuery) OrderBySessionId(asc bool) *UserSessionQuery {
+	q.orderByFields = append(q.orderByFields, "session_id")
+	q.orderByOrders = append(q.orderByOrders, asc)
+	return q
+}
+
+func (q *UserSessionQuery) OrderByUserId(asc bool) *UserSessionQuery {
 	q.orderByFields = append(q.orderByFields, "user_id")
 	q.orderByOrders = append(q.orderByOrders, asc)
 	return q
 }
 
-func (q *UserInfoQuery) OrderByUserName(asc bool) *UserInfoQuery {
-	q.orderByFields = append(q.orderByFields, "user_name")
+func (q *UserSessionQuery) OrderByDeviceId(asc bool) *UserSessionQuery {
+	q.orderByFields = append(q.orderByFields, "device_id")
 	q.orderByOrders = append(q.orderByOrders, asc)
 	return q
 }
 
-func (q *UserInfoQuery) OrderByUserIcon(asc bool) *UserInfoQuery {
-	q.orderByFields = append(q.orderByFields, "user_icon")
+func (q *UserSessionQuery) OrderByUserAgent(asc bool) *UserSessionQuery {
+	q.orderByFields = append(q.orderByFields, "user_agent")
 	q.orderByOrders = append(q.orderByOrders, asc)
 	return q
 }
 
-func (q *UserInfoQuery) OrderByPasswordHash(asc bool) *UserInfoQuery {
-	q.orderByFields = append(q.orderByFields, "password_hash")
+func (q *UserSessionQuery) OrderByClientIp(asc bool) *UserSessionQuery {
+	q.orderByFields = append(q.orderByFields, "client_ip")
 	q.orderByOrders = append(q.orderByOrders, asc)
 	return q
 }
 
-func (q *UserInfoQuery) OrderByCreateTime(asc bool) *UserInfoQuery {
+func (q *UserSessionQuery) OrderByAuthTime(asc bool) *UserSessionQuery {
+	q.orderByFields = append(q.orderByFields, "auth_time")
+	q.orderByOrders = append(q.orderByOrders, asc)
+	return q
+}
+
+func (q *UserSessionQuery) OrderByAmr(asc bool) *UserSessionQuery {
+	q.orderByFields = append(q.orderByFields, "amr")
+	q.orderByOrders = append(q.orderByOrders, asc)
+	return q
+}
+
+func (q *UserSessionQuery) OrderByLastSeenTime(asc bool) *UserSessionQuery {
+	q.orderByFields = append(q.orderByFields, "last_seen_time")
+	q.orderByOrders = append(q.orderByOrders, asc)
+	return q
+}
+
+func (q *UserSessionQuery) OrderByExpireTime(asc bool) *UserSessionQuery {
+	q.orderByFields = append(q.orderByFields, "expire_time")
+	q.orderByOrders = append(q.orderByOrders, asc)
+	return q
+}
+
+func (q *UserSessionQuery) OrderByCreateTime(asc bool) *UserSessionQuery {
 	q.orderByFields = append(q.orderByFields, "create_time")
 	q.orderByOrders = append(q.orderByOrders, asc)
 	return q
 }
 
-func (q *UserInfoQuery) OrderByUpdateTime(asc bool) *UserInfoQuery {
+func (q *UserSessionQuery) OrderByUpdateTime(asc bool) *UserSessionQuery {
 	q.orderByFields = append(q.orderByFields, "update_time")
 	q.orderByOrders = append(q.orderByOrders, asc)
 	return q
 }
 
-func (q *UserInfoQuery) OrderByGroupCount(asc bool) *UserInfoQuery {
+func (q *UserSessionQuery) OrderByGroupCount(asc bool) *UserSessionQuery {
 	q.orderByFields = append(q.orderByFields, "count(*)")
 	q.orderByOrders = append(q.orderByOrders, asc)
 	return q
 }
 
-func (q *UserInfoQuery) Limit(startIncluded int64, count int64) *UserInfoQuery {
+func (q *UserSessionQuery) Limit(startIncluded int64, count int64) *UserSessionQuery {
 	q.hasLimit = true
 	q.limitStartIncluded = startIncluded
 	q.limitCount = count
 	return q
 }
 
-func (q *UserInfoQuery) ForUpdate() *UserInfoQuery {
+func (q *UserSessionQuery) ForUpdate() *UserSessionQuery {
 	q.forUpdate = true
 	return q
 }
 
-func (q *UserInfoQuery) ForShare() *UserInfoQuery {
+func (q *UserSessionQuery) ForShare() *UserSessionQuery {
 	q.forShare = true
 	return q
 }
 
-func (q *UserInfoQuery) SetUserId(v string) *UserInfoQuery {
+func (q *UserSessionQuery) SetSessionId(v string) *UserSessionQuery {
+	q.updateFields = append(q.updateFields, "session_id")
+	q.updateParams = append(q.updateParams, v)
+	return q
+}
+
+func (q *UserSessionQuery) SetUserId(v string) *UserSessionQuery {
 	q.updateFields = append(q.updateFields, "user_id")
 	q.updateParams = append(q.updateParams, v)
 	return q
 }
 
-func (q *UserInfoQuery) SetUserName(v string) *UserInfoQuery {
-	q.updateFields = append(q.updateFields, "user_name")
+func (q *UserSessionQuery) SetDeviceId(v string) *UserSessionQuery {
+	q.updateFields = append(q.updateFields, "device_id")
 	q.updateParams = append(q.updateParams, v)
 	return q
 }
 
-func (q *UserInfoQuery) SetUserIcon(v string) *UserInfoQuery {
-	q.updateFields = append(q.updateFields, "user_icon")
+func (q *UserSessionQuery) SetUserAgent(v string) *UserSessionQuery {
+	q.updateFields = append(q.updateFields, "user_agent")
 	q.updateParams = append(q.updateParams, v)
 	return q
 }
 
-func (q *UserInfoQuery) SetPasswordHash(v string) *UserInfoQuery {
-	q.updateFields = append(q.updateFields, "password_hash")
+func (q *UserSessionQuery) SetClientIp(v string) *UserSessionQuery {
+	q.updateFields = append(q.updateFields, "client_ip")
 	q.updateParams = append(q.updateParams, v)
 	return q
 }
 
-func (q *UserInfoQuery) DuplicatedUpdateUserIcon() *UserInfoQuery {
-	q.duplicatedUpdateFields = append(q.duplicatedUpdateFields, "user_icon=VALUES(user_icon)")
+func (q *UserSessionQuery) SetAuthTime(v time.Time) *UserSessionQuery {
+	q.updateFields = append(q.updateFields, "auth_time")
+	q.updateParams = append(q.updateParams, v)
 	return q
 }
 
-func (q *UserInfoQuery) DuplicatedUpdatePasswordHash() *UserInfoQuery {
-	q.duplicatedUpdateFields = append(q.duplicatedUpdateFields, "password_hash=VALUES(password_hash)")
+func (q *UserSessionQuery) SetAmr(v string) *UserSessionQuery {
+	q.updateFields = append(q.updateFields, "amr")
+	q.updateParams = append(q.updateParams, v)
 	return q
 }
 
-func (q *UserInfoQuery) GetId() *UserInfoQuery {
+func (q *UserSessionQuery) SetLastSeenTime(v time.Time) *UserSessionQuery {
+	q.updateFields = append(q.updateFields, "last_seen_time")
+	q.updateParams = append(q.updateParams, v)
+	return q
+}
+
+func (q *UserSessionQuery) SetExpireTime(v time.Time) *UserSessionQuery {
+	q.updateFields = append(q.updateFields, "expire_time")
+	q.updateParams = append(q.updateParams, v)
+	return q
+}
+
+func (q *UserSessionQuery) DuplicatedUpdateUserId() *UserSessionQuery {
+	q.duplicatedUpdateFields = append(q.duplicatedUpdateFields, "user_id=VALUES(user_id)")
+	return q
+}
+
+func (q *UserSessionQuery) DuplicatedUpdateDeviceId() *UserSessionQuery {
+	q.duplicatedUpdateFields = append(q.duplicatedUpdateFields, "device_id=VALUES(device_id)")
+	return q
+}
+
+func (q *UserSessionQuery) DuplicatedUpdateUserAgent() *UserSessionQuery {
+	q.duplicatedUpdateFields = append(q.duplicatedUpdateFields, "user_agent=VALUES(user_agent)")
+	return q
+}
+
+func (q *UserSessionQuery) DuplicatedUpdateClientIp() *UserSessionQuery {
+	q.duplicatedUpdateFields = append(q.duplicatedUpdateFields, "client_ip=VALUES(client_ip)")
+	return q
+}
+
+func (q *UserSessionQuery) DuplicatedUpdateAuthTime() *UserSessionQuery {
+	q.duplicatedUpdateFields = append(q.duplicatedUpdateFields, "auth_time=VALUES(auth_time)")
+	return q
+}
+
+func (q *UserSessionQuery) DuplicatedUpdateAmr() *UserSessionQuery {
+	q.duplicatedUpdateFields = append(q.duplicatedUpdateFields, "amr=VALUES(amr)")
+	return q
+}
+
+func (q *UserSessionQuery) DuplicatedUpdateLastSeenTime() *UserSessionQuery {
+	q.duplicatedUpdateFields = append(q.duplicatedUpdateFields, "last_seen_time=VALUES(last_seen_time)")
+	return q
+}
+
+func (q *UserSessionQuery) DuplicatedUpdateExpireTime() *UserSessionQuery {
+	q.duplicatedUpdateFields = append(q.duplicatedUpdateFields, "expire_time=VALUES(expire_time)")
+	return q
+}
+
+func (q *UserSessionQuery) GetId() *UserSessionQuery {
 	q.getFields = append(q.getFields, "id")
 	return q
 }
 
-func (q *UserInfoQuery) GetUserId() *UserInfoQuery {
+func (q *UserSessionQuery) GetSessionId() *UserSessionQuery {
+	q.getFields = append(q.getFields, "session_id")
+	return q
+}
+
+func (q *UserSessionQuery) GetUserId() *UserSessionQuery {
 	q.getFields = append(q.getFields, "user_id")
 	return q
 }
 
-func (q *UserInfoQuery) GetUserName() *UserInfoQuery {
-	q.getFields = append(q.getFields, "user_name")
+func (q *UserSessionQuery) GetDeviceId() *UserSessionQuery {
+	q.getFields = append(q.getFields, "device_id")
 	return q
 }
 
-func (q *UserInfoQuery) GetUserIcon() *UserInfoQuery {
-	q.getFields = append(q.getFields, "user_icon")
+func (q *UserSessionQuery) GetUserAgent() *UserSessionQuery {
+	q.getFields = append(q.getFields, "user_agent")
 	return q
 }
 
-func (q *UserInfoQuery) GetPasswordHash() *UserInfoQuery {
-	q.getFields = append(q.getFields, "password_hash")
+func (q *UserSessionQuery) GetClientIp() *UserSessionQuery {
+	q.getFields = append(q.getFields, "client_ip")
 	return q
 }
 
-func (q *UserInfoQuery) GetCreateTime() *UserInfoQuery {
+func (q *UserSessionQuery) GetAuthTime() *UserSessionQuery {
+	q.getFields = append(q.getFields, "auth_time")
+	return q
+}
+
+func (q *UserSessionQuery) GetAmr() *UserSessionQuery {
+	q.getFields = append(q.getFields, "amr")
+	return q
+}
+
+func (q *UserSessionQuery) GetLastSeenTime() *UserSessionQuery {
+	q.getFields = append(q.getFields, "last_seen_time")
+	return q
+}
+
+func (q *UserSessionQuery) GetExpireTime() *UserSessionQuery {
+	q.getFields = append(q.getFields, "expire_time")
+	return q
+}
+
+func (q *UserSessionQuery) GetCreateTime() *UserSessionQuery {
 	q.getFields = append(q.getFields, "create_time")
 	return q
 }
 
-func (q *UserInfoQuery) GetUpdateTime() *UserInfoQuery {
+func (q *UserSessionQuery) GetUpdateTime() *UserSessionQuery {
 	q.getFields = append(q.getFields, "update_time")
 	return q
 }
 
-func (q *UserInfoQuery) Select(ctx context.Context, tx *wrap.Tx) (e *UserInfo, err error) {
+func (q *UserSessionQuery) Select(ctx context.Context, tx *wrap.Tx) (e *UserSession, err error) {
 	if !q.hasLimit {
 		q.limitCount = 1
 		q.hasLimit = true
@@ -4499,16 +11232,16 @@ func (q *UserInfoQuery) Select(ctx context.Context, tx *wrap.Tx) (e *UserInfo, e
 	queryString, params := q.buildSelectQuery()
 	query := bytes.NewBufferString("")
 	if len(q.getFields) == 0 {
-		query.WriteString("SELECT id,user_id,user_name,user_icon,password_hash,create_time,update_time FROM user_info ")
+		query.WriteString("SELECT id,session_id,user_id,device_id,user_agent,client_ip,auth_time,amr,last_seen_time,expire_time,create_time,update_time FROM user_session ")
 	} else {
 		query.WriteString("SELECT ")
 		query.WriteString(strings.Join(q.getFields, ","))
-		query.WriteString(" FROM user_info ")
+		query.WriteString(" FROM user_session ")
 	}
 	query.WriteString(queryString)
-	e = &UserInfo{}
+	e = &UserSession{}
 	row := q.dao.db.QueryRow(ctx, tx, query.String(), params...)
-	err = row.Scan(&e.Id, &e.UserId, &e.UserName, &e.UserIcon, &e.PasswordHash, &e.CreateTime, &e.UpdateTime)
+	err = row.Scan(&e.Id, &e.SessionId, &e.UserId, &e.DeviceId, &e.UserAgent, &e.ClientIp, &e.AuthTime, &e.Amr, &e.LastSeenTime, &e.ExpireTime, &e.CreateTime, &e.UpdateTime)
 	if err == wrap.ErrNoRows {
 		return nil, nil
 	}
@@ -4516,15 +11249,15 @@ func (q *UserInfoQuery) Select(ctx context.Context, tx *wrap.Tx) (e *UserInfo, e
 	return e, err
 }
 
-func (q *UserInfoQuery) SelectList(ctx context.Context, tx *wrap.Tx) (list []*UserInfo, err error) {
+func (q *UserSessionQuery) SelectList(ctx context.Context, tx *wrap.Tx) (list []*UserSession, err error) {
 	queryString, params := q.buildSelectQuery()
 	query := bytes.NewBufferString("")
 	if len(q.getFields) == 0 {
-		query.WriteString("SELECT id,user_id,user_name,user_icon,password_hash,create_time,update_time FROM user_info ")
+		query.WriteString("SELECT id,session_id,user_id,device_id,user_agent,client_ip,auth_time,amr,last_seen_time,expire_time,create_time,update_time FROM user_session ")
 	} else {
 		query.WriteString("SELECT ")
 		query.WriteString(strings.Join(q.getFields, ","))
-		query.WriteString(" FROM user_info ")
+		query.WriteString(" FROM user_session ")
 	}
 	query.WriteString(queryString)
 	rows, err := q.dao.db.Query(ctx, tx, query.String(), params...)
@@ -4532,8 +11265,8 @@ func (q *UserInfoQuery) SelectList(ctx context.Context, tx *wrap.Tx) (list []*Us
 		return nil, err
 	}
 	for rows.Next() {
-		e := UserInfo{}
-		err = rows.Scan(&e.Id, &e.UserId, &e.UserName, &e.UserIcon, &e.PasswordHash, &e.CreateTime, &e.UpdateTime)
+		e := UserSession{}
+		err = rows.Scan(&e.Id, &e.SessionId, &e.UserId, &e.DeviceId, &e.UserAgent, &e.ClientIp, &e.AuthTime, &e.Amr, &e.LastSeenTime, &e.ExpireTime, &e.CreateTime, &e.UpdateTime)
 		if err != nil {
 			return nil, err
 		}
@@ -4547,10 +11280,10 @@ func (q *UserInfoQuery) SelectList(ctx context.Context, tx *wrap.Tx) (list []*Us
 	return list, nil
 }
 
-func (q *UserInfoQuery) SelectCount(ctx context.Context, tx *wrap.Tx) (count int64, err error) {
+func (q *UserSessionQuery) SelectCount(ctx context.Context, tx *wrap.Tx) (count int64, err error) {
 	queryString, params := q.buildSelectQuery()
 	query := bytes.NewBufferString("")
-	query.WriteString("SELECT COUNT(*) FROM user_info ")
+	query.WriteString("SELECT COUNT(*) FROM user_session ")
 	query.WriteString(queryString)
 	row := q.dao.db.QueryRow(ctx, tx, query.String(), params...)
 	err = row.Scan(&count)
@@ -4558,7 +11291,7 @@ func (q *UserInfoQuery) SelectCount(ctx context.Context, tx *wrap.Tx) (count int
 	return count, err
 }
 
-func (q *UserInfoQuery) SelectGroupBy(ctx context.Context, tx *wrap.Tx, withCount bool) (rows *wrap.Rows, err error) {
+func (q *UserSessionQuery) SelectGroupBy(ctx context.Context, tx *wrap.Tx, withCount bool) (rows *wrap.Rows, err error) {
 	queryString, params := q.buildSelectQuery()
 	query := bytes.NewBufferString("")
 	query.WriteString("SELECT ")
@@ -4566,13 +11299,13 @@ func (q *UserInfoQuery) SelectGroupBy(ctx context.Context, tx *wrap.Tx, withCoun
 	if withCount {
 		query.WriteString(",MachineListCount(*) ")
 	}
-	query.WriteString(" FROM user_info ")
+	query.WriteString(" FROM user_session ")
 	query.WriteString(queryString)
 
 	return q.dao.db.Query(ctx, tx, query.String(), params...)
 }
 
-func (q *UserInfoQuery) SelectRow(ctx context.Context, tx *wrap.Tx) (row *wrap.Row) {
+func (q *UserSessionQuery) SelectRow(ctx context.Context, tx *wrap.Tx) (row *wrap.Row) {
 	if !q.hasLimit {
 		q.limitCount = 1
 		q.hasLimit = true
@@ -4582,86 +11315,96 @@ func (q *UserInfoQuery) SelectRow(ctx context.Context, tx *wrap.Tx) (row *wrap.R
 	query := bytes.NewBufferString("")
 	query.WriteString("SELECT ")
 	query.WriteString(strings.Join(q.getFields, ","))
-	query.WriteString(" FROM user_info ")
+	query.WriteString(" FROM user_session ")
 	query.WriteString(queryString)
 	return q.dao.db.QueryRow(ctx, tx, query.String(), params...)
 }
 
-func (q *UserInfoQuery) SelectRows(ctx context.Context, tx *wrap.Tx) (rows *wrap.Rows, err error) {
+func (q *UserSessionQuery) SelectRows(ctx context.Context, tx *wrap.Tx) (rows *wrap.Rows, err error) {
 	queryString, params := q.buildSelectQuery()
 	query := bytes.NewBufferString("")
 	query.WriteString("SELECT ")
 	query.WriteString(strings.Join(q.getFields, ","))
-	query.WriteString(" FROM user_info ")
+	query.WriteString(" FROM user_session ")
 	query.WriteString(queryString)
 	return q.dao.db.Query(ctx, tx, query.String(), params...)
 }
 
-func (q *UserInfoQuery) Insert(ctx context.Context, tx *wrap.Tx, e *UserInfo) (result *wrap.Result, err error) {
+func (q *UserSessionQuery) Insert(ctx context.Context, tx *wrap.Tx, e *UserSession) (result *wrap.Result, err error) {
 	query := bytes.NewBufferString("")
-	query.WriteString("INSERT INTO user_info (user_id,user_name,user_icon,password_hash) VALUES (?,?,?,?)")
-	params := []interface{}{e.UserId, e.UserName, e.UserIcon, e.PasswordHash}
+	query.WriteString("INSERT INTO user_session (session_id,user_id,device_id,user_agent,client_ip,auth_time,amr,last_seen_time,expire_time) VALUES (?,?,?,?,?,?,?,?,?)")
+	params := []interface{}{e.SessionId, e.UserId, e.DeviceId, e.UserAgent, e.ClientIp, e.AuthTime, e.Amr, e.LastSeenTime, e.ExpireTime}
 	return q.dao.db.Exec(ctx, tx, query.String(), params...)
 }
 
-func (q *UserInfoQuery) BatchInsert(ctx context.Context, tx *wrap.Tx, list []*UserInfo) (result *wrap.Result, err error) {
+func (q *UserSessionQuery) BatchInsert(ctx context.Context, tx *wrap.Tx, list []*UserSession) (result *wrap.Result, err error) {
 	query := bytes.NewBufferString("")
-	query.WriteString("INSERT INTO user_info (user_id,user_name,user_icon,password_hash) VALUES ")
-	query.WriteString(wrap.RepeatWithSeparator("(?,?,?,?)", len(list), ","))
-	params := make([]interface{}, len(list)*4)
+	query.WriteString("INSERT INTO user_session (session_id,user_id,device_id,user_agent,client_ip,auth_time,amr,last_seen_time,expire_time) VALUES ")
+	query.WriteString(wrap.RepeatWithSeparator("(?,?,?,?,?,?,?,?,?)", len(list), ","))
+	params := make([]interface{}, len(list)*9)
 	offset := 0
 	for _, e := range list {
-		params[offset+0] = e.UserId
-		params[offset+1] = e.UserName
-		params[offset+2] = e.UserIcon
-		params[offset+3] = e.PasswordHash
-		offset += 4
+		params[offset+0] = e.SessionId
+		params[offset+1] = e.UserId
+		params[offset+2] = e.DeviceId
+		params[offset+3] = e.UserAgent
+		params[offset+4] = e.ClientIp
+		params[offset+5] = e.AuthTime
+		params[offset+6] = e.Amr
+		params[offset+7] = e.LastSeenTime
+		params[offset+8] = e.ExpireTime
+		offset += 9
 	}
 
 	return q.dao.db.Exec(ctx, tx, query.String(), params...)
 }
 
-func (q *UserInfoQuery) InsertOnDuplicatedKeyUpdate(ctx context.Context, tx *wrap.Tx, e *UserInfo) (result *wrap.Result, err error) {
+func (q *UserSessionQuery) InsertOnDuplicatedKeyUpdate(ctx context.Context, tx *wrap.Tx, e *UserSession) (result *wrap.Result, err error) {
 	query := bytes.NewBufferString("")
-	query.WriteString("INSERT INTO user_info (user_id,user_name,user_icon,password_hash) VALUES (?,?,?,?)")
+	query.WriteString("INSERT INTO user_session (session_id,user_id,device_id,user_agent,client_ip,auth_time,amr,last_seen_time,expire_time) VALUES (?,?,?,?,?,?,?,?,?)")
 	query.WriteString(" ON DUPLICATED KEY UPDATE ")
 	if len(q.duplicatedUpdateFields) > 0 {
 		query.WriteString(strings.Join(q.duplicatedUpdateFields, ","))
 		query.WriteString(",")
 	}
 	query.WriteString("update_time=now()")
-	params := []interface{}{e.UserId, e.UserName, e.UserIcon, e.PasswordHash}
+	params := []interface{}{e.SessionId, e.UserId, e.DeviceId, e.UserAgent, e.ClientIp, e.AuthTime, e.Amr, e.LastSeenTime, e.ExpireTime}
 	return q.dao.db.Exec(ctx, tx, query.String(), params...)
 }
 
-func (q *UserInfoQuery) BatchInsertOnDuplicatedKeyUpdate(ctx context.Context, tx *wrap.Tx, list []*UserInfo) (result *wrap.Result, err error) {
+func (q *UserSessionQuery) BatchInsertOnDuplicatedKeyUpdate(ctx context.Context, tx *wrap.Tx, list []*UserSession) (result *wrap.Result, err error) {
 	query := bytes.NewBufferString("")
-	query.WriteString("INSERT INTO user_info (user_id,user_name,user_icon,password_hash) VALUES ")
-	query.WriteString(wrap.RepeatWithSeparator("(?,?,?,?)", len(list), ","))
+	query.WriteString("INSERT INTO user_session (session_id,user_id,device_id,user_agent,client_ip,auth_time,amr,last_seen_time,expire_time) VALUES ")
+	query.WriteString(wrap.RepeatWithSeparator("(?,?,?,?,?,?,?,?,?)", len(list), ","))
 	query.WriteString(" ON DUPLICATED KEY UPDATE")
 	if len(q.duplicatedUpdateFields) > 0 {
 		query.WriteString(strings.Join(q.duplicatedUpdateFields, ","))
 		query.WriteString(",")
 	}
 	query.WriteString("update_time=now()")
-	params := make([]interface{}, len(list)*4)
+	params := make([]interface{}, len(list)*9)
 	offset := 0
 	for _, e := range list {
-		params[offset+0] = e.UserId
-		params[offset+1] = e.UserName
-		params[offset+2] = e.UserIcon
-		params[offset+3] = e.PasswordHash
-		offset += 4
+		params[offset+0] = e.SessionId
+		params[offset+1] = e.UserId
+		params[offset+2] = e.DeviceId
+		params[offset+3] = e.UserAgent
+		params[offset+4] = e.ClientIp
+		params[offset+5] = e.AuthTime
+		params[offset+6] = e.Amr
+		params[offset+7] = e.LastSeenTime
+		params[offset+8] = e.ExpireTime
+		offset += 9
 	}
 
 	return q.dao.db.Exec(ctx, tx, query.String(), params...)
 }
 
-func (q *UserInfoQuery) Update(ctx context.Context, tx *wrap.Tx) (result *wrap.Result, err error) {
+func (q *UserSessionQuery) Update(ctx context.Context, tx *wrap.Tx) (result *wrap.Result, err error) {
 	query := bytes.NewBufferString("")
 	var params []interface{}
 	params = append(params, q.updateParams...)
-	query.WriteString("UPDATE user_info SET ")
+	query.WriteString("UPDATE user_session SET ")
 	updateItems := make([]string, len(q.updateFields))
 	for i, v := range q.updateFields {
 		updateItems[i] = v + "=?"
@@ -4678,42 +11421,50 @@ func (q *UserInfoQuery) Update(ctx context.Context, tx *wrap.Tx) (result *wrap.R
 	return q.dao.db.Exec(ctx, tx, query.String(), params...)
 }
 
-func (q *UserInfoQuery) Delete(ctx context.Context, tx *wrap.Tx) (result *wrap.Result, err error) {
-	query := "DELETE FROM user_info WHERE " + q.where.String()
+func (q *UserSessionQuery) Delete(ctx context.Context, tx *wrap.Tx) (result *wrap.Result, err error) {
+	query := "DELETE FROM user_session WHERE " + q.where.String()
 	return q.dao.db.Exec(ctx, tx, query, q.whereParams...)
 }
 
-type UserInfoDao struct {
+type UserSessionDao struct {
 	logger *zap.Logger
 	db     *DB
 }
 
-func NewUserInfoDao(db *DB) (t *UserInfoDao, err error) {
-	t = &UserInfoDao{}
+func NewUserSessionDao(db *DB) (t *UserSessionDao, err error) {
+	t = &UserSessionDao{}
 	t.logger = log.TypedLogger(t)
 	t.db = db
 
 	return t, nil
 }
 
-func (dao *UserInfoDao) Query() *UserInfoQuery {
-	q := &UserInfoQuery{}
+func (dao *UserSessionDao) Query() *UserSessionQuery {
+	q := &UserSessionQuery{}
 	q.dao = dao
-	q.tableName = "user_info"
+	q.tableName = "user_session"
 	q.where = bytes.NewBufferString("")
 	return q
 }
 
 type DB struct {
 	wrap.DB
-	AccessToken      *AccessTokenDao
-	AccountOperation *AccountOperationDao
-	OauthAccount     *OauthAccountDao
-	OauthState       *OauthStateDao
-	PhoneAccount     *PhoneAccountDao
-	RefreshToken     *RefreshTokenDao
-	SmsCode          *SmsCodeDao
-	UserInfo         *UserInfoDao
+	AccessToken            *AccessTokenDao
+	AccountOperation       *AccountOperationDao
+	Counter                *CounterDao
+	JwtKey                 *JwtKeyDao
+	OauthAccount           *OauthAccountDao
+	OauthAuthorizationCode *OauthAuthorizationCodeDao
+	OauthClient            *OauthClientDao
+	OauthConsent           *OauthConsentDao
+	OauthState             *OauthStateDao
+	PasswordHistory        *PasswordHistoryDao
+	PhoneAccount           *PhoneAccountDao
+	RefreshToken           *RefreshTokenDao
+	ServiceLock            *ServiceLockDao
+	SmsCode                *SmsCodeDao
+	UserInfo               *UserInfoDao
+	UserSession            *UserSessionDao
 }
 
 func NewDB() (d *DB, err error) {
@@ -4745,16 +11496,46 @@ func NewDB() (d *DB, err error) {
 		return nil, err
 	}
 
+	d.Counter, err = NewCounterDao(d)
+	if err != nil {
+		return nil, err
+	}
+
+	d.JwtKey, err = NewJwtKeyDao(d)
+	if err != nil {
+		return nil, err
+	}
+
 	d.OauthAccount, err = NewOauthAccountDao(d)
 	if err != nil {
 		return nil, err
 	}
 
+	d.OauthAuthorizationCode, err = NewOauthAuthorizationCodeDao(d)
+	if err != nil {
+		return nil, err
+	}
+
+	d.OauthClient, err = NewOauthClientDao(d)
+	if err != nil {
+		return nil, err
+	}
+
+	d.OauthConsent, err = NewOauthConsentDao(d)
+	if err != nil {
+		return nil, err
+	}
+
 	d.OauthState, err = NewOauthStateDao(d)
 	if err != nil {
 		return nil, err
 	}
 
+	d.PasswordHistory, err = NewPasswordHistoryDao(d)
+	if err != nil {
+		return nil, err
+	}
+
 	d.PhoneAccount, err = NewPhoneAccountDao(d)
 	if err != nil {
 		return nil, err
@@ -4765,6 +11546,11 @@ func NewDB() (d *DB, err error) {
 		return nil, err
 	}
 
+	d.ServiceLock, err = NewServiceLockDao(d)
+	if err != nil {
+		return nil, err
+	}
+
 	d.SmsCode, err = NewSmsCodeDao(d)
 	if err != nil {
 		return nil, err
@@ -4775,5 +11561,10 @@ func NewDB() (d *DB, err error) {
 		return nil, err
 	}
 
+	d.UserSession, err = NewUserSessionDao(d)
+	if err != nil {
+		return nil, err
+	}
+
 	return d, nil
 }
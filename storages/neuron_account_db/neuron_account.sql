@@ -25,12 +25,17 @@ DROP TABLE IF EXISTS `access_token`;
 CREATE TABLE `access_token` (
   `id` bigint(20) unsigned NOT NULL AUTO_INCREMENT,
   `user_id` varchar(32) NOT NULL,
-  `access_token` varchar(1024) NOT NULL,
+  `jti` varchar(32) NOT NULL,
+  `session_id` varchar(32) NOT NULL,
+  `is_revoked` tinyint(1) NOT NULL,
+  `expire_time` datetime NOT NULL,
   `create_time` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
   `update_time` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
   PRIMARY KEY (`id`),
-  UNIQUE KEY `idx_access_token` (`access_token`),
+  UNIQUE KEY `idx_jti` (`jti`),
   KEY `idx_user_id` (`user_id`),
+  KEY `idx_session_id` (`session_id`),
+  KEY `idx_expire_time` (`expire_time`),
   KEY `idx_update` (`update_time`)
 ) ENGINE=InnoDB AUTO_INCREMENT=274 DEFAULT CHARSET=utf8;
 /*!40101 SET character_set_client = @saved_cs_client */;
@@ -50,6 +55,8 @@ CREATE TABLE `account_operation` (
   `phone_encrypted` varchar(32) NOT NULL,
   `sms_scene` varchar(32) NOT NULL,
   `other_user_id` varchar(32) NOT NULL,
+  `actor_id` varchar(64) NOT NULL,
+  `detail` varchar(256) NOT NULL,
   `create_time` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
   PRIMARY KEY (`id`),
   KEY `idx_user_id` (`user_id`),
@@ -58,6 +65,52 @@ CREATE TABLE `account_operation` (
 ) ENGINE=InnoDB AUTO_INCREMENT=262 DEFAULT CHARSET=utf8;
 /*!40101 SET character_set_client = @saved_cs_client */;
 
+--
+-- Table structure for table `counter`
+--
+
+DROP TABLE IF EXISTS `counter`;
+/*!40101 SET @saved_cs_client     = @@character_set_client */;
+/*!40101 SET character_set_client = utf8 */;
+CREATE TABLE `counter` (
+  `id` bigint(20) unsigned NOT NULL AUTO_INCREMENT,
+  `counter_key` varchar(255) NOT NULL,
+  `count` bigint(20) NOT NULL,
+  `expire_time` datetime(3) NOT NULL,
+  `create_time` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `update_time` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `idx_counter_key` (`counter_key`),
+  KEY `idx_expire_time` (`expire_time`),
+  KEY `idx_update` (`update_time`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8;
+/*!40101 SET character_set_client = @saved_cs_client */;
+
+--
+-- Table structure for table `jwt_key`
+--
+
+DROP TABLE IF EXISTS `jwt_key`;
+/*!40101 SET @saved_cs_client     = @@character_set_client */;
+/*!40101 SET character_set_client = utf8 */;
+CREATE TABLE `jwt_key` (
+  `id` bigint(20) unsigned NOT NULL AUTO_INCREMENT,
+  `kid` varchar(32) NOT NULL,
+  `algorithm` varchar(16) NOT NULL,
+  `private_key` varchar(4096) NOT NULL,
+  `public_key` varchar(1024) NOT NULL,
+  `sign_begin_time` datetime NOT NULL,
+  `sign_end_time` datetime NOT NULL,
+  `expire_time` datetime NOT NULL,
+  `create_time` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `update_time` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `idx_kid` (`kid`),
+  KEY `idx_expire_time` (`expire_time`),
+  KEY `idx_update` (`update_time`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8;
+/*!40101 SET character_set_client = @saved_cs_client */;
+
 --
 -- Table structure for table `oauth_account`
 --
@@ -82,6 +135,85 @@ CREATE TABLE `oauth_account` (
 ) ENGINE=InnoDB DEFAULT CHARSET=utf8;
 /*!40101 SET character_set_client = @saved_cs_client */;
 
+--
+-- Table structure for table `oauth_authorization_code`
+--
+
+DROP TABLE IF EXISTS `oauth_authorization_code`;
+/*!40101 SET @saved_cs_client     = @@character_set_client */;
+/*!40101 SET character_set_client = utf8 */;
+CREATE TABLE `oauth_authorization_code` (
+  `id` bigint(20) unsigned NOT NULL AUTO_INCREMENT,
+  `code` varchar(128) NOT NULL,
+  `client_id` varchar(32) NOT NULL,
+  `user_id` varchar(32) NOT NULL,
+  `redirect_uri` varchar(1024) NOT NULL,
+  `scope` varchar(1024) NOT NULL,
+  `code_challenge` varchar(128) NOT NULL,
+  `code_challenge_method` varchar(16) NOT NULL,
+  `nonce` varchar(256) NOT NULL,
+  `auth_time` datetime NOT NULL,
+  `amr` varchar(64) NOT NULL,
+  `is_used` tinyint(1) NOT NULL,
+  `session_id` varchar(32) NOT NULL,
+  `expire_time` datetime NOT NULL,
+  `create_time` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `update_time` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `idx_code` (`code`),
+  KEY `idx_expire_time` (`expire_time`),
+  KEY `idx_update` (`update_time`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8;
+/*!40101 SET character_set_client = @saved_cs_client */;
+
+--
+-- Table structure for table `oauth_client`
+--
+
+DROP TABLE IF EXISTS `oauth_client`;
+/*!40101 SET @saved_cs_client     = @@character_set_client */;
+/*!40101 SET character_set_client = utf8 */;
+CREATE TABLE `oauth_client` (
+  `id` bigint(20) unsigned NOT NULL AUTO_INCREMENT,
+  `client_id` varchar(32) NOT NULL,
+  `client_secret_hash` varchar(128) NOT NULL,
+  `client_name` varchar(64) NOT NULL,
+  `owner_user_id` varchar(32) NOT NULL,
+  `is_public` tinyint(1) NOT NULL,
+  `redirect_uris` varchar(4096) NOT NULL,
+  `audience` varchar(256) NOT NULL,
+  `grant_types` varchar(256) NOT NULL,
+  `scope` varchar(1024) NOT NULL,
+  `create_time` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `update_time` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `idx_client_id` (`client_id`),
+  KEY `idx_owner_user_id` (`owner_user_id`),
+  KEY `idx_update` (`update_time`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8;
+/*!40101 SET character_set_client = @saved_cs_client */;
+
+--
+-- Table structure for table `oauth_consent`
+--
+
+DROP TABLE IF EXISTS `oauth_consent`;
+/*!40101 SET @saved_cs_client     = @@character_set_client */;
+/*!40101 SET character_set_client = utf8 */;
+CREATE TABLE `oauth_consent` (
+  `id` bigint(20) unsigned NOT NULL AUTO_INCREMENT,
+  `user_id` varchar(32) NOT NULL,
+  `client_id` varchar(32) NOT NULL,
+  `scope` varchar(1024) NOT NULL,
+  `create_time` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `update_time` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `idx_user_client` (`user_id`,`client_id`),
+  KEY `idx_client_id` (`client_id`),
+  KEY `idx_update` (`update_time`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8;
+/*!40101 SET character_set_client = @saved_cs_client */;
+
 --
 -- Table structure for table `oauth_state`
 --
@@ -102,6 +234,25 @@ CREATE TABLE `oauth_state` (
 ) ENGINE=InnoDB DEFAULT CHARSET=utf8;
 /*!40101 SET character_set_client = @saved_cs_client */;
 
+--
+-- Table structure for table `password_history`
+--
+
+DROP TABLE IF EXISTS `password_history`;
+/*!40101 SET @saved_cs_client     = @@character_set_client */;
+/*!40101 SET character_set_client = utf8 */;
+CREATE TABLE `password_history` (
+  `id` bigint(20) unsigned NOT NULL AUTO_INCREMENT,
+  `user_id` varchar(32) NOT NULL,
+  `password_hash` varchar(1024) NOT NULL,
+  `create_time` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `update_time` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  PRIMARY KEY (`id`),
+  KEY `idx_user_id` (`user_id`),
+  KEY `idx_update` (`update_time`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8;
+/*!40101 SET character_set_client = @saved_cs_client */;
+
 --
 -- Table structure for table `phone_account`
 --
@@ -132,12 +283,39 @@ CREATE TABLE `refresh_token` (
   `id` bigint(20) unsigned NOT NULL AUTO_INCREMENT,
   `user_id` varchar(32) NOT NULL,
   `refresh_token` varchar(128) NOT NULL,
+  `session_id` varchar(32) NOT NULL,
+  `client_id` varchar(32) NOT NULL,
+  `scope` varchar(1024) NOT NULL,
+  `is_rotated` tinyint(1) NOT NULL,
+  `expire_time` datetime NOT NULL,
+  `session_expire_time` datetime NOT NULL,
   `create_time` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
   `update_time` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
   PRIMARY KEY (`id`),
   UNIQUE KEY `idx_refresh_token` (`refresh_token`),
-  UNIQUE KEY `user_id_UNIQUE` (`user_id`),
   KEY `idx_user_id` (`user_id`),
+  KEY `idx_session_id` (`session_id`),
+  KEY `idx_session_expire_time` (`session_expire_time`),
+  KEY `idx_update` (`update_time`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8;
+/*!40101 SET character_set_client = @saved_cs_client */;
+
+--
+-- Table structure for table `service_lock`
+--
+
+DROP TABLE IF EXISTS `service_lock`;
+/*!40101 SET @saved_cs_client     = @@character_set_client */;
+/*!40101 SET character_set_client = utf8 */;
+CREATE TABLE `service_lock` (
+  `id` bigint(20) unsigned NOT NULL AUTO_INCREMENT,
+  `lock_name` varchar(64) NOT NULL,
+  `owner` varchar(128) NOT NULL,
+  `expire_time` datetime NOT NULL,
+  `create_time` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `update_time` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `idx_lock_name` (`lock_name`),
   KEY `idx_update` (`update_time`)
 ) ENGINE=InnoDB DEFAULT CHARSET=utf8;
 /*!40101 SET character_set_client = @saved_cs_client */;
@@ -153,14 +331,18 @@ CREATE TABLE `sms_code` (
   `id` bigint(20) unsigned NOT NULL AUTO_INCREMENT,
   `sms_scene` varchar(32) NOT NULL,
   `phone_encrypted` varchar(32) NOT NULL,
-  `sms_code` varchar(8) NOT NULL,
+  `sms_code` varchar(128) NOT NULL,
   `user_id` varchar(32) NOT NULL,
+  `is_used` tinyint(1) NOT NULL,
+  `attempts` int(11) NOT NULL,
+  `is_hashed` tinyint(1) NOT NULL DEFAULT '0',
   `create_time` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
   `update_time` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
   PRIMARY KEY (`id`),
   KEY `idx_update` (`update_time`),
   KEY `idx_scene_phone` (`sms_scene`,`phone_encrypted`),
-  KEY `idx_phone` (`phone_encrypted`)
+  KEY `idx_phone` (`phone_encrypted`),
+  KEY `idx_is_hashed` (`is_hashed`)
 ) ENGINE=InnoDB AUTO_INCREMENT=53 DEFAULT CHARSET=utf8;
 /*!40101 SET character_set_client = @saved_cs_client */;
 
@@ -185,6 +367,34 @@ CREATE TABLE `user_info` (
   KEY `idx_update` (`update_time`)
 ) ENGINE=InnoDB AUTO_INCREMENT=3 DEFAULT CHARSET=utf8;
 /*!40101 SET character_set_client = @saved_cs_client */;
+
+--
+-- Table structure for table `user_session`
+--
+
+DROP TABLE IF EXISTS `user_session`;
+/*!40101 SET @saved_cs_client     = @@character_set_client */;
+/*!40101 SET character_set_client = utf8 */;
+CREATE TABLE `user_session` (
+  `id` bigint(20) unsigned NOT NULL AUTO_INCREMENT,
+  `session_id` varchar(32) NOT NULL,
+  `user_id` varchar(32) NOT NULL,
+  `device_id` varchar(128) NOT NULL,
+  `user_agent` varchar(256) NOT NULL,
+  `client_ip` varchar(64) NOT NULL,
+  `auth_time` datetime NOT NULL,
+  `amr` varchar(64) NOT NULL,
+  `last_seen_time` datetime NOT NULL,
+  `expire_time` datetime NOT NULL,
+  `create_time` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `update_time` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `idx_session_id` (`session_id`),
+  KEY `idx_user_id` (`user_id`),
+  KEY `idx_expire_time` (`expire_time`),
+  KEY `idx_update` (`update_time`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8;
+/*!40101 SET character_set_client = @saved_cs_client */;
 /*!40103 SET TIME_ZONE=@OLD_TIME_ZONE */;
 
 /*!40101 SET SQL_MODE=@OLD_SQL_MODE */;